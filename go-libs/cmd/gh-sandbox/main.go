@@ -0,0 +1,54 @@
+package main
+
+import (
+	"context"
+
+	"github.com/databrickslabs/sandbox/go-libs/github"
+	"github.com/databrickslabs/sandbox/go-libs/lite"
+	"github.com/spf13/pflag"
+)
+
+const productName = "gh-sandbox"
+const productVersion = "0.0.1"
+
+type config struct {
+	github.GitHubConfig
+	Org string
+}
+
+func (c *config) client() *github.GitHubClient {
+	return github.NewClient(&c.GitHubConfig)
+}
+
+func main() {
+	ctx := context.Background()
+	lite.New[config](ctx, lite.Init[config]{
+		Name:       productName,
+		Version:    productVersion,
+		Short:      "Work with GitHub through the sandbox libraries",
+		ConfigPath: "$HOME/.databricks/labs/gh-sandbox/config",
+		EnvPrefix:  "GH_SANDBOX",
+		Bind: func(flags *pflag.FlagSet, cfg *config) {
+			flags.StringVar(&cfg.Org, "org", "databrickslabs", "github organization")
+			flags.StringVar(&cfg.Pat, "github-pat", "", "github pat token")
+			flags.StringVar(&cfg.PrivateKeyPath, "github-private-key", "", "github private key path")
+			flags.Int64Var(&cfg.ApplicationID, "github-application-id", 0, "github app id")
+			flags.Int64Var(&cfg.InstallationID, "github-installation-id", 0, "github app installation id")
+			flags.StringVar(&cfg.BaseURL, "github-base-url", "", "api url of github enterprise server")
+			flags.BoolVar(&cfg.DryRun, "dry-run", false, "log mutating requests instead of sending them")
+			flags.StringVar(&cfg.RecordDir, "record-dir", "", "record responses into the directory")
+			flags.StringVar(&cfg.OfflineDir, "offline-dir", "", "serve recorded responses from the directory")
+		},
+	}).With(
+		newListRepos(),
+		newCreateRelease(),
+		newUploadAssets(),
+		newReleaseAssets(),
+		newWarmUpModule(),
+		newPullRequestMetrics(),
+		newLabelPullRequests(),
+		newWarmCache(),
+		newProtectBranches(),
+		newRulesetCompliance(),
+	).Run(ctx)
+}
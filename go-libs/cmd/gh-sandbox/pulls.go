@@ -0,0 +1,48 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/databrickslabs/sandbox/go-libs/github"
+	"github.com/databrickslabs/sandbox/go-libs/lite"
+	"github.com/databrickslabs/sandbox/go-libs/render"
+	"github.com/spf13/pflag"
+)
+
+func newLabelPullRequests() lite.Registerable[config] {
+	type labelRequest struct {
+		repo   string
+		config string
+	}
+	return &lite.Command[config, labelRequest]{
+		Name:  "label-prs",
+		Short: "Labels open pull requests by paths of changed files",
+		Flags: func(flags *pflag.FlagSet, req *labelRequest) {
+			flags.StringVar(&req.repo, "repo", "", "repository name")
+			flags.StringVar(&req.config, "config", ".github/labeler.yml", "labels to path patterns")
+		},
+		Run: func(cmd *lite.Root[config], req *labelRequest) error {
+			if req.repo == "" {
+				return fmt.Errorf("--repo is required")
+			}
+			raw, err := os.ReadFile(req.config)
+			if err != nil {
+				return err
+			}
+			labelerConfig, err := github.ParseLabelerConfig(raw)
+			if err != nil {
+				return err
+			}
+			labeler := &github.Labeler{
+				Client: cmd.Config.client(),
+				Config: labelerConfig,
+			}
+			added, err := labeler.LabelOpenPullRequests(cmd.Context(), cmd.Config.Org, req.repo)
+			if err != nil {
+				return err
+			}
+			return render.RenderJson(cmd.OutOrStdout(), added)
+		},
+	}
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/databrickslabs/sandbox/go-libs/github"
+	"github.com/databrickslabs/sandbox/go-libs/lite"
+	"github.com/databrickslabs/sandbox/go-libs/render"
+	"github.com/spf13/pflag"
+)
+
+func newCreateRelease() lite.Registerable[config] {
+	type releaseRequest struct {
+		repo string
+		github.CreateReleaseRequest
+	}
+	return &lite.Command[config, releaseRequest]{
+		Name:  "create-release",
+		Short: "Creates a release from a tag",
+		Flags: func(flags *pflag.FlagSet, req *releaseRequest) {
+			flags.StringVar(&req.repo, "repo", "", "repository name")
+			flags.StringVar(&req.TagName, "tag", "", "tag of the release")
+			flags.StringVar(&req.Name, "name", "", "title of the release")
+			flags.StringVar(&req.Body, "notes", "", "release notes")
+			flags.BoolVar(&req.Draft, "draft", false, "create a draft release")
+			flags.BoolVar(&req.Prerelease, "prerelease", false, "mark as a pre-release")
+			flags.BoolVar(&req.GenerateReleaseNotes, "generate-notes", false, "generate release notes")
+		},
+		Run: func(cmd *lite.Root[config], req *releaseRequest) error {
+			if req.repo == "" || req.TagName == "" {
+				return fmt.Errorf("--repo and --tag are required")
+			}
+			release, err := cmd.Config.client().CreateRelease(cmd.Context(), cmd.Config.Org, req.repo, req.CreateReleaseRequest)
+			if err != nil {
+				return err
+			}
+			return render.RenderJson(cmd.OutOrStdout(), release)
+		},
+	}
+}
+
+func newUploadAssets() lite.Registerable[config] {
+	type uploadRequest struct {
+		repo      string
+		releaseID int64
+		dir       string
+		github.UploadDirectoryOptions
+	}
+	return &lite.Command[config, uploadRequest]{
+		Name:  "upload-assets",
+		Short: "Uploads all files of a directory to a release",
+		Flags: func(flags *pflag.FlagSet, req *uploadRequest) {
+			flags.StringVar(&req.repo, "repo", "", "repository name")
+			flags.Int64Var(&req.releaseID, "release-id", 0, "release id")
+			flags.StringVar(&req.dir, "dir", "dist", "directory with assets")
+			flags.IntVar(&req.Parallelism, "parallelism", 4, "concurrent uploads")
+			flags.StringVar(&req.Manifest, "manifest", "manifest.json", "name of the manifest asset, - to skip it")
+		},
+		Run: func(cmd *lite.Root[config], req *uploadRequest) error {
+			if req.repo == "" || req.releaseID == 0 {
+				return fmt.Errorf("--repo and --release-id are required")
+			}
+			assets, err := cmd.Config.client().UploadDirectory(cmd.Context(),
+				cmd.Config.Org, req.repo, req.releaseID, req.dir, req.UploadDirectoryOptions)
+			if err != nil {
+				return err
+			}
+			return render.RenderTemplate(cmd.OutOrStdout(), `Name	Size	URL{{range .}}
+{{.Name}}	{{.Size}}	{{.BrowserDownloadURL}}{{end}}
+`, assets)
+		},
+	}
+}
+
+func newReleaseAssets() lite.Registerable[config] {
+	type assetsRequest struct {
+		github.AssetRetentionPolicy
+	}
+	return &lite.Command[config, assetsRequest]{
+		Name:  "release-assets",
+		Short: "Lists release assets across repositories and cleans up obsolete prerelease assets",
+		Flags: func(flags *pflag.FlagSet, req *assetsRequest) {
+			flags.IntVar(&req.KeepPrereleases, "keep-prereleases", 3, "newest prereleases to keep in every repository")
+			flags.BoolVar(&req.Delete, "delete", false, "delete obsolete assets")
+		},
+		Run: func(cmd *lite.Root[config], req *assetsRequest) error {
+			inventory, err := cmd.Config.client().ReleaseAssetInventory(cmd.Context(),
+				cmd.Config.Org, req.AssetRetentionPolicy)
+			if err != nil {
+				return err
+			}
+			cmd.Logger.Info("Obsolete assets", "bytes", inventory.ObsoleteSize())
+			return inventory.WriteCSV(cmd.OutOrStdout())
+		},
+	}
+}
+
+func newWarmUpModule() lite.Registerable[config] {
+	type warmUpRequest struct {
+		module  string
+		version string
+		github.WarmUpOptions
+	}
+	return &lite.Command[config, warmUpRequest]{
+		Name:  "warm-up-module",
+		Short: "Waits for a released Go module version to be available on the module mirror",
+		Flags: func(flags *pflag.FlagSet, req *warmUpRequest) {
+			flags.StringVar(&req.module, "module", "", "module path")
+			flags.StringVar(&req.version, "version", "", "released version")
+			flags.DurationVar(&req.Timeout, "timeout", 10*time.Minute, "fail, if not available within the timeout")
+		},
+		Run: func(cmd *lite.Root[config], req *warmUpRequest) error {
+			if req.module == "" || req.version == "" {
+				return fmt.Errorf("--module and --version are required")
+			}
+			proxy := &github.GoProxy{}
+			info, err := proxy.WarmUp(cmd.Context(), req.module, req.version, req.WarmUpOptions)
+			if err != nil {
+				return err
+			}
+			return render.RenderJson(cmd.OutOrStdout(), info)
+		},
+	}
+}
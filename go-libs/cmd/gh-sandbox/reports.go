@@ -0,0 +1,91 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/databrickslabs/sandbox/go-libs/github"
+	"github.com/databrickslabs/sandbox/go-libs/lite"
+	"github.com/databrickslabs/sandbox/go-libs/render"
+	"github.com/spf13/pflag"
+)
+
+func newPullRequestMetrics() lite.Registerable[config] {
+	type metricsRequest struct {
+		repos  []string
+		days   int
+		format string
+	}
+	return &lite.Command[config, metricsRequest]{
+		Name:  "pr-metrics",
+		Short: "Reports pull request sizes and review latency",
+		Flags: func(flags *pflag.FlagSet, req *metricsRequest) {
+			flags.StringSliceVar(&req.repos, "repos", nil, "repositories, all by default")
+			flags.IntVar(&req.days, "days", 28, "window in days")
+			flags.StringVar(&req.format, "format", "text", "output format: text, json, csv")
+		},
+		Run: func(cmd *lite.Root[config], req *metricsRequest) error {
+			metrics, err := cmd.Config.client().PullRequestMetrics(cmd.Context(), cmd.Config.Org, github.PullRequestMetricsOptions{
+				Since: time.Now().AddDate(0, 0, -req.days),
+				Repos: req.repos,
+			})
+			if err != nil {
+				return err
+			}
+			switch req.format {
+			case "json":
+				return metrics.WriteJSON(cmd.OutOrStdout())
+			case "csv":
+				return metrics.WriteCSV(cmd.OutOrStdout())
+			case "text":
+				return render.RenderTemplate(cmd.OutOrStdout(), `Pull requests:	{{.Count}}
+Merged:	{{.Merged}}
+Sizes:	{{range $k, $v := .Sizes}}{{$k}}={{$v}} {{end}}
+Median time to first review:	{{.MedianTimeToFirstReview}}
+Median time to merge:	{{.MedianTimeToMerge}}
+`, metrics.Summary())
+			}
+			return fmt.Errorf("unknown format: %s", req.format)
+		},
+	}
+}
+
+func newRulesetCompliance() lite.Registerable[config] {
+	type complianceRequest struct {
+		github.RulesetPolicy
+		failedOnly bool
+		format     string
+	}
+	return &lite.Command[config, complianceRequest]{
+		Name:  "ruleset-compliance",
+		Short: "Checks protection of default branches against a policy baseline",
+		Flags: func(flags *pflag.FlagSet, req *complianceRequest) {
+			flags.IntVar(&req.RequiredApprovals, "required-approvals", 1, "minimum number of approvals")
+			flags.BoolVar(&req.RequireCodeOwnerReviews, "code-owner-reviews", false, "require reviews of code owners")
+			flags.BoolVar(&req.RequireSignedCommits, "signed-commits", false, "require signed commits")
+			flags.BoolVar(&req.BlockForcePushes, "block-force-pushes", true, "require blocked force pushes")
+			flags.StringSliceVar(&req.RequiredStatusChecks, "status-checks", nil, "required status checks")
+			flags.StringSliceVar(&req.Repos, "repos", nil, "repositories, all by default")
+			flags.BoolVar(&req.failedOnly, "failed", false, "only report failed rules")
+			flags.StringVar(&req.format, "format", "text", "output format: text, csv")
+		},
+		Run: func(cmd *lite.Root[config], req *complianceRequest) error {
+			report, err := cmd.Config.client().RulesetCompliance(cmd.Context(), cmd.Config.Org, req.RulesetPolicy)
+			if err != nil {
+				return err
+			}
+			if req.failedOnly {
+				report = report.Failed()
+			}
+			switch req.format {
+			case "csv":
+				return report.WriteCSV(cmd.OutOrStdout())
+			case "text":
+				return render.RenderTemplate(cmd.OutOrStdout(), `Repo	Branch	Rule	Pass	Actual	Source{{range .}}
+{{.Repo}}	{{.Branch}}	{{.Rule}}	{{.Pass}}	{{.Actual}}	{{.Source}}{{end}}
+`, report)
+			}
+			return fmt.Errorf("unknown format: %s", req.format)
+		},
+	}
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/databrickslabs/sandbox/go-libs/env"
+	"github.com/databrickslabs/sandbox/go-libs/github"
+	"github.com/databrickslabs/sandbox/go-libs/lite"
+	"github.com/databrickslabs/sandbox/go-libs/render"
+	"github.com/spf13/pflag"
+)
+
+func newListRepos() lite.Registerable[config] {
+	type listRequest struct {
+		archived bool
+		forks    bool
+	}
+	return &lite.Command[config, listRequest]{
+		Name:  "list-repos",
+		Short: "Lists repositories of the organization",
+		Flags: func(flags *pflag.FlagSet, req *listRequest) {
+			flags.BoolVar(&req.archived, "archived", false, "include archived repositories")
+			flags.BoolVar(&req.forks, "forks", false, "include forks")
+		},
+		Run: func(cmd *lite.Root[config], req *listRequest) error {
+			ctx := cmd.Context()
+			var repos github.Repositories
+			err := cmd.Config.client().StreamRepositories(ctx, cmd.Config.Org, func(r github.Repo) error {
+				if (r.IsArchived && !req.archived) || (r.IsFork && !req.forks) {
+					return nil
+				}
+				repos = append(repos, r)
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+			return render.RenderTemplate(cmd.OutOrStdout(), `Name	Language	Stars	Pushed{{range .}}
+{{.Name}}	{{.Langauge}}	{{.Stars}}	{{.PushedAt.Format "2006-01-02"}}{{end}}
+`, repos)
+		},
+	}
+}
+
+func newWarmCache() lite.Registerable[config] {
+	type warmRequest struct {
+		cacheDir string
+	}
+	return &lite.Command[config, warmRequest]{
+		Name:  "warm-cache",
+		Short: "Loads repositories of the organization into the local cache",
+		Flags: func(flags *pflag.FlagSet, req *warmRequest) {
+			flags.StringVar(&req.cacheDir, "cache-dir", "", "cache directory")
+		},
+		Run: func(cmd *lite.Root[config], req *warmRequest) error {
+			ctx := cmd.Context()
+			if req.cacheDir == "" {
+				home, err := env.UserHomeDir(ctx)
+				if err != nil {
+					return err
+				}
+				req.cacheDir = filepath.Join(home, ".databricks/labs/gh-sandbox/cache")
+			}
+			cache := github.NewRepositoryCache(cmd.Config.client(), cmd.Config.Org, req.cacheDir)
+			repos, err := cache.Load(ctx)
+			if err != nil {
+				return err
+			}
+			cmd.Logger.Info("Cached repositories", "count", len(repos), "dir", req.cacheDir)
+			return nil
+		},
+	}
+}
+
+func newProtectBranches() lite.Registerable[config] {
+	type protectRequest struct {
+		file string
+		fix  bool
+	}
+	return &lite.Command[config, protectRequest]{
+		Name:  "protect-branches",
+		Short: "Converges branch protection of all repositories to the rules of a file",
+		Flags: func(flags *pflag.FlagSet, req *protectRequest) {
+			flags.StringVar(&req.file, "file", "branch-protection.yml", "branch protection file")
+			flags.BoolVar(&req.fix, "fix", false, "apply rules instead of only reporting drift")
+		},
+		Run: func(cmd *lite.Root[config], req *protectRequest) error {
+			raw, err := os.ReadFile(req.file)
+			if err != nil {
+				return err
+			}
+			file, err := github.ParseBranchProtection(raw)
+			if err != nil {
+				return err
+			}
+			drift, err := cmd.Config.client().ReconcileBranchProtection(cmd.Context(), cmd.Config.Org, file, req.fix)
+			if err != nil {
+				return err
+			}
+			return render.RenderTemplate(cmd.OutOrStdout(), `Repo	Setting	Actual	Desired{{range .}}
+{{.Repo}}	{{.Setting}}	{{.Actual}}	{{.Desired}}{{end}}
+`, drift)
+		},
+	}
+}
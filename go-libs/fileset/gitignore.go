@@ -0,0 +1,272 @@
+package fileset
+
+import (
+	"bufio"
+	"os"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// ignoreRule is a single pattern of .gitignore or .gitattributes file,
+// relative to the directory of that file.
+type ignoreRule struct {
+	base    string
+	regex   *regexp.Regexp
+	negate  bool
+	dirOnly bool
+}
+
+func (r ignoreRule) match(relative string, isDir bool) bool {
+	if r.dirOnly && !isDir {
+		return false
+	}
+	if r.base != "" {
+		if !strings.HasPrefix(relative, r.base+"/") {
+			return false
+		}
+		relative = strings.TrimPrefix(relative, r.base+"/")
+	}
+	return r.regex.MatchString(relative)
+}
+
+// Ignore matches paths against gitignore-style patterns, where the last
+// matching pattern decides and negated patterns re-include paths.
+type Ignore struct {
+	rules []ignoreRule
+}
+
+// Ignored checks a slash-separated path relative to the root. Like git,
+// it doesn't look at parent directories, so callers walking the tree have
+// to skip ignored directories themselves.
+func (i *Ignore) Ignored(relative string, isDir bool) bool {
+	ignored := false
+	for _, r := range i.rules {
+		if r.match(relative, isDir) {
+			ignored = !r.negate
+		}
+	}
+	return ignored
+}
+
+// AddPatterns adds lines of a .gitignore file located in base directory,
+// which is relative to the root, or empty for the root itself. Invalid
+// patterns, like [z-a], never match in git, so they are skipped.
+func (i *Ignore) AddPatterns(base string, lines ...string) {
+	for _, line := range lines {
+		line = strings.TrimRight(line, " \t\r")
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		rule := ignoreRule{base: base}
+		if strings.HasPrefix(line, "!") {
+			rule.negate = true
+			line = line[1:]
+		}
+		// escaped leading characters are literal
+		line = strings.TrimPrefix(line, `\`)
+		if strings.HasSuffix(line, "/") {
+			rule.dirOnly = true
+			line = strings.TrimSuffix(line, "/")
+		}
+		regex, err := globRegex(line)
+		if err != nil {
+			continue
+		}
+		rule.regex = regex
+		i.rules = append(i.rules, rule)
+	}
+}
+
+// globRegex translates a gitignore pattern to a regular expression. Patterns
+// without a slash match names at any depth, others are anchored to the base.
+func globRegex(pattern string) (*regexp.Regexp, error) {
+	anchored := strings.Contains(pattern, "/")
+	pattern = strings.TrimPrefix(pattern, "/")
+	var sb strings.Builder
+	sb.WriteString("^")
+	if !anchored {
+		sb.WriteString("(?:.*/)?")
+	}
+	for i := 0; i < len(pattern); i++ {
+		ch := pattern[i]
+		switch {
+		case strings.HasPrefix(pattern[i:], "**/"):
+			sb.WriteString("(?:.*/)?")
+			i += 2
+		case strings.HasPrefix(pattern[i:], "/**") && i+3 == len(pattern):
+			sb.WriteString("/.*")
+			i += 2
+		case strings.HasPrefix(pattern[i:], "**"):
+			sb.WriteString(".*")
+			i++
+		case ch == '*':
+			sb.WriteString("[^/]*")
+		case ch == '?':
+			sb.WriteString("[^/]")
+		case ch == '[':
+			class, size := bracketClass(pattern[i:])
+			if size == 0 {
+				sb.WriteString(`\[`)
+				continue
+			}
+			sb.WriteString(class)
+			i += size - 1
+		case ch == '\\' && i+1 < len(pattern):
+			i++
+			sb.WriteString(regexp.QuoteMeta(string(pattern[i])))
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(ch)))
+		}
+	}
+	// patterns matching a directory match everything inside it
+	sb.WriteString("(?:/.*)?$")
+	return regexp.Compile(sb.String())
+}
+
+// bracketClass translates the bracket expression at the start of the pattern,
+// like [!a-z] or [[:space:]], and returns its length, or zero, if it isn't
+// closed. A closing bracket right after the opening one is literal.
+func bracketClass(pattern string) (string, int) {
+	var sb strings.Builder
+	sb.WriteString("[")
+	i := 1
+	if i < len(pattern) && (pattern[i] == '!' || pattern[i] == '^') {
+		sb.WriteString("^")
+		i++
+	}
+	for start := i; i < len(pattern); i++ {
+		ch := pattern[i]
+		switch {
+		case ch == ']' && i > start:
+			sb.WriteString("]")
+			return sb.String(), i + 1
+		case strings.HasPrefix(pattern[i:], "[:"):
+			// POSIX classes have the same syntax in regular expressions
+			end := strings.Index(pattern[i+2:], ":]")
+			if end < 0 {
+				sb.WriteString(`\[`)
+				continue
+			}
+			sb.WriteString(pattern[i : i+end+4])
+			i += end + 3
+		case ch == '\\' && i+1 < len(pattern):
+			i++
+			sb.WriteString(classLiteral(pattern[i]))
+		default:
+			sb.WriteString(classLiteral(ch))
+		}
+	}
+	return "", 0
+}
+
+// classLiteral escapes characters, that are special in classes of regular
+// expressions, except for the dash of ranges.
+func classLiteral(ch byte) string {
+	switch ch {
+	case '\\', '[', ']', '^':
+		return `\` + string(ch)
+	}
+	return string(ch)
+}
+
+func readLines(filename string) ([]string, error) {
+	f, err := os.Open(filename)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	var lines []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	return lines, scanner.Err()
+}
+
+// exportIgnorePatterns converts .gitattributes lines into patterns of
+// paths with export-ignore attribute, negating the unset ones.
+func exportIgnorePatterns(lines []string) (patterns []string) {
+	for _, line := range lines {
+		fields := strings.Fields(line)
+		if len(fields) < 2 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+		for _, attr := range fields[1:] {
+			switch attr {
+			case "export-ignore":
+				patterns = append(patterns, fields[0])
+			case "-export-ignore", "!export-ignore":
+				patterns = append(patterns, "!"+fields[0])
+			}
+		}
+	}
+	return patterns
+}
+
+// GitChildren lists files in dir like RecursiveChildren, but only those, that
+// git wouldn't ignore according to .gitignore files and .git/info/exclude.
+func GitChildren(dir string) (FileSet, error) {
+	return gitWalk(dir, false)
+}
+
+// ExportChildren lists files, that git archive would put into an archive:
+// files of GitChildren without those marked export-ignore in .gitattributes.
+func ExportChildren(dir string) (FileSet, error) {
+	return gitWalk(dir, true)
+}
+
+func gitWalk(root string, export bool) (found FileSet, err error) {
+	ignore := &Ignore{}
+	exclude, err := readLines(path.Join(root, ".git", "info", "exclude"))
+	if err != nil {
+		return nil, err
+	}
+	ignore.AddPatterns("", exclude...)
+	exportIgnore := &Ignore{}
+	queue := []string{""}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		absolute := path.Join(root, current)
+		gitignore, err := readLines(path.Join(absolute, ".gitignore"))
+		if err != nil {
+			return nil, err
+		}
+		ignore.AddPatterns(current, gitignore...)
+		if export {
+			attributes, err := readLines(path.Join(absolute, ".gitattributes"))
+			if err != nil {
+				return nil, err
+			}
+			exportIgnore.AddPatterns(current, exportIgnorePatterns(attributes)...)
+		}
+		children, err := ReadDir(absolute)
+		if err != nil {
+			return nil, err
+		}
+		for _, child := range children {
+			relative := path.Join(current, child.Name())
+			isDir := child.IsDir()
+			if isDir && child.Name() == ".git" {
+				continue
+			}
+			if ignore.Ignored(relative, isDir) {
+				continue
+			}
+			if export && exportIgnore.Ignored(relative, isDir) {
+				continue
+			}
+			if isDir {
+				queue = append(queue, relative)
+				continue
+			}
+			child.Relative = relative
+			found = append(found, child)
+		}
+	}
+	return found, nil
+}
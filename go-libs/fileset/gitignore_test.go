@@ -0,0 +1,105 @@
+package fileset
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIgnored(t *testing.T) {
+	ignore := &Ignore{}
+	ignore.AddPatterns("", "# comment", "*.log", "!keep.log", "/dist", "build/", "docs/**/*.tmp")
+	ignore.AddPatterns("sub", "local.txt", "/anchored")
+	for _, tc := range []struct {
+		path    string
+		isDir   bool
+		ignored bool
+	}{
+		{"app.log", false, true},
+		{"a/b/app.log", false, true},
+		{"a/keep.log", false, false},
+		{"dist", true, true},
+		{"a/dist", true, false},
+		{"build", true, true},
+		{"build", false, false},
+		{"a/build", true, true},
+		{"docs/x.tmp", false, true},
+		{"docs/a/b/x.tmp", false, true},
+		{"x.tmp", false, false},
+		{"sub/local.txt", false, true},
+		{"sub/deep/local.txt", false, true},
+		{"local.txt", false, false},
+		{"sub/anchored", false, true},
+		{"sub/deep/anchored", false, false},
+	} {
+		assert.Equal(t, tc.ignored, ignore.Ignored(tc.path, tc.isDir), tc.path)
+	}
+}
+
+func TestExportChildren(t *testing.T) {
+	root := t.TempDir()
+	for name, content := range map[string]string{
+		".gitignore":       "*.log\nvendor/\n",
+		".gitattributes":   "/tests export-ignore\n.git* export-ignore\n",
+		".git/HEAD":        "ref: refs/heads/main",
+		"main.go":          "package main",
+		"debug.log":        "",
+		"vendor/x/x.go":    "",
+		"tests/a_test.go":  "",
+		"pkg/.gitignore":   "generated.go\n",
+		"pkg/generated.go": "",
+		"pkg/pkg.go":       "",
+	} {
+		filename := filepath.Join(root, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(filename), 0o755))
+		require.NoError(t, os.WriteFile(filename, []byte(content), 0o600))
+	}
+	relative := func(fs FileSet) (out []string) {
+		for _, v := range fs {
+			out = append(out, v.Relative)
+		}
+		sort.Strings(out)
+		return out
+	}
+
+	all, err := GitChildren(root)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		".gitattributes",
+		".gitignore",
+		"main.go",
+		"pkg/.gitignore",
+		"pkg/pkg.go",
+		"tests/a_test.go",
+	}, relative(all))
+
+	export, err := ExportChildren(root)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"main.go",
+		"pkg/pkg.go",
+	}, relative(export))
+}
+
+func TestIgnoredBracketExpressions(t *testing.T) {
+	ignore := &Ignore{}
+	ignore.AddPatterns("", "*[[:space:]]*", "[!a-c]x", "[]]y", "[z-a]", `v[\]]`)
+	for _, tc := range []struct {
+		path    string
+		ignored bool
+	}{
+		{"with space.txt", true},
+		{"nospace.txt", false},
+		{"dx", true},
+		{"ax", false},
+		{"]y", true},
+		{"z", false},
+		{"v]", true},
+	} {
+		assert.Equal(t, tc.ignored, ignore.Ignored(tc.path, false), tc.path)
+	}
+}
@@ -130,3 +130,48 @@ func (l *Checkout) CreateTag(ctx context.Context, v, msg string) (string, error)
 func (l *Checkout) ForcePush(ctx context.Context, v string) (string, error) {
 	return l.cmd(ctx, "push", l.pushRemote, v, "-f")
 }
+
+// FetchBranch fetches a branch from the fetch remote into FETCH_HEAD.
+func (l *Checkout) FetchBranch(ctx context.Context, branch string) (string, error) {
+	return l.cmd(ctx, "fetch", l.fetchRemote, branch)
+}
+
+// CreateBranch creates or resets a branch to start and checks it out.
+func (l *Checkout) CreateBranch(ctx context.Context, branch, start string) (string, error) {
+	return l.cmd(ctx, "checkout", "-B", branch, start)
+}
+
+// IsMergeCommit is true for commits with more than one parent.
+func (l *Checkout) IsMergeCommit(ctx context.Context, sha string) (bool, error) {
+	out, err := l.cmd(ctx, "rev-list", "--parents", "-n", "1", sha)
+	if err != nil {
+		return false, err
+	}
+	return len(strings.Fields(out)) > 2, nil
+}
+
+// CherryPick applies commits on top of the current branch, recording their
+// origin in the message. Merge commits are picked relative to the first parent.
+func (l *Checkout) CherryPick(ctx context.Context, mainline bool, shas ...string) (string, error) {
+	args := []string{"cherry-pick", "-x"}
+	if mainline {
+		args = append(args, "-m", "1")
+	}
+	return l.cmd(ctx, append(args, shas...)...)
+}
+
+func (l *Checkout) CherryPickAbort(ctx context.Context) (string, error) {
+	return l.cmd(ctx, "cherry-pick", "--abort")
+}
+
+// ConflictingFiles lists unmerged files after a failed merge or cherry-pick.
+func (l *Checkout) ConflictingFiles(ctx context.Context) ([]string, error) {
+	out, err := l.cmd(ctx, "diff", "--name-only", "--diff-filter=U")
+	if err != nil {
+		return nil, err
+	}
+	if out == "" {
+		return nil, nil
+	}
+	return strings.Split(out, "\n"), nil
+}
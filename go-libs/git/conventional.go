@@ -0,0 +1,158 @@
+package git
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// See https://www.conventionalcommits.org/en/v1.0.0/
+
+var ErrNotConventional = errors.New("not a conventional commit")
+
+type ParseMode int
+
+const (
+	// Strict follows the specification and allows only well-known types.
+	Strict ParseMode = iota
+
+	// Lenient tolerates sloppy formatting, like "Fix (api) : thing", and
+	// keeps the header of non-conventional messages as the description.
+	Lenient
+)
+
+// ConventionalTypes are types allowed in Strict mode.
+var ConventionalTypes = []string{
+	"feat", "fix", "build", "chore", "ci", "docs",
+	"perf", "refactor", "revert", "style", "test",
+}
+
+type Footer struct {
+	Token string
+	Value string
+}
+
+type ConventionalCommit struct {
+	Type        string
+	Scope       string
+	Breaking    bool
+	Description string
+	Body        string
+	Footers     []Footer
+}
+
+// Footer returns values of all footers with the token, case-insensitive.
+func (c *ConventionalCommit) Footer(token string) (values []string) {
+	for _, v := range c.Footers {
+		if strings.EqualFold(v.Token, token) {
+			values = append(values, v.Value)
+		}
+	}
+	return values
+}
+
+type Bump int
+
+const (
+	BumpNone Bump = iota
+	BumpPatch
+	BumpMinor
+	BumpMajor
+)
+
+func (b Bump) String() string {
+	switch b {
+	case BumpPatch:
+		return "patch"
+	case BumpMinor:
+		return "minor"
+	case BumpMajor:
+		return "major"
+	}
+	return "none"
+}
+
+// Bump is the semantic version increment implied by the commit.
+func (c *ConventionalCommit) Bump() Bump {
+	switch {
+	case c.Breaking:
+		return BumpMajor
+	case c.Type == "feat":
+		return BumpMinor
+	case c.Type == "fix", c.Type == "perf":
+		return BumpPatch
+	}
+	return BumpNone
+}
+
+var (
+	strictHeader  = regexp.MustCompile(`^([a-z]+)(?:\(([^()\s][^()]*)\))?(!)?: (\S.*)$`)
+	lenientHeader = regexp.MustCompile(`^\s*([A-Za-z]+)\s*(?:\(([^()]*)\))?\s*(!)?\s*:\s*(.+)$`)
+	footerLine    = regexp.MustCompile(`^(BREAKING[ -]CHANGE|[A-Za-z][\w-]*)(?:: | #)(.*)$`)
+)
+
+func ParseConventionalCommit(message string, mode ParseMode) (*ConventionalCommit, error) {
+	message = strings.TrimSpace(strings.ReplaceAll(message, "\r\n", "\n"))
+	header, rest, _ := strings.Cut(message, "\n")
+	commit := &ConventionalCommit{}
+	if mode == Strict {
+		match := strictHeader.FindStringSubmatch(header)
+		if match == nil {
+			return nil, fmt.Errorf("%w: %s", ErrNotConventional, header)
+		}
+		if !slices.Contains(ConventionalTypes, match[1]) {
+			return nil, fmt.Errorf("%w: unknown type: %s", ErrNotConventional, match[1])
+		}
+		if rest != "" && !strings.HasPrefix(rest, "\n") {
+			return nil, fmt.Errorf("%w: no blank line after header", ErrNotConventional)
+		}
+		commit.Type, commit.Scope = match[1], match[2]
+		commit.Breaking, commit.Description = match[3] == "!", match[4]
+	} else {
+		match := lenientHeader.FindStringSubmatch(header)
+		if match != nil {
+			commit.Type = strings.ToLower(match[1])
+			commit.Scope = strings.TrimSpace(match[2])
+			commit.Breaking = match[3] == "!"
+			commit.Description = strings.TrimSpace(match[4])
+		} else {
+			commit.Description = strings.TrimSpace(header)
+		}
+	}
+	commit.Body, commit.Footers = SplitFooters(strings.Trim(rest, "\n"))
+	for _, v := range commit.Footers {
+		if v.Token == "BREAKING CHANGE" || v.Token == "BREAKING-CHANGE" {
+			commit.Breaking = true
+		}
+	}
+	return commit, nil
+}
+
+// SplitFooters separates the last paragraph of the body, if it consists of
+// footers, like "Token: value" or "Token #value". Lines, which don't start
+// a footer, continue the previous one.
+func SplitFooters(text string) (string, []Footer) {
+	if text == "" {
+		return "", nil
+	}
+	body, last := "", text
+	if i := strings.LastIndex(text, "\n\n"); i >= 0 {
+		body, last = text[:i], text[i+2:]
+	}
+	lines := strings.Split(last, "\n")
+	if !footerLine.MatchString(lines[0]) {
+		return text, nil
+	}
+	var footers []Footer
+	for _, line := range lines {
+		match := footerLine.FindStringSubmatch(line)
+		if match != nil {
+			footers = append(footers, Footer{Token: match[1], Value: match[2]})
+			continue
+		}
+		footers[len(footers)-1].Value += "\n" + line
+	}
+	return strings.TrimSpace(body), footers
+}
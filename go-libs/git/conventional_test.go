@@ -0,0 +1,65 @@
+package git
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseConventionalCommit(t *testing.T) {
+	commit, err := ParseConventionalCommit(`feat(api)!: add pagination
+
+Lists are paginated now.
+
+Second paragraph.
+
+Reviewed-by: Jane
+Refs #123
+BREAKING CHANGE: lists return at most 100 items,
+unless asked for more`, Strict)
+	require.NoError(t, err)
+	assert.Equal(t, &ConventionalCommit{
+		Type:        "feat",
+		Scope:       "api",
+		Breaking:    true,
+		Description: "add pagination",
+		Body:        "Lists are paginated now.\n\nSecond paragraph.",
+		Footers: []Footer{
+			{"Reviewed-by", "Jane"},
+			{"Refs", "123"},
+			{"BREAKING CHANGE", "lists return at most 100 items,\nunless asked for more"},
+		},
+	}, commit)
+	assert.Equal(t, BumpMajor, commit.Bump())
+	assert.Equal(t, []string{"Jane"}, commit.Footer("reviewed-by"))
+}
+
+func TestParseConventionalCommitStrict(t *testing.T) {
+	for _, message := range []string{
+		"Fix: capitalized type",
+		"fix:no space",
+		"wip: unknown type",
+		"fix: header\nno blank line",
+		"just a message",
+	} {
+		_, err := ParseConventionalCommit(message, Strict)
+		assert.ErrorIs(t, err, ErrNotConventional, message)
+	}
+}
+
+func TestParseConventionalCommitLenient(t *testing.T) {
+	commit, err := ParseConventionalCommit("Fix (ui) : button color\nno blank line", Lenient)
+	require.NoError(t, err)
+	assert.Equal(t, "fix", commit.Type)
+	assert.Equal(t, "ui", commit.Scope)
+	assert.Equal(t, "button color", commit.Description)
+	assert.Equal(t, "no blank line", commit.Body)
+	assert.Equal(t, BumpPatch, commit.Bump())
+
+	commit, err = ParseConventionalCommit("Update README.md", Lenient)
+	require.NoError(t, err)
+	assert.Equal(t, "", commit.Type)
+	assert.Equal(t, "Update README.md", commit.Description)
+	assert.Equal(t, BumpNone, commit.Bump())
+}
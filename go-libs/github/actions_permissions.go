@@ -0,0 +1,135 @@
+package github
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/databricks/databricks-sdk-go/httpclient"
+)
+
+// See https://docs.github.com/en/rest/actions/permissions
+
+type OrgActionsPermissions struct {
+	// EnabledRepositories is one of: all, none, selected.
+	EnabledRepositories string `json:"enabled_repositories,omitempty"`
+
+	// AllowedActions is one of: all, local_only, selected.
+	AllowedActions     string `json:"allowed_actions,omitempty"`
+	SelectedActionsURL string `json:"selected_actions_url,omitempty"`
+}
+
+type RepoActionsPermissions struct {
+	Enabled bool `json:"enabled"`
+
+	// AllowedActions is one of: all, local_only, selected.
+	AllowedActions     string `json:"allowed_actions,omitempty"`
+	SelectedActionsURL string `json:"selected_actions_url,omitempty"`
+}
+
+type SelectedActions struct {
+	GitHubOwnedAllowed bool     `json:"github_owned_allowed"`
+	VerifiedAllowed    bool     `json:"verified_allowed"`
+	PatternsAllowed    []string `json:"patterns_allowed"`
+}
+
+type WorkflowPermissions struct {
+	// DefaultWorkflowPermissions is one of: read, write.
+	DefaultWorkflowPermissions   string `json:"default_workflow_permissions,omitempty"`
+	CanApprovePullRequestReviews bool   `json:"can_approve_pull_request_reviews"`
+}
+
+type ForkPullRequestApproval struct {
+	// ApprovalPolicy is one of: first_time_contributors_new_to_github,
+	// first_time_contributors, all_external_contributors.
+	ApprovalPolicy string `json:"approval_policy"`
+}
+
+func (c *GitHubClient) GetOrgActionsPermissions(ctx context.Context, org string) (*OrgActionsPermissions, error) {
+	path := fmt.Sprintf("%s/orgs/%s/actions/permissions", gitHubAPI, org)
+	var res OrgActionsPermissions
+	err := c.api.Do(ctx, "GET", path, httpclient.WithResponseUnmarshal(&res))
+	return &res, err
+}
+
+func (c *GitHubClient) SetOrgActionsPermissions(ctx context.Context, org string, req OrgActionsPermissions) error {
+	path := fmt.Sprintf("%s/orgs/%s/actions/permissions", gitHubAPI, org)
+	return c.api.Do(ctx, "PUT", path, httpclient.WithRequestData(req))
+}
+
+func (c *GitHubClient) GetOrgSelectedActions(ctx context.Context, org string) (*SelectedActions, error) {
+	path := fmt.Sprintf("%s/orgs/%s/actions/permissions/selected-actions", gitHubAPI, org)
+	var res SelectedActions
+	err := c.api.Do(ctx, "GET", path, httpclient.WithResponseUnmarshal(&res))
+	return &res, err
+}
+
+// SetOrgSelectedActions configures the allow-list, which is only effective
+// when AllowedActions of the organization is set to "selected".
+func (c *GitHubClient) SetOrgSelectedActions(ctx context.Context, org string, req SelectedActions) error {
+	path := fmt.Sprintf("%s/orgs/%s/actions/permissions/selected-actions", gitHubAPI, org)
+	return c.api.Do(ctx, "PUT", path, httpclient.WithRequestData(req))
+}
+
+func (c *GitHubClient) GetOrgWorkflowPermissions(ctx context.Context, org string) (*WorkflowPermissions, error) {
+	path := fmt.Sprintf("%s/orgs/%s/actions/permissions/workflow", gitHubAPI, org)
+	var res WorkflowPermissions
+	err := c.api.Do(ctx, "GET", path, httpclient.WithResponseUnmarshal(&res))
+	return &res, err
+}
+
+func (c *GitHubClient) SetOrgWorkflowPermissions(ctx context.Context, org string, req WorkflowPermissions) error {
+	path := fmt.Sprintf("%s/orgs/%s/actions/permissions/workflow", gitHubAPI, org)
+	return c.api.Do(ctx, "PUT", path, httpclient.WithRequestData(req))
+}
+
+func (c *GitHubClient) GetOrgForkPullRequestApproval(ctx context.Context, org string) (*ForkPullRequestApproval, error) {
+	path := fmt.Sprintf("%s/orgs/%s/actions/permissions/fork-pr-contributor-approval", gitHubAPI, org)
+	var res ForkPullRequestApproval
+	err := c.api.Do(ctx, "GET", path, httpclient.WithResponseUnmarshal(&res))
+	return &res, err
+}
+
+func (c *GitHubClient) SetOrgForkPullRequestApproval(ctx context.Context, org string, req ForkPullRequestApproval) error {
+	path := fmt.Sprintf("%s/orgs/%s/actions/permissions/fork-pr-contributor-approval", gitHubAPI, org)
+	return c.api.Do(ctx, "PUT", path, httpclient.WithRequestData(req))
+}
+
+func (c *GitHubClient) GetRepoActionsPermissions(ctx context.Context, org, repo string) (*RepoActionsPermissions, error) {
+	path := fmt.Sprintf("%s/repos/%s/%s/actions/permissions", gitHubAPI, org, repo)
+	var res RepoActionsPermissions
+	err := c.api.Do(ctx, "GET", path, httpclient.WithResponseUnmarshal(&res))
+	return &res, err
+}
+
+// SetRepoActionsPermissions enables or disables Actions on the repository and
+// restricts the actions it may run. Organization policy takes precedence.
+func (c *GitHubClient) SetRepoActionsPermissions(ctx context.Context, org, repo string, req RepoActionsPermissions) error {
+	path := fmt.Sprintf("%s/repos/%s/%s/actions/permissions", gitHubAPI, org, repo)
+	return c.api.Do(ctx, "PUT", path, httpclient.WithRequestData(req))
+}
+
+func (c *GitHubClient) GetRepoSelectedActions(ctx context.Context, org, repo string) (*SelectedActions, error) {
+	path := fmt.Sprintf("%s/repos/%s/%s/actions/permissions/selected-actions", gitHubAPI, org, repo)
+	var res SelectedActions
+	err := c.api.Do(ctx, "GET", path, httpclient.WithResponseUnmarshal(&res))
+	return &res, err
+}
+
+func (c *GitHubClient) SetRepoSelectedActions(ctx context.Context, org, repo string, req SelectedActions) error {
+	path := fmt.Sprintf("%s/repos/%s/%s/actions/permissions/selected-actions", gitHubAPI, org, repo)
+	return c.api.Do(ctx, "PUT", path, httpclient.WithRequestData(req))
+}
+
+// GetRepoWorkflowPermissions returns the default permissions granted to the
+// GITHUB_TOKEN when running workflows in the repository.
+func (c *GitHubClient) GetRepoWorkflowPermissions(ctx context.Context, org, repo string) (*WorkflowPermissions, error) {
+	path := fmt.Sprintf("%s/repos/%s/%s/actions/permissions/workflow", gitHubAPI, org, repo)
+	var res WorkflowPermissions
+	err := c.api.Do(ctx, "GET", path, httpclient.WithResponseUnmarshal(&res))
+	return &res, err
+}
+
+func (c *GitHubClient) SetRepoWorkflowPermissions(ctx context.Context, org, repo string, req WorkflowPermissions) error {
+	path := fmt.Sprintf("%s/repos/%s/%s/actions/permissions/workflow", gitHubAPI, org, repo)
+	return c.api.Do(ctx, "PUT", path, httpclient.WithRequestData(req))
+}
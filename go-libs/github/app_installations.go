@@ -0,0 +1,95 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/databricks/databricks-sdk-go/httpclient"
+)
+
+type Installation struct {
+	ID                  int64             `json:"id,omitempty"`
+	AppID               int64             `json:"app_id,omitempty"`
+	AppSlug             string            `json:"app_slug,omitempty"`
+	Account             User              `json:"account,omitempty"`
+	TargetID            int64             `json:"target_id,omitempty"`
+	TargetType          string            `json:"target_type,omitempty"` // Organization, User
+	RepositorySelection string            `json:"repository_selection,omitempty"`
+	Permissions         map[string]string `json:"permissions,omitempty"`
+	Events              []string          `json:"events,omitempty"`
+	HTMLURL             string            `json:"html_url,omitempty"`
+	AccessTokensURL     string            `json:"access_tokens_url,omitempty"`
+	RepositoriesURL     string            `json:"repositories_url,omitempty"`
+	CreatedAt           time.Time         `json:"created_at,omitempty"`
+	UpdatedAt           time.Time         `json:"updated_at,omitempty"`
+	SuspendedAt         *time.Time        `json:"suspended_at,omitempty"`
+}
+
+// withAppJWT authenticates a single request as the GitHub App itself,
+// instead of one of its installations, which is required by /app endpoints.
+func (c *GitHubClient) withAppJWT() httpclient.DoOption {
+	return httpclient.WithRequestVisitor(func(r *http.Request) error {
+		token, err := (&ghAppTokenSource{&c.cfg.GitHubTokenSource}).Token()
+		if err != nil {
+			return fmt.Errorf("app jwt: %w", err)
+		}
+		r.Header.Set("Authorization", fmt.Sprintf("%s %s", token.TokenType, token.AccessToken))
+		return nil
+	})
+}
+
+// ListAppInstallations lists installations of the GitHub App configured by
+// ApplicationID and the private key.
+func (c *GitHubClient) ListAppInstallations(ctx context.Context) ([]Installation, error) {
+	path := fmt.Sprintf("%s/app/installations", gitHubAPI)
+	var res []Installation
+	err := c.api.Do(ctx, "GET", path,
+		c.withAppJWT(),
+		httpclient.WithRequestData(listOptions{PerPage: 100}),
+		httpclient.WithResponseUnmarshal(&res))
+	return res, err
+}
+
+func (c *GitHubClient) ListAppInstallationsIterator() *Iterator[Installation] {
+	path := fmt.Sprintf("%s/app/installations", gitHubAPI)
+	return Paginate[Installation](c, path, nil, c.withAppJWT())
+}
+
+func (c *GitHubClient) GetOrgInstallation(ctx context.Context, org string) (*Installation, error) {
+	path := fmt.Sprintf("%s/orgs/%s/installation", gitHubAPI, org)
+	var res Installation
+	err := c.api.Do(ctx, "GET", path, c.withAppJWT(), httpclient.WithResponseUnmarshal(&res))
+	return &res, err
+}
+
+func (c *GitHubClient) GetRepoInstallation(ctx context.Context, org, repo string) (*Installation, error) {
+	path := fmt.Sprintf("%s/repos/%s/%s/installation", gitHubAPI, org, repo)
+	var res Installation
+	err := c.api.Do(ctx, "GET", path, c.withAppJWT(), httpclient.WithResponseUnmarshal(&res))
+	return &res, err
+}
+
+// ListInstallationRepositories lists repositories accessible to the installation
+// token, which the client is authenticated with.
+func (c *GitHubClient) ListInstallationRepositories(ctx context.Context) (Repositories, error) {
+	return ToSlice(ctx, c.ListInstallationRepositoriesIterator())
+}
+
+func (c *GitHubClient) ListInstallationRepositoriesIterator() *Iterator[Repo] {
+	path := fmt.Sprintf("%s/installation/repositories", gitHubAPI)
+	return paginateField[Repo](c, path, "repositories", nil)
+}
+
+// AddRepoToInstallation grants an installation access to a repository. This
+// requires a user token with admin rights on the repository, not an app token.
+func (c *GitHubClient) AddRepoToInstallation(ctx context.Context, installationID, repoID int64) error {
+	path := fmt.Sprintf("%s/user/installations/%d/repositories/%d", gitHubAPI, installationID, repoID)
+	return c.api.Do(ctx, "PUT", path)
+}
+
+func (c *GitHubClient) RemoveRepoFromInstallation(ctx context.Context, installationID, repoID int64) error {
+	path := fmt.Sprintf("%s/user/installations/%d/repositories/%d", gitHubAPI, installationID, repoID)
+	return c.api.Do(ctx, "DELETE", path)
+}
@@ -0,0 +1,153 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/databricks/databricks-sdk-go/httpclient"
+	"github.com/databricks/databricks-sdk-go/logger"
+)
+
+// ArchiveRepo makes the repository read-only for everyone.
+func (c *GitHubClient) ArchiveRepo(ctx context.Context, org, repo string) error {
+	return c.setArchived(ctx, org, repo, true)
+}
+
+func (c *GitHubClient) UnarchiveRepo(ctx context.Context, org, repo string) error {
+	return c.setArchived(ctx, org, repo, false)
+}
+
+func (c *GitHubClient) setArchived(ctx context.Context, org, repo string, archived bool) error {
+	path := fmt.Sprintf("%s/repos/%s/%s", gitHubAPI, org, repo)
+	return c.api.Do(ctx, "PATCH", path, httpclient.WithRequestData(map[string]any{
+		"archived": archived,
+	}))
+}
+
+type ArchivePolicy struct {
+	// InactiveMonths is the number of months without pushes, after which
+	// repositories are warned about archival. Default is 12.
+	InactiveMonths int
+
+	// GracePeriod between the warning and the archival. Default is 30 days.
+	GracePeriod time.Duration
+
+	// WarningLabel marks warning issues. Default is "archive-warning".
+	WarningLabel string
+
+	// Exclude lists repositories, that are never archived.
+	Exclude []string
+
+	// now is the test seam
+	now func() time.Time
+}
+
+type ArchiveAction struct {
+	Repo string
+
+	// Action is one of: warned, archived
+	Action string
+	Issue  *Issue
+}
+
+// ArchiveInactiveRepos opens a warning issue in repositories without pushes
+// for InactiveMonths, and archives them, if nobody pushed during the grace
+// period after the warning. Pushing anything, like closing the issue with a
+// commit, keeps the repository, and warnings older than the last push are
+// closed, so that the grace period starts over. Forks are never archived.
+func (c *GitHubClient) ArchiveInactiveRepos(ctx context.Context, org string, policy ArchivePolicy) ([]ArchiveAction, error) {
+	if policy.InactiveMonths == 0 {
+		policy.InactiveMonths = 12
+	}
+	if policy.GracePeriod == 0 {
+		policy.GracePeriod = 30 * 24 * time.Hour
+	}
+	if policy.WarningLabel == "" {
+		policy.WarningLabel = "archive-warning"
+	}
+	if policy.now == nil {
+		policy.now = time.Now
+	}
+	now := policy.now()
+	cutoff := now.AddDate(0, -policy.InactiveMonths, 0)
+	var inactive []Repo
+	err := c.StreamRepositories(ctx, org, func(r Repo) error {
+		if r.IsArchived || r.IsFork || slices.Contains(policy.Exclude, r.Name) {
+			return nil
+		}
+		if r.PushedAt.After(cutoff) {
+			return nil
+		}
+		inactive = append(inactive, r)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("repositories: %w", err)
+	}
+	var actions []ArchiveAction
+	for _, repo := range inactive {
+		warnings, err := c.ListIssues(ctx, org, repo.Name, IssueListOptions{
+			Labels: policy.WarningLabel,
+		})
+		if err != nil {
+			return actions, fmt.Errorf("%s: warnings: %w", repo.Name, err)
+		}
+		// warnings from before the last push are outdated, as somebody
+		// pushed in reply to them, and the repository is warned anew
+		var current []Issue
+		for _, warning := range warnings {
+			if warning.CreatedAt.After(repo.PushedAt) {
+				current = append(current, warning)
+				continue
+			}
+			_, err = c.CloseIssue(ctx, org, repo.Name, warning.Number, "not_planned")
+			if err != nil {
+				return actions, fmt.Errorf("%s: close warning: %w", repo.Name, err)
+			}
+			logger.Infof(ctx, "Closed outdated warning %s", warning.HTMLURL)
+		}
+		if len(current) == 0 {
+			issue, err := c.CreateIssue(ctx, org, repo.Name, NewIssue{
+				Title:  "This repository will be archived",
+				Body:   archiveWarning(repo, policy, now),
+				Labels: []string{policy.WarningLabel},
+			})
+			if err != nil {
+				return actions, fmt.Errorf("%s: warn: %w", repo.Name, err)
+			}
+			logger.Infof(ctx, "Warned %s about archival in %s", repo.Name, issue.HTMLURL)
+			actions = append(actions, ArchiveAction{
+				Repo:   repo.Name,
+				Action: "warned",
+				Issue:  issue,
+			})
+			continue
+		}
+		// issues are listed from the newest to the oldest
+		warning := current[len(current)-1]
+		if now.Sub(warning.CreatedAt) < policy.GracePeriod {
+			continue
+		}
+		err = c.ArchiveRepo(ctx, org, repo.Name)
+		if err != nil {
+			return actions, fmt.Errorf("%s: archive: %w", repo.Name, err)
+		}
+		logger.Infof(ctx, "Archived %s", repo.Name)
+		actions = append(actions, ArchiveAction{
+			Repo:   repo.Name,
+			Action: "archived",
+			Issue:  &warning,
+		})
+	}
+	return actions, nil
+}
+
+func archiveWarning(repo Repo, policy ArchivePolicy, now time.Time) string {
+	return fmt.Sprintf("There were no pushes to this repository since %s, "+
+		"so it will be archived after %s.\n\n"+
+		"Push any commit to keep the repository active.",
+		repo.PushedAt.Format("2006-01-02"),
+		now.Add(policy.GracePeriod).Format("2006-01-02"))
+}
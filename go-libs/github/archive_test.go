@@ -0,0 +1,75 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArchiveInactiveRepos(t *testing.T) {
+	var archived []string
+	var created NewIssue
+	var closed map[string]any
+	client := NewClient(&GitHubConfig{
+		GitHubTokenSource: GitHubTokenSource{Pat: "x"},
+		transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			switch r.Method + " " + r.URL.Path {
+			case "GET /users/a/repos":
+				return jsonResponse(200, `[
+					{"name": "active", "pushed_at": "2024-05-01T00:00:00Z"},
+					{"name": "new-stale", "pushed_at": "2022-01-01T00:00:00Z"},
+					{"name": "old-stale", "pushed_at": "2022-01-01T00:00:00Z"},
+					{"name": "recent-warning", "pushed_at": "2022-01-01T00:00:00Z"},
+					{"name": "outdated-warning", "pushed_at": "2023-01-01T00:00:00Z"},
+					{"name": "fork", "fork": true, "pushed_at": "2022-01-01T00:00:00Z"}
+				]`), nil
+			case "GET /repos/a/new-stale/issues":
+				assert.Equal(t, "archive-warning", r.URL.Query().Get("labels"))
+				return jsonResponse(200, `[]`), nil
+			case "POST /repos/a/new-stale/issues":
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&created))
+				return jsonResponse(201, `{"number": 1, "html_url": "https://github.com/a/new-stale/issues/1"}`), nil
+			case "GET /repos/a/old-stale/issues":
+				return jsonResponse(200, `[{"number": 3, "created_at": "2024-04-01T00:00:00Z"}]`), nil
+			case "GET /repos/a/recent-warning/issues":
+				return jsonResponse(200, `[{"number": 3, "created_at": "2024-05-30T00:00:00Z"}]`), nil
+			case "GET /repos/a/outdated-warning/issues":
+				return jsonResponse(200, `[{"number": 2, "created_at": "2022-06-01T00:00:00Z"}]`), nil
+			case "PATCH /repos/a/outdated-warning/issues/2":
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&closed))
+				return jsonResponse(200, `{"number": 2}`), nil
+			case "POST /repos/a/outdated-warning/issues":
+				return jsonResponse(201, `{"number": 4}`), nil
+			case "PATCH /repos/a/old-stale":
+				var body map[string]any
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+				assert.Equal(t, true, body["archived"])
+				archived = append(archived, "old-stale")
+				return jsonResponse(200, `{}`), nil
+			}
+			t.Errorf("unexpected: %s %s", r.Method, r.URL.Path)
+			return jsonResponse(404, `{"message": "Not Found"}`), nil
+		}),
+	})
+	actions, err := client.ArchiveInactiveRepos(context.Background(), "a", ArchivePolicy{
+		now: func() time.Time {
+			return time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, actions, 3)
+	assert.Equal(t, "new-stale", actions[0].Repo)
+	assert.Equal(t, "warned", actions[0].Action)
+	assert.Equal(t, []string{"archive-warning"}, created.Labels)
+	assert.Contains(t, created.Body, "after 2024-07-01")
+	assert.Equal(t, "archived", actions[1].Action)
+	assert.Equal(t, []string{"old-stale"}, archived)
+	assert.Equal(t, "outdated-warning", actions[2].Repo)
+	assert.Equal(t, "warned", actions[2].Action)
+	assert.Equal(t, "closed", closed["state"])
+}
@@ -0,0 +1,40 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+)
+
+// Artifact is a file or a directory uploaded by a workflow run, which is
+// downloaded as a zip archive.
+type Artifact struct {
+	ID                 int64     `json:"id"`
+	Name               string    `json:"name"`
+	SizeInBytes        int64     `json:"size_in_bytes"`
+	ArchiveDownloadURL string    `json:"archive_download_url,omitempty"`
+	Digest             string    `json:"digest,omitempty"`
+	Expired            bool      `json:"expired"`
+	CreatedAt          time.Time `json:"created_at"`
+	ExpiresAt          time.Time `json:"expires_at"`
+	WorkflowRun        struct {
+		ID         int64  `json:"id"`
+		HeadBranch string `json:"head_branch,omitempty"`
+		HeadSHA    string `json:"head_sha,omitempty"`
+	} `json:"workflow_run"`
+}
+
+// ListArtifacts returns artifacts of the workflow run, including expired
+// ones, which can no longer be downloaded.
+func (c *GitHubClient) ListArtifacts(ctx context.Context, org, repo string, runID int64) ([]Artifact, error) {
+	path := fmt.Sprintf("%s/repos/%s/%s/actions/runs/%d/artifacts", gitHubAPI, org, repo, runID)
+	return ToSlice(ctx, paginateField[Artifact](c, path, "artifacts", nil))
+}
+
+// DownloadArtifact streams the zip archive of the artifact to w. Archives of
+// expired artifacts are gone and fail with an error.
+func (c *GitHubClient) DownloadArtifact(ctx context.Context, org, repo string, artifactID int64, w io.Writer) error {
+	path := fmt.Sprintf("%s/repos/%s/%s/actions/artifacts/%d/zip", gitHubAPI, org, repo, artifactID)
+	return c.download(ctx, path, w)
+}
@@ -0,0 +1,53 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArtifacts(t *testing.T) {
+	client := NewClient(&GitHubConfig{
+		GitHubTokenSource: GitHubTokenSource{Pat: "x"},
+		transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			switch r.URL.Path {
+			case "/repos/a/b/actions/runs/7/artifacts":
+				return jsonResponse(200, `{"total_count": 2, "artifacts": [
+					{"id": 1, "name": "test-report", "size_in_bytes": 3},
+					{"id": 2, "name": "coverage", "expired": true}
+				]}`), nil
+			case "/repos/a/b/actions/artifacts/1/zip":
+				return &http.Response{
+					StatusCode: 200,
+					Header:     http.Header{"Content-Type": []string{"application/zip"}},
+					Body:       io.NopCloser(strings.NewReader("PK\x03")),
+					Request:    r,
+				}, nil
+			case "/repos/a/b/actions/artifacts/2/zip":
+				return jsonResponse(410, `{"message": "Artifact has expired"}`), nil
+			}
+			t.Fatalf("unexpected %s", r.URL.Path)
+			return nil, nil
+		}),
+	})
+	ctx := context.Background()
+	artifacts, err := client.ListArtifacts(ctx, "a", "b", 7)
+	require.NoError(t, err)
+	require.Len(t, artifacts, 2)
+	assert.Equal(t, "test-report", artifacts[0].Name)
+	assert.True(t, artifacts[1].Expired)
+
+	var buf bytes.Buffer
+	err = client.DownloadArtifact(ctx, "a", "b", 1, &buf)
+	require.NoError(t, err)
+	assert.Equal(t, "PK\x03", buf.String())
+
+	err = client.DownloadArtifact(ctx, "a", "b", 2, &buf)
+	assert.ErrorContains(t, err, "expired")
+}
@@ -0,0 +1,190 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/databricks/databricks-sdk-go/httpclient"
+	"github.com/databricks/databricks-sdk-go/logger"
+)
+
+const gitHubUploads = "https://uploads.github.com"
+
+var uploadRetryDelay = 2 * time.Second
+
+func (c *GitHubClient) ListReleaseAssets(ctx context.Context, org, repo string, releaseID int64) ([]Asset, error) {
+	path := fmt.Sprintf("%s/repos/%s/%s/releases/%d/assets", gitHubAPI, org, repo, releaseID)
+	var res []Asset
+	err := c.api.Do(ctx, "GET", path,
+		httpclient.WithRequestData(listOptions{PerPage: 100}),
+		httpclient.WithResponseUnmarshal(&res))
+	return res, err
+}
+
+func (c *GitHubClient) ListReleaseAssetsIterator(org, repo string, releaseID int64) *Iterator[Asset] {
+	path := fmt.Sprintf("%s/repos/%s/%s/releases/%d/assets", gitHubAPI, org, repo, releaseID)
+	return Paginate[Asset](c, path, nil)
+}
+
+func (c *GitHubClient) DeleteReleaseAsset(ctx context.Context, org, repo string, assetID int64) error {
+	path := fmt.Sprintf("%s/repos/%s/%s/releases/assets/%d", gitHubAPI, org, repo, assetID)
+	return c.api.Do(ctx, "DELETE", path)
+}
+
+type UploadAssetOptions struct {
+	// Name of the asset, which is the base name of the file by default.
+	Name  string
+	Label string
+
+	// ContentType is detected from the file extension by default.
+	ContentType string
+
+	// Progress is called with the number of bytes sent so far, starting
+	// from zero again for every attempt.
+	Progress func(sent, total int64)
+
+	// Retries of failed uploads, 3 by default. GitHub cannot resume uploads,
+	// so every retry deletes the partially uploaded asset and starts over.
+	Retries int
+}
+
+// UploadAsset uploads a file to a release. Partially uploaded assets, which
+// GitHub keeps after dropped connections, block uploads with the same name,
+// so they are deleted before every attempt.
+func (c *GitHubClient) UploadAsset(ctx context.Context, org, repo string, releaseID int64, file string, opts UploadAssetOptions) (*Asset, error) {
+	if opts.Name == "" {
+		opts.Name = filepath.Base(file)
+	}
+	if opts.ContentType == "" {
+		opts.ContentType = detectContentType(file)
+	}
+	if opts.Retries == 0 {
+		opts.Retries = 3
+	}
+	var err error
+	for attempt := 0; attempt <= opts.Retries; attempt++ {
+		if attempt > 0 {
+			logger.Warnf(ctx, "Retrying upload of %s (%d/%d): %s", opts.Name, attempt, opts.Retries, err)
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-time.After(time.Duration(attempt) * uploadRetryDelay):
+			}
+		}
+		err = c.deletePartialAsset(ctx, org, repo, releaseID, opts.Name)
+		if err != nil {
+			return nil, fmt.Errorf("cleanup: %w", err)
+		}
+		var asset *Asset
+		asset, err = c.uploadAsset(ctx, org, repo, releaseID, file, opts)
+		if err == nil {
+			return asset, nil
+		}
+		var apiErr *httpclient.HttpError
+		if errors.As(err, &apiErr) && apiErr.StatusCode < 500 {
+			break
+		}
+	}
+	return nil, fmt.Errorf("upload %s: %w", opts.Name, err)
+}
+
+func (c *GitHubClient) uploadAsset(ctx context.Context, org, repo string, releaseID int64, file string, opts UploadAssetOptions) (*Asset, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	stat, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	query := url.Values{"name": {opts.Name}}
+	if opts.Label != "" {
+		query.Set("label", opts.Label)
+	}
+	path := fmt.Sprintf("%s/repos/%s/%s/releases/%d/assets?%s",
+		gitHubUploads, org, repo, releaseID, query.Encode())
+	var body io.Reader = f
+	if opts.Progress != nil {
+		opts.Progress(0, stat.Size())
+		body = &progressReader{r: f, total: stat.Size(), fn: opts.Progress}
+	}
+	var res Asset
+	err = c.api.Do(ctx, "POST", path,
+		httpclient.WithRequestHeader("Content-Type", opts.ContentType),
+		httpclient.WithRequestVisitor(func(r *http.Request) error {
+			// uploads are rejected without explicit size
+			r.ContentLength = stat.Size()
+			return nil
+		}),
+		httpclient.WithRequestData(body),
+		httpclient.WithResponseUnmarshal(&res))
+	if err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+func (c *GitHubClient) deletePartialAsset(ctx context.Context, org, repo string, releaseID int64, name string) error {
+	assets, err := c.ListReleaseAssets(ctx, org, repo, releaseID)
+	if err != nil {
+		return err
+	}
+	for _, v := range assets {
+		if v.Name != name || v.State == "uploaded" {
+			continue
+		}
+		logger.Infof(ctx, "Deleting partially uploaded %s (state: %s)", v.Name, v.State)
+		err = c.DeleteReleaseAsset(ctx, org, repo, v.ID)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func detectContentType(file string) string {
+	switch filepath.Ext(file) {
+	case ".gz", ".tgz":
+		return "application/gzip"
+	case ".zip":
+		return "application/zip"
+	}
+	contentType := mime.TypeByExtension(filepath.Ext(file))
+	if contentType != "" {
+		return contentType
+	}
+	// binaries mostly have no extension, so look at their contents
+	f, err := os.Open(file)
+	if err != nil {
+		return "application/octet-stream"
+	}
+	defer f.Close()
+	head := make([]byte, 512)
+	n, _ := io.ReadFull(f, head)
+	return http.DetectContentType(head[:n])
+}
+
+type progressReader struct {
+	r     io.Reader
+	sent  int64
+	total int64
+	fn    func(sent, total int64)
+}
+
+func (pr *progressReader) Read(p []byte) (int, error) {
+	n, err := pr.r.Read(p)
+	if n > 0 {
+		pr.sent += int64(n)
+		pr.fn(pr.sent, pr.total)
+	}
+	return n, err
+}
@@ -0,0 +1,175 @@
+package github
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+type UploadDirectoryOptions struct {
+	// Parallelism is the number of concurrent uploads, 4 by default.
+	Parallelism int
+
+	// Manifest is the name of the asset, uploaded after all files, that lists
+	// names, sizes, content types and SHA256 digests of the uploaded files.
+	// It's "manifest.json" by default and "-" skips it.
+	Manifest string
+
+	// Progress is called for every file, see UploadAssetOptions.
+	Progress func(name string, sent, total int64)
+
+	// Retries of every upload, see UploadAssetOptions.
+	Retries int
+}
+
+type ManifestEntry struct {
+	Name        string `json:"name"`
+	Size        int64  `json:"size"`
+	ContentType string `json:"content_type"`
+	SHA256      string `json:"sha256"`
+}
+
+// UploadDirectory uploads all regular files in dir as assets of a release.
+// Hidden files and subdirectories are skipped. Returns uploaded assets sorted
+// by name, with the manifest being the last one.
+func (c *GitHubClient) UploadDirectory(ctx context.Context, org, repo string, releaseID int64, dir string, opts UploadDirectoryOptions) ([]Asset, error) {
+	if opts.Parallelism == 0 {
+		opts.Parallelism = 4
+	}
+	if opts.Manifest == "" {
+		opts.Manifest = "manifest.json"
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var files []string
+	for _, v := range entries {
+		if !v.Type().IsRegular() || strings.HasPrefix(v.Name(), ".") {
+			continue
+		}
+		if v.Name() == opts.Manifest {
+			return nil, fmt.Errorf("%s conflicts with the manifest", v.Name())
+		}
+		files = append(files, v.Name())
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		errs     []error
+		assets   []Asset
+		manifest []ManifestEntry
+	)
+	sem := make(chan struct{}, opts.Parallelism)
+	for _, name := range files {
+		wg.Add(1)
+		go func(name string) {
+			defer wg.Done()
+			select {
+			case sem <- struct{}{}:
+				defer func() { <-sem }()
+			case <-ctx.Done():
+				return
+			}
+			file := filepath.Join(dir, name)
+			entry, err := manifestEntry(file)
+			if err != nil {
+				mu.Lock()
+				errs = append(errs, fmt.Errorf("%s: %w", name, err))
+				mu.Unlock()
+				cancel()
+				return
+			}
+			uploadOpts := UploadAssetOptions{
+				Name:        name,
+				ContentType: entry.ContentType,
+				Retries:     opts.Retries,
+			}
+			if opts.Progress != nil {
+				uploadOpts.Progress = func(sent, total int64) {
+					opts.Progress(name, sent, total)
+				}
+			}
+			asset, err := c.UploadAsset(ctx, org, repo, releaseID, file, uploadOpts)
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				errs = append(errs, err)
+				cancel()
+				return
+			}
+			assets = append(assets, *asset)
+			manifest = append(manifest, entry)
+		}(name)
+	}
+	wg.Wait()
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	// uploads, that didn't start, don't report the cancellation
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	sort.Slice(assets, func(i, j int) bool {
+		return assets[i].Name < assets[j].Name
+	})
+	if opts.Manifest == "-" {
+		return assets, nil
+	}
+	sort.Slice(manifest, func(i, j int) bool {
+		return manifest[i].Name < manifest[j].Name
+	})
+	asset, err := c.uploadManifest(ctx, org, repo, releaseID, opts.Manifest, manifest)
+	if err != nil {
+		return nil, fmt.Errorf("manifest: %w", err)
+	}
+	return append(assets, *asset), nil
+}
+
+func manifestEntry(file string) (entry ManifestEntry, err error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+	h := sha256.New()
+	entry.Size, err = io.Copy(h, f)
+	if err != nil {
+		return
+	}
+	entry.Name = filepath.Base(file)
+	entry.SHA256 = hex.EncodeToString(h.Sum(nil))
+	entry.ContentType = detectContentType(file)
+	return
+}
+
+func (c *GitHubClient) uploadManifest(ctx context.Context, org, repo string, releaseID int64, name string, manifest []ManifestEntry) (*Asset, error) {
+	raw, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	dir, err := os.MkdirTemp("", "manifest-*")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, name)
+	err = os.WriteFile(file, raw, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	return c.UploadAsset(ctx, org, repo, releaseID, file, UploadAssetOptions{
+		ContentType: "application/json",
+	})
+}
@@ -0,0 +1,137 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUploadAssetRetriesWithCleanup(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "tool.zip")
+	err := os.WriteFile(file, []byte("hello"), 0o600)
+	require.NoError(t, err)
+
+	uploadRetryDelay = 0
+	var calls []string
+	uploads := 0
+	client := NewClient(&GitHubConfig{
+		GitHubTokenSource: GitHubTokenSource{Pat: "x"},
+		transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			calls = append(calls, r.Method+" "+r.URL.Host+r.URL.Path)
+			switch r.Method {
+			case "GET":
+				if uploads == 0 {
+					return jsonResponse(200, `[]`), nil
+				}
+				return jsonResponse(200, `[{"id": 7, "name": "tool.zip", "state": "starter"}]`), nil
+			case "DELETE":
+				return jsonResponse(204, ``), nil
+			}
+			uploads++
+			assert.Equal(t, "application/zip", r.Header.Get("Content-Type"))
+			assert.Equal(t, "tool.zip", r.URL.Query().Get("name"))
+			assert.Equal(t, int64(5), r.ContentLength)
+			io.ReadAll(r.Body)
+			if uploads == 1 {
+				return jsonResponse(502, `{}`), nil
+			}
+			return jsonResponse(201, `{"id": 8, "name": "tool.zip", "state": "uploaded"}`), nil
+		}),
+	})
+	var progress []int64
+	asset, err := client.UploadAsset(context.Background(), "org", "repo", 1, file, UploadAssetOptions{
+		Progress: func(sent, total int64) {
+			assert.Equal(t, int64(5), total)
+			progress = append(progress, sent)
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(8), asset.ID)
+	assert.Equal(t, []int64{0, 5, 0, 5}, progress)
+	assert.Equal(t, []string{
+		"GET api.github.com/repos/org/repo/releases/1/assets",
+		"POST uploads.github.com/repos/org/repo/releases/1/assets",
+		"GET api.github.com/repos/org/repo/releases/1/assets",
+		"DELETE api.github.com/repos/org/repo/releases/assets/7",
+		"POST uploads.github.com/repos/org/repo/releases/1/assets",
+	}, calls)
+}
+
+func TestUploadDirectory(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"a.zip", "b.tar.gz", "c", ".hidden"} {
+		err := os.WriteFile(filepath.Join(dir, name), []byte(name), 0o600)
+		require.NoError(t, err)
+	}
+	var mu sync.Mutex
+	uploaded := map[string]string{}
+	var manifest []ManifestEntry
+	client := NewClient(&GitHubConfig{
+		GitHubTokenSource: GitHubTokenSource{Pat: "x"},
+		transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			if r.Method == "GET" {
+				return jsonResponse(200, `[]`), nil
+			}
+			name := r.URL.Query().Get("name")
+			body, _ := io.ReadAll(r.Body)
+			mu.Lock()
+			uploaded[name] = r.Header.Get("Content-Type")
+			mu.Unlock()
+			if name == "manifest.json" {
+				require.NoError(t, json.Unmarshal(body, &manifest))
+			}
+			return jsonResponse(201, fmt.Sprintf(`{"name": %q}`, name)), nil
+		}),
+	})
+	assets, err := client.UploadDirectory(context.Background(), "org", "repo", 1, dir, UploadDirectoryOptions{
+		Parallelism: 2,
+	})
+	require.NoError(t, err)
+	var names []string
+	for _, v := range assets {
+		names = append(names, v.Name)
+	}
+	assert.Equal(t, []string{"a.zip", "b.tar.gz", "c", "manifest.json"}, names)
+	assert.Equal(t, map[string]string{
+		"a.zip":         "application/zip",
+		"b.tar.gz":      "application/gzip",
+		"c":             "text/plain; charset=utf-8",
+		"manifest.json": "application/json",
+	}, uploaded)
+	require.Len(t, manifest, 3)
+	assert.Equal(t, ManifestEntry{
+		Name:        "c",
+		Size:        1,
+		ContentType: "text/plain; charset=utf-8",
+		SHA256:      "2e7d2c03a9507ae265ecf5b5356885a53393a2029d241394997265a1a25aefc6",
+	}, manifest[2])
+}
+
+func TestUploadDirectoryCancelled(t *testing.T) {
+	dir := t.TempDir()
+	err := os.WriteFile(filepath.Join(dir, "a.zip"), []byte("a"), 0o600)
+	require.NoError(t, err)
+	client := NewClient(&GitHubConfig{
+		GitHubTokenSource: GitHubTokenSource{Pat: "x"},
+		transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL)
+			return nil, nil
+		}),
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assets, err := client.UploadDirectory(ctx, "org", "repo", 1, dir, UploadDirectoryOptions{
+		Manifest: "-",
+	})
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Nil(t, assets)
+}
@@ -0,0 +1,246 @@
+package github
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/databricks/databricks-sdk-go/logger"
+	"github.com/databrickslabs/sandbox/go-libs/process"
+)
+
+var (
+	ErrChecksumMismatch  = errors.New("checksum mismatch")
+	ErrNoChecksums       = errors.New("no checksums in release")
+	ErrSignatureMissing  = errors.New("no signature of checksums in release")
+	ErrSignatureMismatch = errors.New("signature verification failed")
+)
+
+type VerifyOptions struct {
+	// RequireSignature fails verification of releases without a signature
+	// of the checksums file, or without a way to verify it.
+	RequireSignature bool
+
+	// GPGKeyring is a keyring with trusted public keys to verify detached
+	// .asc or .sig signatures of the checksums file with gpg.
+	GPGKeyring string
+
+	// CosignKey is a public key to verify .sig signatures with cosign.
+	// For keyless signatures, set CosignIdentity and CosignIssuer, which
+	// are matched against the .pem certificate or the .bundle of a release.
+	CosignKey      string
+	CosignIdentity string
+	CosignIssuer   string
+}
+
+// ParseChecksums reads the output of sha256sum, like SHA256SUMS or
+// checksums.txt assets, into a map of file names to hex digests.
+func ParseChecksums(r io.Reader) (map[string]string, error) {
+	checksums := map[string]string{}
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("invalid checksum line: %s", line)
+		}
+		// binary mode of sha256sum prefixes names with an asterisk
+		name := strings.TrimPrefix(fields[1], "*")
+		checksums[name] = strings.ToLower(fields[0])
+	}
+	return checksums, scanner.Err()
+}
+
+// findChecksumsAsset looks for a checksums file of the whole release and
+// falls back to a checksum file of the single asset, like tool.zip.sha256.
+func findChecksumsAsset(release Release, asset Asset) (*Asset, bool) {
+	for i, v := range release.Assets {
+		name := strings.ToLower(v.Name)
+		if name == "sha256sums" || name == "sha256sums.txt" ||
+			strings.HasSuffix(name, "checksums.txt") ||
+			strings.HasSuffix(name, "_sha256sums") {
+			return &release.Assets[i], true
+		}
+	}
+	for i, v := range release.Assets {
+		if v.Name == asset.Name+".sha256" {
+			return &release.Assets[i], false
+		}
+	}
+	return nil, false
+}
+
+func findAsset(release Release, name string) (*Asset, bool) {
+	for i, v := range release.Assets {
+		if v.Name == name {
+			return &release.Assets[i], true
+		}
+	}
+	return nil, false
+}
+
+// VerifyAsset checks the downloaded file of a release asset against the
+// checksums published with the release, and the signature of the checksums,
+// if there's one. Mismatches are always errors.
+func (c *GitHubClient) VerifyAsset(ctx context.Context, org, repo string, release Release, asset Asset, file string, opts VerifyOptions) error {
+	checksumsAsset, isReleaseWide := findChecksumsAsset(release, asset)
+	if checksumsAsset == nil {
+		return fmt.Errorf("%w: %s", ErrNoChecksums, release.Version)
+	}
+	var checksums bytes.Buffer
+	err := c.DownloadAsset(ctx, org, repo, checksumsAsset.ID, &checksums)
+	if err != nil {
+		return fmt.Errorf("download %s: %w", checksumsAsset.Name, err)
+	}
+	expected, err := ParseChecksums(bytes.NewReader(checksums.Bytes()))
+	if err != nil {
+		return fmt.Errorf("%s: %w", checksumsAsset.Name, err)
+	}
+	digest, ok := expected[asset.Name]
+	if !isReleaseWide && len(expected) == 1 {
+		// tool.zip.sha256 may refer to the file by any name or no name at all
+		for _, v := range expected {
+			digest, ok = v, true
+		}
+	}
+	if !ok {
+		return fmt.Errorf("%w: %s is not in %s", ErrChecksumMismatch, asset.Name, checksumsAsset.Name)
+	}
+	err = verifySHA256(file, digest)
+	if err != nil {
+		return fmt.Errorf("%s: %w", asset.Name, err)
+	}
+	logger.Debugf(ctx, "Verified checksum of %s", asset.Name)
+	return c.verifySignature(ctx, org, repo, release, *checksumsAsset, checksums.Bytes(), opts)
+}
+
+func verifySHA256(file, expected string) error {
+	f, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	h := sha256.New()
+	_, err = io.Copy(h, f)
+	if err != nil {
+		return err
+	}
+	actual := hex.EncodeToString(h.Sum(nil))
+	if actual != strings.ToLower(expected) {
+		return fmt.Errorf("%w: expected sha256 %s, got %s", ErrChecksumMismatch, expected, actual)
+	}
+	return nil
+}
+
+func (c *GitHubClient) verifySignature(ctx context.Context, org, repo string, release Release,
+	checksumsAsset Asset, checksums []byte, opts VerifyOptions) error {
+	var signature, certificate, bundle *Asset
+	var isGPG bool
+	if v, ok := findAsset(release, checksumsAsset.Name+".asc"); ok {
+		signature, isGPG = v, true
+	} else if v, ok := findAsset(release, checksumsAsset.Name+".sig"); ok {
+		signature = v
+		certificate, _ = findAsset(release, checksumsAsset.Name+".pem")
+		// .sig files without cosign key or certificate are gpg signatures
+		isGPG = certificate == nil && opts.CosignKey == ""
+	}
+	if v, ok := findAsset(release, checksumsAsset.Name+".bundle"); ok {
+		bundle, isGPG = v, false
+	}
+	if signature == nil && bundle == nil {
+		if opts.RequireSignature {
+			return fmt.Errorf("%w: %s", ErrSignatureMissing, checksumsAsset.Name)
+		}
+		logger.Debugf(ctx, "No signature for %s", checksumsAsset.Name)
+		return nil
+	}
+	// verification runs in a temporary directory
+	for _, v := range []*string{&opts.GPGKeyring, &opts.CosignKey} {
+		if *v == "" {
+			continue
+		}
+		abs, err := filepath.Abs(*v)
+		if err != nil {
+			return err
+		}
+		*v = abs
+	}
+	var args []string
+	switch {
+	case isGPG && opts.GPGKeyring != "":
+		args = []string{"gpg", "--batch", "--no-default-keyring",
+			"--keyring", opts.GPGKeyring, "--verify", "signature", "checksums"}
+	case !isGPG && opts.CosignKey != "":
+		args = []string{"cosign", "verify-blob", "--key", opts.CosignKey}
+	case !isGPG && opts.CosignIdentity != "" && opts.CosignIssuer != "":
+		args = []string{"cosign", "verify-blob",
+			"--certificate-identity", opts.CosignIdentity,
+			"--certificate-oidc-issuer", opts.CosignIssuer}
+	default:
+		if opts.RequireSignature {
+			return fmt.Errorf("%w: not configured to verify signature of %s",
+				ErrSignatureMismatch, checksumsAsset.Name)
+		}
+		logger.Warnf(ctx, "Skipping unverifiable signature of %s", checksumsAsset.Name)
+		return nil
+	}
+	dir, err := os.MkdirTemp("", "verify-*")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+	files := map[string][]byte{"checksums": checksums}
+	for name, asset := range map[string]*Asset{
+		"signature":   signature,
+		"certificate": certificate,
+		"bundle":      bundle,
+	} {
+		if asset == nil {
+			continue
+		}
+		var buf bytes.Buffer
+		err = c.DownloadAsset(ctx, org, repo, asset.ID, &buf)
+		if err != nil {
+			return fmt.Errorf("download %s: %w", asset.Name, err)
+		}
+		files[name] = buf.Bytes()
+		if args[0] == "cosign" {
+			args = append(args, "--"+name, name)
+		}
+	}
+	for name, data := range files {
+		err = os.WriteFile(filepath.Join(dir, name), data, 0o600)
+		if err != nil {
+			return err
+		}
+	}
+	if args[0] == "cosign" {
+		args = append(args, "checksums")
+	}
+	_, err = process.Background(ctx, args, process.WithDir(dir))
+	if errors.Is(err, exec.ErrNotFound) {
+		return fmt.Errorf("%s is required to verify %s: %w", args[0], checksumsAsset.Name, err)
+	}
+	var processErr *process.ProcessError
+	if errors.As(err, &processErr) {
+		return fmt.Errorf("%w: %s: %s", ErrSignatureMismatch,
+			checksumsAsset.Name, strings.TrimSpace(processErr.Stderr))
+	}
+	if err != nil {
+		return fmt.Errorf("%s: %w", args[0], err)
+	}
+	logger.Debugf(ctx, "Verified %s signature of %s", args[0], checksumsAsset.Name)
+	return nil
+}
@@ -0,0 +1,69 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseChecksums(t *testing.T) {
+	checksums, err := ParseChecksums(strings.NewReader(`
+# generated
+ABCDEF  tool_linux_amd64.tar.gz
+012345 *tool_windows_amd64.zip
+`))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"tool_linux_amd64.tar.gz": "abcdef",
+		"tool_windows_amd64.zip":  "012345",
+	}, checksums)
+
+	_, err = ParseChecksums(strings.NewReader("abc"))
+	assert.ErrorContains(t, err, "invalid checksum line: abc")
+}
+
+func TestVerifyAsset(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "download")
+	err := os.WriteFile(file, []byte("hello"), 0o600)
+	require.NoError(t, err)
+	hello := "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"
+
+	checksums := hello + "  tool.zip\n"
+	client := NewClient(&GitHubConfig{
+		GitHubTokenSource: GitHubTokenSource{Pat: "x"},
+		transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			assert.Equal(t, "/repos/org/repo/releases/assets/2", r.URL.Path)
+			return jsonResponse(200, checksums), nil
+		}),
+	})
+	release := Release{
+		Version: "v0.1.0",
+		Assets: []Asset{
+			{ID: 1, Name: "tool.zip"},
+			{ID: 2, Name: "checksums.txt"},
+		},
+	}
+	ctx := context.Background()
+	err = client.VerifyAsset(ctx, "org", "repo", release, release.Assets[0], file, VerifyOptions{})
+	assert.NoError(t, err)
+
+	checksums = fmt.Sprintf("%064d  tool.zip\n", 0)
+	err = client.VerifyAsset(ctx, "org", "repo", release, release.Assets[0], file, VerifyOptions{})
+	assert.ErrorIs(t, err, ErrChecksumMismatch)
+
+	checksums = hello + "  tool.zip\n"
+	err = client.VerifyAsset(ctx, "org", "repo", release, release.Assets[0], file, VerifyOptions{
+		RequireSignature: true,
+	})
+	assert.ErrorIs(t, err, ErrSignatureMissing)
+
+	err = client.VerifyAsset(ctx, "org", "repo", Release{}, release.Assets[0], file, VerifyOptions{})
+	assert.ErrorIs(t, err, ErrNoChecksums)
+}
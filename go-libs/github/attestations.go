@@ -0,0 +1,201 @@
+package github
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/databricks/databricks-sdk-go/httpclient"
+	"github.com/databricks/databricks-sdk-go/logger"
+	"github.com/databrickslabs/sandbox/go-libs/process"
+)
+
+var (
+	ErrNoAttestations     = errors.New("no attestations")
+	ErrProvenanceMismatch = errors.New("provenance verification failed")
+)
+
+const slsaProvenancePredicate = "https://slsa.dev/provenance/v1"
+
+// Attestation is a Sigstore bundle with a signed in-toto statement about
+// an artifact, like its build provenance.
+// See https://docs.github.com/en/rest/repos/repos#list-attestations
+type Attestation struct {
+	Bundle       json.RawMessage `json:"bundle"`
+	RepositoryID int64           `json:"repository_id"`
+	BundleURL    string          `json:"bundle_url,omitempty"`
+}
+
+// Statement is an in-toto statement of an attestation.
+// See https://github.com/in-toto/attestation/blob/main/spec/v1/statement.md
+type Statement struct {
+	Type    string `json:"_type"`
+	Subject []struct {
+		Name   string            `json:"name"`
+		Digest map[string]string `json:"digest"`
+	} `json:"subject"`
+	PredicateType string          `json:"predicateType"`
+	Predicate     json.RawMessage `json:"predicate"`
+}
+
+// Statement decodes the statement from the DSSE envelope of the bundle
+// without verifying its signature.
+func (a Attestation) Statement() (*Statement, error) {
+	var bundle struct {
+		Envelope struct {
+			PayloadType string `json:"payloadType"`
+			Payload     string `json:"payload"`
+		} `json:"dsseEnvelope"`
+	}
+	err := json.Unmarshal(a.Bundle, &bundle)
+	if err != nil {
+		return nil, fmt.Errorf("bundle: %w", err)
+	}
+	if bundle.Envelope.PayloadType != "application/vnd.in-toto+json" {
+		return nil, fmt.Errorf("unsupported payload: %s", bundle.Envelope.PayloadType)
+	}
+	payload, err := base64.StdEncoding.DecodeString(bundle.Envelope.Payload)
+	if err != nil {
+		return nil, fmt.Errorf("payload: %w", err)
+	}
+	var statement Statement
+	err = json.Unmarshal(payload, &statement)
+	if err != nil {
+		return nil, fmt.Errorf("statement: %w", err)
+	}
+	return &statement, nil
+}
+
+func (s *Statement) hasSubject(digest string) bool {
+	algorithm, value, _ := strings.Cut(digest, ":")
+	for _, v := range s.Subject {
+		if strings.EqualFold(v.Digest[algorithm], value) {
+			return true
+		}
+	}
+	return false
+}
+
+// ListAttestations returns attestations of an artifact with the digest, like
+// sha256:abc..., that were created in the repository.
+func (c *GitHubClient) ListAttestations(ctx context.Context, org, repo, digest string) ([]Attestation, error) {
+	path := fmt.Sprintf("%s/repos/%s/%s/attestations/%s", gitHubAPI, org, repo, digest)
+	var res struct {
+		Attestations []Attestation `json:"attestations"`
+	}
+	err := c.api.Do(ctx, "GET", path,
+		httpclient.WithRequestData(listOptions{PerPage: maxPageSize}),
+		httpclient.WithResponseUnmarshal(&res))
+	if errors.Is(err, ErrNotFound) {
+		return nil, nil
+	}
+	return res.Attestations, err
+}
+
+func fileDigest(file string) (string, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	h := sha256.New()
+	_, err = io.Copy(h, f)
+	if err != nil {
+		return "", err
+	}
+	return "sha256:" + hex.EncodeToString(h.Sum(nil)), nil
+}
+
+type ProvenanceOptions struct {
+	// SignerWorkflow restricts provenance to builds by the workflow, like
+	// org/repo/.github/workflows/release.yml.
+	SignerWorkflow string
+}
+
+// VerifyProvenance checks, that the file was built in the repository, as
+// attested by a SLSA build provenance attestation of the artifact. Signatures
+// of the attestations are verified with the gh CLI against Sigstore roots of
+// trust, so that forged bundles are rejected. Returns the verified statement.
+func (c *GitHubClient) VerifyProvenance(ctx context.Context, org, repo, file string, opts ProvenanceOptions) (*Statement, error) {
+	digest, err := fileDigest(file)
+	if err != nil {
+		return nil, err
+	}
+	attestations, err := c.ListAttestations(ctx, org, repo, digest)
+	if err != nil {
+		return nil, fmt.Errorf("attestations: %w", err)
+	}
+	var bundles []Attestation
+	var statement *Statement
+	for _, v := range attestations {
+		s, err := v.Statement()
+		if err != nil {
+			logger.Debugf(ctx, "Skipping attestation of %s: %s", filepath.Base(file), err)
+			continue
+		}
+		if s.PredicateType != slsaProvenancePredicate || !s.hasSubject(digest) {
+			continue
+		}
+		bundles = append(bundles, v)
+		statement = s
+	}
+	if len(bundles) == 0 {
+		return nil, fmt.Errorf("%w: %s (%s)", ErrNoAttestations, filepath.Base(file), digest)
+	}
+	err = verifyBundles(ctx, org, repo, file, bundles, opts)
+	if err != nil {
+		return nil, err
+	}
+	logger.Debugf(ctx, "Verified provenance of %s", filepath.Base(file))
+	return statement, nil
+}
+
+func verifyBundles(ctx context.Context, org, repo, file string, bundles []Attestation, opts ProvenanceOptions) error {
+	file, err := filepath.Abs(file)
+	if err != nil {
+		return err
+	}
+	dir, err := os.MkdirTemp("", "provenance-*")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+	var jsonl []byte
+	for _, v := range bundles {
+		jsonl = append(jsonl, v.Bundle...)
+		jsonl = append(jsonl, '\n')
+	}
+	err = os.WriteFile(filepath.Join(dir, "bundles.jsonl"), jsonl, 0o600)
+	if err != nil {
+		return err
+	}
+	args := []string{"gh", "attestation", "verify", file,
+		"--bundle", "bundles.jsonl",
+		"--repo", fmt.Sprintf("%s/%s", org, repo),
+		"--predicate-type", slsaProvenancePredicate}
+	if opts.SignerWorkflow != "" {
+		args = append(args, "--signer-workflow", opts.SignerWorkflow)
+	}
+	_, err = process.Background(ctx, args, process.WithDir(dir))
+	if errors.Is(err, exec.ErrNotFound) {
+		return fmt.Errorf("gh is required to verify provenance of %s: %w", filepath.Base(file), err)
+	}
+	var processErr *process.ProcessError
+	if errors.As(err, &processErr) {
+		return fmt.Errorf("%w: %s: %s", ErrProvenanceMismatch,
+			filepath.Base(file), strings.TrimSpace(processErr.Stderr))
+	}
+	if err != nil {
+		return fmt.Errorf("gh: %w", err)
+	}
+	return nil
+}
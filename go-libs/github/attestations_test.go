@@ -0,0 +1,46 @@
+package github
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAttestationStatement(t *testing.T) {
+	payload := base64.StdEncoding.EncodeToString([]byte(`{
+		"_type": "https://in-toto.io/Statement/v1",
+		"subject": [{"name": "cli.zip", "digest": {"sha256": "ABC"}}],
+		"predicateType": "https://slsa.dev/provenance/v1",
+		"predicate": {"buildDefinition": {}}
+	}`))
+	attestation := Attestation{Bundle: []byte(`{"dsseEnvelope": {
+		"payloadType": "application/vnd.in-toto+json",
+		"payload": "` + payload + `"
+	}}`)}
+	statement, err := attestation.Statement()
+	require.NoError(t, err)
+	assert.Equal(t, slsaProvenancePredicate, statement.PredicateType)
+	assert.True(t, statement.hasSubject("sha256:abc"))
+	assert.False(t, statement.hasSubject("sha256:def"))
+}
+
+func TestVerifyProvenanceWithoutAttestations(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "cli.zip")
+	require.NoError(t, os.WriteFile(file, []byte("hello"), 0o600))
+	client := NewClient(&GitHubConfig{
+		GitHubTokenSource: GitHubTokenSource{Pat: "x"},
+		transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			assert.Equal(t, "/repos/a/b/attestations/"+
+				"sha256:2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824", r.URL.Path)
+			return jsonResponse(404, `{"message": "Not Found"}`), nil
+		}),
+	})
+	_, err := client.VerifyProvenance(context.Background(), "a", "b", file, ProvenanceOptions{})
+	assert.ErrorIs(t, err, ErrNoAttestations)
+}
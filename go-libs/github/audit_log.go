@@ -0,0 +1,164 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/databricks/databricks-sdk-go/logger"
+)
+
+// AuditEntry describes a mutating request made by the client.
+type AuditEntry struct {
+	Time      time.Time     `json:"time"`
+	Actor     string        `json:"actor,omitempty"`
+	Subsystem string        `json:"subsystem,omitempty"`
+	Method    string        `json:"method"`
+	URL       string        `json:"url"`
+	Request   string        `json:"request,omitempty"`
+	Status    int           `json:"status,omitempty"`
+	Error     string        `json:"error,omitempty"`
+	Duration  time.Duration `json:"duration"`
+	DryRun    bool          `json:"dry_run,omitempty"`
+}
+
+// AuditLog records every mutating request, including GraphQL mutations and
+// retried attempts, for compliance review of automation runs. Entries are
+// appended to a JSONL file, passed to a callback, or both. Secrets are
+// redacted from URLs and request summaries like in logs. The log may be
+// shared by clients.
+type AuditLog struct {
+	// Actor is recorded as the one making the changes, like the login of a
+	// bot or the URL of a workflow run.
+	Actor string
+
+	// OnEntry is called for every entry, after it is written to the file.
+	OnEntry func(ctx context.Context, entry AuditEntry)
+
+	mu   sync.Mutex
+	file *os.File
+	now  func() time.Time
+}
+
+// NewAuditLog creates a log, that appends entries to the file, or only
+// calls OnEntry, if the file is empty.
+func NewAuditLog(file, actor string) (*AuditLog, error) {
+	a := &AuditLog{Actor: actor, now: time.Now}
+	if file == "" {
+		return a, nil
+	}
+	f, err := os.OpenFile(file, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	a.file = f
+	return a, nil
+}
+
+// Close closes the file of the log.
+func (a *AuditLog) Close() error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.file == nil {
+		return nil
+	}
+	err := a.file.Close()
+	a.file = nil
+	return err
+}
+
+func (a *AuditLog) record(ctx context.Context, entry AuditEntry) {
+	a.mu.Lock()
+	if a.file != nil {
+		raw, err := json.Marshal(entry)
+		if err == nil {
+			_, err = a.file.Write(append(raw, '\n'))
+		}
+		if err != nil {
+			// audit must not break automation, but nobody should miss the gap
+			logger.Errorf(ctx, "Failed to audit %s %s: %s", entry.Method, entry.URL, err)
+		}
+	}
+	a.mu.Unlock()
+	if a.OnEntry != nil {
+		a.OnEntry(ctx, entry)
+	}
+}
+
+type auditTransport struct {
+	next     http.RoundTripper
+	log      *AuditLog
+	redactor *redactor
+	dryRun   bool
+}
+
+func (t *auditTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	if r.Method == "GET" || r.Method == "HEAD" || r.Method == "OPTIONS" {
+		return t.next.RoundTrip(r)
+	}
+	body, summary, err := auditBody(r)
+	if err != nil {
+		return nil, err
+	}
+	if strings.HasSuffix(r.URL.Path, "/graphql") && !isGraphQLMutation(body) {
+		return t.next.RoundTrip(r)
+	}
+	now := time.Now
+	if t.log.now != nil {
+		now = t.log.now
+	}
+	start := now()
+	resp, err := t.next.RoundTrip(r)
+	entry := AuditEntry{
+		Time:     start.UTC(),
+		Actor:    t.log.Actor,
+		Method:   r.Method,
+		URL:      t.redactor.redact(r.URL.String()),
+		Request:  t.redactor.redact(summary),
+		Duration: now().Sub(start),
+		DryRun:   t.dryRun,
+	}
+	entry.Subsystem, _ = r.Context().Value(subsystemKey{}).(string)
+	if resp != nil {
+		entry.Status = resp.StatusCode
+	}
+	if err != nil {
+		entry.Error = t.redactor.redact(err.Error())
+	}
+	t.log.record(r.Context(), entry)
+	return resp, err
+}
+
+// auditCaptureBytes limits request bodies, that are read to be recorded
+const auditCaptureBytes = 64 << 10
+
+// auditBody reads small JSON bodies and puts them back into the request.
+// Other bodies, like streamed uploads of assets, are left untouched and are
+// only summarized by their size and type.
+func auditBody(r *http.Request) ([]byte, string, error) {
+	if r.Body == nil || r.Body == http.NoBody {
+		return nil, "", nil
+	}
+	contentType := r.Header.Get("Content-Type")
+	if !strings.HasPrefix(contentType, "application/json") ||
+		r.ContentLength < 0 || r.ContentLength > auditCaptureBytes {
+		return nil, fmt.Sprintf("(%d bytes of %s)", r.ContentLength, contentType), nil
+	}
+	body, err := io.ReadAll(r.Body)
+	r.Body.Close()
+	if err != nil {
+		return nil, "", err
+	}
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	r.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(body)), nil
+	}
+	return body, describeBody(r, body), nil
+}
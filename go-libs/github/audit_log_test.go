@@ -0,0 +1,116 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuditLogRecordsMutations(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "audit.jsonl")
+	audit, err := NewAuditLog(file, "release-bot")
+	require.NoError(t, err)
+	var entries []AuditEntry
+	audit.OnEntry = func(ctx context.Context, entry AuditEntry) {
+		entries = append(entries, entry)
+	}
+	client := NewClient(&GitHubConfig{
+		GitHubTokenSource: GitHubTokenSource{Pat: "x"},
+		AuditLog:          audit,
+		transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			if r.Method == "DELETE" {
+				return jsonResponse(404, `{"message": "Not Found"}`), nil
+			}
+			return jsonResponse(201, `{"number": 1}`), nil
+		}),
+	})
+	ctx := WithSubsystem(context.Background(), "releases")
+	_, err = client.GetPullRequest(ctx, "a", "b", 1)
+	require.NoError(t, err)
+	_, err = client.CreatePullRequest(ctx, "a", "b", NewPullRequest{
+		Title: "Release",
+		Head:  "release",
+		Base:  "main",
+	})
+	require.NoError(t, err)
+	err = client.DeleteDeploymentProtectionRule(ctx, "a", "b", "prod", 1)
+	require.ErrorIs(t, err, ErrNotFound)
+	require.NoError(t, audit.Close())
+
+	require.Len(t, entries, 2)
+	assert.Equal(t, "release-bot", entries[0].Actor)
+	assert.Equal(t, "releases", entries[0].Subsystem)
+	assert.Equal(t, "POST", entries[0].Method)
+	assert.Equal(t, "https://api.github.com/repos/a/b/pulls", entries[0].URL)
+	assert.Contains(t, entries[0].Request, `"title":"Release"`)
+	assert.Equal(t, 201, entries[0].Status)
+	assert.Equal(t, "DELETE", entries[1].Method)
+	assert.Equal(t, 404, entries[1].Status)
+
+	raw, err := os.ReadFile(file)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(raw)), "\n")
+	require.Len(t, lines, 2)
+	var first AuditEntry
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &first))
+	assert.Equal(t, entries[0].URL, first.URL)
+	assert.Equal(t, entries[0].Time, first.Time)
+}
+
+func TestAuditLogRecordsDryRun(t *testing.T) {
+	audit, err := NewAuditLog("", "")
+	require.NoError(t, err)
+	var entries []AuditEntry
+	audit.OnEntry = func(ctx context.Context, entry AuditEntry) {
+		entries = append(entries, entry)
+	}
+	client := NewClient(&GitHubConfig{
+		GitHubTokenSource: GitHubTokenSource{Pat: "x"},
+		DryRun:            true,
+		AuditLog:          audit,
+		transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			return jsonResponse(200, `{"data": {"repository": {"id": "R_1"}}}`), nil
+		}),
+	})
+	ctx := context.Background()
+	_, err = client.repoNodeID(ctx, "a", "b")
+	require.NoError(t, err)
+	err = client.DeleteDeploymentProtectionRule(ctx, "a", "b", "prod", 1)
+	require.NoError(t, err)
+
+	require.Len(t, entries, 1)
+	assert.True(t, entries[0].DryRun)
+	assert.Equal(t, 204, entries[0].Status)
+}
+
+func TestAuditLogLeavesUploadsStreaming(t *testing.T) {
+	upload := strings.NewReader(strings.Repeat("x", 1<<20))
+	r, err := http.NewRequest("POST", "https://uploads.github.com/a", io.NopCloser(upload))
+	require.NoError(t, err)
+	r.ContentLength = 1 << 20
+	r.Header.Set("Content-Type", "application/octet-stream")
+	body, summary, err := auditBody(r)
+	require.NoError(t, err)
+	assert.Nil(t, body)
+	assert.Equal(t, "(1048576 bytes of application/octet-stream)", summary)
+	assert.Equal(t, 1<<20, upload.Len())
+
+	r, err = http.NewRequest("PATCH", "https://api.github.com/a", strings.NewReader(`{"a":1}`))
+	require.NoError(t, err)
+	r.Header.Set("Content-Type", "application/json")
+	body, summary, err = auditBody(r)
+	require.NoError(t, err)
+	assert.Equal(t, `{"a":1}`, string(body))
+	assert.Equal(t, `{"a":1}`, summary)
+	again, err := io.ReadAll(r.Body)
+	require.NoError(t, err)
+	assert.Equal(t, body, again)
+}
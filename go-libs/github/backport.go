@@ -0,0 +1,174 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/databricks/databricks-sdk-go/logger"
+	"github.com/databrickslabs/sandbox/go-libs/git"
+)
+
+var ErrNotMerged = errors.New("pull request is not merged")
+
+// Backport cherry-picks merged pull requests onto release branches and opens
+// backport pull requests for them. Target branches come from labels, like
+// "backport-0.x" for the "0.x" branch.
+type Backport struct {
+	Client   *GitHubClient
+	Checkout *git.Checkout
+
+	// LabelPrefix marks labels with target branches. Default is "backport-".
+	LabelPrefix string
+}
+
+type BackportResult struct {
+	Target      string
+	Branch      string
+	PullRequest *PullRequest
+
+	// Commits are cherry-picked: the merge commit, the squashed commit, or
+	// the range of rebased commits.
+	Commits []string
+
+	// Conflicts are files, that failed to cherry-pick cleanly.
+	Conflicts []string
+	Err       error
+}
+
+func (b *Backport) labelPrefix() string {
+	if b.LabelPrefix == "" {
+		return "backport-"
+	}
+	return b.LabelPrefix
+}
+
+// Targets returns release branches requested by labels of the pull request.
+func (b *Backport) Targets(pr *PullRequest) (targets []string) {
+	prefix := b.labelPrefix()
+	for _, v := range pr.Labels {
+		target, ok := strings.CutPrefix(v.Name, prefix)
+		if !ok || target == "" {
+			continue
+		}
+		targets = append(targets, target)
+	}
+	return targets
+}
+
+// Run backports a merged pull request to the targets, or to branches from its
+// labels, if no targets are given. Every outcome is reported as a comment on
+// the original pull request, so failing targets don't block the others.
+func (b *Backport) Run(ctx context.Context, org, repo string, number int, targets ...string) ([]BackportResult, error) {
+	pr, err := b.Client.GetPullRequest(ctx, org, repo, number)
+	if err != nil {
+		return nil, fmt.Errorf("get pull request: %w", err)
+	}
+	if !pr.Merged || pr.MergeCommitSHA == "" {
+		return nil, fmt.Errorf("#%d: %w", number, ErrNotMerged)
+	}
+	if len(targets) == 0 {
+		targets = b.Targets(pr)
+	}
+	var results []BackportResult
+	for _, target := range targets {
+		result := b.backport(ctx, org, repo, pr, target)
+		results = append(results, result)
+		err = b.comment(ctx, org, repo, pr, result)
+		if err != nil {
+			return results, fmt.Errorf("comment: %w", err)
+		}
+	}
+	return results, nil
+}
+
+func (b *Backport) backport(ctx context.Context, org, repo string, pr *PullRequest, target string) BackportResult {
+	result := BackportResult{
+		Target: target,
+		Branch: fmt.Sprintf("backport/%d-to-%s", pr.Number, target),
+	}
+	_, err := b.Checkout.FetchBranch(ctx, target)
+	if err != nil {
+		result.Err = fmt.Errorf("fetch: %w", err)
+		return result
+	}
+	_, err = b.Checkout.CreateBranch(ctx, result.Branch, "FETCH_HEAD")
+	if err != nil {
+		result.Err = fmt.Errorf("branch: %w", err)
+		return result
+	}
+	mainline, err := b.Checkout.IsMergeCommit(ctx, pr.MergeCommitSHA)
+	if err != nil {
+		result.Err = fmt.Errorf("merge commit: %w", err)
+		return result
+	}
+	result.Commits = []string{pr.MergeCommitSHA}
+	if !mainline && pr.Commits > 1 {
+		result.Commits, err = b.mergedCommits(ctx, org, repo, pr)
+		if err != nil {
+			result.Err = fmt.Errorf("merged commits: %w", err)
+			return result
+		}
+	}
+	_, err = b.Checkout.CherryPick(ctx, mainline, result.Commits...)
+	if err != nil {
+		result.Err = fmt.Errorf("cherry-pick: %w", err)
+		result.Conflicts, _ = b.Checkout.ConflictingFiles(ctx)
+		_, abortErr := b.Checkout.CherryPickAbort(ctx)
+		if abortErr != nil {
+			logger.Warnf(ctx, "Failed to abort cherry-pick: %s", abortErr)
+		}
+		return result
+	}
+	_, err = b.Checkout.ForcePush(ctx, result.Branch)
+	if err != nil {
+		result.Err = fmt.Errorf("push: %w", err)
+		return result
+	}
+	result.PullRequest, err = b.Client.CreatePullRequest(ctx, org, repo, NewPullRequest{
+		Title: fmt.Sprintf("[%s] %s", target, pr.Title),
+		Head:  result.Branch,
+		Base:  target,
+		Body:  fmt.Sprintf("Backport of #%d to `%s`.\n\n%s", pr.Number, target, pr.Body),
+	})
+	if err != nil {
+		result.Err = fmt.Errorf("create pull request: %w", err)
+	}
+	return result
+}
+
+// mergedCommits returns the range of commits, that a rebase merge put on the
+// base branch, or the squashed commit. Rebased commits keep their messages,
+// unlike the squashed commit, so the last of them has the message of the last
+// commit of the pull request.
+func (b *Backport) mergedCommits(ctx context.Context, org, repo string, pr *PullRequest) ([]string, error) {
+	commits, err := b.Client.ListPullRequestCommits(ctx, org, repo, pr.Number)
+	if err != nil {
+		return nil, err
+	}
+	merged, err := b.Client.GetCommit(ctx, org, repo, pr.MergeCommitSHA)
+	if err != nil {
+		return nil, err
+	}
+	if len(commits) < 2 || merged.Message != commits[len(commits)-1].Commit.Message {
+		return []string{pr.MergeCommitSHA}, nil
+	}
+	return []string{fmt.Sprintf("%s~%d..%s", pr.MergeCommitSHA, len(commits), pr.MergeCommitSHA)}, nil
+}
+
+func (b *Backport) comment(ctx context.Context, org, repo string, pr *PullRequest, result BackportResult) error {
+	var body string
+	switch {
+	case result.PullRequest != nil:
+		body = fmt.Sprintf("Backported to `%s` in #%d.", result.Target, result.PullRequest.Number)
+	case len(result.Conflicts) > 0:
+		body = fmt.Sprintf("Backport to `%s` has conflicts in:\n\n%s\n\nPlease backport manually:\n\n"+
+			"```\ngit fetch origin %s\ngit checkout -B %s FETCH_HEAD\ngit cherry-pick -x %s\n```",
+			result.Target, "- "+strings.Join(result.Conflicts, "\n- "), result.Target, result.Branch, strings.Join(result.Commits, " "))
+	default:
+		body = fmt.Sprintf("Backport to `%s` failed: %s", result.Target, result.Err)
+	}
+	_, err := b.Client.CreateIssueComment(ctx, org, repo, pr.Number, body)
+	return err
+}
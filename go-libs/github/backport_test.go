@@ -0,0 +1,127 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"os/exec"
+	"strconv"
+	"testing"
+
+	"github.com/databrickslabs/sandbox/go-libs/git"
+	"github.com/databrickslabs/sandbox/go-libs/process"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBackportOpensPullRequestsAndReportsConflicts(t *testing.T) {
+	ctx, stub := process.WithStub(context.Background())
+	stub.WithStdoutFor("git remote -v", "origin\tgit@github.com:a/b.git (fetch)\norigin\tgit@github.com:a/b.git (push)")
+	stub.WithStdoutFor("git rev-list --parents -n 1 abc", "abc def")
+	stub.WithStdoutFor("git diff --name-only --diff-filter=U", "go.mod\ngo.sum")
+	picks := 0
+	stub.WithCallback(func(cmd *exec.Cmd) error {
+		if cmd.Args[1] != "cherry-pick" || cmd.Args[2] != "-x" {
+			return nil
+		}
+		picks++
+		if picks > 1 {
+			return errors.New("conflict")
+		}
+		return nil
+	})
+
+	checkout, err := git.NewCheckout(ctx, t.TempDir())
+	require.NoError(t, err)
+
+	var comments []string
+	client := NewClient(&GitHubConfig{
+		GitHubTokenSource: GitHubTokenSource{Pat: "x"},
+		transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			switch r.Method + " " + r.URL.Path {
+			case "GET /repos/a/b/pulls/12":
+				return jsonResponse(200, `{"number": 12, "title": "Fix bug", "merged": true,
+					"merge_commit_sha": "abc", "labels": [{"name": "backport-0.x"}, {"name": "bug"}]}`), nil
+			case "POST /repos/a/b/pulls":
+				var body NewPullRequest
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+				assert.Equal(t, "backport/12-to-0.x", body.Head)
+				assert.Equal(t, "0.x", body.Base)
+				assert.Equal(t, "[0.x] Fix bug", body.Title)
+				return jsonResponse(201, `{"number": 13}`), nil
+			case "POST /repos/a/b/issues/12/comments":
+				var body struct{ Body string }
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+				comments = append(comments, body.Body)
+				return jsonResponse(201, `{"id": 1}`), nil
+			}
+			return jsonResponse(404, `{"message": "Not Found"}`), nil
+		}),
+	})
+	backport := &Backport{Client: client, Checkout: checkout}
+	results, err := backport.Run(ctx, "a", "b", 12, "0.x", "1.x")
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	assert.Equal(t, 13, results[0].PullRequest.Number)
+	assert.Nil(t, results[1].PullRequest)
+	assert.Equal(t, []string{"go.mod", "go.sum"}, results[1].Conflicts)
+	require.Len(t, comments, 2)
+	assert.Equal(t, "Backported to `0.x` in #13.", comments[0])
+	assert.Contains(t, comments[1], "Backport to `1.x` has conflicts in:\n\n- go.mod\n- go.sum")
+	assert.Contains(t, stub.Commands(), "git push git@github.com:a/b.git backport/12-to-0.x -f")
+	assert.Contains(t, stub.Commands(), "git cherry-pick --abort")
+	assert.NotContains(t, stub.Commands(), "git push git@github.com:a/b.git backport/12-to-1.x -f")
+}
+
+func TestBackportTargets(t *testing.T) {
+	backport := &Backport{}
+	assert.Equal(t, []string{"0.x", "1.2"}, backport.Targets(&PullRequest{
+		Labels: []Label{{Name: "backport-0.x"}, {Name: "bug"}, {Name: "backport-1.2"}, {Name: "backport-"}},
+	}))
+}
+
+func TestBackportPicksRebasedCommits(t *testing.T) {
+	for _, tc := range []struct {
+		name, message, pick string
+	}{
+		{"rebase", "Handle retries", "git cherry-pick -x abc~3..abc"},
+		{"squash", "Fix bug (#12)\n\n* Handle retries", "git cherry-pick -x abc"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx, stub := process.WithStub(context.Background())
+			stub.WithStdoutFor("git remote -v", "origin\tgit@github.com:a/b.git (fetch)\norigin\tgit@github.com:a/b.git (push)")
+			stub.WithStdoutFor("git rev-list --parents -n 1 abc", "abc def")
+			checkout, err := git.NewCheckout(ctx, t.TempDir())
+			require.NoError(t, err)
+			client := NewClient(&GitHubConfig{
+				GitHubTokenSource: GitHubTokenSource{Pat: "x"},
+				transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+					switch r.Method + " " + r.URL.Path {
+					case "GET /repos/a/b/pulls/12":
+						return jsonResponse(200, `{"number": 12, "title": "Fix bug", "merged": true,
+							"merge_commit_sha": "abc", "commits": 3}`), nil
+					case "GET /repos/a/b/pulls/12/commits":
+						return jsonResponse(200, `[
+							{"sha": "1", "commit": {"message": "Add test"}},
+							{"sha": "2", "commit": {"message": "Fix bug"}},
+							{"sha": "3", "commit": {"message": "Handle retries"}}
+						]`), nil
+					case "GET /repos/a/b/git/commits/abc":
+						return jsonResponse(200, `{"sha": "abc", "message": `+strconv.Quote(tc.message)+`}`), nil
+					case "POST /repos/a/b/pulls":
+						return jsonResponse(201, `{"number": 13}`), nil
+					case "POST /repos/a/b/issues/12/comments":
+						return jsonResponse(201, `{"id": 1}`), nil
+					}
+					return jsonResponse(404, `{"message": "Not Found"}`), nil
+				}),
+			})
+			backport := &Backport{Client: client, Checkout: checkout}
+			results, err := backport.Run(ctx, "a", "b", 12, "0.x")
+			require.NoError(t, err)
+			require.NoError(t, results[0].Err)
+			assert.Contains(t, stub.Commands(), tc.pick)
+		})
+	}
+}
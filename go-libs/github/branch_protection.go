@@ -0,0 +1,261 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/databricks/databricks-sdk-go/httpclient"
+	"github.com/databricks/databricks-sdk-go/logger"
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultBranchKey stands for the default branch of every repository in
+// branch protection files.
+const DefaultBranchKey = "$default"
+
+// BranchProtectionRule is a flattened classic branch protection.
+// See https://docs.github.com/en/rest/branches/branch-protection
+type BranchProtectionRule struct {
+	RequiredStatusChecks          []string `yaml:"required_status_checks,omitempty"`
+	StrictStatusChecks            bool     `yaml:"strict_status_checks,omitempty"`
+	RequiredApprovals             int      `yaml:"required_approvals,omitempty"`
+	DismissStaleReviews           bool     `yaml:"dismiss_stale_reviews,omitempty"`
+	RequireCodeOwnerReviews       bool     `yaml:"require_code_owner_reviews,omitempty"`
+	RequireLastPushApproval       bool     `yaml:"require_last_push_approval,omitempty"`
+	EnforceAdmins                 bool     `yaml:"enforce_admins,omitempty"`
+	RequireLinearHistory          bool     `yaml:"require_linear_history,omitempty"`
+	RequireConversationResolution bool     `yaml:"require_conversation_resolution,omitempty"`
+	AllowForcePushes              bool     `yaml:"allow_force_pushes,omitempty"`
+	AllowDeletions                bool     `yaml:"allow_deletions,omitempty"`
+}
+
+// BranchProtectionFile is a protection-as-code document, like:
+//
+//	branches:
+//	  $default:
+//	    required_approvals: 1
+//	    required_status_checks: [build]
+//	repos:
+//	  sandbox:
+//	    release/v0.x:
+//	      required_approvals: 2
+//
+// Branches apply to every repository of the org, except forks and archived
+// ones, while repos override the whole rule of a branch or add new branches.
+type BranchProtectionFile struct {
+	Branches map[string]BranchProtectionRule            `yaml:"branches"`
+	Repos    map[string]map[string]BranchProtectionRule `yaml:"repos,omitempty"`
+}
+
+func ParseBranchProtection(raw []byte) (*BranchProtectionFile, error) {
+	var file BranchProtectionFile
+	err := yaml.Unmarshal(raw, &file)
+	if err != nil {
+		return nil, fmt.Errorf("branch protection: %w", err)
+	}
+	return &file, nil
+}
+
+// Desired returns rules by branch name for the repository. Rules of the repo
+// win over rules of the org, and rules of an explicitly named branch win over
+// the $default rule of the same level.
+func (f *BranchProtectionFile) Desired(repo Repo) map[string]BranchProtectionRule {
+	desired := map[string]BranchProtectionRule{}
+	for _, rules := range []map[string]BranchProtectionRule{f.Branches, f.Repos[repo.Name]} {
+		if rule, ok := rules[DefaultBranchKey]; ok {
+			desired[repo.DefaultBranch] = rule
+		}
+		for branch, rule := range rules {
+			if branch == DefaultBranchKey {
+				continue
+			}
+			desired[branch] = rule
+		}
+	}
+	return desired
+}
+
+type branchProtection struct {
+	RequiredStatusChecks *struct {
+		Strict   bool     `json:"strict"`
+		Contexts []string `json:"contexts"`
+	} `json:"required_status_checks"`
+	RequiredPullRequestReviews *struct {
+		DismissStaleReviews          bool `json:"dismiss_stale_reviews"`
+		RequireCodeOwnerReviews      bool `json:"require_code_owner_reviews"`
+		RequiredApprovingReviewCount int  `json:"required_approving_review_count"`
+		RequireLastPushApproval      bool `json:"require_last_push_approval"`
+	} `json:"required_pull_request_reviews"`
+	EnforceAdmins                  enabled `json:"enforce_admins"`
+	RequiredLinearHistory          enabled `json:"required_linear_history"`
+	RequiredConversationResolution enabled `json:"required_conversation_resolution"`
+	AllowForcePushes               enabled `json:"allow_force_pushes"`
+	AllowDeletions                 enabled `json:"allow_deletions"`
+	RequiredSignatures             enabled `json:"required_signatures"`
+}
+
+type enabled struct {
+	Enabled bool `json:"enabled"`
+}
+
+// GetBranchProtection returns the protection of a branch, which is empty for
+// unprotected branches.
+func (c *GitHubClient) GetBranchProtection(ctx context.Context, org, repo, branch string) (*BranchProtectionRule, error) {
+	res, err := c.branchProtection(ctx, org, repo, branch)
+	if err != nil {
+		return nil, err
+	}
+	if res == nil {
+		return &BranchProtectionRule{}, nil
+	}
+	rule := &BranchProtectionRule{
+		EnforceAdmins:                 res.EnforceAdmins.Enabled,
+		RequireLinearHistory:          res.RequiredLinearHistory.Enabled,
+		RequireConversationResolution: res.RequiredConversationResolution.Enabled,
+		AllowForcePushes:              res.AllowForcePushes.Enabled,
+		AllowDeletions:                res.AllowDeletions.Enabled,
+	}
+	if res.RequiredStatusChecks != nil {
+		rule.RequiredStatusChecks = res.RequiredStatusChecks.Contexts
+		rule.StrictStatusChecks = res.RequiredStatusChecks.Strict
+	}
+	if res.RequiredPullRequestReviews != nil {
+		rule.RequiredApprovals = res.RequiredPullRequestReviews.RequiredApprovingReviewCount
+		rule.DismissStaleReviews = res.RequiredPullRequestReviews.DismissStaleReviews
+		rule.RequireCodeOwnerReviews = res.RequiredPullRequestReviews.RequireCodeOwnerReviews
+		rule.RequireLastPushApproval = res.RequiredPullRequestReviews.RequireLastPushApproval
+	}
+	return rule, nil
+}
+
+// branchProtection returns nil for unprotected branches
+func (c *GitHubClient) branchProtection(ctx context.Context, org, repo, branch string) (*branchProtection, error) {
+	path := fmt.Sprintf("%s/repos/%s/%s/branches/%s/protection", gitHubAPI, org, repo, escapeRef(branch))
+	var res branchProtection
+	err := c.api.Do(ctx, "GET", path, httpclient.WithResponseUnmarshal(&res))
+	var apiErr *httpclient.HttpError
+	if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// UpdateBranchProtection replaces the protection of a branch. Push
+// restrictions are not managed.
+func (c *GitHubClient) UpdateBranchProtection(ctx context.Context, org, repo, branch string, rule BranchProtectionRule) error {
+	path := fmt.Sprintf("%s/repos/%s/%s/branches/%s/protection", gitHubAPI, org, repo, escapeRef(branch))
+	body := map[string]any{
+		"required_status_checks":           nil,
+		"required_pull_request_reviews":    nil,
+		"restrictions":                     nil,
+		"enforce_admins":                   rule.EnforceAdmins,
+		"required_linear_history":          rule.RequireLinearHistory,
+		"required_conversation_resolution": rule.RequireConversationResolution,
+		"allow_force_pushes":               rule.AllowForcePushes,
+		"allow_deletions":                  rule.AllowDeletions,
+	}
+	if len(rule.RequiredStatusChecks) > 0 {
+		body["required_status_checks"] = map[string]any{
+			"strict":   rule.StrictStatusChecks,
+			"contexts": rule.RequiredStatusChecks,
+		}
+	}
+	if rule.RequiredApprovals > 0 || rule.RequireCodeOwnerReviews ||
+		rule.DismissStaleReviews || rule.RequireLastPushApproval {
+		body["required_pull_request_reviews"] = map[string]any{
+			"dismiss_stale_reviews":           rule.DismissStaleReviews,
+			"require_code_owner_reviews":      rule.RequireCodeOwnerReviews,
+			"required_approving_review_count": rule.RequiredApprovals,
+			"require_last_push_approval":      rule.RequireLastPushApproval,
+		}
+	}
+	return c.api.Do(ctx, "PUT", path, httpclient.WithRequestData(body))
+}
+
+// diffProtection reports fields of the rule, that differ, by their YAML names
+func diffProtection(repo, branch string, actual, desired BranchProtectionRule) (drift []SettingDrift) {
+	// the checks are shared with the caller, like the protection file
+	actual.RequiredStatusChecks = slices.Clone(actual.RequiredStatusChecks)
+	desired.RequiredStatusChecks = slices.Clone(desired.RequiredStatusChecks)
+	slices.Sort(actual.RequiredStatusChecks)
+	slices.Sort(desired.RequiredStatusChecks)
+	a, d := reflect.ValueOf(actual), reflect.ValueOf(desired)
+	for i := 0; i < a.NumField(); i++ {
+		have, want := a.Field(i).Interface(), d.Field(i).Interface()
+		if a.Field(i).Kind() == reflect.Slice && a.Field(i).Len() == 0 && d.Field(i).Len() == 0 {
+			continue
+		}
+		if reflect.DeepEqual(have, want) {
+			continue
+		}
+		name, _, _ := strings.Cut(a.Type().Field(i).Tag.Get("yaml"), ",")
+		drift = append(drift, SettingDrift{
+			Repo:    repo,
+			Setting: fmt.Sprintf("%s: %s", branch, name),
+			Actual:  have,
+			Desired: want,
+		})
+	}
+	return drift
+}
+
+// ReconcileBranchProtection reports drift of branch protection across the org
+// and applies desired rules, if fix is true. Without fix, it's a dry-run.
+// Branches, that don't exist in a repository, are skipped.
+func (c *GitHubClient) ReconcileBranchProtection(ctx context.Context, org string, file *BranchProtectionFile, fix bool) ([]SettingDrift, error) {
+	var repos []Repo
+	err := c.StreamRepositories(ctx, org, func(r Repo) error {
+		if r.IsFork || r.IsArchived {
+			return nil
+		}
+		repos = append(repos, r)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("repositories: %w", err)
+	}
+	var all []SettingDrift
+	for _, repo := range repos {
+		desired := file.Desired(repo)
+		existing, err := c.ListBranches(ctx, org, repo.Name, ListBranchesOptions{})
+		if err != nil {
+			return all, fmt.Errorf("%s: branches: %w", repo.Name, err)
+		}
+		var branches []string
+		for branch := range desired {
+			// rules of the org may name branches, that only some repositories have
+			if !slices.ContainsFunc(existing, func(b Branch) bool { return b.Name == branch }) {
+				logger.Debugf(ctx, "Skipping protection of missing %s in %s", branch, repo.Name)
+				continue
+			}
+			branches = append(branches, branch)
+		}
+		sort.Strings(branches)
+		for _, branch := range branches {
+			actual, err := c.GetBranchProtection(ctx, org, repo.Name, branch)
+			if err != nil {
+				return all, fmt.Errorf("%s: %s: %w", repo.Name, branch, err)
+			}
+			drift := diffProtection(repo.Name, branch, *actual, desired[branch])
+			all = append(all, drift...)
+			if !fix || len(drift) == 0 {
+				continue
+			}
+			err = c.UpdateBranchProtection(ctx, org, repo.Name, branch, desired[branch])
+			if err != nil {
+				return all, fmt.Errorf("%s: %s: %w", repo.Name, branch, err)
+			}
+			logger.Infof(ctx, "Fixed protection of %s in %s", branch, repo.Name)
+		}
+	}
+	return all, nil
+}
@@ -0,0 +1,120 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReconcileBranchProtection(t *testing.T) {
+	file := &BranchProtectionFile{
+		Branches: map[string]BranchProtectionRule{
+			DefaultBranchKey: {
+				RequiredApprovals:    1,
+				RequiredStatusChecks: []string{"lint", "build"},
+			},
+		},
+		Repos: map[string]map[string]BranchProtectionRule{
+			"b": {"release": {RequiredApprovals: 2}},
+			"c": {"release": {RequiredApprovals: 2}},
+		},
+	}
+	var updates []string
+	var body map[string]any
+	client := NewClient(&GitHubConfig{
+		GitHubTokenSource: GitHubTokenSource{Pat: "x"},
+		transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			switch r.Method + " " + r.URL.Path {
+			case "GET /users/a/repos":
+				return jsonResponse(200, `[{"name": "b", "default_branch": "main"},
+					{"name": "c", "default_branch": "master"}, {"name": "d", "archived": true}]`), nil
+			case "GET /repos/a/b/branches":
+				return jsonResponse(200, `[{"name": "main"}]`), nil
+			case "GET /repos/a/c/branches":
+				return jsonResponse(200, `[{"name": "master"}, {"name": "release"}]`), nil
+			case "GET /repos/a/b/branches/main/protection":
+				return jsonResponse(200, `{"required_status_checks": {"contexts": ["build", "lint"]},
+					"required_pull_request_reviews": {"required_approving_review_count": 1}}`), nil
+			case "GET /repos/a/c/branches/master/protection":
+				return jsonResponse(200, `{"required_pull_request_reviews": {"required_approving_review_count": 1},
+					"allow_force_pushes": {"enabled": true}}`), nil
+			case "GET /repos/a/c/branches/release/protection":
+				return jsonResponse(404, `{"message": "Branch not protected"}`), nil
+			case "PUT /repos/a/c/branches/master/protection", "PUT /repos/a/c/branches/release/protection":
+				updates = append(updates, r.URL.Path)
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+				return jsonResponse(200, `{}`), nil
+			}
+			return jsonResponse(404, `{"message": "Not Found"}`), nil
+		}),
+	})
+	drift, err := client.ReconcileBranchProtection(context.Background(), "a", file, false)
+	require.NoError(t, err)
+	var settings []string
+	for _, v := range drift {
+		settings = append(settings, v.Repo+" "+v.Setting)
+	}
+	assert.Equal(t, []string{
+		"c master: required_status_checks",
+		"c master: allow_force_pushes",
+		"c release: required_approvals",
+	}, settings)
+	assert.Empty(t, updates)
+
+	_, err = client.ReconcileBranchProtection(context.Background(), "a", file, true)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"/repos/a/c/branches/master/protection",
+		"/repos/a/c/branches/release/protection",
+	}, updates)
+	assert.Nil(t, body["required_status_checks"])
+	assert.Equal(t, float64(2), body["required_pull_request_reviews"].(map[string]any)["required_approving_review_count"])
+	// diffing doesn't sort the file in place
+	assert.Equal(t, []string{"lint", "build"}, file.Branches[DefaultBranchKey].RequiredStatusChecks)
+}
+
+func TestDesiredBranchProtectionPrefersRepoRules(t *testing.T) {
+	file := &BranchProtectionFile{
+		Branches: map[string]BranchProtectionRule{
+			DefaultBranchKey: {RequiredApprovals: 1},
+			"release":        {RequiredApprovals: 3},
+		},
+		Repos: map[string]map[string]BranchProtectionRule{
+			"b": {"main": {RequiredApprovals: 2}},
+			"c": {DefaultBranchKey: {RequiredApprovals: 2}, "release": {RequiredApprovals: 4}},
+		},
+	}
+	assert.Equal(t, map[string]BranchProtectionRule{
+		"main":    {RequiredApprovals: 2},
+		"release": {RequiredApprovals: 3},
+	}, file.Desired(Repo{Name: "b", DefaultBranch: "main"}))
+	assert.Equal(t, map[string]BranchProtectionRule{
+		"main":    {RequiredApprovals: 2},
+		"release": {RequiredApprovals: 4},
+	}, file.Desired(Repo{Name: "c", DefaultBranch: "main"}))
+	assert.Equal(t, map[string]BranchProtectionRule{
+		"release": {RequiredApprovals: 3},
+	}, file.Desired(Repo{Name: "d", DefaultBranch: "release"}))
+}
+
+func TestUpdateBranchProtectionSendsReviewSettings(t *testing.T) {
+	var body map[string]any
+	client := NewClient(&GitHubConfig{
+		GitHubTokenSource: GitHubTokenSource{Pat: "x"},
+		transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			return jsonResponse(200, `{}`), nil
+		}),
+	})
+	err := client.UpdateBranchProtection(context.Background(), "a", "b", "main", BranchProtectionRule{
+		DismissStaleReviews: true,
+	})
+	require.NoError(t, err)
+	reviews, ok := body["required_pull_request_reviews"].(map[string]any)
+	require.True(t, ok)
+	assert.Equal(t, true, reviews["dismiss_stale_reviews"])
+}
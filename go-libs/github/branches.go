@@ -0,0 +1,63 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/databricks/databricks-sdk-go/httpclient"
+)
+
+// See https://docs.github.com/en/rest/branches/branches
+
+type Branch struct {
+	Name   string `json:"name"`
+	Commit struct {
+		SHA string `json:"sha"`
+	} `json:"commit"`
+	Protected bool `json:"protected"`
+}
+
+type ListBranchesOptions struct {
+	// Protected only lists protected branches.
+	Protected bool `url:"protected,omitempty"`
+
+	Page    int `url:"page,omitempty"`
+	PerPage int `url:"per_page,omitempty"`
+}
+
+func (c *GitHubClient) ListBranches(ctx context.Context, org, repo string, opts ListBranchesOptions) ([]Branch, error) {
+	return ToSlice(ctx, c.ListBranchesIterator(org, repo, opts))
+}
+
+func (c *GitHubClient) ListBranchesIterator(org, repo string, opts ListBranchesOptions) *Iterator[Branch] {
+	path := fmt.Sprintf("%s/repos/%s/%s/branches", gitHubAPI, org, repo)
+	return Paginate[Branch](c, path, opts)
+}
+
+// GetBranch returns the branch, following renames of the branch, or fails
+// with ErrRefNotFound.
+func (c *GitHubClient) GetBranch(ctx context.Context, org, repo, branch string) (*Branch, error) {
+	path := fmt.Sprintf("%s/repos/%s/%s/branches/%s", gitHubAPI, org, repo, escapeRef(branch))
+	var res Branch
+	err := c.api.Do(ctx, "GET", path, httpclient.WithResponseUnmarshal(&res))
+	if errors.Is(err, ErrNotFound) {
+		return nil, fmt.Errorf("%s: %w", branch, ErrRefNotFound)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// DeleteBranch deletes the branch, which fails with ErrValidation for the
+// default and protected branches.
+func (c *GitHubClient) DeleteBranch(ctx context.Context, org, repo, branch string) error {
+	return c.DeleteRef(ctx, org, repo, "heads/"+branch)
+}
+
+// DeleteBranchProtection removes the protection of the branch.
+func (c *GitHubClient) DeleteBranchProtection(ctx context.Context, org, repo, branch string) error {
+	path := fmt.Sprintf("%s/repos/%s/%s/branches/%s/protection", gitHubAPI, org, repo, escapeRef(branch))
+	return c.api.Do(ctx, "DELETE", path)
+}
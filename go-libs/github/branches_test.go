@@ -0,0 +1,49 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBranches(t *testing.T) {
+	var deleted []string
+	client := NewClient(&GitHubConfig{
+		GitHubTokenSource: GitHubTokenSource{Pat: "x"},
+		transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			switch r.Method + " " + r.URL.Path {
+			case "GET /repos/a/b/branches":
+				assert.Equal(t, "true", r.URL.Query().Get("protected"))
+				return jsonResponse(200, `[{"name": "main", "commit": {"sha": "abc"}, "protected": true}]`), nil
+			case "GET /repos/a/b/branches/release/v0.1":
+				return jsonResponse(200, `{"name": "release/v0.1", "commit": {"sha": "def"}}`), nil
+			case "DELETE /repos/a/b/git/refs/heads/feature", "DELETE /repos/a/b/branches/main/protection":
+				deleted = append(deleted, r.URL.Path)
+				return jsonResponse(204, ``), nil
+			}
+			return jsonResponse(404, `{"message": "Branch not found"}`), nil
+		}),
+	})
+	ctx := context.Background()
+	branches, err := client.ListBranches(ctx, "a", "b", ListBranchesOptions{Protected: true})
+	require.NoError(t, err)
+	require.Len(t, branches, 1)
+	assert.Equal(t, "abc", branches[0].Commit.SHA)
+
+	branch, err := client.GetBranch(ctx, "a", "b", "release/v0.1")
+	require.NoError(t, err)
+	assert.Equal(t, "def", branch.Commit.SHA)
+
+	_, err = client.GetBranch(ctx, "a", "b", "nope")
+	assert.ErrorIs(t, err, ErrRefNotFound)
+
+	require.NoError(t, client.DeleteBranch(ctx, "a", "b", "feature"))
+	require.NoError(t, client.DeleteBranchProtection(ctx, "a", "b", "main"))
+	assert.Equal(t, []string{
+		"/repos/a/b/git/refs/heads/feature",
+		"/repos/a/b/branches/main/protection",
+	}, deleted)
+}
@@ -0,0 +1,134 @@
+package github
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/databricks/databricks-sdk-go/logger"
+)
+
+var (
+	ErrCircuitOpen          = errors.New("circuit breaker is open")
+	ErrRetryBudgetExhausted = errors.New("retry budget exhausted")
+)
+
+const (
+	defaultCircuitBreakerCooldown = 1 * time.Minute
+	defaultRetryBudgetWindow      = 1 * time.Minute
+)
+
+// circuitBreaker fails requests fast, once the API keeps failing with server
+// errors or secondary rate limits, giving it time to recover. Independently of
+// that, it caps the number of failed attempts within a window, so that retries
+// of many concurrent requests don't turn into a retry storm.
+type circuitBreaker struct {
+	next      http.RoundTripper
+	threshold int
+	cooldown  time.Duration
+	budget    int
+	window    time.Duration
+	now       func() time.Time
+
+	mu          sync.Mutex
+	consecutive int
+	openedAt    time.Time
+	failures    []time.Time
+}
+
+func newCircuitBreaker(cfg *GitHubConfig, next http.RoundTripper) *circuitBreaker {
+	cb := &circuitBreaker{
+		next:      next,
+		threshold: cfg.CircuitBreakerThreshold,
+		cooldown:  cfg.CircuitBreakerCooldown,
+		budget:    cfg.RetryBudget,
+		window:    cfg.RetryBudgetWindow,
+		now:       time.Now,
+	}
+	if cb.cooldown == 0 {
+		cb.cooldown = defaultCircuitBreakerCooldown
+	}
+	if cb.window == 0 {
+		cb.window = defaultRetryBudgetWindow
+	}
+	return cb
+}
+
+func (cb *circuitBreaker) RoundTrip(r *http.Request) (*http.Response, error) {
+	err := cb.allow()
+	if err != nil {
+		return nil, err
+	}
+	resp, err := cb.next.RoundTrip(r)
+	if err == nil && !isServerFailure(resp) {
+		cb.mu.Lock()
+		cb.consecutive = 0
+		cb.mu.Unlock()
+		return resp, nil
+	}
+	err = cb.recordFailure(r, err)
+	if err != nil && resp != nil {
+		io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+		return nil, err
+	}
+	return resp, err
+}
+
+func (cb *circuitBreaker) allow() error {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	if cb.threshold == 0 || cb.consecutive < cb.threshold {
+		return nil
+	}
+	now := cb.now()
+	if now.Before(cb.openedAt.Add(cb.cooldown)) {
+		return ErrCircuitOpen
+	}
+	// half-open: let this request probe the API and keep failing the others,
+	// until either it succeeds and closes the circuit, or fails and re-opens it.
+	cb.openedAt = now
+	return nil
+}
+
+// recordFailure returns either the original transport error, or one of the
+// circuit breaker errors, which are not retried by the API client.
+func (cb *circuitBreaker) recordFailure(r *http.Request, err error) error {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	now := cb.now()
+	cb.consecutive++
+	if cb.threshold > 0 && cb.consecutive == cb.threshold {
+		cb.openedAt = now
+		logger.Warnf(r.Context(), "Opening circuit breaker for %s after %d consecutive failures", cb.cooldown, cb.consecutive)
+	}
+	if cb.budget > 0 {
+		cutoff := now.Add(-cb.window)
+		for len(cb.failures) > 0 && cb.failures[0].Before(cutoff) {
+			cb.failures = cb.failures[1:]
+		}
+		cb.failures = append(cb.failures, now)
+		if len(cb.failures) > cb.budget {
+			return fmt.Errorf("%w: %d failures within %s", ErrRetryBudgetExhausted, len(cb.failures), cb.window)
+		}
+	}
+	return err
+}
+
+// isServerFailure is true for responses that indicate an overloaded API
+func isServerFailure(resp *http.Response) bool {
+	return resp.StatusCode >= 500 || isSecondaryRateLimit(resp)
+}
+
+// isSecondaryRateLimit detects secondary (abuse) rate limits, which are
+// signalled with a Retry-After header on a 403 or 429 response.
+func isSecondaryRateLimit(resp *http.Response) bool {
+	switch resp.StatusCode {
+	case http.StatusForbidden, http.StatusTooManyRequests:
+		return resp.Header.Get("Retry-After") != ""
+	}
+	return false
+}
@@ -0,0 +1,59 @@
+package github
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCircuitBreakerOpensAndRecovers(t *testing.T) {
+	status := 502
+	calls := 0
+	cb := newCircuitBreaker(&GitHubConfig{
+		CircuitBreakerThreshold: 2,
+		CircuitBreakerCooldown:  time.Minute,
+	}, roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		calls++
+		return jsonResponse(status, `{}`), nil
+	}))
+	now := time.Now()
+	cb.now = func() time.Time { return now }
+	req, _ := http.NewRequest("GET", "https://api.github.com/a", nil)
+
+	for i := 0; i < 2; i++ {
+		resp, err := cb.RoundTrip(req)
+		assert.NoError(t, err)
+		assert.Equal(t, 502, resp.StatusCode)
+	}
+	_, err := cb.RoundTrip(req)
+	assert.ErrorIs(t, err, ErrCircuitOpen)
+	assert.Equal(t, 2, calls)
+
+	// half-open probe succeeds and closes the circuit
+	now = now.Add(2 * time.Minute)
+	status = 200
+	_, err = cb.RoundTrip(req)
+	assert.NoError(t, err)
+	_, err = cb.RoundTrip(req)
+	assert.NoError(t, err)
+	assert.Equal(t, 4, calls)
+}
+
+func TestRetryBudget(t *testing.T) {
+	cb := newCircuitBreaker(&GitHubConfig{
+		RetryBudget: 2,
+	}, roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		resp := jsonResponse(403, `{}`)
+		resp.Header.Set("Retry-After", "1")
+		return resp, nil
+	}))
+	req, _ := http.NewRequest("GET", "https://api.github.com/a", nil)
+	for i := 0; i < 2; i++ {
+		_, err := cb.RoundTrip(req)
+		assert.NoError(t, err)
+	}
+	_, err := cb.RoundTrip(req)
+	assert.ErrorIs(t, err, ErrRetryBudgetExhausted)
+}
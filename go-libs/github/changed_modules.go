@@ -0,0 +1,87 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/databricks/databricks-sdk-go/httpclient"
+	"github.com/databricks/databricks-sdk-go/logger"
+)
+
+// compareFilesLimit is the maximum number of files returned by the compare API.
+// See https://docs.github.com/en/rest/commits/commits#compare-two-commits
+const compareFilesLimit = 300
+
+// CompareFiles returns files changed between base and head. Files are listed
+// only on the first page of the comparison, so commits are not fetched.
+func (c *GitHubClient) CompareFiles(ctx context.Context, org, repo, base, head string) ([]CommitFile, error) {
+	path := fmt.Sprintf("%s/repos/%s/%s/compare/%s...%s", gitHubAPI, org, repo, escapeRef(base), escapeRef(head))
+	var response struct {
+		Files []CommitFile `json:"files,omitempty"`
+	}
+	err := c.api.Do(ctx, "GET", path,
+		httpclient.WithRequestData(listOptions{PerPage: 1}),
+		httpclient.WithResponseUnmarshal(&response))
+	return response.Files, err
+}
+
+// ChangedModules returns module path prefixes, like "go-libs" or
+// "acceptance", that have files changed between base and head, for selective
+// builds and tests. When the comparison is too large to be listed completely,
+// all modules are considered changed.
+func (c *GitHubClient) ChangedModules(ctx context.Context, org, repo, base, head string, modules ...string) ([]string, error) {
+	files, err := c.CompareFiles(ctx, org, repo, base, head)
+	if err != nil {
+		return nil, fmt.Errorf("compare: %w", err)
+	}
+	if len(files) >= compareFilesLimit {
+		logger.Warnf(ctx, "%s...%s changes %d or more files, considering all modules changed",
+			base, head, compareFilesLimit)
+		return modules, nil
+	}
+	return ModulesOf(files, modules...), nil
+}
+
+// ModulesOf returns modules, which contain any of the files, in the order of
+// modules. Files belong to the longest matching prefix, so that nested modules
+// don't trigger their parents. Renames change both the old and the new module.
+func ModulesOf(files []CommitFile, modules ...string) []string {
+	changed := map[string]bool{}
+	for _, f := range files {
+		for _, name := range []string{f.Filename, f.PreviousFilename} {
+			module, ok := moduleOf(name, modules)
+			if ok {
+				changed[module] = true
+			}
+		}
+	}
+	var out []string
+	for _, v := range modules {
+		if changed[v] {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+func moduleOf(name string, modules []string) (string, bool) {
+	if name == "" {
+		return "", false
+	}
+	best, bestLen := "", -1
+	for _, v := range modules {
+		prefix := strings.Trim(path.Clean(v), "/")
+		if prefix == "." {
+			// the root module contains everything
+			prefix = ""
+		} else if name != prefix && !strings.HasPrefix(name, prefix+"/") {
+			continue
+		}
+		if len(prefix) > bestLen {
+			best, bestLen = v, len(prefix)
+		}
+	}
+	return best, bestLen >= 0
+}
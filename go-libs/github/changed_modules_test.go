@@ -0,0 +1,37 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestModulesOf(t *testing.T) {
+	files := []CommitFile{
+		{Filename: "go-libs/github/github.go"},
+		{Filename: "acceptance/main.go", PreviousFilename: "metascan/main.go"},
+		{Filename: "README.md"},
+	}
+	assert.Equal(t, []string{"go-libs/github", "metascan", "acceptance"},
+		ModulesOf(files, "go-libs", "go-libs/github", "metascan", "acceptance", "runtime-packages"))
+	assert.Equal(t, []string{".", "go-libs/"},
+		ModulesOf(files, ".", "go-libs/"))
+	assert.Nil(t, ModulesOf(files, "go-lib"))
+}
+
+func TestChangedModules(t *testing.T) {
+	client := NewClient(&GitHubConfig{
+		GitHubTokenSource: GitHubTokenSource{Pat: "x"},
+		transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			assert.Equal(t, "/repos/a/b/compare/main...release/v0.1", r.URL.Path)
+			assert.Equal(t, "1", r.URL.Query().Get("per_page"))
+			return jsonResponse(200, `{"files": [{"filename": "go-libs/go.mod"}]}`), nil
+		}),
+	})
+	modules, err := client.ChangedModules(context.Background(), "a", "b", "main", "release/v0.1", "go-libs", "metascan")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"go-libs"}, modules)
+}
@@ -0,0 +1,236 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/databricks/databricks-sdk-go/httpclient"
+)
+
+// GitHub accepts at most 50 annotations per create or update request
+// and appends annotations from subsequent updates to the existing ones.
+const maxAnnotationsPerRequest = 50
+
+// maxCheckRunActions is the number of buttons GitHub shows on a check run
+const maxCheckRunActions = 3
+
+type CheckRunAnnotation struct {
+	Path        string `json:"path"`
+	StartLine   int    `json:"start_line"`
+	EndLine     int    `json:"end_line"`
+	StartColumn int    `json:"start_column,omitempty"`
+	EndColumn   int    `json:"end_column,omitempty"`
+
+	// AnnotationLevel is one of: notice, warning, failure.
+	AnnotationLevel string `json:"annotation_level"`
+	Message         string `json:"message"`
+	Title           string `json:"title,omitempty"`
+	RawDetails      string `json:"raw_details,omitempty"`
+}
+
+type CheckRunImage struct {
+	Alt      string `json:"alt"`
+	ImageURL string `json:"image_url"`
+	Caption  string `json:"caption,omitempty"`
+}
+
+type CheckRunOutput struct {
+	Title            string               `json:"title"`
+	Summary          string               `json:"summary"`
+	Text             string               `json:"text,omitempty"`
+	Annotations      []CheckRunAnnotation `json:"annotations,omitempty"`
+	AnnotationsCount int                  `json:"annotations_count,omitempty"`
+	Images           []CheckRunImage      `json:"images,omitempty"`
+}
+
+// CheckRunAction is a button on the check run, which sends the check_run
+// webhook with the requested_action action and the identifier to the app,
+// that created the check run, like "Apply fixes" of a linter.
+type CheckRunAction struct {
+	// Label is up to 20 characters.
+	Label string `json:"label"`
+
+	// Description is up to 40 characters.
+	Description string `json:"description"`
+
+	// Identifier is up to 20 characters.
+	Identifier string `json:"identifier"`
+}
+
+// CheckRunEventPayload is the payload of the check_run webhook, which is
+// delivered to the app, that created the check run, for example once a
+// CheckRunAction is clicked.
+type CheckRunEventPayload struct {
+	Action          string   `json:"action"` // created, completed, rerequested, requested_action
+	CheckRun        CheckRun `json:"check_run"`
+	RequestedAction *struct {
+		Identifier string `json:"identifier"`
+	} `json:"requested_action,omitempty"`
+	Repository Repo `json:"repository"`
+}
+
+type CheckRun struct {
+	ID          int64          `json:"id,omitempty"`
+	HeadSHA     string         `json:"head_sha,omitempty"`
+	Name        string         `json:"name,omitempty"`
+	ExternalID  string         `json:"external_id,omitempty"`
+	Status      string         `json:"status,omitempty"`     // queued, in_progress, completed
+	Conclusion  string         `json:"conclusion,omitempty"` // success, failure, neutral, cancelled, skipped, timed_out, action_required
+	StartedAt   time.Time      `json:"started_at,omitempty"`
+	CompletedAt time.Time      `json:"completed_at,omitempty"`
+	URL         string         `json:"url,omitempty"`
+	HTMLURL     string         `json:"html_url,omitempty"`
+	DetailsURL  string         `json:"details_url,omitempty"`
+	Output      CheckRunOutput `json:"output,omitempty"`
+}
+
+type NewCheckRun struct {
+	Name        string           `json:"name"`
+	HeadSHA     string           `json:"head_sha"`
+	DetailsURL  string           `json:"details_url,omitempty"`
+	ExternalID  string           `json:"external_id,omitempty"`
+	Status      string           `json:"status,omitempty"`
+	Conclusion  string           `json:"conclusion,omitempty"`
+	StartedAt   *time.Time       `json:"started_at,omitempty"`
+	CompletedAt *time.Time       `json:"completed_at,omitempty"`
+	Output      *CheckRunOutput  `json:"output,omitempty"`
+	Actions     []CheckRunAction `json:"actions,omitempty"`
+}
+
+type CheckRunUpdate struct {
+	Name        string           `json:"name,omitempty"`
+	DetailsURL  string           `json:"details_url,omitempty"`
+	ExternalID  string           `json:"external_id,omitempty"`
+	Status      string           `json:"status,omitempty"`
+	Conclusion  string           `json:"conclusion,omitempty"`
+	StartedAt   *time.Time       `json:"started_at,omitempty"`
+	CompletedAt *time.Time       `json:"completed_at,omitempty"`
+	Output      *CheckRunOutput  `json:"output,omitempty"`
+	Actions     []CheckRunAction `json:"actions,omitempty"`
+}
+
+// CreateCheckRun creates a check run. If there are more annotations than
+// GitHub accepts in a single request, the rest are uploaded with follow-up
+// updates of the same check run.
+func (c *GitHubClient) CreateCheckRun(ctx context.Context, org, repo string, req NewCheckRun) (*CheckRun, error) {
+	if len(req.Actions) > maxCheckRunActions {
+		return nil, fmt.Errorf("at most %d actions are allowed, got %d", maxCheckRunActions, len(req.Actions))
+	}
+	var rest [][]CheckRunAnnotation
+	if req.Output != nil {
+		output := *req.Output
+		chunks := chunkAnnotations(output.Annotations)
+		output.Annotations, rest = chunks[0], chunks[1:]
+		req.Output = &output
+	}
+	path := fmt.Sprintf("%s/repos/%s/%s/check-runs", gitHubAPI, org, repo)
+	var res CheckRun
+	err := c.api.Do(ctx, "POST", path,
+		httpclient.WithRequestData(req),
+		httpclient.WithResponseUnmarshal(&res))
+	if err != nil {
+		return nil, err
+	}
+	return c.appendAnnotations(ctx, org, repo, &res, req.Output, rest)
+}
+
+// UpdateCheckRun updates a check run, uploading annotations in batches
+// when there are too many for a single request.
+func (c *GitHubClient) UpdateCheckRun(ctx context.Context, org, repo string, checkRunID int64, req CheckRunUpdate) (*CheckRun, error) {
+	if len(req.Actions) > maxCheckRunActions {
+		return nil, fmt.Errorf("at most %d actions are allowed, got %d", maxCheckRunActions, len(req.Actions))
+	}
+	var rest [][]CheckRunAnnotation
+	if req.Output != nil {
+		output := *req.Output
+		chunks := chunkAnnotations(output.Annotations)
+		output.Annotations, rest = chunks[0], chunks[1:]
+		req.Output = &output
+	}
+	res, err := c.updateCheckRun(ctx, org, repo, checkRunID, req)
+	if err != nil {
+		return nil, err
+	}
+	return c.appendAnnotations(ctx, org, repo, res, req.Output, rest)
+}
+
+func (c *GitHubClient) updateCheckRun(ctx context.Context, org, repo string, checkRunID int64, req CheckRunUpdate) (*CheckRun, error) {
+	path := fmt.Sprintf("%s/repos/%s/%s/check-runs/%d", gitHubAPI, org, repo, checkRunID)
+	var res CheckRun
+	err := c.api.Do(ctx, "PATCH", path,
+		httpclient.WithRequestData(req),
+		httpclient.WithResponseUnmarshal(&res))
+	if err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+func (c *GitHubClient) appendAnnotations(ctx context.Context, org, repo string, run *CheckRun, output *CheckRunOutput, chunks [][]CheckRunAnnotation) (*CheckRun, error) {
+	for i, chunk := range chunks {
+		// title and summary are required every time the output is sent
+		res, err := c.updateCheckRun(ctx, org, repo, run.ID, CheckRunUpdate{
+			Output: &CheckRunOutput{
+				Title:       output.Title,
+				Summary:     output.Summary,
+				Text:        output.Text,
+				Annotations: chunk,
+			},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("annotations batch %d of %d: %w", i+2, len(chunks)+1, err)
+		}
+		run = res
+	}
+	return run, nil
+}
+
+func (c *GitHubClient) GetCheckRun(ctx context.Context, org, repo string, checkRunID int64) (*CheckRun, error) {
+	path := fmt.Sprintf("%s/repos/%s/%s/check-runs/%d", gitHubAPI, org, repo, checkRunID)
+	var res CheckRun
+	err := c.api.Do(ctx, "GET", path, httpclient.WithResponseUnmarshal(&res))
+	if err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+type CheckRunListOptions struct {
+	CheckName string `url:"check_name,omitempty"`
+	Status    string `url:"status,omitempty"`
+	Filter    string `url:"filter,omitempty"` // latest, all
+
+	Page    int `url:"page,omitempty"`
+	PerPage int `url:"per_page,omitempty"`
+}
+
+// ListCheckRunsForRef lists check runs for a SHA, a branch name, or a tag name.
+func (c *GitHubClient) ListCheckRunsForRef(ctx context.Context, org, repo, ref string, opts CheckRunListOptions) ([]CheckRun, error) {
+	path := fmt.Sprintf("%s/repos/%s/%s/commits/%s/check-runs", gitHubAPI, org, repo, escapeRef(ref))
+	if opts.PerPage == 0 {
+		opts.PerPage = 100
+	}
+	var res struct {
+		TotalCount int        `json:"total_count"`
+		CheckRuns  []CheckRun `json:"check_runs"`
+	}
+	err := c.api.Do(ctx, "GET", path,
+		httpclient.WithRequestData(opts),
+		httpclient.WithResponseUnmarshal(&res))
+	return res.CheckRuns, err
+}
+
+func (c *GitHubClient) ListCheckRunsForRefIterator(org, repo, ref string, opts CheckRunListOptions) *Iterator[CheckRun] {
+	path := fmt.Sprintf("%s/repos/%s/%s/commits/%s/check-runs", gitHubAPI, org, repo, escapeRef(ref))
+	return paginateField[CheckRun](c, path, "check_runs", opts)
+}
+
+// chunkAnnotations always returns at least one, possibly empty, chunk
+func chunkAnnotations(all []CheckRunAnnotation) (chunks [][]CheckRunAnnotation) {
+	for len(all) > maxAnnotationsPerRequest {
+		chunks = append(chunks, all[:maxAnnotationsPerRequest])
+		all = all[maxAnnotationsPerRequest:]
+	}
+	return append(chunks, all)
+}
@@ -0,0 +1,197 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+// RoundTrip sets the request of responses, which the API client requires.
+func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	resp, err := f(r)
+	if resp != nil && resp.Request == nil {
+		resp.Request = r
+	}
+	return resp, err
+}
+
+func jsonResponse(status int, body string) *http.Response {
+	return &http.Response{
+		StatusCode: status,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(strings.NewReader(body)),
+	}
+}
+
+func TestCreateCheckRunUploadsAnnotationsInBatches(t *testing.T) {
+	var calls []string
+	var batches []int
+	client := NewClient(&GitHubConfig{
+		GitHubTokenSource: GitHubTokenSource{Pat: "x"},
+		transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			calls = append(calls, r.Method+" "+r.URL.Path)
+			var body struct {
+				Output CheckRunOutput `json:"output"`
+			}
+			err := json.NewDecoder(r.Body).Decode(&body)
+			if err != nil {
+				return nil, err
+			}
+			assert.Equal(t, "lint", body.Output.Title)
+			batches = append(batches, len(body.Output.Annotations))
+			return jsonResponse(200, `{"id": 7}`), nil
+		}),
+	})
+	annotations := make([]CheckRunAnnotation, 120)
+	run, err := client.CreateCheckRun(context.Background(), "a", "b", NewCheckRun{
+		Name:    "lint",
+		HeadSHA: "abc",
+		Output: &CheckRunOutput{
+			Title:       "lint",
+			Summary:     "120 findings",
+			Annotations: annotations,
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(7), run.ID)
+	assert.Equal(t, []string{
+		"POST /repos/a/b/check-runs",
+		"PATCH /repos/a/b/check-runs/7",
+		"PATCH /repos/a/b/check-runs/7",
+	}, calls)
+	assert.Equal(t, []int{50, 50, 20}, batches)
+}
+
+func TestChunkAnnotations(t *testing.T) {
+	assert.Len(t, chunkAnnotations(nil), 1)
+	assert.Len(t, chunkAnnotations(make([]CheckRunAnnotation, 50)), 1)
+	assert.Len(t, chunkAnnotations(make([]CheckRunAnnotation, 51)), 2)
+}
+
+func TestSummarizeChecks(t *testing.T) {
+	statuses := []CommitStatus{
+		{Context: "ci/a", State: "success"},
+		{Context: "ci/b", State: "pending"},
+	}
+	runs := []CheckRun{
+		{Name: "lint", Status: "completed", Conclusion: "success"},
+		{Name: "self", Status: "in_progress"},
+	}
+	summary := summarizeChecks("main", statuses, runs, WaitForChecksOptions{
+		Ignore: []string{"self"},
+	})
+	assert.Equal(t, "pending", summary.State)
+	assert.Equal(t, []string{"ci/b"}, summary.Pending)
+
+	statuses[1].State = "success"
+	summary = summarizeChecks("main", statuses, runs, WaitForChecksOptions{
+		Ignore:   []string{"self"},
+		Required: []string{"integration"},
+	})
+	assert.Equal(t, "main: pending: integration", summary.String())
+
+	runs = append(runs, CheckRun{Name: "integration", Status: "completed", Conclusion: "timed_out"})
+	summary = summarizeChecks("main", statuses, runs, WaitForChecksOptions{
+		Ignore: []string{"self"},
+	})
+	assert.Equal(t, "main: failed: integration (timed_out)", summary.String())
+
+	summary = summarizeChecks("main", nil, nil, WaitForChecksOptions{})
+	assert.Equal(t, "pending", summary.State)
+}
+
+func TestGetPullRequestChecks(t *testing.T) {
+	client := NewClient(&GitHubConfig{
+		GitHubTokenSource: GitHubTokenSource{Pat: "x"},
+		transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			switch r.URL.Path {
+			case "/repos/a/b/pulls/1":
+				return jsonResponse(200, `{"number": 1, "head": {"ref": "feature", "sha": "abc"}}`), nil
+			case "/repos/a/b/commits/abc/status":
+				return jsonResponse(200, `{"state": "success", "statuses": [{"context": "ci/jenkins", "state": "success"}]}`), nil
+			case "/repos/a/b/commits/abc/check-runs":
+				return jsonResponse(200, `{"total_count": 1, "check_runs": [
+					{"name": "build", "status": "completed", "conclusion": "failure", "output": {"title": "2 tests failed"}}
+				]}`), nil
+			}
+			return jsonResponse(404, `{"message": "Not Found"}`), nil
+		}),
+	})
+	checks, err := client.GetPullRequestChecks(context.Background(), "a", "b", 1)
+	require.NoError(t, err)
+	assert.Equal(t, "abc", checks.SHA)
+	assert.Len(t, checks.Statuses, 1)
+	assert.Len(t, checks.CheckRuns, 1)
+	assert.Equal(t, "failure", checks.Summary.State)
+	assert.Equal(t, []string{"ci/jenkins"}, checks.Summary.Succeeded)
+	assert.Equal(t, "2 tests failed", checks.Summary.Failed[0].Description)
+}
+
+func TestWaitForChecksReturnsLastSummaryOnError(t *testing.T) {
+	polls := 0
+	client := NewClient(&GitHubConfig{
+		GitHubTokenSource: GitHubTokenSource{Pat: "x"},
+		transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			switch r.URL.Path {
+			case "/repos/a/b/commits/abc/status":
+				polls++
+				if polls > 1 {
+					return jsonResponse(422, `{"message": "No commit found for SHA: abc"}`), nil
+				}
+				return jsonResponse(200, `{"state": "pending", "statuses": []}`), nil
+			case "/repos/a/b/commits/abc/check-runs":
+				return jsonResponse(200, `{"total_count": 1, "check_runs": [
+					{"name": "build", "status": "in_progress"}
+				]}`), nil
+			}
+			return jsonResponse(404, `{"message": "Not Found"}`), nil
+		}),
+	})
+	summary, err := client.WaitForChecks(context.Background(), "a", "b", "abc", WaitForChecksOptions{
+		MinInterval: time.Millisecond,
+	})
+	assert.ErrorContains(t, err, "No commit found")
+	require.NotNil(t, summary)
+	assert.Equal(t, "pending", summary.State)
+	assert.Equal(t, []string{"build"}, summary.Pending)
+}
+
+func TestCheckRunActions(t *testing.T) {
+	var actions []any
+	client := NewClient(&GitHubConfig{
+		GitHubTokenSource: GitHubTokenSource{Pat: "x"},
+		transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			var body map[string]any
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			actions = body["actions"].([]any)
+			return jsonResponse(200, `{"id": 7, "status": "completed"}`), nil
+		}),
+	})
+	fix := CheckRunAction{Label: "Apply fixes", Description: "Commit suggested fixes", Identifier: "fix"}
+	run, err := client.UpdateCheckRun(context.Background(), "a", "b", 7, CheckRunUpdate{
+		Status:     "completed",
+		Conclusion: "failure",
+		Actions:    []CheckRunAction{fix},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "completed", run.Status)
+	assert.Equal(t, []any{map[string]any{
+		"label":       "Apply fixes",
+		"description": "Commit suggested fixes",
+		"identifier":  "fix",
+	}}, actions)
+
+	_, err = client.CreateCheckRun(context.Background(), "a", "b", NewCheckRun{
+		Actions: []CheckRunAction{fix, fix, fix, fix},
+	})
+	assert.EqualError(t, err, "at most 3 actions are allowed, got 4")
+}
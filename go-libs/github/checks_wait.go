@@ -0,0 +1,222 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/databricks/databricks-sdk-go/logger"
+)
+
+type WaitForChecksOptions struct {
+	// Timeout defaults to 30 minutes
+	Timeout time.Duration
+
+	// MinInterval is the first delay between polls, which doubles after every
+	// poll up to MaxInterval. Defaults to 5 seconds and 1 minute respectively.
+	MinInterval time.Duration
+	MaxInterval time.Duration
+
+	// Required lists check run names or status contexts that must be reported
+	// before the ref is considered green.
+	Required []string
+
+	// Ignore lists check run names or status contexts to skip, like the name
+	// of the check that is waiting.
+	Ignore []string
+}
+
+type FailedCheck struct {
+	Name        string
+	Conclusion  string
+	Description string
+	URL         string
+}
+
+type ChecksSummary struct {
+	Ref string
+
+	// State is one of: success, failure, pending
+	State     string
+	Succeeded []string
+	Pending   []string
+	Failed    []FailedCheck
+}
+
+func (s *ChecksSummary) String() string {
+	switch s.State {
+	case "failure":
+		var names []string
+		for _, v := range s.Failed {
+			names = append(names, fmt.Sprintf("%s (%s)", v.Name, v.Conclusion))
+		}
+		return fmt.Sprintf("%s: failed: %s", s.Ref, strings.Join(names, ", "))
+	case "pending":
+		return fmt.Sprintf("%s: pending: %s", s.Ref, strings.Join(s.Pending, ", "))
+	}
+	return fmt.Sprintf("%s: %d checks passed", s.Ref, len(s.Succeeded))
+}
+
+// WaitForChecks polls both commit statuses and check runs on a ref with
+// exponential backoff until all of them succeed, any of them fails, or the
+// timeout passes. A ref without any checks reported is considered pending,
+// because CI may not have picked it up yet. The latest summary is returned
+// even when the error is not nil, and it's nil only if the first poll fails.
+func (c *GitHubClient) WaitForChecks(ctx context.Context, org, repo, ref string, opts WaitForChecksOptions) (*ChecksSummary, error) {
+	if opts.Timeout == 0 {
+		opts.Timeout = 30 * time.Minute
+	}
+	if opts.MinInterval == 0 {
+		opts.MinInterval = 5 * time.Second
+	}
+	if opts.MaxInterval == 0 {
+		opts.MaxInterval = time.Minute
+	}
+	ctx, cancel := context.WithTimeout(ctx, opts.Timeout)
+	defer cancel()
+	interval := opts.MinInterval
+	var last *ChecksSummary
+	for {
+		summary, err := c.checksSummary(ctx, org, repo, ref, opts)
+		if err != nil {
+			return last, err
+		}
+		last = summary
+		switch summary.State {
+		case "success":
+			return summary, nil
+		case "failure":
+			return summary, fmt.Errorf("checks failed: %s", summary)
+		}
+		logger.Infof(ctx, "Waiting %s for %s", interval, summary)
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return summary, fmt.Errorf("timed out: %s", summary)
+		case <-timer.C:
+		}
+		interval *= 2
+		if interval > opts.MaxInterval {
+			interval = opts.MaxInterval
+		}
+	}
+}
+
+type PullRequestChecks struct {
+	Number    int
+	SHA       string
+	Statuses  []CommitStatus
+	CheckRuns []CheckRun
+	Summary   *ChecksSummary
+}
+
+// GetPullRequestChecks returns the latest check runs and commit statuses
+// reported on the head commit of a pull request.
+func (c *GitHubClient) GetPullRequestChecks(ctx context.Context, org, repo string, number int) (*PullRequestChecks, error) {
+	pr, err := c.GetPullRequest(ctx, org, repo, number)
+	if err != nil {
+		return nil, fmt.Errorf("pull request: %w", err)
+	}
+	sha := pr.Head.SHA
+	statuses, runs, err := c.latestChecks(ctx, org, repo, sha)
+	if err != nil {
+		return nil, err
+	}
+	return &PullRequestChecks{
+		Number:    number,
+		SHA:       sha,
+		Statuses:  statuses,
+		CheckRuns: runs,
+		Summary:   summarizeChecks(sha, statuses, runs, WaitForChecksOptions{}),
+	}, nil
+}
+
+func (c *GitHubClient) checksSummary(ctx context.Context, org, repo, ref string, opts WaitForChecksOptions) (*ChecksSummary, error) {
+	statuses, runs, err := c.latestChecks(ctx, org, repo, ref)
+	if err != nil {
+		return nil, err
+	}
+	return summarizeChecks(ref, statuses, runs, opts), nil
+}
+
+func (c *GitHubClient) latestChecks(ctx context.Context, org, repo, ref string) ([]CommitStatus, []CheckRun, error) {
+	combined, err := c.GetCombinedStatus(ctx, org, repo, ref)
+	if err != nil {
+		return nil, nil, fmt.Errorf("combined status: %w", err)
+	}
+	runs, err := c.ListCheckRunsForRef(ctx, org, repo, ref, CheckRunListOptions{
+		Filter: "latest",
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("check runs: %w", err)
+	}
+	return combined.Statuses, runs, nil
+}
+
+func summarizeChecks(ref string, statuses []CommitStatus, runs []CheckRun, opts WaitForChecksOptions) *ChecksSummary {
+	ignore := map[string]bool{}
+	for _, v := range opts.Ignore {
+		ignore[v] = true
+	}
+	seen := map[string]bool{}
+	summary := &ChecksSummary{Ref: ref}
+	for _, v := range statuses {
+		if ignore[v.Context] {
+			continue
+		}
+		seen[v.Context] = true
+		switch v.State {
+		case "success":
+			summary.Succeeded = append(summary.Succeeded, v.Context)
+		case "pending":
+			summary.Pending = append(summary.Pending, v.Context)
+		default:
+			summary.Failed = append(summary.Failed, FailedCheck{
+				Name:        v.Context,
+				Conclusion:  v.State,
+				Description: v.Description,
+				URL:         v.TargetURL,
+			})
+		}
+	}
+	for _, v := range runs {
+		if ignore[v.Name] {
+			continue
+		}
+		seen[v.Name] = true
+		if v.Status != "completed" {
+			summary.Pending = append(summary.Pending, v.Name)
+			continue
+		}
+		switch v.Conclusion {
+		case "success", "neutral", "skipped":
+			summary.Succeeded = append(summary.Succeeded, v.Name)
+		default:
+			summary.Failed = append(summary.Failed, FailedCheck{
+				Name:        v.Name,
+				Conclusion:  v.Conclusion,
+				Description: v.Output.Title,
+				URL:         v.HTMLURL,
+			})
+		}
+	}
+	for _, v := range opts.Required {
+		if !seen[v] {
+			summary.Pending = append(summary.Pending, v)
+		}
+	}
+	sort.Strings(summary.Succeeded)
+	sort.Strings(summary.Pending)
+	switch {
+	case len(summary.Failed) > 0:
+		summary.State = "failure"
+	case len(summary.Pending) > 0, len(summary.Succeeded) == 0:
+		summary.State = "pending"
+	default:
+		summary.State = "success"
+	}
+	return summary
+}
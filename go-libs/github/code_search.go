@@ -0,0 +1,117 @@
+package github
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/databricks/databricks-sdk-go/httpclient"
+	"github.com/databricks/databricks-sdk-go/logger"
+	"github.com/databrickslabs/sandbox/go-libs/localcache"
+)
+
+const (
+	// codeSearchInterval keeps requests within the rate limit of code
+	// search, which is 10 requests per minute and is stricter than the one
+	// of other search endpoints.
+	codeSearchInterval = 6 * time.Second
+
+	codeSearchCacheTTL = 24 * time.Hour
+)
+
+type CodeSearchResult struct {
+	Name       string `json:"name"`
+	Path       string `json:"path"`
+	SHA        string `json:"sha"`
+	HTMLURL    string `json:"html_url"`
+	Repository Repo   `json:"repository"`
+}
+
+type CodeSearchResults []CodeSearchResult
+
+// Repos returns sorted full names of repositories with matches.
+func (r CodeSearchResults) Repos() []string {
+	seen := map[string]bool{}
+	var repos []string
+	for _, v := range r {
+		if seen[v.Repository.FullName] {
+			continue
+		}
+		seen[v.Repository.FullName] = true
+		repos = append(repos, v.Repository.FullName)
+	}
+	sort.Strings(repos)
+	return repos
+}
+
+// CodeSearch runs code search queries in repositories of the organization,
+// like `"oldpkg.Deprecated(" language:go`, and caches results on disk, as
+// code search is slow to change and has the strictest rate limit. Requests
+// are spaced out by the client, so that a batch of queries doesn't hit the
+// secondary rate limit.
+type CodeSearch struct {
+	client   *GitHubClient
+	org      string
+	cacheDir string
+
+	mu   sync.Mutex
+	last time.Time
+
+	now   func() time.Time
+	sleep func(ctx context.Context, d time.Duration) error
+}
+
+func NewCodeSearch(client *GitHubClient, org, cacheDir string) *CodeSearch {
+	return &CodeSearch{
+		client:   client,
+		org:      org,
+		cacheDir: cacheDir,
+		now:      time.Now,
+		sleep:    sleepContext,
+	}
+}
+
+// Search returns files matching the query in the default branches of
+// repositories of the organization. Results of the same query are reused for
+// a day. GitHub only returns the first 1000 matches.
+// See https://docs.github.com/en/rest/search/search#search-code
+func (s *CodeSearch) Search(ctx context.Context, query string) (CodeSearchResults, error) {
+	q := fmt.Sprintf("%s org:%s", query, s.org)
+	sum := sha256.Sum256([]byte(q))
+	name := fmt.Sprintf("%s-code-search-%x", s.org, sum[:8])
+	cache := localcache.NewLocalCache[CodeSearchResults](s.cacheDir, name, codeSearchCacheTTL)
+	return cache.Load(ctx, func() (CodeSearchResults, error) {
+		logger.Debugf(ctx, "Searching code: %s", q)
+		path := fmt.Sprintf("%s/search/code", gitHubAPI)
+		it := paginateField[CodeSearchResult](s.client, path, "items", struct {
+			Query string `url:"q"`
+		}{q}, httpclient.WithRequestVisitor(s.throttle))
+		res, err := ToSlice(ctx, it)
+		if err != nil {
+			return nil, fmt.Errorf("search code: %w", err)
+		}
+		return res, nil
+	})
+}
+
+// throttle waits, until the interval since the previous request passes.
+func (s *CodeSearch) throttle(r *http.Request) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.last.IsZero() {
+		wait := s.last.Add(codeSearchInterval).Sub(s.now())
+		if wait > 0 {
+			logger.Debugf(r.Context(), "Throttling code search for %s", wait)
+			err := s.sleep(r.Context(), wait)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	s.last = s.now()
+	return nil
+}
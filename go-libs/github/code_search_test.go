@@ -0,0 +1,52 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCodeSearchThrottlesAndCaches(t *testing.T) {
+	var queries []string
+	client := NewClient(&GitHubConfig{
+		GitHubTokenSource: GitHubTokenSource{Pat: "x"},
+		transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			require.Equal(t, "GET /search/code", r.Method+" "+r.URL.Path)
+			queries = append(queries, r.URL.Query().Get("q"))
+			if r.URL.Query().Get("page") == "2" {
+				return jsonResponse(200, `{"total_count": 3, "items": [
+					{"path": "c.go", "repository": {"full_name": "a/x"}}]}`), nil
+			}
+			resp := jsonResponse(200, `{"total_count": 3, "items": [
+				{"path": "a.go", "repository": {"full_name": "a/y"}},
+				{"path": "b.go", "repository": {"full_name": "a/x"}}]}`)
+			resp.Header.Set("Link", `<https://api.github.com/search/code?q=x&page=2>; rel="next"`)
+			return resp, nil
+		}),
+	})
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	var waits []time.Duration
+	search := NewCodeSearch(client, "a", t.TempDir())
+	search.now = func() time.Time { return now }
+	search.sleep = func(ctx context.Context, d time.Duration) error {
+		waits = append(waits, d)
+		now = now.Add(d)
+		return nil
+	}
+	ctx := context.Background()
+	res, err := search.Search(ctx, `"ioutil.ReadAll(" language:go`)
+	require.NoError(t, err)
+	assert.Len(t, res, 3)
+	assert.Equal(t, []string{"a/x", "a/y"}, res.Repos())
+	assert.Equal(t, `"ioutil.ReadAll(" language:go org:a`, queries[0])
+	assert.Equal(t, []time.Duration{codeSearchInterval}, waits)
+
+	cached, err := search.Search(ctx, `"ioutil.ReadAll(" language:go`)
+	require.NoError(t, err)
+	assert.Equal(t, res, cached)
+	assert.Len(t, queries, 2)
+}
@@ -0,0 +1,106 @@
+package github
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/databricks/databricks-sdk-go/httpclient"
+	"github.com/databrickslabs/sandbox/go-libs/fileset"
+)
+
+// codeOwnersLocations are checked in the same order as GitHub does.
+// See https://docs.github.com/en/repositories/managing-your-repositorys-settings-and-features/customizing-your-repository/about-code-owners
+var codeOwnersLocations = []string{".github/CODEOWNERS", "CODEOWNERS", "docs/CODEOWNERS"}
+
+type CodeOwnersRule struct {
+	Pattern string
+
+	// Owners are @user, @org/team, or email addresses. Rules without
+	// owners make paths unowned.
+	Owners []string
+
+	ignore *fileset.Ignore
+}
+
+// CodeOwners are rules of a CODEOWNERS file, where the last matching rule
+// takes precedence.
+type CodeOwners []CodeOwnersRule
+
+func ParseCodeOwners(r io.Reader) (CodeOwners, error) {
+	var rules CodeOwners
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line, _, _ := strings.Cut(scanner.Text(), "#")
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		ignore := &fileset.Ignore{}
+		ignore.AddPatterns("", fields[0])
+		rules = append(rules, CodeOwnersRule{
+			Pattern: fields[0],
+			Owners:  fields[1:],
+			ignore:  ignore,
+		})
+	}
+	return rules, scanner.Err()
+}
+
+func (r CodeOwnersRule) match(file string) bool {
+	if r.ignore.Ignored(file, false) {
+		return true
+	}
+	// like in .gitignore, patterns matching directories own everything in them
+	for dir := path.Dir(file); dir != "."; dir = path.Dir(dir) {
+		if r.ignore.Ignored(dir, true) {
+			return true
+		}
+	}
+	return false
+}
+
+// Owners returns owners of a slash-separated path relative to the repository root.
+func (o CodeOwners) Owners(file string) []string {
+	for i := len(o) - 1; i >= 0; i-- {
+		if o[i].match(file) {
+			return o[i].Owners
+		}
+	}
+	return nil
+}
+
+// GetCodeOwners reads CODEOWNERS file from the ref, returning no rules
+// if the repository doesn't have one.
+func (c *GitHubClient) GetCodeOwners(ctx context.Context, org, repo, ref string) (CodeOwners, error) {
+	for _, location := range codeOwnersLocations {
+		raw, err := c.getRawFile(ctx, org, repo, location, ref)
+		var apiErr *httpclient.HttpError
+		if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", location, err)
+		}
+		return ParseCodeOwners(bytes.NewReader(raw))
+	}
+	return nil, nil
+}
+
+func (c *GitHubClient) getRawFile(ctx context.Context, org, repo, file, ref string) ([]byte, error) {
+	path := fmt.Sprintf("%s/repos/%s/%s/contents/%s", gitHubAPI, org, repo, escapeRef(file))
+	var buf bytes.Buffer
+	err := c.api.Do(ctx, "GET", path,
+		httpclient.WithRequestHeader("Accept", "application/vnd.github.raw"),
+		httpclient.WithRequestData(struct {
+			Ref string `url:"ref,omitempty"`
+		}{ref}),
+		httpclient.WithResponseUnmarshal(&buf))
+	return buf.Bytes(), err
+}
@@ -0,0 +1,25 @@
+package github
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCodeOwners(t *testing.T) {
+	owners, err := ParseCodeOwners(strings.NewReader(`# default owners
+*       @org/core
+*.md    @docs jane@example.com # inline comment
+/go-libs/github/ @alice
+docs/   @bob
+/go-libs/github/types
+`))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"@org/core"}, owners.Owners("go.work"))
+	assert.Equal(t, []string{"@docs", "jane@example.com"}, owners.Owners("go-libs/README.md"))
+	assert.Equal(t, []string{"@alice"}, owners.Owners("go-libs/github/github.go"))
+	assert.Equal(t, []string{"@bob"}, owners.Owners("runtime-packages/docs/index.html"))
+	assert.Empty(t, owners.Owners("go-libs/github/types/repos.go"))
+}
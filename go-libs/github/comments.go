@@ -0,0 +1,102 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/databricks/databricks-sdk-go/httpclient"
+)
+
+type IssueComment struct {
+	ID                int64     `json:"id,omitempty"`
+	NodeID            string    `json:"node_id,omitempty"`
+	Body              string    `json:"body,omitempty"`
+	User              User      `json:"user,omitempty"`
+	AuthorAssociation string    `json:"author_association,omitempty"`
+	HTMLURL           string    `json:"html_url,omitempty"`
+	IssueURL          string    `json:"issue_url,omitempty"`
+	CreatedAt         time.Time `json:"created_at,omitempty"`
+	UpdatedAt         time.Time `json:"updated_at,omitempty"`
+}
+
+// CreateIssueComment comments on an issue or a pull request.
+func (c *GitHubClient) CreateIssueComment(ctx context.Context, org, repo string, number int, body string) (*IssueComment, error) {
+	path := fmt.Sprintf("%s/repos/%s/%s/issues/%d/comments", gitHubAPI, org, repo, number)
+	var res IssueComment
+	err := c.api.Do(ctx, "POST", path,
+		httpclient.WithRequestData(map[string]string{
+			"body": body,
+		}),
+		httpclient.WithResponseUnmarshal(&res))
+	return &res, err
+}
+
+// ListIssueComments lists all comments of an issue or a pull request, from
+// the oldest. Review comments on the diff are not included.
+func (c *GitHubClient) ListIssueComments(ctx context.Context, org, repo string, number int) ([]IssueComment, error) {
+	path := fmt.Sprintf("%s/repos/%s/%s/issues/%d/comments", gitHubAPI, org, repo, number)
+	return listAll[IssueComment](ctx, c, path, nil)
+}
+
+func (c *GitHubClient) ListIssueCommentsIterator(org, repo string, number int) *Iterator[IssueComment] {
+	path := fmt.Sprintf("%s/repos/%s/%s/issues/%d/comments", gitHubAPI, org, repo, number)
+	return Paginate[IssueComment](c, path, nil)
+}
+
+// UpdateComment replaces the body of a comment. Comment IDs are unique
+// within the repository, so the issue number is not needed.
+func (c *GitHubClient) UpdateComment(ctx context.Context, org, repo string, commentID int64, body string) (*IssueComment, error) {
+	path := fmt.Sprintf("%s/repos/%s/%s/issues/comments/%d", gitHubAPI, org, repo, commentID)
+	var res IssueComment
+	err := c.api.Do(ctx, "PATCH", path,
+		httpclient.WithRequestData(map[string]string{
+			"body": body,
+		}),
+		httpclient.WithResponseUnmarshal(&res))
+	return &res, err
+}
+
+func (c *GitHubClient) DeleteComment(ctx context.Context, org, repo string, commentID int64) error {
+	path := fmt.Sprintf("%s/repos/%s/%s/issues/comments/%d", gitHubAPI, org, repo, commentID)
+	return c.api.Do(ctx, "DELETE", path)
+}
+
+// stickyMarker is a hidden HTML comment, that identifies a sticky comment
+func stickyMarker(marker string) string {
+	return fmt.Sprintf("<!-- %s -->", marker)
+}
+
+// FindComment returns the first comment with the marker of UpsertComment,
+// or nil, if there's none.
+func (c *GitHubClient) FindComment(ctx context.Context, org, repo string, number int, marker string) (*IssueComment, error) {
+	comments, err := c.ListIssueComments(ctx, org, repo, number)
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range comments {
+		if strings.Contains(v.Body, stickyMarker(marker)) {
+			return &v, nil
+		}
+	}
+	return nil, nil
+}
+
+// UpsertComment maintains a sticky comment, like a status report of a bot,
+// which is identified by a marker, hidden in its body. The comment is created
+// on the first call and is edited on subsequent ones.
+func (c *GitHubClient) UpsertComment(ctx context.Context, org, repo string, number int, marker, body string) (*IssueComment, error) {
+	existing, err := c.FindComment(ctx, org, repo, number, marker)
+	if err != nil {
+		return nil, fmt.Errorf("find: %w", err)
+	}
+	body = fmt.Sprintf("%s\n%s", stickyMarker(marker), body)
+	if existing == nil {
+		return c.CreateIssueComment(ctx, org, repo, number, body)
+	}
+	if existing.Body == body {
+		return existing, nil
+	}
+	return c.UpdateComment(ctx, org, repo, existing.ID, body)
+}
@@ -0,0 +1,48 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpsertCommentEditsStickyComment(t *testing.T) {
+	comments := `[]`
+	var calls []string
+	client := NewClient(&GitHubConfig{
+		GitHubTokenSource: GitHubTokenSource{Pat: "x"},
+		transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			calls = append(calls, r.Method+" "+r.URL.Path)
+			if r.Method == "GET" {
+				return jsonResponse(200, comments), nil
+			}
+			raw, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			var body struct {
+				Body string `json:"body"`
+			}
+			require.NoError(t, json.Unmarshal(raw, &body))
+			assert.Equal(t, "<!-- coverage -->\n85%", body.Body)
+			return jsonResponse(200, `{"id": 2}`), nil
+		}),
+	})
+	ctx := context.Background()
+	_, err := client.UpsertComment(ctx, "a", "b", 3, "coverage", "85%")
+	require.NoError(t, err)
+
+	comments = `[{"id": 1, "body": "LGTM"}, {"id": 2, "body": "<!-- coverage -->\n80%"}]`
+	comment, err := client.UpsertComment(ctx, "a", "b", 3, "coverage", "85%")
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), comment.ID)
+	assert.Equal(t, []string{
+		"GET /repos/a/b/issues/3/comments",
+		"POST /repos/a/b/issues/3/comments",
+		"GET /repos/a/b/issues/3/comments",
+		"PATCH /repos/a/b/issues/comments/2",
+	}, calls)
+}
@@ -0,0 +1,207 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/databricks/databricks-sdk-go/httpclient"
+)
+
+// See https://docs.github.com/en/rest/repos/contents
+
+type ContentEntry struct {
+	Type        string `json:"type"` // file, dir, symlink, submodule
+	Name        string `json:"name"`
+	Path        string `json:"path"`
+	SHA         string `json:"sha"`
+	Size        int    `json:"size"`
+	Encoding    string `json:"encoding,omitempty"`
+	Content     string `json:"content,omitempty"`
+	HTMLURL     string `json:"html_url,omitempty"`
+	DownloadURL string `json:"download_url,omitempty"`
+}
+
+// Decode returns the content of a file. Files larger than 1 MB are not
+// inlined by GitHub and have to be downloaded from DownloadURL instead.
+func (e *ContentEntry) Decode() ([]byte, error) {
+	if e.Encoding != "base64" {
+		return nil, fmt.Errorf("%s: unsupported encoding: %s", e.Path, e.Encoding)
+	}
+	raw, err := base64.StdEncoding.DecodeString(e.Content)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", e.Path, err)
+	}
+	return raw, nil
+}
+
+// GetContents returns either the file, or up to 1000 entries of the
+// directory, at the path on the ref. Empty ref is the default branch.
+func (c *GitHubClient) GetContents(ctx context.Context, org, repo, file, ref string) (*ContentEntry, []ContentEntry, error) {
+	path := fmt.Sprintf("%s/repos/%s/%s/contents/%s", gitHubAPI, org, repo, escapeRef(file))
+	var raw json.RawMessage
+	err := c.api.Do(ctx, "GET", path,
+		httpclient.WithRequestData(struct {
+			Ref string `url:"ref,omitempty"`
+		}{ref}),
+		httpclient.WithResponseUnmarshal(&raw))
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(raw) > 0 && raw[0] == '[' {
+		var dir []ContentEntry
+		err = json.Unmarshal(raw, &dir)
+		return nil, dir, err
+	}
+	var entry ContentEntry
+	err = json.Unmarshal(raw, &entry)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &entry, nil, nil
+}
+
+type FileUpdate struct {
+	Message string
+	Content []byte
+
+	// Branch to commit to. Default is the default branch.
+	Branch string
+
+	// SHA is the blob SHA of the replaced version of the file, which guards
+	// against concurrent updates. It must be empty for new files.
+	SHA string
+}
+
+// FileCommit is the result of changing a single file.
+type FileCommit struct {
+	// Content is nil for deleted files.
+	Content *ContentEntry `json:"content"`
+	Commit  struct {
+		SHA     string `json:"sha"`
+		HTMLURL string `json:"html_url,omitempty"`
+	} `json:"commit"`
+}
+
+// CreateOrUpdateFile commits new content of a file. Updates fail with
+// ErrValidation or a 409 Conflict, if the SHA doesn't match the current
+// version of the file.
+func (c *GitHubClient) CreateOrUpdateFile(ctx context.Context, org, repo, file string, req FileUpdate) (*FileCommit, error) {
+	path := fmt.Sprintf("%s/repos/%s/%s/contents/%s", gitHubAPI, org, repo, escapeRef(file))
+	body := map[string]any{
+		"message": req.Message,
+		"content": base64.StdEncoding.EncodeToString(req.Content),
+	}
+	if req.Branch != "" {
+		body["branch"] = req.Branch
+	}
+	// new files have no blob SHA
+	if req.SHA != "" {
+		body["sha"] = req.SHA
+	}
+	var res FileCommit
+	err := c.api.Do(ctx, "PUT", path,
+		httpclient.WithRequestData(body),
+		httpclient.WithResponseUnmarshal(&res))
+	if err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// DeleteFile commits removal of the file with the blob SHA.
+func (c *GitHubClient) DeleteFile(ctx context.Context, org, repo, file, branch, sha, message string) (*FileCommit, error) {
+	path := fmt.Sprintf("%s/repos/%s/%s/contents/%s", gitHubAPI, org, repo, escapeRef(file))
+	body := map[string]any{
+		"message": message,
+		"sha":     sha,
+	}
+	if branch != "" {
+		body["branch"] = branch
+	}
+	var res FileCommit
+	err := c.api.Do(ctx, "DELETE", path,
+		withJSONBody(body),
+		httpclient.WithResponseUnmarshal(&res))
+	if err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// withJSONBody sends the body with DELETE requests, for which WithRequestData
+// encodes a query string instead.
+func withJSONBody(body any) httpclient.DoOption {
+	return httpclient.WithRequestVisitor(func(r *http.Request) error {
+		raw, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		r.Body = io.NopCloser(bytes.NewReader(raw))
+		r.GetBody = func() (io.ReadCloser, error) {
+			return io.NopCloser(bytes.NewReader(raw)), nil
+		}
+		r.ContentLength = int64(len(raw))
+		r.Header.Set("Content-Type", "application/json")
+		return nil
+	})
+}
+
+// listDirectory lists up to 1000 entries of a directory on the ref
+func (c *GitHubClient) listDirectory(ctx context.Context, org, repo, dir, ref string) ([]ContentEntry, error) {
+	_, entries, err := c.GetContents(ctx, org, repo, dir, ref)
+	return entries, err
+}
+
+// getFile returns the content of a file along with its blob SHA, which is
+// required to update the file.
+func (c *GitHubClient) getFile(ctx context.Context, org, repo, file, ref string) ([]byte, string, error) {
+	entry, _, err := c.GetContents(ctx, org, repo, file, ref)
+	if err != nil {
+		return nil, "", err
+	}
+	if entry == nil {
+		return nil, "", fmt.Errorf("%s: is a directory", file)
+	}
+	raw, err := entry.Decode()
+	if err != nil {
+		return nil, "", err
+	}
+	return raw, entry.SHA, nil
+}
+
+// readme returns the path, the content, and the blob SHA of the preferred
+// README of the repository on the ref, whatever its name and extension are.
+func (c *GitHubClient) readme(ctx context.Context, org, repo, ref string) (string, []byte, string, error) {
+	path := fmt.Sprintf("%s/repos/%s/%s/readme", gitHubAPI, org, repo)
+	var res ContentEntry
+	err := c.api.Do(ctx, "GET", path,
+		httpclient.WithRequestData(struct {
+			Ref string `url:"ref,omitempty"`
+		}{ref}),
+		httpclient.WithResponseUnmarshal(&res))
+	if err != nil {
+		return "", nil, "", err
+	}
+	raw, err := res.Decode()
+	if err != nil {
+		return "", nil, "", err
+	}
+	return res.Path, raw, res.SHA, nil
+}
+
+// updateFile commits new content of a file to the branch. The sha is the blob
+// SHA of the replaced version, which guards against concurrent updates.
+func (c *GitHubClient) updateFile(ctx context.Context, org, repo, file, branch, sha, message string, content []byte) error {
+	_, err := c.CreateOrUpdateFile(ctx, org, repo, file, FileUpdate{
+		Message: message,
+		Content: content,
+		Branch:  branch,
+		SHA:     sha,
+	})
+	return err
+}
@@ -0,0 +1,87 @@
+package github
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetContents(t *testing.T) {
+	client := NewClient(&GitHubConfig{
+		GitHubTokenSource: GitHubTokenSource{Pat: "x"},
+		transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			assert.Equal(t, "v1", r.URL.Query().Get("ref"))
+			switch r.URL.Path {
+			case "/repos/a/b/contents/docs":
+				return jsonResponse(200, `[{"type": "file", "name": "a.md", "path": "docs/a.md"},
+					{"type": "dir", "name": "img", "path": "docs/img"}]`), nil
+			case "/repos/a/b/contents/docs/a.md":
+				return jsonResponse(200, `{"type": "file", "path": "docs/a.md", "sha": "blob",
+					"encoding": "base64", "content": "`+base64.StdEncoding.EncodeToString([]byte("# A"))+`"}`), nil
+			}
+			t.Fatalf("unexpected %s", r.URL.Path)
+			return nil, nil
+		}),
+	})
+	ctx := context.Background()
+	file, dir, err := client.GetContents(ctx, "a", "b", "docs", "v1")
+	require.NoError(t, err)
+	assert.Nil(t, file)
+	require.Len(t, dir, 2)
+	assert.Equal(t, "dir", dir[1].Type)
+
+	file, dir, err = client.GetContents(ctx, "a", "b", "docs/a.md", "v1")
+	require.NoError(t, err)
+	assert.Nil(t, dir)
+	raw, err := file.Decode()
+	require.NoError(t, err)
+	assert.Equal(t, "# A", string(raw))
+	assert.Equal(t, "blob", file.SHA)
+}
+
+func TestCreateOrUpdateAndDeleteFile(t *testing.T) {
+	var bodies []map[string]any
+	client := NewClient(&GitHubConfig{
+		GitHubTokenSource: GitHubTokenSource{Pat: "x"},
+		transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			assert.Equal(t, "/repos/a/b/contents/VERSION", r.URL.Path)
+			raw, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			var body map[string]any
+			require.NoError(t, json.Unmarshal(raw, &body))
+			bodies = append(bodies, body)
+			if r.Method == "DELETE" {
+				return jsonResponse(200, `{"content": null, "commit": {"sha": "c2"}}`), nil
+			}
+			return jsonResponse(200, `{"content": {"path": "VERSION", "sha": "blob2"}, "commit": {"sha": "c1"}}`), nil
+		}),
+	})
+	ctx := context.Background()
+	res, err := client.CreateOrUpdateFile(ctx, "a", "b", "VERSION", FileUpdate{
+		Message: "Bump version",
+		Content: []byte("v2"),
+		Branch:  "release",
+		SHA:     "blob1",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "c1", res.Commit.SHA)
+	assert.Equal(t, "blob2", res.Content.SHA)
+	assert.Equal(t, map[string]any{
+		"message": "Bump version",
+		"content": base64.StdEncoding.EncodeToString([]byte("v2")),
+		"branch":  "release",
+		"sha":     "blob1",
+	}, bodies[0])
+
+	res, err = client.DeleteFile(ctx, "a", "b", "VERSION", "", "blob2", "Remove version")
+	require.NoError(t, err)
+	assert.Nil(t, res.Content)
+	assert.Equal(t, "c2", res.Commit.SHA)
+	assert.Equal(t, map[string]any{"message": "Remove version", "sha": "blob2"}, bodies[1])
+}
@@ -0,0 +1,137 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/databricks/databricks-sdk-go/httpclient"
+)
+
+// See https://docs.github.com/en/rest/orgs/custom-properties
+
+type CustomPropertyDefinition struct {
+	PropertyName string `json:"property_name"`
+
+	// ValueType is one of: string, single_select, multi_select, true_false
+	ValueType        string   `json:"value_type"`
+	Required         bool     `json:"required,omitempty"`
+	DefaultValue     any      `json:"default_value,omitempty"`
+	Description      string   `json:"description,omitempty"`
+	AllowedValues    []string `json:"allowed_values,omitempty"`
+	ValuesEditableBy string   `json:"values_editable_by,omitempty"` // org_actors, org_and_repo_actors
+}
+
+// CustomPropertyValue holds either a string, a list of strings for
+// multi_select properties, or nil to unset the value.
+type CustomPropertyValue struct {
+	PropertyName string `json:"property_name"`
+	Value        any    `json:"value"`
+}
+
+type CustomProperties []CustomPropertyValue
+
+// Lookup returns values of a property, normalized to a list of strings,
+// regardless of the property being single or multi-valued.
+func (cp CustomProperties) Lookup(name string) ([]string, bool) {
+	for _, v := range cp {
+		if v.PropertyName != name {
+			continue
+		}
+		switch x := v.Value.(type) {
+		case nil:
+			return nil, false
+		case string:
+			return []string{x}, true
+		case []string:
+			return x, true
+		case []any:
+			var out []string
+			for _, y := range x {
+				out = append(out, fmt.Sprint(y))
+			}
+			return out, true
+		default:
+			return []string{fmt.Sprint(x)}, true
+		}
+	}
+	return nil, false
+}
+
+type RepoCustomProperties struct {
+	RepositoryID       int64            `json:"repository_id"`
+	RepositoryName     string           `json:"repository_name"`
+	RepositoryFullName string           `json:"repository_full_name"`
+	Properties         CustomProperties `json:"properties"`
+}
+
+func (c *GitHubClient) ListOrgCustomPropertySchema(ctx context.Context, org string) ([]CustomPropertyDefinition, error) {
+	path := fmt.Sprintf("%s/orgs/%s/properties/schema", gitHubAPI, org)
+	var res []CustomPropertyDefinition
+	err := c.api.Do(ctx, "GET", path, httpclient.WithResponseUnmarshal(&res))
+	return res, err
+}
+
+func (c *GitHubClient) GetOrgCustomProperty(ctx context.Context, org, name string) (*CustomPropertyDefinition, error) {
+	path := fmt.Sprintf("%s/orgs/%s/properties/schema/%s", gitHubAPI, org, url.PathEscape(name))
+	var res CustomPropertyDefinition
+	err := c.api.Do(ctx, "GET", path, httpclient.WithResponseUnmarshal(&res))
+	return &res, err
+}
+
+// SetOrgCustomProperties creates new or updates existing property definitions in bulk
+func (c *GitHubClient) SetOrgCustomProperties(ctx context.Context, org string, properties ...CustomPropertyDefinition) ([]CustomPropertyDefinition, error) {
+	path := fmt.Sprintf("%s/orgs/%s/properties/schema", gitHubAPI, org)
+	var res []CustomPropertyDefinition
+	err := c.api.Do(ctx, "PATCH", path,
+		httpclient.WithRequestData(map[string]any{
+			"properties": properties,
+		}),
+		httpclient.WithResponseUnmarshal(&res))
+	return res, err
+}
+
+func (c *GitHubClient) DeleteOrgCustomProperty(ctx context.Context, org, name string) error {
+	path := fmt.Sprintf("%s/orgs/%s/properties/schema/%s", gitHubAPI, org, url.PathEscape(name))
+	return c.api.Do(ctx, "DELETE", path)
+}
+
+// ListOrgCustomPropertyValues returns property values of every repository in the org
+func (c *GitHubClient) ListOrgCustomPropertyValues(ctx context.Context, org string) ([]RepoCustomProperties, error) {
+	path := fmt.Sprintf("%s/orgs/%s/properties/values", gitHubAPI, org)
+	var res []RepoCustomProperties
+	err := c.api.Do(ctx, "GET", path,
+		httpclient.WithRequestData(listOptions{PerPage: 100}),
+		httpclient.WithResponseUnmarshal(&res))
+	return res, err
+}
+
+func (c *GitHubClient) ListOrgCustomPropertyValuesIterator(org string) *Iterator[RepoCustomProperties] {
+	path := fmt.Sprintf("%s/orgs/%s/properties/values", gitHubAPI, org)
+	return Paginate[RepoCustomProperties](c, path, nil)
+}
+
+// SetOrgCustomPropertyValues sets the same property values on up to 30 repositories at once
+func (c *GitHubClient) SetOrgCustomPropertyValues(ctx context.Context, org string, repos []string, properties ...CustomPropertyValue) error {
+	path := fmt.Sprintf("%s/orgs/%s/properties/values", gitHubAPI, org)
+	return c.api.Do(ctx, "PATCH", path, httpclient.WithRequestData(map[string]any{
+		"repository_names": repos,
+		"properties":       properties,
+	}))
+}
+
+func (c *GitHubClient) GetRepoCustomProperties(ctx context.Context, org, repo string) (CustomProperties, error) {
+	path := fmt.Sprintf("%s/repos/%s/%s/properties/values", gitHubAPI, org, repo)
+	var res CustomProperties
+	err := c.api.Do(ctx, "GET", path, httpclient.WithResponseUnmarshal(&res))
+	return res, err
+}
+
+// SetRepoCustomProperties creates or updates property values of a repository.
+// Use nil value to remove the property from the repository.
+func (c *GitHubClient) SetRepoCustomProperties(ctx context.Context, org, repo string, properties ...CustomPropertyValue) error {
+	path := fmt.Sprintf("%s/repos/%s/%s/properties/values", gitHubAPI, org, repo)
+	return c.api.Do(ctx, "PATCH", path, httpclient.WithRequestData(map[string]any{
+		"properties": properties,
+	}))
+}
@@ -0,0 +1,198 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/databricks/databricks-sdk-go/httpclient"
+	"github.com/databricks/databricks-sdk-go/logger"
+)
+
+// workflowsDir is where GitHub Actions workflows live
+const workflowsDir = ".github/workflows"
+
+// RenameBranch renames a branch. GitHub redirects the old name in the web UI
+// and moves open pull requests and branch protection to the new name.
+func (c *GitHubClient) RenameBranch(ctx context.Context, org, repo, branch, newName string) error {
+	path := fmt.Sprintf("%s/repos/%s/%s/branches/%s/rename", gitHubAPI, org, repo, escapeRef(branch))
+	return c.api.Do(ctx, "POST", path, httpclient.WithRequestData(map[string]any{
+		"new_name": newName,
+	}))
+}
+
+type DefaultBranchMigration struct {
+	// From is the current default branch. Default is "master".
+	From string
+
+	// To is the new name of the default branch. Default is "main".
+	To string
+}
+
+type DefaultBranchMigrationResult struct {
+	Renamed                bool
+	RetargetedPullRequests []int
+	ProtectionUpdated      bool
+	UpdatedWorkflows       []string
+}
+
+// MigrateDefaultBranch renames the default branch, makes sure that open pull
+// requests and branch protection follow it, and commits workflow files, that
+// reference the old name, to the new branch. GitHub does the first two on its
+// own most of the time, so they are verified rather than assumed. It's safe to
+// run again after a failure.
+func (c *GitHubClient) MigrateDefaultBranch(ctx context.Context, org, repo string, m DefaultBranchMigration) (*DefaultBranchMigrationResult, error) {
+	if m.From == "" {
+		m.From = "master"
+	}
+	if m.To == "" {
+		m.To = "main"
+	}
+	r, err := c.GetRepo(ctx, org, repo)
+	if err != nil {
+		return nil, fmt.Errorf("repo: %w", err)
+	}
+	res := &DefaultBranchMigrationResult{}
+	if r.DefaultBranch != m.From && r.DefaultBranch != m.To {
+		return nil, fmt.Errorf("default branch is %s, not %s", r.DefaultBranch, m.From)
+	}
+	if r.DefaultBranch == m.From {
+		protection, err := c.GetBranchProtection(ctx, org, repo, m.From)
+		if err != nil {
+			return nil, fmt.Errorf("protection: %w", err)
+		}
+		prs, err := c.ListPullRequests(ctx, org, repo, PullRequestListOptions{
+			State:   "open",
+			Base:    m.From,
+			PerPage: 100,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("pull requests: %w", err)
+		}
+		err = c.RenameBranch(ctx, org, repo, m.From, m.To)
+		if err != nil {
+			return nil, fmt.Errorf("rename: %w", err)
+		}
+		res.Renamed = true
+		logger.Infof(ctx, "Renamed %s to %s in %s", m.From, m.To, repo)
+		err = c.retargetPullRequests(ctx, org, repo, m.To, prs, res)
+		if err != nil {
+			return res, err
+		}
+		err = c.ensureProtection(ctx, org, repo, m.To, *protection, res)
+		if err != nil {
+			return res, err
+		}
+	}
+	err = c.retargetWorkflows(ctx, org, repo, m, res)
+	if err != nil {
+		return res, fmt.Errorf("workflows: %w", err)
+	}
+	return res, nil
+}
+
+func (c *GitHubClient) retargetPullRequests(ctx context.Context, org, repo, base string, prs []PullRequest, res *DefaultBranchMigrationResult) error {
+	for _, pr := range prs {
+		current, err := c.GetPullRequest(ctx, org, repo, pr.Number)
+		if err != nil {
+			return fmt.Errorf("pull request %d: %w", pr.Number, err)
+		}
+		if current.Base.Ref == base {
+			continue
+		}
+		err = c.EditPullRequest(ctx, org, repo, pr.Number, PullRequestUpdate{
+			Base: base,
+		})
+		if err != nil {
+			return fmt.Errorf("retarget %d: %w", pr.Number, err)
+		}
+		res.RetargetedPullRequests = append(res.RetargetedPullRequests, pr.Number)
+	}
+	return nil
+}
+
+func (c *GitHubClient) ensureProtection(ctx context.Context, org, repo, branch string, desired BranchProtectionRule, res *DefaultBranchMigrationResult) error {
+	actual, err := c.GetBranchProtection(ctx, org, repo, branch)
+	if err != nil {
+		return fmt.Errorf("protection: %w", err)
+	}
+	if len(diffProtection(repo, branch, *actual, desired)) == 0 {
+		return nil
+	}
+	err = c.UpdateBranchProtection(ctx, org, repo, branch, desired)
+	if err != nil {
+		return fmt.Errorf("protection: %w", err)
+	}
+	res.ProtectionUpdated = true
+	return nil
+}
+
+func (c *GitHubClient) retargetWorkflows(ctx context.Context, org, repo string, m DefaultBranchMigration, res *DefaultBranchMigrationResult) error {
+	entries, err := c.listDirectory(ctx, org, repo, workflowsDir, m.To)
+	var apiErr *httpclient.HttpError
+	if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	for _, v := range entries {
+		ext := path.Ext(v.Name)
+		if v.Type != "file" || (ext != ".yml" && ext != ".yaml") {
+			continue
+		}
+		raw, sha, err := c.getFile(ctx, org, repo, v.Path, m.To)
+		if err != nil {
+			return err
+		}
+		updated := retargetWorkflow(string(raw), m.From, m.To)
+		if updated == string(raw) {
+			continue
+		}
+		message := fmt.Sprintf("Retarget %s from %s to %s", v.Name, m.From, m.To)
+		err = c.updateFile(ctx, org, repo, v.Path, m.To, sha, message, []byte(updated))
+		if err != nil {
+			return fmt.Errorf("%s: %w", v.Path, err)
+		}
+		res.UpdatedWorkflows = append(res.UpdatedWorkflows, v.Path)
+	}
+	return nil
+}
+
+var branchesKey = regexp.MustCompile(`^(\s*)(- )?branches(-ignore)?:(.*)$`)
+
+// retargetWorkflow replaces the branch in branch filters of workflow triggers
+// and in refs/heads/ references, like github.ref conditions. Other mentions
+// of the name, like in comments or scripts, are left as is.
+func retargetWorkflow(workflow, from, to string) string {
+	name := regexp.QuoteMeta(from)
+	token := regexp.MustCompile(`(^|[\s\[,'"])` + name + `($|[\s\],'"])`)
+	ref := regexp.MustCompile(`refs/heads/` + name + `($|[^\w./-])`)
+	lines := strings.Split(workflow, "\n")
+	keyIndent := -1
+	for i, line := range lines {
+		indent := len(line) - len(strings.TrimLeft(line, " "))
+		trimmed := strings.TrimSpace(line)
+		if keyIndent >= 0 && trimmed != "" && !strings.HasPrefix(trimmed, "#") {
+			if indent > keyIndent && strings.HasPrefix(trimmed, "- ") {
+				lines[i] = token.ReplaceAllString(line, "${1}"+to+"${2}")
+				continue
+			}
+			keyIndent = -1
+		}
+		if m := branchesKey.FindStringSubmatch(line); m != nil {
+			value := strings.TrimSpace(m[4])
+			if value == "" || strings.HasPrefix(value, "#") {
+				keyIndent = len(m[1])
+			} else {
+				lines[i] = token.ReplaceAllString(line, "${1}"+to+"${2}")
+			}
+		}
+		lines[i] = ref.ReplaceAllString(lines[i], "refs/heads/"+to+"${1}")
+	}
+	return strings.Join(lines, "\n")
+}
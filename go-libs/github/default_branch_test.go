@@ -0,0 +1,105 @@
+package github
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetargetWorkflow(t *testing.T) {
+	workflow := `on:
+  push:
+    branches:
+      - master
+      - 'release/**'
+  pull_request:
+    branches: [ master, masterpiece ]
+jobs:
+  publish:
+    # deploys from master
+    if: github.ref == 'refs/heads/master'
+    steps:
+      - run: git push origin refs/heads/master-backup
+`
+	assert.Equal(t, `on:
+  push:
+    branches:
+      - main
+      - 'release/**'
+  pull_request:
+    branches: [ main, masterpiece ]
+jobs:
+  publish:
+    # deploys from master
+    if: github.ref == 'refs/heads/main'
+    steps:
+      - run: git push origin refs/heads/master-backup
+`, retargetWorkflow(workflow, "master", "main"))
+}
+
+func TestMigrateDefaultBranch(t *testing.T) {
+	renamed := false
+	var updated map[string]string
+	client := NewClient(&GitHubConfig{
+		GitHubTokenSource: GitHubTokenSource{Pat: "x"},
+		transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			switch r.Method + " " + r.URL.Path {
+			case "GET /repos/a/b":
+				return jsonResponse(200, `{"name": "b", "default_branch": "master"}`), nil
+			case "GET /repos/a/b/branches/master/protection":
+				return jsonResponse(200, `{"required_linear_history": {"enabled": true}}`), nil
+			case "GET /repos/a/b/branches/main/protection":
+				return jsonResponse(404, `{"message": "Branch not protected"}`), nil
+			case "PUT /repos/a/b/branches/main/protection":
+				return jsonResponse(200, `{}`), nil
+			case "GET /repos/a/b/pulls":
+				assert.Equal(t, "master", r.URL.Query().Get("base"))
+				return jsonResponse(200, `[{"number": 1}, {"number": 2}]`), nil
+			case "POST /repos/a/b/branches/master/rename":
+				renamed = true
+				return jsonResponse(201, `{"name": "main"}`), nil
+			case "GET /repos/a/b/pulls/1":
+				return jsonResponse(200, `{"number": 1, "base": {"ref": "main"}}`), nil
+			case "GET /repos/a/b/pulls/2":
+				return jsonResponse(200, `{"number": 2, "base": {"ref": "master"}}`), nil
+			case "PATCH /repos/a/b/pulls/2":
+				return jsonResponse(200, `{}`), nil
+			case "GET /repos/a/b/contents/.github/workflows":
+				assert.Equal(t, "main", r.URL.Query().Get("ref"))
+				return jsonResponse(200, `[
+					{"type": "file", "name": "push.yml", "path": ".github/workflows/push.yml"},
+					{"type": "file", "name": "nightly.yml", "path": ".github/workflows/nightly.yml"},
+					{"type": "file", "name": "README.md", "path": ".github/workflows/README.md"}
+				]`), nil
+			case "GET /repos/a/b/contents/.github/workflows/push.yml":
+				content := base64.StdEncoding.EncodeToString([]byte("on:\n  push:\n    branches: [master]\n"))
+				return jsonResponse(200, `{"sha": "s1", "encoding": "base64", "content": "`+content+`"}`), nil
+			case "GET /repos/a/b/contents/.github/workflows/nightly.yml":
+				content := base64.StdEncoding.EncodeToString([]byte("on:\n  schedule:\n    - cron: '0 0 * * *'\n"))
+				return jsonResponse(200, `{"sha": "s2", "encoding": "base64", "content": "`+content+`"}`), nil
+			case "PUT /repos/a/b/contents/.github/workflows/push.yml":
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&updated))
+				return jsonResponse(200, `{}`), nil
+			}
+			t.Errorf("unexpected: %s %s", r.Method, r.URL.Path)
+			return jsonResponse(404, `{"message": "Not Found"}`), nil
+		}),
+	})
+	res, err := client.MigrateDefaultBranch(context.Background(), "a", "b", DefaultBranchMigration{})
+	require.NoError(t, err)
+	assert.True(t, renamed)
+	assert.True(t, res.Renamed)
+	assert.Equal(t, []int{2}, res.RetargetedPullRequests)
+	assert.True(t, res.ProtectionUpdated)
+	assert.Equal(t, []string{".github/workflows/push.yml"}, res.UpdatedWorkflows)
+	assert.Equal(t, "s1", updated["sha"])
+	assert.Equal(t, "main", updated["branch"])
+	content, err := base64.StdEncoding.DecodeString(updated["content"])
+	require.NoError(t, err)
+	assert.Equal(t, "on:\n  push:\n    branches: [main]\n", string(content))
+}
@@ -0,0 +1,286 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/databricks/databricks-sdk-go/logger"
+)
+
+const dependabotLogin = "dependabot[bot]"
+
+// Semver levels of updates, from the safest.
+const (
+	UpdatePatch = "patch"
+	UpdateMinor = "minor"
+	UpdateMajor = "major"
+)
+
+var updateLevels = []string{UpdatePatch, UpdateMinor, UpdateMajor}
+
+// dependabotTitle matches titles of single dependency updates, like "Bump
+// lodash from 4.17.20 to 4.17.21 in /web", with an optional commit prefix,
+// like "build(deps): bump ...". Grouped updates don't match.
+var dependabotTitle = regexp.MustCompile(`(?i)^(?:[\w()-]+!?: )?(?:bump|update) (\S+)(?: requirement)? from (\S+) to (\S+)(?: in (\S+))?$`)
+
+// DependabotUpdate is a dependency update parsed from the title of the pull
+// request.
+type DependabotUpdate struct {
+	Dependency string
+	From       string
+	To         string
+
+	// Directory is empty for updates in the root of the repository.
+	Directory string
+
+	// Level is one of: patch, minor, major, or empty for versions, that
+	// aren't semantic, like commit SHAs.
+	Level string
+}
+
+func ParseDependabotTitle(title string) (*DependabotUpdate, bool) {
+	m := dependabotTitle.FindStringSubmatch(title)
+	if m == nil {
+		return nil, false
+	}
+	return &DependabotUpdate{
+		Dependency: m[1],
+		From:       m[2],
+		To:         m[3],
+		Directory:  m[4],
+		Level:      updateLevel(m[2], m[3]),
+	}, true
+}
+
+// updateLevel compares numeric major, minor, and patch versions, ignoring
+// the v prefix and prerelease suffixes.
+func updateLevel(from, to string) string {
+	a, ok := versionParts(from)
+	if !ok {
+		return ""
+	}
+	b, ok := versionParts(to)
+	if !ok {
+		return ""
+	}
+	switch {
+	case a[0] != b[0]:
+		return UpdateMajor
+	case a[1] != b[1]:
+		return UpdateMinor
+	}
+	return UpdatePatch
+}
+
+func versionParts(v string) (parts [3]int, ok bool) {
+	v = strings.TrimPrefix(v, "v")
+	v, _, _ = strings.Cut(v, "-")
+	v, _, _ = strings.Cut(v, "+")
+	split := strings.Split(v, ".")
+	if len(split) > 3 {
+		return parts, false
+	}
+	for i, s := range split {
+		n, err := strconv.Atoi(s)
+		if err != nil {
+			return parts, false
+		}
+		parts[i] = n
+	}
+	return parts, true
+}
+
+// AutoMergePolicy decides, which Dependabot pull requests are merged
+// without a human review.
+type AutoMergePolicy struct {
+	// MaxLevel is the riskiest update, that qualifies: patch, minor, or
+	// major. Default is patch.
+	MaxLevel string
+
+	// Allow lists dependencies or path.Match patterns of them, like
+	// "@types/*", that qualify. Default is all dependencies.
+	Allow []string
+
+	// Deny lists dependencies or patterns, that never qualify, even if
+	// they're allowed.
+	Deny []string
+
+	// Method defaults to squash merges.
+	Method MergeMethod
+
+	// RequiredChecks must be reported, besides all reported checks being
+	// green. Pending checks don't qualify, so that pull requests are
+	// evaluated again on the next run.
+	RequiredChecks []string
+
+	// IgnoredChecks don't block merges, even if they fail.
+	IgnoredChecks []string
+}
+
+// AutoMergeDecision is the outcome of the policy for a pull request.
+type AutoMergeDecision struct {
+	Number int
+	Title  string
+	Update *DependabotUpdate
+
+	// Qualified pull requests are approved and have auto-merge enabled.
+	Qualified bool
+
+	// Reason explains, why the pull request doesn't qualify.
+	Reason string
+
+	// Merged is true, if the pull request was already mergeable and got
+	// merged right away instead of having auto-merge enabled.
+	Merged bool
+
+	// Err is the failure to approve or merge the qualified pull request,
+	// which doesn't stop the run.
+	Err error
+}
+
+// DependabotAutoMerger approves Dependabot pull requests, that qualify for
+// the policy, and enables auto-merge on them, so that GitHub merges them
+// once branch protection is satisfied.
+type DependabotAutoMerger struct {
+	Client *GitHubClient
+	Policy AutoMergePolicy
+}
+
+func matchesAny(patterns []string, name string) bool {
+	for _, pattern := range patterns {
+		ok, err := path.Match(pattern, name)
+		if err == nil && ok {
+			return true
+		}
+	}
+	return false
+}
+
+// Evaluate decides on the pull request without changing anything.
+func (m *DependabotAutoMerger) Evaluate(ctx context.Context, org, repo string, pr *PullRequest) (*AutoMergeDecision, error) {
+	decision := &AutoMergeDecision{Number: pr.Number, Title: pr.Title}
+	reject := func(format string, args ...any) (*AutoMergeDecision, error) {
+		decision.Reason = fmt.Sprintf(format, args...)
+		return decision, nil
+	}
+	if pr.User.Login != dependabotLogin {
+		return reject("opened by %s", pr.User.Login)
+	}
+	if pr.Draft {
+		return reject("draft")
+	}
+	update, ok := ParseDependabotTitle(pr.Title)
+	if !ok {
+		return reject("not a single dependency update")
+	}
+	decision.Update = update
+	maxLevel := m.Policy.MaxLevel
+	if maxLevel == "" {
+		maxLevel = UpdatePatch
+	}
+	if update.Level == "" {
+		return reject("%s to %s is not a semantic version", update.From, update.To)
+	}
+	if !slices.Contains(updateLevels, maxLevel) {
+		return nil, fmt.Errorf("unknown update level: %s", maxLevel)
+	}
+	if slices.Index(updateLevels, update.Level) > slices.Index(updateLevels, maxLevel) {
+		return reject("%s update is above %s", update.Level, maxLevel)
+	}
+	if len(m.Policy.Allow) > 0 && !matchesAny(m.Policy.Allow, update.Dependency) {
+		return reject("%s is not allowed", update.Dependency)
+	}
+	if matchesAny(m.Policy.Deny, update.Dependency) {
+		return reject("%s is denied", update.Dependency)
+	}
+	summary, err := m.Client.checksSummary(ctx, org, repo, pr.Head.SHA, WaitForChecksOptions{
+		Required: m.Policy.RequiredChecks,
+		Ignore:   m.Policy.IgnoredChecks,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("#%d: checks: %w", pr.Number, err)
+	}
+	if summary.State != "success" {
+		return reject("%s", summary)
+	}
+	decision.Qualified = true
+	return decision, nil
+}
+
+// Run evaluates open Dependabot pull requests of the repository, approves
+// qualifying ones, and enables auto-merge on them, or merges them right away,
+// if they are already mergeable. Failures of a single pull request are kept
+// in Err of its decision. Use Evaluate or the DryRun of the client to preview
+// decisions.
+func (m *DependabotAutoMerger) Run(ctx context.Context, org, repo string) ([]AutoMergeDecision, error) {
+	prs, err := ToSlice(ctx, m.Client.ListPullRequestsIterator(org, repo, PullRequestListOptions{State: "open"}))
+	if err != nil {
+		return nil, fmt.Errorf("pull requests: %w", err)
+	}
+	method := m.Policy.Method
+	if method == "" {
+		method = MergeMethodSquash
+	}
+	var decisions []AutoMergeDecision
+	for i := range prs {
+		pr := &prs[i]
+		if pr.User.Login != dependabotLogin {
+			continue
+		}
+		decision, err := m.Evaluate(ctx, org, repo, pr)
+		if err != nil {
+			return decisions, err
+		}
+		if !decision.Qualified {
+			logger.Debugf(ctx, "Skipping #%d: %s", pr.Number, decision.Reason)
+		} else if pr.AutoMerge.MergeMethod == "" {
+			// otherwise approved by an earlier run
+			decision.Merged, decision.Err = m.approveAndMerge(ctx, org, repo, pr, decision, method)
+			if decision.Err != nil {
+				logger.Warnf(ctx, "Failed to auto-merge #%d: %s", pr.Number, decision.Err)
+			}
+		}
+		decisions = append(decisions, *decision)
+	}
+	return decisions, nil
+}
+
+// approveAndMerge approves the pull request and enables auto-merge on it, or
+// merges it right away, if it's already mergeable, as GitHub rejects enabling
+// auto-merge on pull requests in the clean status.
+func (m *DependabotAutoMerger) approveAndMerge(ctx context.Context, org, repo string, pr *PullRequest, decision *AutoMergeDecision, method MergeMethod) (bool, error) {
+	_, err := m.Client.CreateReview(ctx, org, repo, pr.Number, NewReview{
+		CommitID: pr.Head.SHA,
+		Body:     fmt.Sprintf("Auto-approved %s update of %s", decision.Update.Level, decision.Update.Dependency),
+		Event:    ReviewApprove,
+	})
+	if err != nil {
+		return false, fmt.Errorf("approve: %w", err)
+	}
+	autoMergeErr := m.Client.EnableAutoMerge(ctx, org, repo, pr.Number, method)
+	if autoMergeErr == nil {
+		logger.Infof(ctx, "Enabled auto-merge of #%d: %s", pr.Number, pr.Title)
+		return false, nil
+	}
+	current, err := m.Client.GetPullRequest(ctx, org, repo, pr.Number)
+	if err != nil {
+		return false, fmt.Errorf("auto-merge: %w", autoMergeErr)
+	}
+	if current.MergeableState != "clean" && current.MergeableState != "has_hooks" {
+		return false, fmt.Errorf("auto-merge: %w", autoMergeErr)
+	}
+	_, err = m.Client.MergePullRequest(ctx, org, repo, pr.Number, MergeOptions{
+		Method: method,
+		SHA:    pr.Head.SHA,
+	})
+	if err != nil {
+		return false, fmt.Errorf("merge: %w", err)
+	}
+	logger.Infof(ctx, "Merged #%d: %s", pr.Number, pr.Title)
+	return true, nil
+}
@@ -0,0 +1,151 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDependabotTitle(t *testing.T) {
+	for title, want := range map[string]*DependabotUpdate{
+		"Bump lodash from 4.17.20 to 4.17.21": {
+			Dependency: "lodash", From: "4.17.20", To: "4.17.21", Level: UpdatePatch,
+		},
+		"build(deps): bump golang.org/x/net from 0.17.0 to 0.19.0 in /go-libs": {
+			Dependency: "golang.org/x/net", From: "0.17.0", To: "0.19.0", Directory: "/go-libs", Level: UpdateMinor,
+		},
+		"Update requests requirement from v2.31 to v3.0.0-rc1": {
+			Dependency: "requests", From: "v2.31", To: "v3.0.0-rc1", Level: UpdateMajor,
+		},
+		"Bump actions/checkout from a81bbbf to b4ffde6": {
+			Dependency: "actions/checkout", From: "a81bbbf", To: "b4ffde6",
+		},
+	} {
+		update, ok := ParseDependabotTitle(title)
+		require.True(t, ok, title)
+		assert.Equal(t, want, update, title)
+	}
+	_, ok := ParseDependabotTitle("Bump the npm group with 3 updates")
+	assert.False(t, ok)
+}
+
+func TestDependabotAutoMerger(t *testing.T) {
+	var approved []string
+	var mutations []map[string]any
+	client := NewClient(&GitHubConfig{
+		GitHubTokenSource: GitHubTokenSource{Pat: "x"},
+		transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			switch r.Method + " " + r.URL.Path {
+			case "GET /repos/a/b/pulls":
+				return jsonResponse(200, `[
+					{"number": 1, "title": "Bump lodash from 4.17.20 to 4.17.21", "user": {"login": "dependabot[bot]"}, "head": {"sha": "green"}},
+					{"number": 2, "title": "Bump react from 17.0.2 to 18.2.0", "user": {"login": "dependabot[bot]"}, "head": {"sha": "green"}},
+					{"number": 3, "title": "Bump left-pad from 1.0.0 to 1.0.1", "user": {"login": "dependabot[bot]"}, "head": {"sha": "green"}},
+					{"number": 4, "title": "Bump @types/node from 20.1.0 to 20.1.1", "user": {"login": "dependabot[bot]"}, "head": {"sha": "red"}},
+					{"number": 5, "title": "Fix typo", "user": {"login": "jane"}}
+				]`), nil
+			case "GET /repos/a/b/commits/green/status", "GET /repos/a/b/commits/red/status":
+				return jsonResponse(200, `{"statuses": []}`), nil
+			case "GET /repos/a/b/commits/green/check-runs":
+				return jsonResponse(200, `{"check_runs": [{"name": "build", "status": "completed", "conclusion": "success"}]}`), nil
+			case "GET /repos/a/b/commits/red/check-runs":
+				return jsonResponse(200, `{"check_runs": [{"name": "build", "status": "completed", "conclusion": "failure"}]}`), nil
+			case "POST /repos/a/b/pulls/1/reviews":
+				var review NewReview
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&review))
+				approved = append(approved, string(review.Event)+" "+review.CommitID)
+				return jsonResponse(200, `{}`), nil
+			case "POST /graphql":
+				var body struct {
+					Variables map[string]any `json:"variables"`
+				}
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+				if body.Variables["number"] != nil {
+					return jsonResponse(200, `{"data": {"repository": {"pullRequest": {"id": "PR_1"}}}}`), nil
+				}
+				mutations = append(mutations, body.Variables)
+				return jsonResponse(200, `{"data": {}}`), nil
+			}
+			t.Fatalf("unexpected %s %s", r.Method, r.URL.Path)
+			return nil, nil
+		}),
+	})
+	merger := &DependabotAutoMerger{
+		Client: client,
+		Policy: AutoMergePolicy{
+			MaxLevel:       UpdateMinor,
+			Deny:           []string{"left-*"},
+			RequiredChecks: []string{"build"},
+		},
+	}
+	decisions, err := merger.Run(context.Background(), "a", "b")
+	require.NoError(t, err)
+	var reasons []string
+	for _, v := range decisions {
+		reasons = append(reasons, v.Reason)
+	}
+	assert.Equal(t, []string{
+		"",
+		"major update is above minor",
+		"left-pad is denied",
+		"red: failed: build (failure)",
+	}, reasons)
+	assert.True(t, decisions[0].Qualified)
+	assert.Equal(t, []string{"APPROVE green"}, approved)
+	assert.Equal(t, []map[string]any{{"id": "PR_1", "method": "SQUASH"}}, mutations)
+}
+
+func TestDependabotAutoMergerMergesMergeable(t *testing.T) {
+	var merged []map[string]any
+	client := NewClient(&GitHubConfig{
+		GitHubTokenSource: GitHubTokenSource{Pat: "x"},
+		transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			switch r.Method + " " + r.URL.Path {
+			case "GET /repos/a/b/pulls":
+				return jsonResponse(200, `[
+					{"number": 1, "title": "Bump lodash from 4.17.20 to 4.17.21", "user": {"login": "dependabot[bot]"}, "head": {"sha": "green"}},
+					{"number": 2, "title": "Bump chalk from 5.0.0 to 5.0.1", "user": {"login": "dependabot[bot]"}, "head": {"sha": "green"}}
+				]`), nil
+			case "GET /repos/a/b/commits/green/status":
+				return jsonResponse(200, `{"statuses": []}`), nil
+			case "GET /repos/a/b/commits/green/check-runs":
+				return jsonResponse(200, `{"check_runs": [{"name": "build", "status": "completed", "conclusion": "success"}]}`), nil
+			case "POST /repos/a/b/pulls/1/reviews", "POST /repos/a/b/pulls/2/reviews":
+				return jsonResponse(200, `{}`), nil
+			case "POST /graphql":
+				var body struct {
+					Variables map[string]any `json:"variables"`
+				}
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+				if body.Variables["number"] != nil {
+					return jsonResponse(200, `{"data": {"repository": {"pullRequest": {"id": "PR_1"}}}}`), nil
+				}
+				return jsonResponse(200, `{"errors": [{"message": "Pull request is in clean status"}]}`), nil
+			case "GET /repos/a/b/pulls/1":
+				return jsonResponse(200, `{"number": 1, "mergeable_state": "clean"}`), nil
+			case "GET /repos/a/b/pulls/2":
+				return jsonResponse(200, `{"number": 2, "mergeable_state": "blocked"}`), nil
+			case "PUT /repos/a/b/pulls/1/merge":
+				var body map[string]any
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+				merged = append(merged, body)
+				return jsonResponse(200, `{"merged": true}`), nil
+			}
+			t.Fatalf("unexpected %s %s", r.Method, r.URL.Path)
+			return nil, nil
+		}),
+	})
+	merger := &DependabotAutoMerger{Client: client}
+	decisions, err := merger.Run(context.Background(), "a", "b")
+	require.NoError(t, err)
+	require.Len(t, decisions, 2)
+	assert.True(t, decisions[0].Merged)
+	assert.NoError(t, decisions[0].Err)
+	assert.Equal(t, []map[string]any{{"merge_method": "squash", "sha": "green"}}, merged)
+	assert.False(t, decisions[1].Merged)
+	assert.ErrorContains(t, decisions[1].Err, "clean status")
+}
@@ -0,0 +1,90 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/databricks/databricks-sdk-go/httpclient"
+)
+
+// See https://docs.github.com/en/rest/deployments/protection-rules
+
+type ProtectionRuleApp struct {
+	ID             int64  `json:"id,omitempty"`
+	NodeID         string `json:"node_id,omitempty"`
+	Slug           string `json:"slug,omitempty"`
+	IntegrationURL string `json:"integration_url,omitempty"`
+}
+
+type DeploymentProtectionRule struct {
+	ID      int64             `json:"id,omitempty"`
+	NodeID  string            `json:"node_id,omitempty"`
+	Enabled bool              `json:"enabled,omitempty"`
+	App     ProtectionRuleApp `json:"app,omitempty"`
+}
+
+func (c *GitHubClient) protectionRulesPath(org, repo, environment string) string {
+	return fmt.Sprintf("%s/repos/%s/%s/environments/%s/deployment_protection_rules",
+		gitHubAPI, org, repo, url.PathEscape(environment))
+}
+
+// ListDeploymentProtectionRules lists custom protection rules enabled on an environment
+func (c *GitHubClient) ListDeploymentProtectionRules(ctx context.Context, org, repo, environment string) ([]DeploymentProtectionRule, error) {
+	var res struct {
+		TotalCount int                        `json:"total_count"`
+		Rules      []DeploymentProtectionRule `json:"custom_deployment_protection_rules"`
+	}
+	err := c.api.Do(ctx, "GET", c.protectionRulesPath(org, repo, environment),
+		httpclient.WithResponseUnmarshal(&res))
+	return res.Rules, err
+}
+
+// ListAvailableProtectionRuleApps lists GitHub Apps installed on the repository,
+// which can be registered as custom protection rules.
+func (c *GitHubClient) ListAvailableProtectionRuleApps(ctx context.Context, org, repo, environment string) ([]ProtectionRuleApp, error) {
+	var res struct {
+		TotalCount int                 `json:"total_count"`
+		Apps       []ProtectionRuleApp `json:"available_custom_deployment_protection_rule_integrations"`
+	}
+	err := c.api.Do(ctx, "GET", c.protectionRulesPath(org, repo, environment)+"/apps",
+		httpclient.WithResponseUnmarshal(&res))
+	return res.Apps, err
+}
+
+func (c *GitHubClient) GetDeploymentProtectionRule(ctx context.Context, org, repo, environment string, ruleID int64) (*DeploymentProtectionRule, error) {
+	path := fmt.Sprintf("%s/%d", c.protectionRulesPath(org, repo, environment), ruleID)
+	var res DeploymentProtectionRule
+	err := c.api.Do(ctx, "GET", path, httpclient.WithResponseUnmarshal(&res))
+	return &res, err
+}
+
+// CreateDeploymentProtectionRule registers a GitHub App as a gate for deployments to the environment
+func (c *GitHubClient) CreateDeploymentProtectionRule(ctx context.Context, org, repo, environment string, integrationID int64) (*DeploymentProtectionRule, error) {
+	var res DeploymentProtectionRule
+	err := c.api.Do(ctx, "POST", c.protectionRulesPath(org, repo, environment),
+		httpclient.WithRequestData(map[string]int64{
+			"integration_id": integrationID,
+		}),
+		httpclient.WithResponseUnmarshal(&res))
+	return &res, err
+}
+
+func (c *GitHubClient) DeleteDeploymentProtectionRule(ctx context.Context, org, repo, environment string, ruleID int64) error {
+	path := fmt.Sprintf("%s/%d", c.protectionRulesPath(org, repo, environment), ruleID)
+	return c.api.Do(ctx, "DELETE", path)
+}
+
+type DeploymentProtectionRuleReview struct {
+	EnvironmentName string `json:"environment_name"`
+	State           string `json:"state"` // approved, rejected
+	Comment         string `json:"comment,omitempty"`
+}
+
+// ReviewDeploymentProtectionRule satisfies or rejects a gate on the deployment
+// of a workflow run. It has to be called with the token of the GitHub App,
+// which received the deployment_protection_rule webhook event.
+func (c *GitHubClient) ReviewDeploymentProtectionRule(ctx context.Context, org, repo string, runID int64, req DeploymentProtectionRuleReview) error {
+	path := fmt.Sprintf("%s/repos/%s/%s/actions/runs/%d/deployment_protection_rule", gitHubAPI, org, repo, runID)
+	return c.api.Do(ctx, "POST", path, httpclient.WithRequestData(req))
+}
@@ -0,0 +1,130 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/databricks/databricks-sdk-go/httpclient"
+)
+
+type Environment struct {
+	ID      int64  `json:"id,omitempty"`
+	NodeID  string `json:"node_id,omitempty"`
+	Name    string `json:"name,omitempty"`
+	URL     string `json:"url,omitempty"`
+	HTMLURL string `json:"html_url,omitempty"`
+}
+
+type Deployment struct {
+	ID          int64     `json:"id,omitempty"`
+	NodeID      string    `json:"node_id,omitempty"`
+	SHA         string    `json:"sha,omitempty"`
+	Ref         string    `json:"ref,omitempty"`
+	Task        string    `json:"task,omitempty"`
+	Environment string    `json:"environment,omitempty"`
+	Description string    `json:"description,omitempty"`
+	Creator     User      `json:"creator,omitempty"`
+	CreatedAt   time.Time `json:"created_at,omitempty"`
+	UpdatedAt   time.Time `json:"updated_at,omitempty"`
+	URL         string    `json:"url,omitempty"`
+	StatusesURL string    `json:"statuses_url,omitempty"`
+}
+
+type DeploymentReviewer struct {
+	Type     string `json:"type,omitempty"` // User, Team
+	Reviewer struct {
+		Login string `json:"login,omitempty"` // for users
+		Slug  string `json:"slug,omitempty"`  // for teams
+	} `json:"reviewer,omitempty"`
+}
+
+type PendingDeployment struct {
+	Environment           Environment          `json:"environment,omitempty"`
+	WaitTimer             int                  `json:"wait_timer,omitempty"`
+	WaitTimerStartedAt    time.Time            `json:"wait_timer_started_at,omitempty"`
+	CurrentUserCanApprove bool                 `json:"current_user_can_approve,omitempty"`
+	Reviewers             []DeploymentReviewer `json:"reviewers,omitempty"`
+}
+
+type PendingDeploymentsReview struct {
+	EnvironmentIDs []int64 `json:"environment_ids"`
+	State          string  `json:"state"` // approved, rejected
+	Comment        string  `json:"comment"`
+}
+
+// ListPendingDeployments lists environments of a workflow run, which are
+// waiting for a reviewer to approve or reject the deployment.
+func (c *GitHubClient) ListPendingDeployments(ctx context.Context, org, repo string, runID int64) ([]PendingDeployment, error) {
+	path := fmt.Sprintf("%s/repos/%s/%s/actions/runs/%d/pending_deployments", gitHubAPI, org, repo, runID)
+	var res []PendingDeployment
+	err := c.api.Do(ctx, "GET", path, httpclient.WithResponseUnmarshal(&res))
+	return res, err
+}
+
+func (c *GitHubClient) ReviewPendingDeployments(ctx context.Context, org, repo string, runID int64, req PendingDeploymentsReview) ([]Deployment, error) {
+	path := fmt.Sprintf("%s/repos/%s/%s/actions/runs/%d/pending_deployments", gitHubAPI, org, repo, runID)
+	var res []Deployment
+	err := c.api.Do(ctx, "POST", path,
+		httpclient.WithRequestData(req),
+		httpclient.WithResponseUnmarshal(&res))
+	return res, err
+}
+
+// ApprovePendingDeployments approves deployments of a workflow run to the given
+// environments, or to all environments the current user can approve, if none
+// are given. This is the building block for ChatOps-style promotions.
+func (c *GitHubClient) ApprovePendingDeployments(ctx context.Context, org, repo string, runID int64, comment string, environments ...string) ([]Deployment, error) {
+	return c.reviewPendingByName(ctx, org, repo, runID, "approved", comment, environments)
+}
+
+func (c *GitHubClient) RejectPendingDeployments(ctx context.Context, org, repo string, runID int64, comment string, environments ...string) ([]Deployment, error) {
+	return c.reviewPendingByName(ctx, org, repo, runID, "rejected", comment, environments)
+}
+
+func (c *GitHubClient) reviewPendingByName(ctx context.Context, org, repo string, runID int64, state, comment string, environments []string) ([]Deployment, error) {
+	pending, err := c.ListPendingDeployments(ctx, org, repo, runID)
+	if err != nil {
+		return nil, fmt.Errorf("pending deployments: %w", err)
+	}
+	wanted := map[string]bool{}
+	for _, v := range environments {
+		wanted[v] = true
+	}
+	found := map[string]bool{}
+	var ids []int64
+	for _, v := range pending {
+		if len(wanted) > 0 && !wanted[v.Environment.Name] {
+			continue
+		}
+		if !v.CurrentUserCanApprove {
+			if len(wanted) == 0 {
+				// only environments the user can review are implied
+				continue
+			}
+			return nil, fmt.Errorf("not allowed to review deployment to %s", v.Environment.Name)
+		}
+		found[v.Environment.Name] = true
+		ids = append(ids, v.Environment.ID)
+	}
+	var missing []string
+	for name := range wanted {
+		if !found[name] {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return nil, fmt.Errorf("no pending deployments to %s", strings.Join(missing, ", "))
+	}
+	if len(ids) == 0 {
+		return nil, fmt.Errorf("no pending deployments for run %d, that can be reviewed", runID)
+	}
+	return c.ReviewPendingDeployments(ctx, org, repo, runID, PendingDeploymentsReview{
+		EnvironmentIDs: ids,
+		State:          state,
+		Comment:        comment,
+	})
+}
@@ -0,0 +1,40 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApprovePendingDeploymentsSkipsOthers(t *testing.T) {
+	var review PendingDeploymentsReview
+	client := NewClient(&GitHubConfig{
+		GitHubTokenSource: GitHubTokenSource{Pat: "x"},
+		transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			switch r.Method + " " + r.URL.Path {
+			case "GET /repos/a/b/actions/runs/1/pending_deployments":
+				return jsonResponse(200, `[
+					{"environment": {"id": 10, "name": "staging"}, "current_user_can_approve": true},
+					{"environment": {"id": 20, "name": "prod"}, "current_user_can_approve": false}
+				]`), nil
+			case "POST /repos/a/b/actions/runs/1/pending_deployments":
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&review))
+				return jsonResponse(200, `[{"id": 1, "environment": "staging"}]`), nil
+			}
+			t.Fatalf("unexpected %s %s", r.Method, r.URL.Path)
+			return nil, nil
+		}),
+	})
+	ctx := context.Background()
+	_, err := client.ApprovePendingDeployments(ctx, "a", "b", 1, "ship it")
+	require.NoError(t, err)
+	assert.Equal(t, []int64{10}, review.EnvironmentIDs)
+	assert.Equal(t, "approved", review.State)
+
+	_, err = client.ApprovePendingDeployments(ctx, "a", "b", 1, "ship it", "prod")
+	assert.ErrorContains(t, err, "not allowed to review deployment to prod")
+}
@@ -0,0 +1,156 @@
+package github
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/databricks/databricks-sdk-go/logger"
+)
+
+// DownloadSample is the cumulative download count of a release asset at
+// the time of the snapshot.
+type DownloadSample struct {
+	Time      time.Time `json:"time"`
+	Repo      string    `json:"repo"`
+	Release   string    `json:"release"`
+	Asset     string    `json:"asset"`
+	Downloads int64     `json:"downloads"`
+}
+
+// DownloadStore keeps download samples over time.
+type DownloadStore interface {
+	Append(ctx context.Context, samples []DownloadSample) error
+}
+
+// DownloadLog is a DownloadStore in a file with a JSON sample per line,
+// which is cheap to append to and easy to load into notebooks.
+type DownloadLog struct {
+	mu   sync.Mutex
+	Path string
+}
+
+func (l *DownloadLog) Append(ctx context.Context, samples []DownloadSample) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	err := os.MkdirAll(filepath.Dir(l.Path), 0o755)
+	if err != nil {
+		return err
+	}
+	f, err := os.OpenFile(l.Path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	enc := json.NewEncoder(f)
+	for _, v := range samples {
+		err = enc.Encode(v)
+		if err != nil {
+			f.Close()
+			return err
+		}
+	}
+	return f.Close()
+}
+
+// Samples reads all samples from the log, in the order they were appended.
+func (l *DownloadLog) Samples() ([]DownloadSample, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	f, err := os.Open(l.Path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	var samples []DownloadSample
+	scanner := bufio.NewScanner(f)
+	for line := 1; scanner.Scan(); line++ {
+		var v DownloadSample
+		err = json.Unmarshal(scanner.Bytes(), &v)
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", l.Path, line, err)
+		}
+		samples = append(samples, v)
+	}
+	return samples, scanner.Err()
+}
+
+// DownloadCollector periodically snapshots download counts of release assets
+// into a store, because GitHub only reports the cumulative count, so adoption
+// over time has to be tracked by the caller.
+type DownloadCollector struct {
+	Client *GitHubClient
+	Org    string
+	Repos  []string
+	Store  DownloadStore
+
+	// Interval between snapshots. Default is 24 hours.
+	Interval time.Duration
+
+	// now is the test seam
+	now func() time.Time
+}
+
+// Collect takes a snapshot of all assets of all releases of the repositories.
+func (dc *DownloadCollector) Collect(ctx context.Context) ([]DownloadSample, error) {
+	now := time.Now
+	if dc.now != nil {
+		now = dc.now
+	}
+	t := now().UTC()
+	var samples []DownloadSample
+	for _, repo := range dc.Repos {
+		releases, err := dc.Client.Versions(ctx, dc.Org, repo)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", repo, err)
+		}
+		for _, release := range releases {
+			for _, asset := range release.Assets {
+				samples = append(samples, DownloadSample{
+					Time:      t,
+					Repo:      repo,
+					Release:   release.Version,
+					Asset:     asset.Name,
+					Downloads: asset.DownloadCount,
+				})
+			}
+		}
+	}
+	err := dc.Store.Append(ctx, samples)
+	if err != nil {
+		return nil, fmt.Errorf("store: %w", err)
+	}
+	return samples, nil
+}
+
+// Run collects snapshots every interval until the context is done. Failed
+// snapshots are logged and retried on the next tick.
+func (dc *DownloadCollector) Run(ctx context.Context) error {
+	interval := dc.Interval
+	if interval == 0 {
+		interval = 24 * time.Hour
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		samples, err := dc.Collect(ctx)
+		if err != nil {
+			logger.Warnf(ctx, "Failed to collect downloads: %s", err)
+		} else {
+			logger.Infof(ctx, "Collected %d download samples", len(samples))
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDownloadCollector(t *testing.T) {
+	downloads := 10
+	client := NewClient(&GitHubConfig{
+		GitHubTokenSource: GitHubTokenSource{Pat: "x"},
+		transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			downloads += 5
+			return jsonResponse(200, `[{"tag_name": "v0.1.0", "assets": [
+				{"name": "a.zip", "download_count": `+strconv.Itoa(downloads)+`},
+				{"name": "b.zip", "download_count": 1}
+			]}]`), nil
+		}),
+	})
+	log := &DownloadLog{Path: filepath.Join(t.TempDir(), "downloads.jsonl")}
+	day := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	collector := &DownloadCollector{
+		Client: client,
+		Org:    "a",
+		Repos:  []string{"b"},
+		Store:  log,
+		now: func() time.Time {
+			day = day.AddDate(0, 0, 1)
+			return day
+		},
+	}
+	ctx := context.Background()
+	for i := 0; i < 2; i++ {
+		_, err := collector.Collect(ctx)
+		require.NoError(t, err)
+	}
+	samples, err := log.Samples()
+	require.NoError(t, err)
+	require.Len(t, samples, 4)
+	assert.Equal(t, int64(15), samples[0].Downloads)
+	assert.Equal(t, int64(20), samples[2].Downloads)
+	assert.Equal(t, "2024-01-03", samples[2].Time.Format("2006-01-02"))
+	assert.Equal(t, "a.zip", samples[2].Asset)
+}
+
+func TestReleaseDownloadCount(t *testing.T) {
+	release := Release{Assets: []Asset{{DownloadCount: 3}, {DownloadCount: 4}}}
+	assert.Equal(t, int64(7), release.DownloadCount())
+}
@@ -0,0 +1,82 @@
+package github
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/databricks/databricks-sdk-go/logger"
+)
+
+// dryRunTruncateBytes limits logged request bodies
+const dryRunTruncateBytes = 2048
+
+// dryRun logs mutating requests instead of sending them and responds with
+// an empty body, which leaves response objects of callers zero-valued, as it's
+// impossible to synthesize objects for every endpoint. GraphQL queries are
+// read-only and go through, unlike GraphQL mutations.
+type dryRun struct {
+	next http.RoundTripper
+}
+
+func (d *dryRun) RoundTrip(r *http.Request) (*http.Response, error) {
+	if r.Method == "GET" || r.Method == "HEAD" || r.Method == "OPTIONS" {
+		return d.next.RoundTrip(r)
+	}
+	var body []byte
+	if r.Body != nil {
+		var err error
+		body, err = io.ReadAll(r.Body)
+		r.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+	if strings.HasSuffix(r.URL.Path, "/graphql") && !isGraphQLMutation(body) {
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		return d.next.RoundTrip(r)
+	}
+	logger.Infof(r.Context(), "[dry-run] %s %s %s", r.Method, r.URL, describeBody(r, body))
+	status := http.StatusOK
+	if r.Method == "DELETE" {
+		status = http.StatusNoContent
+	}
+	return &http.Response{
+		Status:     fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		StatusCode: status,
+		Proto:      "HTTP/1.1",
+		ProtoMajor: 1,
+		ProtoMinor: 1,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       http.NoBody,
+		Request:    r,
+	}, nil
+}
+
+func isGraphQLMutation(body []byte) bool {
+	var req struct {
+		Query string `json:"query"`
+	}
+	err := json.Unmarshal(body, &req)
+	if err != nil {
+		// not a GraphQL request, which is safer to treat as a mutation
+		return true
+	}
+	return strings.HasPrefix(strings.TrimSpace(req.Query), "mutation")
+}
+
+func describeBody(r *http.Request, body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+	if !json.Valid(body) {
+		return fmt.Sprintf("(%d bytes of %s)", len(body), r.Header.Get("Content-Type"))
+	}
+	if len(body) > dryRunTruncateBytes {
+		return string(body[:dryRunTruncateBytes]) + "..."
+	}
+	return string(body)
+}
@@ -0,0 +1,42 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDryRunSkipsMutations(t *testing.T) {
+	var calls []string
+	client := NewClient(&GitHubConfig{
+		GitHubTokenSource: GitHubTokenSource{Pat: "x"},
+		DryRun:            true,
+		transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			calls = append(calls, r.Method+" "+r.URL.Path)
+			return jsonResponse(200, `{"data": {"repository": {"id": "R_1"}}, "number": 1}`), nil
+		}),
+	})
+	ctx := context.Background()
+	pr, err := client.CreatePullRequest(ctx, "a", "b", NewPullRequest{
+		Title: "Preview",
+		Head:  "feature",
+		Base:  "main",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 0, pr.Number)
+
+	err = client.DeleteDeploymentProtectionRule(ctx, "a", "b", "prod", 1)
+	require.NoError(t, err)
+
+	_, err = client.GetPullRequest(ctx, "a", "b", 1)
+	require.NoError(t, err)
+
+	id, err := client.repoNodeID(ctx, "a", "b")
+	require.NoError(t, err)
+	assert.Equal(t, "R_1", id)
+
+	assert.Equal(t, []string{"GET /repos/a/b/pulls/1", "POST /graphql"}, calls)
+}
@@ -0,0 +1,86 @@
+package github
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Methods build URLs of github.com, which are rewritten for GitHub Enterprise
+// Server, so that every method, including upload endpoints, works with both.
+const (
+	gitHubAPIHost     = "api.github.com"
+	gitHubUploadsHost = "uploads.github.com"
+)
+
+// enterpriseURLs are the endpoints of GitHub Enterprise Server
+type enterpriseURLs struct {
+	api     *url.URL
+	uploads *url.URL
+	graphQL *url.URL
+}
+
+// newEnterpriseURLs derives endpoints from the base URL, like
+// https://github.example.com/api/v3, which serves uploads from /api/uploads
+// and GraphQL from /api/graphql.
+func newEnterpriseURLs(baseURL, uploadURL string) (*enterpriseURLs, error) {
+	baseURL = strings.TrimSuffix(baseURL, "/")
+	api, err := url.Parse(baseURL)
+	if err != nil {
+		return nil, fmt.Errorf("base url: %w", err)
+	}
+	if api.Scheme == "" || api.Host == "" {
+		return nil, fmt.Errorf("base url: absolute url expected: %s", baseURL)
+	}
+	root, isV3 := strings.CutSuffix(baseURL, "/v3")
+	graphQL := baseURL + "/graphql"
+	if isV3 {
+		graphQL = root + "/graphql"
+	}
+	if uploadURL == "" {
+		uploadURL = baseURL
+		if isV3 {
+			uploadURL = root + "/uploads"
+		}
+	}
+	uploads, err := url.Parse(strings.TrimSuffix(uploadURL, "/"))
+	if err != nil {
+		return nil, fmt.Errorf("upload url: %w", err)
+	}
+	gql, err := url.Parse(graphQL)
+	if err != nil {
+		return nil, fmt.Errorf("graphql url: %w", err)
+	}
+	return &enterpriseURLs{api: api, uploads: uploads, graphQL: gql}, nil
+}
+
+// rewrite points a github.com request to the enterprise server, keeping the
+// escaped path and the query. Other URLs, like next pages from Link headers,
+// already point to the server and are left as is.
+func (e *enterpriseURLs) rewrite(u *url.URL) {
+	var base *url.URL
+	switch {
+	case u.Host == gitHubAPIHost && u.Path == "/graphql":
+		u.Scheme, u.Host = e.graphQL.Scheme, e.graphQL.Host
+		u.Path, u.RawPath = e.graphQL.Path, e.graphQL.RawPath
+		return
+	case u.Host == gitHubAPIHost:
+		base = e.api
+	case u.Host == gitHubUploadsHost:
+		base = e.uploads
+	default:
+		return
+	}
+	u.Scheme, u.Host = base.Scheme, base.Host
+	if u.RawPath != "" {
+		u.RawPath = base.EscapedPath() + u.RawPath
+	}
+	u.Path = base.Path + u.Path
+}
+
+func (e *enterpriseURLs) visit(r *http.Request) error {
+	e.rewrite(r.URL)
+	r.Host = ""
+	return nil
+}
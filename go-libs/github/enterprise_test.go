@@ -0,0 +1,51 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnterpriseURLs(t *testing.T) {
+	e, err := newEnterpriseURLs("https://ghe.example.com/api/v3/", "")
+	require.NoError(t, err)
+	for in, out := range map[string]string{
+		"https://api.github.com/repos/a/b/commits/release%2Fv1/status?per_page=100": "https://ghe.example.com/api/v3/repos/a/b/commits/release%2Fv1/status?per_page=100",
+		"https://api.github.com/graphql":                                            "https://ghe.example.com/api/graphql",
+		"https://uploads.github.com/repos/a/b/releases/1/assets?name=x":             "https://ghe.example.com/api/uploads/repos/a/b/releases/1/assets?name=x",
+		"https://ghe.example.com/api/v3/repos/a/b/pulls?page=2":                     "https://ghe.example.com/api/v3/repos/a/b/pulls?page=2",
+	} {
+		u, err := url.Parse(in)
+		require.NoError(t, err)
+		e.rewrite(u)
+		assert.Equal(t, out, u.String())
+	}
+
+	e, err = newEnterpriseURLs("https://proxy.example.com/github", "https://uploads.example.com")
+	require.NoError(t, err)
+	assert.Equal(t, "https://proxy.example.com/github/graphql", e.graphQL.String())
+	assert.Equal(t, "https://uploads.example.com", e.uploads.String())
+
+	_, err = newEnterpriseURLs("ghe.example.com", "")
+	assert.ErrorContains(t, err, "absolute url expected")
+}
+
+func TestClientWithBaseURL(t *testing.T) {
+	var requested []string
+	client := NewClient(&GitHubConfig{
+		GitHubTokenSource: GitHubTokenSource{Pat: "x"},
+		BaseURL:           "https://ghe.example.com/api/v3",
+		transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			requested = append(requested, r.URL.String())
+			return jsonResponse(200, `{"name": "b"}`), nil
+		}),
+	})
+	repo, err := client.GetRepo(context.Background(), "a", "b")
+	require.NoError(t, err)
+	assert.Equal(t, "b", repo.Name)
+	assert.Equal(t, []string{"https://ghe.example.com/api/v3/repos/a/b"}, requested)
+}
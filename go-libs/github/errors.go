@@ -0,0 +1,215 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/databricks/databricks-sdk-go/httpclient"
+)
+
+// Errors of API responses, so that callers don't have to match on messages.
+// The original *httpclient.HttpError is still available with errors.As.
+var (
+	ErrNotFound     = errors.New("not found")
+	ErrUnauthorized = errors.New("unauthorized")
+	ErrRateLimited  = errors.New("rate limited")
+	ErrValidation   = errors.New("validation failed")
+)
+
+// RateLimitError is ErrRateLimited with the time, when requests are allowed
+// again. Reset is zero, if GitHub didn't say.
+type RateLimitError struct {
+	Reset time.Time
+
+	// Secondary is true for secondary (abuse) rate limits.
+	Secondary bool
+
+	err *httpclient.HttpError
+}
+
+func (e *RateLimitError) Error() string {
+	if e.Reset.IsZero() {
+		return fmt.Sprintf("%s: %s", ErrRateLimited, errorMessage(e.err))
+	}
+	return fmt.Sprintf("%s until %s: %s", ErrRateLimited, e.Reset.Format(time.RFC3339), errorMessage(e.err))
+}
+
+func (e *RateLimitError) Is(target error) bool {
+	return target == ErrRateLimited
+}
+
+func (e *RateLimitError) Unwrap() error {
+	return e.err
+}
+
+// FieldError explains, why a field of a request is invalid.
+// See https://docs.github.com/en/rest/using-the-rest-api/troubleshooting-the-rest-api
+type FieldError struct {
+	Resource string `json:"resource"`
+	Field    string `json:"field"`
+
+	// Code is one of: missing, missing_field, invalid, already_exists,
+	// unprocessable, or custom, when Message explains the error.
+	Code    string `json:"code"`
+	Message string `json:"message,omitempty"`
+}
+
+func (e FieldError) String() string {
+	if e.Code == "custom" || (e.Field == "" && e.Message != "") {
+		return e.Message
+	}
+	return fmt.Sprintf("%s.%s: %s", e.Resource, e.Field, e.Code)
+}
+
+// ValidationError is ErrValidation with field errors of 422 responses.
+type ValidationError struct {
+	Errors []FieldError
+
+	err *httpclient.HttpError
+}
+
+func (e *ValidationError) Error() string {
+	if len(e.Errors) == 0 {
+		return fmt.Sprintf("%s: %s", ErrValidation, errorMessage(e.err))
+	}
+	var fields []string
+	for _, v := range e.Errors {
+		fields = append(fields, v.String())
+	}
+	return fmt.Sprintf("%s: %s: %s", ErrValidation, errorMessage(e.err), strings.Join(fields, ", "))
+}
+
+func (e *ValidationError) Is(target error) bool {
+	return target == ErrValidation
+}
+
+func (e *ValidationError) Unwrap() error {
+	return e.err
+}
+
+// statusError is ErrNotFound or ErrUnauthorized, which carry no details.
+type statusError struct {
+	kind error
+	err  *httpclient.HttpError
+}
+
+func (e *statusError) Error() string {
+	return fmt.Sprintf("%s: %s", e.kind, errorMessage(e.err))
+}
+
+func (e *statusError) Is(target error) bool {
+	return target == e.kind
+}
+
+func (e *statusError) Unwrap() error {
+	return e.err
+}
+
+// errorMessage returns the message of the JSON body of the error, which the
+// API client keeps as is.
+func errorMessage(err *httpclient.HttpError) string {
+	var body struct {
+		Message string `json:"message"`
+	}
+	if json.Unmarshal([]byte(err.Message), &body) != nil || body.Message == "" {
+		return err.Message
+	}
+	return body.Message
+}
+
+// errorResponse is the last failed response of a request, which the API
+// client reduces to a message.
+type errorResponse struct {
+	header http.Header
+	body   []byte
+}
+
+type errorResponseKey struct{}
+
+func captureErrorResponse(r *http.Request, resp *http.Response) error {
+	failure, ok := r.Context().Value(errorResponseKey{}).(*errorResponse)
+	if !ok || resp.StatusCode < 400 {
+		return nil
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	failure.header = resp.Header.Clone()
+	failure.body = body
+	return nil
+}
+
+// apiClient maps errors of all requests to the typed errors above.
+// Secrets are masked in logs of all requests.
+type apiClient struct {
+	*httpclient.ApiClient
+	redactor *redactor
+}
+
+func (a apiClient) Do(ctx context.Context, method, path string, opts ...httpclient.DoOption) error {
+	var failure errorResponse
+	ctx = context.WithValue(ctx, errorResponseKey{}, &failure)
+	ctx = a.redactor.withRedaction(ctx)
+	err := a.ApiClient.Do(ctx, method, path, opts...)
+	return typedError(err, &failure)
+}
+
+func typedError(err error, failure *errorResponse) error {
+	var apiErr *httpclient.HttpError
+	if !errors.As(err, &apiErr) {
+		return err
+	}
+	switch {
+	case isRateLimited(apiErr.StatusCode, failure.header):
+		return &RateLimitError{
+			Reset:     rateLimitReset(failure.header, time.Now()),
+			Secondary: failure.header.Get("Retry-After") != "",
+			err:       apiErr,
+		}
+	case apiErr.StatusCode == http.StatusUnauthorized:
+		return &statusError{ErrUnauthorized, apiErr}
+	case apiErr.StatusCode == http.StatusNotFound:
+		return &statusError{ErrNotFound, apiErr}
+	case apiErr.StatusCode == http.StatusUnprocessableEntity:
+		var body struct {
+			Errors []FieldError `json:"errors"`
+		}
+		// some endpoints report errors as plain strings, which are ignored
+		_ = json.Unmarshal(failure.body, &body)
+		return &ValidationError{Errors: body.Errors, err: apiErr}
+	}
+	return err
+}
+
+// isRateLimited detects primary rate limits, which are 403 or 429 responses
+// without remaining requests, and secondary ones with Retry-After.
+func isRateLimited(status int, h http.Header) bool {
+	switch status {
+	case http.StatusTooManyRequests:
+		return true
+	case http.StatusForbidden:
+		return h.Get("Retry-After") != "" || h.Get("X-RateLimit-Remaining") == "0"
+	}
+	return false
+}
+
+func rateLimitReset(h http.Header, now time.Time) time.Time {
+	if seconds, err := strconv.Atoi(h.Get("Retry-After")); err == nil {
+		return now.Add(time.Duration(seconds) * time.Second)
+	}
+	if reset, err := strconv.ParseInt(h.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+		return time.Unix(reset, 0)
+	}
+	return time.Time{}
+}
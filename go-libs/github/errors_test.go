@@ -0,0 +1,81 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/databricks/databricks-sdk-go/httpclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func clientResponding(resp *http.Response) *GitHubClient {
+	return NewClient(&GitHubConfig{
+		GitHubTokenSource: GitHubTokenSource{Pat: "x"},
+		transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			return resp, nil
+		}),
+	})
+}
+
+func TestNotFoundIsTyped(t *testing.T) {
+	client := clientResponding(jsonResponse(404, `{"message": "Not Found"}`))
+	_, err := client.GetRelease(context.Background(), "a", "b", 1)
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.NotErrorIs(t, err, ErrUnauthorized)
+
+	var apiErr *httpclient.HttpError
+	require.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, 404, apiErr.StatusCode)
+}
+
+func TestUnauthorizedIsTyped(t *testing.T) {
+	client := clientResponding(jsonResponse(401, `{"message": "Bad credentials"}`))
+	_, err := client.GetRelease(context.Background(), "a", "b", 1)
+	assert.ErrorIs(t, err, ErrUnauthorized)
+}
+
+func TestRateLimitedHasResetTime(t *testing.T) {
+	resp := jsonResponse(403, `{"message": "API rate limit exceeded"}`)
+	resp.Header.Set("X-RateLimit-Remaining", "0")
+	resp.Header.Set("X-RateLimit-Reset", "4102444800")
+	client := clientResponding(resp)
+	_, err := client.GetRelease(context.Background(), "a", "b", 1)
+	assert.ErrorIs(t, err, ErrRateLimited)
+
+	var rateErr *RateLimitError
+	require.True(t, errors.As(err, &rateErr))
+	assert.Equal(t, time.Unix(4102444800, 0), rateErr.Reset)
+	assert.False(t, rateErr.Secondary)
+}
+
+func TestForbiddenIsNotRateLimited(t *testing.T) {
+	resp := jsonResponse(403, `{"message": "Resource not accessible by integration"}`)
+	resp.Header.Set("X-RateLimit-Remaining", "4999")
+	client := clientResponding(resp)
+	_, err := client.GetRelease(context.Background(), "a", "b", 1)
+	assert.NotErrorIs(t, err, ErrRateLimited)
+}
+
+func TestValidationHasFieldErrors(t *testing.T) {
+	client := clientResponding(jsonResponse(422, `{
+		"message": "Validation Failed",
+		"errors": [{"resource": "Release", "code": "already_exists", "field": "tag_name"}]
+	}`))
+	_, err := client.CreateRelease(context.Background(), "a", "b", CreateReleaseRequest{
+		TagName: "v1.0.0",
+	})
+	assert.ErrorIs(t, err, ErrValidation)
+
+	var validationErr *ValidationError
+	require.True(t, errors.As(err, &validationErr))
+	assert.Equal(t, []FieldError{{
+		Resource: "Release",
+		Field:    "tag_name",
+		Code:     "already_exists",
+	}}, validationErr.Errors)
+	assert.ErrorContains(t, err, "Release.tag_name: already_exists")
+}
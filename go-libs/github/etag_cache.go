@@ -0,0 +1,202 @@
+package github
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// ETagCache keeps responses to GET requests together with their ETags in
+// memory and, optionally, in a directory, which survives restarts. Cached
+// responses are revalidated with If-None-Match on every request, and GitHub
+// doesn't count 304 Not Modified responses against the rate limit. Only JSON
+// responses within MaxResponseBytes are cached, so downloads keep streaming.
+// The cache may be shared by clients using the same credentials.
+type ETagCache struct {
+	dir string
+
+	mu      sync.Mutex
+	entries map[string]*snapshot
+	hits    int
+}
+
+// NewETagCache creates a cache, that is kept in the directory, or in memory
+// only, if the directory is empty.
+func NewETagCache(dir string) *ETagCache {
+	return &ETagCache{
+		dir:     dir,
+		entries: map[string]*snapshot{},
+	}
+}
+
+// Hits returns the number of responses served from the cache.
+func (c *ETagCache) Hits() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.hits
+}
+
+// etagKey differs for every representation of the same URL, like raw files
+func etagKey(r *http.Request) string {
+	sum := sha256.Sum256([]byte(r.URL.String() + "\n" + r.Header.Get("Accept")))
+	return hex.EncodeToString(sum[:16])
+}
+
+func (c *ETagCache) get(key string) (*snapshot, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if s, ok := c.entries[key]; ok {
+		return s, nil
+	}
+	if c.dir == "" {
+		return nil, nil
+	}
+	raw, err := os.ReadFile(filepath.Join(c.dir, key+".json"))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var s snapshot
+	err = json.Unmarshal(raw, &s)
+	if err != nil {
+		return nil, fmt.Errorf("etag cache: %w", err)
+	}
+	c.entries[key] = &s
+	return &s, nil
+}
+
+func (c *ETagCache) put(key string, s *snapshot) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[key] = s
+	if c.dir == "" {
+		return nil
+	}
+	err := os.MkdirAll(c.dir, 0o755)
+	if err != nil {
+		return fmt.Errorf("etag cache: %w", err)
+	}
+	raw, err := json.Marshal(s)
+	if err != nil {
+		return fmt.Errorf("etag cache: %w", err)
+	}
+	return os.WriteFile(filepath.Join(c.dir, key+".json"), raw, 0o600)
+}
+
+func (c *ETagCache) hit() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.hits++
+}
+
+type etagTransport struct {
+	next  http.RoundTripper
+	cache *ETagCache
+
+	// limit is MaxResponseBytes, or zero for no limit
+	limit int64
+}
+
+// cacheable are JSON API responses, unlike downloads of release assets,
+// artifacts and logs, which are redirected to octet streams.
+func cacheable(resp *http.Response) bool {
+	if resp.StatusCode != http.StatusOK || resp.Header.Get("ETag") == "" {
+		return false
+	}
+	mediaType, _, err := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if err != nil {
+		return false
+	}
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
+
+func (t *etagTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	if r.Method != "GET" || r.Header.Get("If-None-Match") != "" {
+		return t.next.RoundTrip(r)
+	}
+	key := etagKey(r)
+	cached, err := t.cache.get(key)
+	if err != nil {
+		return nil, err
+	}
+	if cached != nil {
+		r = r.Clone(r.Context())
+		r.Header.Set("If-None-Match", cached.Header.Get("ETag"))
+	}
+	resp, err := t.next.RoundTrip(r)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusNotModified && cached != nil {
+		resp.Body.Close()
+		t.cache.hit()
+		// keep fresh rate limit headers, but take the rest from the cache
+		header := resp.Header.Clone()
+		for k, v := range cached.Header {
+			header[k] = v
+		}
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", cached.StatusCode, http.StatusText(cached.StatusCode)),
+			StatusCode:    cached.StatusCode,
+			Proto:         resp.Proto,
+			ProtoMajor:    resp.ProtoMajor,
+			ProtoMinor:    resp.ProtoMinor,
+			Header:        header,
+			Body:          io.NopCloser(bytes.NewReader(cached.Body)),
+			ContentLength: int64(len(cached.Body)),
+			Request:       r,
+		}, nil
+	}
+	if !cacheable(resp) {
+		return resp, nil
+	}
+	if t.limit > 0 && resp.ContentLength > t.limit {
+		return resp, nil
+	}
+	reader := io.Reader(resp.Body)
+	if t.limit > 0 {
+		reader = io.LimitReader(resp.Body, t.limit+1)
+	}
+	body, err := io.ReadAll(reader)
+	if err != nil {
+		resp.Body.Close()
+		return nil, err
+	}
+	if t.limit > 0 && int64(len(body)) > t.limit {
+		// leave it to the response limit to fail the request
+		resp.Body = &prefixedBody{io.MultiReader(bytes.NewReader(body), resp.Body), resp.Body}
+		return resp, nil
+	}
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	s := &snapshot{
+		Method:     r.Method,
+		URL:        r.URL.String(),
+		StatusCode: resp.StatusCode,
+		Header:     recordHeaders(resp.Header),
+		Body:       body,
+	}
+	err = t.cache.put(key, s)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// prefixedBody replays the already read prefix of the body
+type prefixedBody struct {
+	io.Reader
+	io.Closer
+}
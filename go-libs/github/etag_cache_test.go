@@ -0,0 +1,76 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestETagCacheServesNotModified(t *testing.T) {
+	etagServer := roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			return &http.Response{
+				StatusCode: http.StatusNotModified,
+				Header:     http.Header{"X-Ratelimit-Remaining": []string{"4999"}},
+				Body:       http.NoBody,
+			}, nil
+		}
+		res := jsonResponse(200, `{"name": "b", "default_branch": "main"}`)
+		res.Header.Set("ETag", `"v1"`)
+		return res, nil
+	})
+	dir := t.TempDir()
+	ctx := context.Background()
+	for i := 0; i < 2; i++ {
+		// new clients share the cache through the directory
+		cache := NewETagCache(dir)
+		client := NewClient(&GitHubConfig{
+			GitHubTokenSource: GitHubTokenSource{Pat: "x"},
+			ETagCache:         cache,
+			transport:         etagServer,
+		})
+		repo, err := client.GetRepo(ctx, "a", "b")
+		require.NoError(t, err)
+		assert.Equal(t, "main", repo.DefaultBranch)
+		assert.Equal(t, i, cache.Hits())
+	}
+}
+
+func TestETagCacheSkipsOversizedAndDownloads(t *testing.T) {
+	dir := t.TempDir()
+	client := NewClient(&GitHubConfig{
+		GitHubTokenSource: GitHubTokenSource{Pat: "x"},
+		ETagCache:         NewETagCache(dir),
+		MaxResponseBytes:  1024,
+		transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			var res *http.Response
+			switch r.URL.Path {
+			case "/repos/a/b":
+				res = jsonResponse(200, `{"name": "b", "description": "`+strings.Repeat("x", 4096)+`"}`)
+			case "/repos/a/b/releases/assets/1":
+				res = jsonResponse(200, `binary`)
+				res.Header.Set("Content-Type", "application/octet-stream")
+			}
+			res.Header.Set("ETag", `"v1"`)
+			return res, nil
+		}),
+	})
+	ctx := context.Background()
+	_, err := client.GetRepo(ctx, "a", "b")
+	assert.ErrorIs(t, err, ErrResponseTooLarge)
+
+	var buf bytes.Buffer
+	err = client.DownloadAsset(ctx, "a", "b", 1, &buf)
+	require.NoError(t, err)
+	assert.Equal(t, "binary", buf.String())
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
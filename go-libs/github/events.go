@@ -0,0 +1,176 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/databricks/databricks-sdk-go/httpclient"
+)
+
+// See https://docs.github.com/en/rest/using-the-rest-api/github-event-types
+
+type EventRepo struct {
+	ID   int64  `json:"id,omitempty"`
+	Name string `json:"name,omitempty"` // org/repo
+	URL  string `json:"url,omitempty"`
+}
+
+type Event struct {
+	ID        string          `json:"id"`
+	Type      string          `json:"type"` // PushEvent, PullRequestEvent, ...
+	Actor     User            `json:"actor"`
+	Repo      EventRepo       `json:"repo"`
+	Org       *User           `json:"org,omitempty"`
+	Public    bool            `json:"public"`
+	Payload   json.RawMessage `json:"payload"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+type PushEventPayload struct {
+	PushID       int64  `json:"push_id"`
+	Size         int    `json:"size"`
+	DistinctSize int    `json:"distinct_size"`
+	Ref          string `json:"ref"`
+	Head         string `json:"head"`
+	Before       string `json:"before"`
+	Commits      []struct {
+		SHA      string       `json:"sha"`
+		Author   CommitAuthor `json:"author"`
+		Message  string       `json:"message"`
+		Distinct bool         `json:"distinct"`
+	} `json:"commits"`
+}
+
+type PullRequestEventPayload struct {
+	Action      string      `json:"action"`
+	Number      int         `json:"number"`
+	PullRequest PullRequest `json:"pull_request"`
+}
+
+type PullRequestReviewEventPayload struct {
+	Action      string            `json:"action"`
+	Review      PullRequestReview `json:"review"`
+	PullRequest PullRequest       `json:"pull_request"`
+}
+
+type IssuesEventPayload struct {
+	Action string `json:"action"`
+	Issue  Issue  `json:"issue"`
+}
+
+type IssueCommentEventPayload struct {
+	Action  string       `json:"action"`
+	Issue   Issue        `json:"issue"`
+	Comment IssueComment `json:"comment"`
+}
+
+// RefEventPayload is the payload of CreateEvent and DeleteEvent.
+type RefEventPayload struct {
+	Ref     string `json:"ref"`
+	RefType string `json:"ref_type"` // repository, branch, tag
+}
+
+type ReleaseEventPayload struct {
+	Action  string  `json:"action"`
+	Release Release `json:"release"`
+}
+
+type ForkEventPayload struct {
+	Forkee Repo `json:"forkee"`
+}
+
+type WatchEventPayload struct {
+	Action string `json:"action"` // started
+}
+
+// ParsePayload decodes the payload into one of *...EventPayload types by the
+// type of the event. Payloads of other events are returned as map[string]any.
+func (e *Event) ParsePayload() (any, error) {
+	var payload any
+	switch e.Type {
+	case "PushEvent":
+		payload = &PushEventPayload{}
+	case "PullRequestEvent":
+		payload = &PullRequestEventPayload{}
+	case "PullRequestReviewEvent":
+		payload = &PullRequestReviewEventPayload{}
+	case "IssuesEvent":
+		payload = &IssuesEventPayload{}
+	case "IssueCommentEvent":
+		payload = &IssueCommentEventPayload{}
+	case "CreateEvent", "DeleteEvent":
+		payload = &RefEventPayload{}
+	case "ReleaseEvent":
+		payload = &ReleaseEventPayload{}
+	case "ForkEvent":
+		payload = &ForkEventPayload{}
+	case "WatchEvent":
+		payload = &WatchEventPayload{}
+	default:
+		payload = &map[string]any{}
+	}
+	err := json.Unmarshal(e.Payload, payload)
+	if err != nil {
+		return nil, fmt.Errorf("%s payload: %w", e.Type, err)
+	}
+	if m, ok := payload.(*map[string]any); ok {
+		return *m, nil
+	}
+	return payload, nil
+}
+
+// ListRepoEvents lists public events of the repository from the newest.
+// The API keeps up to 300 events from the last 30 days and updates them
+// with a delay, so it's a polling fallback, when webhooks can't be installed.
+// Default perPage is 100.
+func (c *GitHubClient) ListRepoEvents(ctx context.Context, org, repo string, perPage int) ([]Event, error) {
+	path := fmt.Sprintf("%s/repos/%s/%s/events", gitHubAPI, org, repo)
+	return c.listEvents(ctx, path, perPage)
+}
+
+// ListOrgEvents lists public events of the organization from the newest.
+func (c *GitHubClient) ListOrgEvents(ctx context.Context, org string, perPage int) ([]Event, error) {
+	path := fmt.Sprintf("%s/orgs/%s/events", gitHubAPI, org)
+	return c.listEvents(ctx, path, perPage)
+}
+
+// ListRepoEventsIterator walks all events available for the repository.
+func (c *GitHubClient) ListRepoEventsIterator(org, repo string) *Iterator[Event] {
+	path := fmt.Sprintf("%s/repos/%s/%s/events", gitHubAPI, org, repo)
+	return Paginate[Event](c, path, nil)
+}
+
+// ListOrgEventsIterator walks all events available for the organization.
+func (c *GitHubClient) ListOrgEventsIterator(org string) *Iterator[Event] {
+	path := fmt.Sprintf("%s/orgs/%s/events", gitHubAPI, org)
+	return Paginate[Event](c, path, nil)
+}
+
+func (c *GitHubClient) listEvents(ctx context.Context, path string, perPage int) ([]Event, error) {
+	if perPage == 0 {
+		perPage = 100
+	}
+	var events []Event
+	err := c.api.Do(ctx, "GET", path,
+		httpclient.WithRequestData(listOptions{PerPage: perPage}),
+		httpclient.WithResponseUnmarshal(&events))
+	return events, err
+}
+
+// EventsAfter returns events newer than the one with lastID, from the oldest,
+// so that pollers can process each event once.
+func EventsAfter(events []Event, lastID string) []Event {
+	last, _ := strconv.ParseInt(lastID, 10, 64)
+	var out []Event
+	for i := len(events) - 1; i >= 0; i-- {
+		id, err := strconv.ParseInt(events[i].ID, 10, 64)
+		if err != nil || id <= last {
+			continue
+		}
+		out = append(out, events[i])
+	}
+	return out
+}
@@ -0,0 +1,52 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListRepoEvents(t *testing.T) {
+	client := NewClient(&GitHubConfig{
+		GitHubTokenSource: GitHubTokenSource{Pat: "x"},
+		transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			if r.URL.Path != "/repos/a/b/events" {
+				return jsonResponse(404, `{"message": "Not Found"}`), nil
+			}
+			assert.Equal(t, "100", r.URL.Query().Get("per_page"))
+			return jsonResponse(200, `[
+				{"id": "3", "type": "IssuesEvent", "payload": {"action": "opened", "issue": {"number": 7, "title": "Bug"}}},
+				{"id": "2", "type": "PushEvent", "payload": {"ref": "refs/heads/main", "head": "abc", "commits": [{"sha": "abc"}]}},
+				{"id": "1", "type": "GollumEvent", "payload": {"pages": []}}
+			]`), nil
+		}),
+	})
+	events, err := client.ListRepoEvents(context.Background(), "a", "b", 0)
+	require.NoError(t, err)
+	require.Len(t, events, 3)
+
+	payload, err := events[0].ParsePayload()
+	require.NoError(t, err)
+	issues := payload.(*IssuesEventPayload)
+	assert.Equal(t, "opened", issues.Action)
+	assert.Equal(t, 7, issues.Issue.Number)
+
+	payload, err = events[1].ParsePayload()
+	require.NoError(t, err)
+	push := payload.(*PushEventPayload)
+	assert.Equal(t, "refs/heads/main", push.Ref)
+	assert.Len(t, push.Commits, 1)
+
+	payload, err = events[2].ParsePayload()
+	require.NoError(t, err)
+	assert.Contains(t, payload, "pages")
+
+	newer := EventsAfter(events, "1")
+	require.Len(t, newer, 2)
+	assert.Equal(t, "2", newer[0].ID)
+	assert.Equal(t, "3", newer[1].ID)
+	assert.Len(t, EventsAfter(events, ""), 3)
+}
@@ -0,0 +1,87 @@
+package github
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/databricks/databricks-sdk-go/logger"
+)
+
+// greetingMarker identifies welcome comments, so that redelivered webhooks
+// don't greet twice.
+const greetingMarker = "first-time-contributor"
+
+const defaultGreeting = `Welcome, @{{.Author}}, and thank you for your first contribution to {{.Org}}! ` +
+	`A maintainer will review this pull request soon.`
+
+// MergedPullRequests counts merged pull requests of the author across all
+// repositories of the org, which the credentials can see.
+func (c *GitHubClient) MergedPullRequests(ctx context.Context, org, author string) (int, error) {
+	return c.countIssues(ctx, fmt.Sprintf("org:%s author:%s is:pr is:merged", org, author))
+}
+
+// Greeter welcomes authors of pull requests, who have no merged contributions
+// in the org yet.
+type Greeter struct {
+	Client *GitHubClient
+
+	// Message is a template with PullRequestTemplateData. Default thanks the
+	// author and promises a review.
+	Message string
+
+	// Label is applied to pull requests of first-time contributors, unless
+	// it's empty.
+	Label string
+}
+
+// Greet comments on the pull request and labels it, if the author is a
+// first-time contributor. Bots are never greeted. Returns true, if the author
+// is a first-time contributor.
+func (g *Greeter) Greet(ctx context.Context, org, repo string, pr *PullRequest) (bool, error) {
+	if pr.User.Type == "Bot" {
+		return false, nil
+	}
+	merged, err := g.Client.MergedPullRequests(ctx, org, pr.User.Login)
+	if err != nil {
+		return false, fmt.Errorf("merged pull requests: %w", err)
+	}
+	if merged > 0 {
+		return false, nil
+	}
+	message := g.Message
+	if message == "" {
+		message = defaultGreeting
+	}
+	body, err := RenderTemplate(message, PullRequestTemplateData{
+		Org:    org,
+		Repo:   repo,
+		Branch: pr.Head.Ref,
+		Base:   pr.Base.Ref,
+		Title:  pr.Title,
+		Author: pr.User.Login,
+	})
+	if err != nil {
+		return true, fmt.Errorf("message: %w", err)
+	}
+	_, err = g.Client.UpsertComment(ctx, org, repo, pr.Number, greetingMarker, body)
+	if err != nil {
+		return true, fmt.Errorf("comment: %w", err)
+	}
+	if g.Label != "" {
+		_, err = g.Client.AddLabels(ctx, org, repo, pr.Number, g.Label)
+		if err != nil {
+			return true, fmt.Errorf("label: %w", err)
+		}
+	}
+	logger.Infof(ctx, "Greeted %s on %s/%s#%d", pr.User.Login, org, repo, pr.Number)
+	return true, nil
+}
+
+// HandlePullRequestEvent greets authors of newly opened pull requests from
+// pull_request webhook events.
+func (g *Greeter) HandlePullRequestEvent(ctx context.Context, org, repo string, event *PullRequestEventPayload) (bool, error) {
+	if event.Action != "opened" {
+		return false, nil
+	}
+	return g.Greet(ctx, org, repo, &event.PullRequest)
+}
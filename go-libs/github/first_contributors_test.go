@@ -0,0 +1,62 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGreeterWelcomesFirstTimeContributors(t *testing.T) {
+	merged := map[string]string{"alice": `{"total_count": 0}`, "bob": `{"total_count": 3}`}
+	var calls []string
+	client := NewClient(&GitHubConfig{
+		GitHubTokenSource: GitHubTokenSource{Pat: "x"},
+		transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			if r.URL.Path == "/search/issues" {
+				q := r.URL.Query().Get("q")
+				for author, body := range merged {
+					if q == "org:a author:"+author+" is:pr is:merged" {
+						return jsonResponse(200, body), nil
+					}
+				}
+				t.Fatalf("unexpected query: %s", q)
+			}
+			calls = append(calls, r.Method+" "+r.URL.Path)
+			if r.URL.Path == "/repos/a/b/issues/3/comments" && r.Method == "POST" {
+				return jsonResponse(201, `{"id": 1}`), nil
+			}
+			return jsonResponse(200, `[]`), nil
+		}),
+	})
+	g := &Greeter{Client: client, Label: "first-time-contributor"}
+	ctx := context.Background()
+
+	greeted, err := g.HandlePullRequestEvent(ctx, "a", "b", &PullRequestEventPayload{
+		Action:      "opened",
+		PullRequest: PullRequest{Number: 1, User: User{Login: "bob"}},
+	})
+	require.NoError(t, err)
+	assert.False(t, greeted)
+
+	greeted, err = g.HandlePullRequestEvent(ctx, "a", "b", &PullRequestEventPayload{
+		Action:      "opened",
+		PullRequest: PullRequest{Number: 2, User: User{Login: "dependabot[bot]", Type: "Bot"}},
+	})
+	require.NoError(t, err)
+	assert.False(t, greeted)
+
+	greeted, err = g.HandlePullRequestEvent(ctx, "a", "b", &PullRequestEventPayload{
+		Action:      "opened",
+		PullRequest: PullRequest{Number: 3, User: User{Login: "alice"}},
+	})
+	require.NoError(t, err)
+	assert.True(t, greeted)
+	assert.Equal(t, []string{
+		"GET /repos/a/b/issues/3/comments",
+		"POST /repos/a/b/issues/3/comments",
+		"POST /repos/a/b/issues/3/labels",
+	}, calls)
+}
@@ -0,0 +1,324 @@
+package github
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/databricks/databricks-sdk-go/httpclient"
+	"github.com/databricks/databricks-sdk-go/logger"
+	"github.com/databrickslabs/sandbox/go-libs/localcache"
+)
+
+// See https://docs.github.com/en/rest/actions/workflow-jobs
+
+type workflowJob struct {
+	ID         int64  `json:"id"`
+	RunID      int64  `json:"run_id"`
+	Name       string `json:"name"`
+	Status     string `json:"status"`
+	Conclusion string `json:"conclusion,omitempty"`
+	WebURL     string `json:"html_url,omitempty"`
+}
+
+func (c *GitHubClient) listAttemptJobs(ctx context.Context, org, repo string, runID int64, attempt int) ([]workflowJob, error) {
+	path := fmt.Sprintf("%s/repos/%s/%s/actions/runs/%d/attempts/%d/jobs", gitHubAPI, org, repo, runID, attempt)
+	return ToSlice(ctx, paginateField[workflowJob](c, path, "jobs", nil))
+}
+
+// jobLogs downloads plain text logs of a job, which GitHub keeps for a
+// limited time only.
+func (c *GitHubClient) jobLogs(ctx context.Context, org, repo string, jobID int64) ([]byte, error) {
+	path := fmt.Sprintf("%s/repos/%s/%s/actions/jobs/%d/logs", gitHubAPI, org, repo, jobID)
+	var buf bytes.Buffer
+	err := c.api.Do(ctx, "GET", path, httpclient.WithResponseUnmarshal(&buf))
+	return buf.Bytes(), err
+}
+
+var (
+	// every line of job logs starts with a timestamp, like 2024-01-01T00:00:00.1234567Z
+	logTimestamp  = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T[\d:.]+Z `)
+	goTestFail    = regexp.MustCompile(`^\s*--- FAIL: (\S+)`)
+	goPackageFail = regexp.MustCompile(`^FAIL\s+(\S+)\s`)
+)
+
+// ParseTestFailures extracts failed Go tests from the output of go test, like
+// github.com/org/repo/pkg.TestName/subtest. Tests are named without a package,
+// if the output has no package summary. Parents of failed subtests are not
+// reported, as they fail because of their subtests.
+func ParseTestFailures(r io.Reader) ([]string, error) {
+	var failures, pending []string
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := logTimestamp.ReplaceAllString(scanner.Text(), "")
+		if match := goTestFail.FindStringSubmatch(line); match != nil {
+			pending = append(pending, match[1])
+			continue
+		}
+		if match := goPackageFail.FindStringSubmatch(line); match != nil {
+			for _, test := range pending {
+				failures = append(failures, match[1]+"."+test)
+			}
+			pending = nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	failures = append(failures, pending...)
+	return leafTests(failures), nil
+}
+
+// leafTests removes duplicates and tests, that have failed subtests
+func leafTests(tests []string) []string {
+	sort.Strings(tests)
+	var leaves []string
+	for i, test := range tests {
+		if i > 0 && tests[i-1] == test {
+			continue
+		}
+		if i+1 < len(tests) && strings.HasPrefix(tests[i+1], test+"/") {
+			continue
+		}
+		leaves = append(leaves, test)
+	}
+	return leaves
+}
+
+// FlakyTest is a score of a test in a repository.
+type FlakyTest struct {
+	Repo     string `json:"repo"`
+	Test     string `json:"test"`
+	Failures int    `json:"failures"`
+
+	// Flakes are failures on commits, where the same workflow has also
+	// succeeded, either in a re-run or in another run.
+	Flakes int `json:"flakes"`
+
+	LastFailure time.Time `json:"last_failure"`
+	LastRunURL  string    `json:"last_run_url,omitempty"`
+}
+
+// FlakyScoreboard accumulates test failures across updates.
+type FlakyScoreboard struct {
+	Tests map[string]*FlakyTest `json:"tests"`
+
+	// ScoredRuns are attempts of runs by repository, like "123/1", that are
+	// already accounted for, and whether they were flaky, as a failed attempt
+	// turns out to be flaky, once the run is re-run successfully. Only runs
+	// within the latest window are kept.
+	ScoredRuns map[string]map[string]bool `json:"scored_attempts"`
+}
+
+// Ranking returns tests from the most flaky ones. Tests, that always fail,
+// go after flaky ones, as they are just broken.
+func (s *FlakyScoreboard) Ranking() []FlakyTest {
+	var ranking []FlakyTest
+	for _, v := range s.Tests {
+		ranking = append(ranking, *v)
+	}
+	sort.Slice(ranking, func(i, j int) bool {
+		a, b := ranking[i], ranking[j]
+		if a.Flakes != b.Flakes {
+			return a.Flakes > b.Flakes
+		}
+		if a.Failures != b.Failures {
+			return a.Failures > b.Failures
+		}
+		if a.Repo != b.Repo {
+			return a.Repo < b.Repo
+		}
+		return a.Test < b.Test
+	})
+	return ranking
+}
+
+func (s *FlakyScoreboard) record(repo, test string, run WorkflowRun, flaky bool) {
+	key := repo + ":" + test
+	score, ok := s.Tests[key]
+	if !ok {
+		score = &FlakyTest{Repo: repo, Test: test}
+		s.Tests[key] = score
+	}
+	score.Failures++
+	if flaky {
+		score.Flakes++
+	}
+	if run.UpdatedAt.After(score.LastFailure) {
+		score.LastFailure = run.UpdatedAt
+		score.LastRunURL = run.WebURL
+	}
+}
+
+// markFlaky counts an already recorded failure as a flake
+func (s *FlakyScoreboard) markFlaky(repo, test string) {
+	score, ok := s.Tests[repo+":"+test]
+	if ok {
+		score.Flakes++
+	}
+}
+
+type FlakyTestOptions struct {
+	// Repos defaults to all repositories of the org, except forks and
+	// archived ones.
+	Repos []string
+
+	// Workflow is a file name, like "push.yml". Default is all workflows.
+	Workflow string
+
+	// Runs is the number of the latest completed runs per repository, that
+	// are inspected on every update. Default is 50.
+	Runs int
+}
+
+// FlakyTestTracker maintains a scoreboard of Go tests, that fail in GitHub
+// Actions, in a local cache directory, so that every update only downloads
+// logs of runs, that completed since the previous one.
+type FlakyTestTracker struct {
+	client *GitHubClient
+	org    string
+	cache  localcache.LocalCache[FlakyScoreboard]
+}
+
+func NewFlakyTestTracker(client *GitHubClient, org, cacheDir string) *FlakyTestTracker {
+	return &FlakyTestTracker{
+		client: client,
+		org:    org,
+		cache:  localcache.NewLocalCache[FlakyScoreboard](cacheDir, fmt.Sprintf("%s-flaky-tests", org), 0),
+	}
+}
+
+// Update downloads logs of failed jobs of new runs, extracts test failures
+// and merges them into the persisted scoreboard. Runs are scored once, when
+// they are first seen completed.
+func (t *FlakyTestTracker) Update(ctx context.Context, opts FlakyTestOptions) (*FlakyScoreboard, error) {
+	if opts.Runs == 0 {
+		opts.Runs = 50
+	}
+	repos := opts.Repos
+	if len(repos) == 0 {
+		err := t.client.StreamRepositories(ctx, t.org, func(r Repo) error {
+			if r.IsFork || r.IsArchived {
+				return nil
+			}
+			repos = append(repos, r.Name)
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("repositories: %w", err)
+		}
+	}
+	scoreboard, err := t.cache.Update(ctx, func(scoreboard FlakyScoreboard) (FlakyScoreboard, error) {
+		if scoreboard.Tests == nil {
+			scoreboard.Tests = map[string]*FlakyTest{}
+		}
+		if scoreboard.ScoredRuns == nil {
+			scoreboard.ScoredRuns = map[string]map[string]bool{}
+		}
+		for _, repo := range repos {
+			err := t.scoreRepo(ctx, &scoreboard, repo, opts)
+			if err != nil {
+				return scoreboard, fmt.Errorf("%s: %w", repo, err)
+			}
+		}
+		return scoreboard, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &scoreboard, nil
+}
+
+func (t *FlakyTestTracker) scoreRepo(ctx context.Context, scoreboard *FlakyScoreboard, repo string, opts FlakyTestOptions) error {
+	path := fmt.Sprintf("%s/repos/%s/%s/actions/runs", gitHubAPI, t.org, repo)
+	if opts.Workflow != "" {
+		path = fmt.Sprintf("%s/repos/%s/%s/actions/workflows/%s/runs", gitHubAPI, t.org, repo, opts.Workflow)
+	}
+	it := paginateField[WorkflowRun](t.client, path, "workflow_runs", struct {
+		Status string `url:"status,omitempty"`
+	}{"completed"})
+	var runs []WorkflowRun
+	for len(runs) < opts.Runs && it.HasNext(ctx) {
+		run, err := it.Next(ctx)
+		if err != nil {
+			return err
+		}
+		runs = append(runs, run)
+	}
+	// the same workflow on the same commit is expected to give the same result
+	succeeded := map[string]bool{}
+	for _, run := range runs {
+		if run.Conclusion == "success" {
+			succeeded[fmt.Sprintf("%d@%s", run.WorkflowID, run.HeadSHA)] = true
+		}
+	}
+	scored := scoreboard.ScoredRuns[repo]
+	window := map[string]bool{}
+	for _, run := range runs {
+		var attempts []int
+		switch {
+		case run.Conclusion == "failure":
+			attempts = []int{run.RunAttempt}
+		case run.Conclusion == "success" && run.RunAttempt > 1:
+			for attempt := 1; attempt < run.RunAttempt; attempt++ {
+				attempts = append(attempts, attempt)
+			}
+		}
+		flaky := succeeded[fmt.Sprintf("%d@%s", run.WorkflowID, run.HeadSHA)]
+		for _, attempt := range attempts {
+			// re-runs keep the ID of the run, but not the attempt
+			key := fmt.Sprintf("%d/%d", run.ID, attempt)
+			wasFlaky, ok := scored[key]
+			window[key] = wasFlaky || flaky
+			if ok && (wasFlaky || !flaky) {
+				continue
+			}
+			failures, err := t.attemptFailures(ctx, repo, run.ID, attempt)
+			if err != nil {
+				return fmt.Errorf("run %d: %w", run.ID, err)
+			}
+			for _, test := range failures {
+				if ok {
+					scoreboard.markFlaky(repo, test)
+					continue
+				}
+				scoreboard.record(repo, test, run, flaky)
+			}
+		}
+	}
+	scoreboard.ScoredRuns[repo] = window
+	return nil
+}
+
+// attemptFailures returns unique test failures across failed jobs of an attempt
+func (t *FlakyTestTracker) attemptFailures(ctx context.Context, repo string, runID int64, attempt int) ([]string, error) {
+	jobs, err := t.client.listAttemptJobs(ctx, t.org, repo, runID, attempt)
+	if err != nil {
+		return nil, fmt.Errorf("jobs: %w", err)
+	}
+	var failures []string
+	for _, job := range jobs {
+		if job.Conclusion != "failure" {
+			continue
+		}
+		logs, err := t.client.jobLogs(ctx, t.org, repo, job.ID)
+		if err != nil {
+			// logs expire, which should not stop scoring of other jobs
+			logger.Warnf(ctx, "Skipping logs of %s: %s", job.WebURL, err)
+			continue
+		}
+		tests, err := ParseTestFailures(bytes.NewReader(logs))
+		if err != nil {
+			return nil, fmt.Errorf("job %d: %w", job.ID, err)
+		}
+		failures = append(failures, tests...)
+	}
+	return leafTests(failures), nil
+}
@@ -0,0 +1,110 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTestFailures(t *testing.T) {
+	logs := `2024-01-01T00:00:01.0000000Z === RUN   TestA
+2024-01-01T00:00:01.0000000Z --- FAIL: TestA (0.00s)
+2024-01-01T00:00:01.0000000Z     --- FAIL: TestA/first (0.00s)
+2024-01-01T00:00:01.0000000Z         a_test.go:10: nope
+2024-01-01T00:00:01.0000000Z --- FAIL: TestB (1.20s)
+2024-01-01T00:00:01.0000000Z FAIL
+2024-01-01T00:00:01.0000000Z FAIL	github.com/x/y/pkg	1.234s
+2024-01-01T00:00:01.0000000Z ok  	github.com/x/y/other	0.100s
+2024-01-01T00:00:01.0000000Z --- FAIL: TestC (0.00s)
+`
+	failures, err := ParseTestFailures(strings.NewReader(logs))
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"TestC",
+		"github.com/x/y/pkg.TestA/first",
+		"github.com/x/y/pkg.TestB",
+	}, failures)
+}
+
+func TestFlakyTestTracker(t *testing.T) {
+	// run 1 failed on a commit, that succeeded in run 2, run 3 succeeded on
+	// the second attempt, and run 4 is a genuine failure.
+	runs := `{"workflow_runs": [
+		{"id": 4, "workflow_id": 7, "head_sha": "c", "run_attempt": 1, "conclusion": "failure", "html_url": "https://github.com/a/b/actions/runs/4", "updated_at": "2024-01-04T00:00:00Z"},
+		{"id": 3, "workflow_id": 7, "head_sha": "b", "run_attempt": 2, "conclusion": "success", "updated_at": "2024-01-03T00:00:00Z"},
+		{"id": 2, "workflow_id": 7, "head_sha": "a", "run_attempt": 1, "conclusion": "success", "updated_at": "2024-01-02T00:00:00Z"},
+		{"id": 1, "workflow_id": 7, "head_sha": "a", "run_attempt": 1, "conclusion": "failure", "updated_at": "2024-01-01T00:00:00Z"}
+	]}`
+	jobs := map[string]string{
+		"/repos/a/b/actions/runs/4/attempts/1/jobs": `{"jobs": [{"id": 41, "conclusion": "failure"}]}`,
+		"/repos/a/b/actions/runs/3/attempts/1/jobs": `{"jobs": [{"id": 31, "conclusion": "failure"}, {"id": 32, "conclusion": "success"}]}`,
+		"/repos/a/b/actions/runs/1/attempts/1/jobs": `{"jobs": [{"id": 11, "conclusion": "failure"}]}`,
+	}
+	logs := map[string]string{
+		"/repos/a/b/actions/jobs/41/logs": "--- FAIL: TestA (0.00s)\nFAIL\tx/pkg\t0.1s\n",
+		"/repos/a/b/actions/jobs/31/logs": "--- FAIL: TestB (0.00s)\n    --- FAIL: TestB/sub (0.00s)\nFAIL\tx/pkg\t0.1s\n",
+		"/repos/a/b/actions/jobs/11/logs": "--- FAIL: TestA (0.00s)\nFAIL\tx/pkg\t0.1s\n",
+	}
+	var downloads int
+	client := NewClient(&GitHubConfig{
+		GitHubTokenSource: GitHubTokenSource{Pat: "x"},
+		transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			if r.URL.Path == "/repos/a/b/actions/runs" {
+				assert.Equal(t, "completed", r.URL.Query().Get("status"))
+				return jsonResponse(200, runs), nil
+			}
+			if body, ok := jobs[r.URL.Path]; ok {
+				return jsonResponse(200, body), nil
+			}
+			if body, ok := logs[r.URL.Path]; ok {
+				downloads++
+				res := jsonResponse(200, body)
+				res.Header.Set("Content-Type", "text/plain")
+				return res, nil
+			}
+			t.Fatalf("unexpected request: %s", r.URL.Path)
+			return nil, nil
+		}),
+	})
+	ctx := context.Background()
+	tracker := NewFlakyTestTracker(client, "a", t.TempDir())
+	opts := FlakyTestOptions{Repos: []string{"b"}}
+	_, err := tracker.Update(ctx, opts)
+	require.NoError(t, err)
+	assert.Equal(t, 3, downloads)
+
+	// runs are not scored twice
+	scoreboard, err := tracker.Update(ctx, opts)
+	require.NoError(t, err)
+	assert.Equal(t, 3, downloads)
+
+	ranking := scoreboard.Ranking()
+	require.Len(t, ranking, 2)
+	assert.Equal(t, "x/pkg.TestA", ranking[0].Test)
+	assert.Equal(t, 2, ranking[0].Failures)
+	assert.Equal(t, 1, ranking[0].Flakes)
+	assert.Equal(t, "https://github.com/a/b/actions/runs/4", ranking[0].LastRunURL)
+	assert.Equal(t, "x/pkg.TestB/sub", ranking[1].Test)
+	assert.Equal(t, 1, ranking[1].Failures)
+	assert.Equal(t, 1, ranking[1].Flakes)
+
+	// run 4 succeeded on a re-run, which makes its failure a flake
+	runs = strings.Replace(runs, `"run_attempt": 1, "conclusion": "failure", "html_url"`,
+		`"run_attempt": 2, "conclusion": "success", "html_url"`, 1)
+	scoreboard, err = tracker.Update(ctx, opts)
+	require.NoError(t, err)
+	assert.Equal(t, 4, downloads)
+	ranking = scoreboard.Ranking()
+	assert.Equal(t, "x/pkg.TestA", ranking[0].Test)
+	assert.Equal(t, 2, ranking[0].Failures)
+	assert.Equal(t, 2, ranking[0].Flakes)
+
+	// and is not scored again
+	_, err = tracker.Update(ctx, opts)
+	require.NoError(t, err)
+	assert.Equal(t, 4, downloads)
+}
@@ -0,0 +1,297 @@
+package github
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/databricks/databricks-sdk-go/httpclient"
+)
+
+// See https://docs.github.com/en/rest/git
+
+// TreeEntry is a file, a directory, or a submodule in a tree.
+type TreeEntry struct {
+	Path string `json:"path"`
+
+	// Mode is one of: 100644 for files, 100755 for executables, 040000 for
+	// directories, 160000 for submodules, 120000 for symlinks.
+	Mode string `json:"mode"`
+
+	// Type is one of: blob, tree, commit.
+	Type string `json:"type"`
+
+	// SHA is nil to delete the path from the base tree.
+	SHA *string `json:"sha"`
+}
+
+// CreateBlob stores the content in the repository and returns its SHA.
+func (c *GitHubClient) CreateBlob(ctx context.Context, org, repo string, content []byte) (string, error) {
+	path := fmt.Sprintf("%s/repos/%s/%s/git/blobs", gitHubAPI, org, repo)
+	var res struct {
+		SHA string `json:"sha"`
+	}
+	err := c.api.Do(ctx, "POST", path,
+		httpclient.WithRequestData(map[string]string{
+			"content":  base64.StdEncoding.EncodeToString(content),
+			"encoding": "base64",
+		}),
+		httpclient.WithResponseUnmarshal(&res))
+	return res.SHA, err
+}
+
+// CreateTree creates a tree with the entries on top of the base tree, or
+// with only the entries, if the base tree is empty. Returns its SHA.
+func (c *GitHubClient) CreateTree(ctx context.Context, org, repo, baseTree string, entries []TreeEntry) (string, error) {
+	path := fmt.Sprintf("%s/repos/%s/%s/git/trees", gitHubAPI, org, repo)
+	body := map[string]any{
+		"tree": entries,
+	}
+	if baseTree != "" {
+		body["base_tree"] = baseTree
+	}
+	var res struct {
+		SHA string `json:"sha"`
+	}
+	err := c.api.Do(ctx, "POST", path,
+		httpclient.WithRequestData(body),
+		httpclient.WithResponseUnmarshal(&res))
+	return res.SHA, err
+}
+
+// GetTree returns entries of the tree, without descending into subtrees.
+func (c *GitHubClient) GetTree(ctx context.Context, org, repo, sha string) ([]TreeEntry, error) {
+	path := fmt.Sprintf("%s/repos/%s/%s/git/trees/%s", gitHubAPI, org, repo, sha)
+	var res struct {
+		Tree []TreeEntry `json:"tree"`
+	}
+	err := c.api.Do(ctx, "GET", path, httpclient.WithResponseUnmarshal(&res))
+	return res.Tree, err
+}
+
+// treeModes returns modes of the paths, that exist in the tree, walking only
+// the directories of the paths, as recursive listings of large trees are
+// truncated.
+func (c *GitHubClient) treeModes(ctx context.Context, org, repo, sha string, paths []string) (map[string]string, error) {
+	trees := map[string][]TreeEntry{}
+	var list func(dir string) ([]TreeEntry, error)
+	list = func(dir string) ([]TreeEntry, error) {
+		if entries, ok := trees[dir]; ok {
+			return entries, nil
+		}
+		treeSHA := sha
+		if dir != "" {
+			parentDir, name := splitPath(dir)
+			parent, err := list(parentDir)
+			if err != nil {
+				return nil, err
+			}
+			treeSHA = ""
+			for _, v := range parent {
+				if v.Path == name && v.Type == "tree" && v.SHA != nil {
+					treeSHA = *v.SHA
+				}
+			}
+			if treeSHA == "" {
+				// a new directory
+				trees[dir] = nil
+				return nil, nil
+			}
+		}
+		entries, err := c.GetTree(ctx, org, repo, treeSHA)
+		if err != nil {
+			return nil, fmt.Errorf("tree of %q: %w", dir, err)
+		}
+		trees[dir] = entries
+		return entries, nil
+	}
+	modes := map[string]string{}
+	for _, path := range paths {
+		dir, name := splitPath(path)
+		entries, err := list(dir)
+		if err != nil {
+			return nil, err
+		}
+		for _, v := range entries {
+			if v.Path == name && v.Type == "blob" {
+				modes[path] = v.Mode
+			}
+		}
+	}
+	return modes, nil
+}
+
+func splitPath(path string) (dir, name string) {
+	i := strings.LastIndex(path, "/")
+	if i < 0 {
+		return "", path
+	}
+	return path[:i], path[i+1:]
+}
+
+// GetCommit returns the commit from the git database, without statistics
+// and files, unlike the commits API.
+func (c *GitHubClient) GetCommit(ctx context.Context, org, repo, sha string) (*Commit, error) {
+	path := fmt.Sprintf("%s/repos/%s/%s/git/commits/%s", gitHubAPI, org, repo, sha)
+	var res Commit
+	err := c.api.Do(ctx, "GET", path, httpclient.WithResponseUnmarshal(&res))
+	if err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+type NewCommit struct {
+	Message string
+	Tree    string
+	Parents []string
+
+	// Author and Committer default to the authenticated user, and their
+	// dates default to now.
+	Author    *CommitAuthor
+	Committer *CommitAuthor
+}
+
+// gitIdentity omits the zero date, which GitHub would take literally.
+func gitIdentity(a *CommitAuthor) map[string]any {
+	if a == nil {
+		return nil
+	}
+	identity := map[string]any{
+		"name":  a.Name,
+		"email": a.Email,
+	}
+	if !a.Date.IsZero() {
+		identity["date"] = a.Date
+	}
+	return identity
+}
+
+// CreateCommit creates a commit without updating any refs, which is up to
+// UpdateRef or CreateRef.
+func (c *GitHubClient) CreateCommit(ctx context.Context, org, repo string, req NewCommit) (*Commit, error) {
+	path := fmt.Sprintf("%s/repos/%s/%s/git/commits", gitHubAPI, org, repo)
+	body := map[string]any{
+		"message": req.Message,
+		"tree":    req.Tree,
+		"parents": req.Parents,
+	}
+	if req.Author != nil {
+		body["author"] = gitIdentity(req.Author)
+	}
+	if req.Committer != nil {
+		body["committer"] = gitIdentity(req.Committer)
+	}
+	var res Commit
+	err := c.api.Do(ctx, "POST", path,
+		httpclient.WithRequestData(body),
+		httpclient.WithResponseUnmarshal(&res))
+	if err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+type NewTag struct {
+	Tag     string
+	Message string
+
+	// Object is the SHA of the tagged commit.
+	Object string
+
+	// Tagger defaults to the authenticated user.
+	Tagger *CommitAuthor
+}
+
+// CreateTag creates an annotated tag of the commit along with its ref, as
+// tag objects alone are not visible to git. Returns the SHA of the tag object.
+func (c *GitHubClient) CreateTag(ctx context.Context, org, repo string, req NewTag) (string, error) {
+	path := fmt.Sprintf("%s/repos/%s/%s/git/tags", gitHubAPI, org, repo)
+	body := map[string]any{
+		"tag":     req.Tag,
+		"message": req.Message,
+		"object":  req.Object,
+		"type":    "commit",
+	}
+	if req.Tagger != nil {
+		body["tagger"] = gitIdentity(req.Tagger)
+	}
+	var res struct {
+		SHA string `json:"sha"`
+	}
+	err := c.api.Do(ctx, "POST", path,
+		httpclient.WithRequestData(body),
+		httpclient.WithResponseUnmarshal(&res))
+	if err != nil {
+		return "", err
+	}
+	_, err = c.CreateRef(ctx, org, repo, "refs/tags/"+req.Tag, res.SHA)
+	if err != nil {
+		return "", fmt.Errorf("ref: %w", err)
+	}
+	return res.SHA, nil
+}
+
+// CommitFiles adds, changes, or deletes several files on the branch with a
+// single commit, without a local clone. Files with nil contents are deleted,
+// changed files keep their mode, like the executable bit, and new files are
+// written as regular, non-executable files. The
+// branch is only fast-forwarded, so the commit fails with ErrNotFastForward,
+// if somebody pushes to the branch in the meantime. Returns the commit SHA.
+func (c *GitHubClient) CommitFiles(ctx context.Context, org, repo, branch, message string, files map[string][]byte) (string, error) {
+	head, err := c.branchHead(ctx, org, repo, branch)
+	if err != nil {
+		return "", fmt.Errorf("branch: %w", err)
+	}
+	parent, err := c.GetCommit(ctx, org, repo, head)
+	if err != nil {
+		return "", fmt.Errorf("commit %.7s: %w", head, err)
+	}
+	paths := make([]string, 0, len(files))
+	for path := range files {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	modes, err := c.treeModes(ctx, org, repo, parent.Tree.SHA, paths)
+	if err != nil {
+		return "", err
+	}
+	var entries []TreeEntry
+	for _, path := range paths {
+		entry := TreeEntry{Path: path, Mode: "100644", Type: "blob"}
+		if mode, ok := modes[path]; ok {
+			entry.Mode = mode
+		}
+		if files[path] != nil {
+			sha, err := c.CreateBlob(ctx, org, repo, files[path])
+			if err != nil {
+				return "", fmt.Errorf("blob of %s: %w", path, err)
+			}
+			entry.SHA = &sha
+		}
+		entries = append(entries, entry)
+	}
+	tree, err := c.CreateTree(ctx, org, repo, parent.Tree.SHA, entries)
+	if err != nil {
+		return "", fmt.Errorf("tree: %w", err)
+	}
+	commit, err := c.CreateCommit(ctx, org, repo, NewCommit{
+		Message: message,
+		Tree:    tree,
+		Parents: []string{head},
+	})
+	if err != nil {
+		return "", fmt.Errorf("commit: %w", err)
+	}
+	err = c.fastForward(ctx, org, repo, branch, commit.SHA)
+	if errors.Is(err, ErrValidation) {
+		return "", fmt.Errorf("%s has moved from %.7s: %w", branch, head, ErrNotFastForward)
+	}
+	if err != nil {
+		return "", fmt.Errorf("update %s: %w", branch, err)
+	}
+	return commit.SHA, nil
+}
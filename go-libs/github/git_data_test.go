@@ -0,0 +1,140 @@
+package github
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommitFiles(t *testing.T) {
+	var blobs []string
+	var tree, commit map[string]any
+	client := NewClient(&GitHubConfig{
+		GitHubTokenSource: GitHubTokenSource{Pat: "x"},
+		transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			var body map[string]any
+			if r.Body != nil {
+				raw, err := io.ReadAll(r.Body)
+				require.NoError(t, err)
+				if len(raw) > 0 {
+					require.NoError(t, json.Unmarshal(raw, &body))
+				}
+			}
+			switch r.Method + " " + r.URL.Path {
+			case "GET /repos/a/b/git/ref/heads/main":
+				return jsonResponse(200, `{"object": {"sha": "head"}}`), nil
+			case "GET /repos/a/b/git/commits/head":
+				return jsonResponse(200, `{"tree": {"sha": "base"}}`), nil
+			case "GET /repos/a/b/git/trees/base":
+				return jsonResponse(200, `{"tree": [
+					{"path": "VERSION", "mode": "100644", "type": "blob", "sha": "v0"},
+					{"path": "obsolete.txt", "mode": "100644", "type": "blob", "sha": "o"},
+					{"path": "scripts", "mode": "040000", "type": "tree", "sha": "scripts"}
+				]}`), nil
+			case "GET /repos/a/b/git/trees/scripts":
+				return jsonResponse(200, `{"tree": [
+					{"path": "build.sh", "mode": "100755", "type": "blob", "sha": "b"}
+				]}`), nil
+			case "POST /repos/a/b/git/blobs":
+				content, err := base64.StdEncoding.DecodeString(body["content"].(string))
+				require.NoError(t, err)
+				blobs = append(blobs, string(content))
+				return jsonResponse(201, `{"sha": "blob-`+string(content)+`"}`), nil
+			case "POST /repos/a/b/git/trees":
+				tree = body
+				return jsonResponse(201, `{"sha": "tree"}`), nil
+			case "POST /repos/a/b/git/commits":
+				commit = body
+				return jsonResponse(201, `{"sha": "commit"}`), nil
+			case "PATCH /repos/a/b/git/refs/heads/main":
+				assert.Equal(t, "commit", body["sha"])
+				assert.Equal(t, false, body["force"])
+				return jsonResponse(200, `{}`), nil
+			}
+			t.Fatalf("unexpected %s %s", r.Method, r.URL.Path)
+			return nil, nil
+		}),
+	})
+	sha, err := client.CommitFiles(context.Background(), "a", "b", "main", "Bump versions", map[string][]byte{
+		"go.mod":           []byte("v2"),
+		"VERSION":          []byte("v1"),
+		"obsolete.txt":     nil,
+		"scripts/build.sh": []byte("v3"),
+		"docs/new.md":      []byte("v4"),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "commit", sha)
+	assert.Equal(t, []string{"v1", "v4", "v2", "v3"}, blobs)
+	assert.Equal(t, "base", tree["base_tree"])
+	assert.Equal(t, []any{
+		map[string]any{"path": "VERSION", "mode": "100644", "type": "blob", "sha": "blob-v1"},
+		map[string]any{"path": "docs/new.md", "mode": "100644", "type": "blob", "sha": "blob-v4"},
+		map[string]any{"path": "go.mod", "mode": "100644", "type": "blob", "sha": "blob-v2"},
+		map[string]any{"path": "obsolete.txt", "mode": "100644", "type": "blob", "sha": nil},
+		map[string]any{"path": "scripts/build.sh", "mode": "100755", "type": "blob", "sha": "blob-v3"},
+	}, tree["tree"])
+	assert.Equal(t, "Bump versions", commit["message"])
+	assert.Equal(t, []any{"head"}, commit["parents"])
+}
+
+func TestCommitFilesAfterConcurrentPush(t *testing.T) {
+	client := NewClient(&GitHubConfig{
+		GitHubTokenSource: GitHubTokenSource{Pat: "x"},
+		transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			switch r.Method + " " + r.URL.Path {
+			case "GET /repos/a/b/git/ref/heads/main":
+				return jsonResponse(200, `{"object": {"sha": "head"}}`), nil
+			case "PATCH /repos/a/b/git/refs/heads/main":
+				return jsonResponse(422, `{"message": "Update is not a fast forward"}`), nil
+			case "GET /repos/a/b/git/trees/base":
+				return jsonResponse(200, `{"tree": []}`), nil
+			}
+			return jsonResponse(201, `{"sha": "x", "tree": {"sha": "base"}}`), nil
+		}),
+	})
+	_, err := client.CommitFiles(context.Background(), "a", "b", "main", "Bump", map[string][]byte{
+		"VERSION": []byte("v1"),
+	})
+	assert.ErrorIs(t, err, ErrNotFastForward)
+}
+
+func TestCreateTag(t *testing.T) {
+	var tag, ref map[string]any
+	client := NewClient(&GitHubConfig{
+		GitHubTokenSource: GitHubTokenSource{Pat: "x"},
+		transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			var body map[string]any
+			raw, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			require.NoError(t, json.Unmarshal(raw, &body))
+			switch r.Method + " " + r.URL.Path {
+			case "POST /repos/a/b/git/tags":
+				tag = body
+				return jsonResponse(201, `{"sha": "tag"}`), nil
+			case "POST /repos/a/b/git/refs":
+				ref = body
+				return jsonResponse(201, `{"ref": "refs/tags/v0.1.0"}`), nil
+			}
+			t.Fatalf("unexpected %s %s", r.Method, r.URL.Path)
+			return nil, nil
+		}),
+	})
+	sha, err := client.CreateTag(context.Background(), "a", "b", NewTag{
+		Tag:     "v0.1.0",
+		Message: "First release",
+		Object:  "commit",
+		Tagger:  &CommitAuthor{Name: "Bot", Email: "bot@example.com"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "tag", sha)
+	assert.Equal(t, "commit", tag["type"])
+	assert.Equal(t, "commit", tag["object"])
+	assert.Equal(t, map[string]any{"name": "Bot", "email": "bot@example.com"}, tag["tagger"])
+	assert.Equal(t, map[string]any{"ref": "refs/tags/v0.1.0", "sha": "tag"}, ref)
+}
@@ -4,6 +4,9 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
 	"time"
 
 	"github.com/databricks/databricks-sdk-go/httpclient"
@@ -12,8 +15,9 @@ import (
 const gitHubAPI = "https://api.github.com"
 
 type GitHubClient struct {
-	api *httpclient.ApiClient
-	cfg *GitHubConfig
+	api        apiClient
+	cfg        *GitHubConfig
+	rateLimits *rateLimitTracker
 }
 
 type GitHubConfig struct {
@@ -26,13 +30,96 @@ type GitHubConfig struct {
 	DebugTruncateBytes int
 	RateLimitPerSecond int
 
+	// RedactPatterns are masked in logs of requests, in addition to the
+	// Authorization header, GitHub tokens, and credentials in URLs.
+	RedactPatterns []*regexp.Regexp
+
+	// CircuitBreakerThreshold is the number of consecutive server errors or
+	// secondary rate limits, after which requests fail with ErrCircuitOpen
+	// for CircuitBreakerCooldown (1 minute by default). Zero disables it.
+	CircuitBreakerThreshold int
+	CircuitBreakerCooldown  time.Duration
+
+	// RetryBudget is the number of failed attempts tolerated within
+	// RetryBudgetWindow (1 minute by default), after which failures are
+	// reported as ErrRetryBudgetExhausted and are not retried. Zero disables it.
+	RetryBudget       int
+	RetryBudgetWindow time.Duration
+
+	// OnRateLimitWait is called before sleeping until a rate limit resets.
+	// Requests are retried after rate limits, unless the wait would exceed
+	// RetryTimeout, in which case they fail with RateLimitError.
+	OnRateLimitWait func(ctx context.Context, wait time.Duration, secondary bool)
+
+	// MaxResponseBytes fails requests with ErrResponseTooLarge, once their
+	// response body exceeds the size. Zero means no limit.
+	MaxResponseBytes int64
+
+	// RateBudget splits the rate limit across subsystems of the application,
+	// which attribute requests with WithSubsystem.
+	RateBudget *RateBudget
+
+	// ETagCache revalidates responses to GET requests with their ETags, so
+	// that unchanged responses don't count against the rate limit.
+	ETagCache *ETagCache
+
+	// DryRun logs POST, PATCH, PUT, and DELETE requests, except for GraphQL
+	// queries, instead of sending them and responds with an empty body, so
+	// that fleet-wide changes can be previewed safely. Objects returned by
+	// skipped requests are zero-valued.
+	DryRun bool
+
+	// AuditLog records mutating requests, including the ones skipped with
+	// DryRun.
+	AuditLog *AuditLog
+
+	// RecordDir saves responses to GET requests into the directory, so that
+	// they can be served later with OfflineDir.
+	RecordDir string
+
+	// OfflineDir serves GET requests from responses recorded with RecordDir
+	// and fails all other requests with ErrOffline, so that demos and tests
+	// can run without network access or credentials.
+	OfflineDir string
+
+	// PageSize is the number of items requested per page by methods, that
+	// follow pagination, up to 100. Default is 100.
+	PageSize int
+
+	// MaxListItems stops pagination, once the number of items is reached.
+	// Zero means no limit.
+	MaxListItems int
+
+	// BaseURL of the REST API of GitHub Enterprise Server, like
+	// https://github.example.com/api/v3. Default is https://api.github.com.
+	BaseURL string
+
+	// UploadURL for release assets of GitHub Enterprise Server. Default is
+	// derived from BaseURL, like https://github.example.com/api/uploads.
+	UploadURL string
+
 	transport http.RoundTripper
 }
 
 func NewClient(cfg *GitHubConfig) *GitHubClient {
+	var visitors []httpclient.RequestVisitor
+	if cfg.BaseURL != "" {
+		enterprise, err := newEnterpriseURLs(cfg.BaseURL, cfg.UploadURL)
+		visitors = append(visitors, func(r *http.Request) error {
+			if err != nil {
+				return err
+			}
+			return enterprise.visit(r)
+		})
+		cfg.GitHubTokenSource.enterprise = enterprise
+	}
+	rateLimits := &rateLimitTracker{}
 	return &GitHubClient{
-		api: httpclient.NewApiClient(httpclient.ClientConfig{
-			Visitors: []httpclient.RequestVisitor{func(r *http.Request) error {
+		api: apiClient{ApiClient: httpclient.NewApiClient(httpclient.ClientConfig{
+			Visitors: append(visitors, func(r *http.Request) error {
+				if cfg.OfflineDir != "" {
+					return nil
+				}
 				token, err := cfg.Token()
 				if err != nil {
 					return fmt.Errorf("token: %w", err)
@@ -40,28 +127,35 @@ func NewClient(cfg *GitHubConfig) *GitHubClient {
 				auth := fmt.Sprintf("%s %s", token.TokenType, token.AccessToken)
 				r.Header.Set("Authorization", auth)
 				return nil
-			}},
+			}),
 			RetryTimeout:       cfg.RetryTimeout,
 			HTTPTimeout:        cfg.HTTPTimeout,
 			InsecureSkipVerify: cfg.InsecureSkipVerify,
 			DebugHeaders:       cfg.DebugHeaders,
 			DebugTruncateBytes: cfg.DebugTruncateBytes,
 			RateLimitPerSecond: cfg.RateLimitPerSecond,
-			Transport:          cfg.transport,
-		}),
-		cfg: cfg,
+			Transport:          newTransport(cfg, rateLimits),
+		}), redactor: &redactor{cfg.RedactPatterns}},
+		cfg:        cfg,
+		rateLimits: rateLimits,
 	}
 }
 
+// Versions returns all releases of the repository, from the newest.
 func (c *GitHubClient) Versions(ctx context.Context, org, repo string) (Versions, error) {
-	var releases Versions
 	url := fmt.Sprintf("%s/repos/%s/%s/releases", gitHubAPI, org, repo)
-	err := c.api.Do(ctx, "GET", url, httpclient.WithResponseUnmarshal(&releases))
-	return releases, err
+	return listAll[Release](ctx, c, url, nil)
+}
+
+// VersionsIterator walks releases of the repository, from the newest.
+func (c *GitHubClient) VersionsIterator(org, repo string) *Iterator[Release] {
+	url := fmt.Sprintf("%s/repos/%s/%s/releases", gitHubAPI, org, repo)
+	return Paginate[Release](c, url, nil)
 }
 
 type CreateReleaseRequest struct {
 	TagName                string `json:"tag_name,omitempty"`
+	TargetCommitish        string `json:"target_commitish,omitempty"`
 	Name                   string `json:"name,omitempty"`
 	Body                   string `json:"body,omitempty"`
 	Draft                  bool   `json:"draft,omitempty"`
@@ -79,29 +173,102 @@ func (c *GitHubClient) CreateRelease(ctx context.Context, org, repo string, req
 	return &res, err
 }
 
+func (c *GitHubClient) GetRelease(ctx context.Context, org, repo string, releaseID int64) (*Release, error) {
+	var res Release
+	url := fmt.Sprintf("%s/repos/%s/%s/releases/%d", gitHubAPI, org, repo, releaseID)
+	err := c.api.Do(ctx, "GET", url, httpclient.WithResponseUnmarshal(&res))
+	return &res, err
+}
+
+// GetReleaseByTag returns a published release. Draft releases are not
+// associated with tags yet, so they can only be found by listing.
+func (c *GitHubClient) GetReleaseByTag(ctx context.Context, org, repo, tag string) (*Release, error) {
+	var res Release
+	url := fmt.Sprintf("%s/repos/%s/%s/releases/tags/%s", gitHubAPI, org, repo, escapeRef(tag))
+	err := c.api.Do(ctx, "GET", url, httpclient.WithResponseUnmarshal(&res))
+	return &res, err
+}
+
+// UpdateReleaseRequest changes only the fields, that are set.
+type UpdateReleaseRequest struct {
+	TagName    *string `json:"tag_name,omitempty"`
+	Name       *string `json:"name,omitempty"`
+	Body       *string `json:"body,omitempty"`
+	Draft      *bool   `json:"draft,omitempty"`
+	Prerelease *bool   `json:"prerelease,omitempty"`
+
+	// MakeLatest is one of: true, false, legacy.
+	MakeLatest string `json:"make_latest,omitempty"`
+}
+
+func (c *GitHubClient) UpdateRelease(ctx context.Context, org, repo string, releaseID int64, req UpdateReleaseRequest) (*Release, error) {
+	var res Release
+	url := fmt.Sprintf("%s/repos/%s/%s/releases/%d", gitHubAPI, org, repo, releaseID)
+	err := c.api.Do(ctx, "PATCH", url,
+		httpclient.WithRequestData(req),
+		httpclient.WithResponseUnmarshal(&res))
+	return &res, err
+}
+
+// PublishRelease publishes a draft release, which creates its tag, if it
+// doesn't exist yet.
+func (c *GitHubClient) PublishRelease(ctx context.Context, org, repo string, releaseID int64) (*Release, error) {
+	draft := false
+	return c.UpdateRelease(ctx, org, repo, releaseID, UpdateReleaseRequest{
+		Draft: &draft,
+	})
+}
+
+// DeleteRelease deletes a release with its assets, but keeps its tag.
+func (c *GitHubClient) DeleteRelease(ctx context.Context, org, repo string, releaseID int64) error {
+	url := fmt.Sprintf("%s/repos/%s/%s/releases/%d", gitHubAPI, org, repo, releaseID)
+	return c.api.Do(ctx, "DELETE", url)
+}
+
 func (c *GitHubClient) GetRepo(ctx context.Context, org, name string) (repo Repo, err error) {
 	url := fmt.Sprintf("%s/repos/%s/%s", gitHubAPI, org, name)
 	err = c.api.Do(ctx, "GET", url, httpclient.WithResponseUnmarshal(&repo))
 	return
 }
 
+// ListRepositories returns all repositories of the org.
 func (c *GitHubClient) ListRepositories(ctx context.Context, org string) (Repositories, error) {
-	var repos Repositories
 	url := fmt.Sprintf("%s/users/%s/repos", gitHubAPI, org)
-	err := c.api.Do(ctx, "GET", url, httpclient.WithResponseUnmarshal(&repos))
-	return repos, err
+	return listAll[Repo](ctx, c, url, nil)
+}
+
+func (c *GitHubClient) ListRepositoriesIterator(org string) *Iterator[Repo] {
+	url := fmt.Sprintf("%s/users/%s/repos", gitHubAPI, org)
+	return Paginate[Repo](c, url, nil)
 }
 
-func (c *GitHubClient) ListRuns(ctx context.Context, org, repo, workflow string) ([]workflowRun, error) {
-	path := fmt.Sprintf("%s/repos/%s/%s/actions/workflows/%v.yml/runs", gitHubAPI, org, repo, workflow)
+// StreamRepositories calls fn for every repository of the org as it is
+// decoded from the response, which is cheaper than ListRepositories
+// for organizations with many repositories.
+func (c *GitHubClient) StreamRepositories(ctx context.Context, org string, fn func(Repo) error) error {
+	path := fmt.Sprintf("%s/users/%s/repos", gitHubAPI, org)
+	return streamList(ctx, c, path, nil, fn)
+}
+
+// ListRuns returns a page of runs of the workflow, which is a file name,
+// like release.yml, or a numeric ID.
+func (c *GitHubClient) ListRuns(ctx context.Context, org, repo, workflow string, opts ListRunsOptions) ([]WorkflowRun, error) {
+	path := fmt.Sprintf("%s/repos/%s/%s/actions/workflows/%s/runs", gitHubAPI, org, repo, workflow)
 	var response struct {
 		TotalCount   *int          `json:"total_count,omitempty"`
-		WorkflowRuns []workflowRun `json:"workflow_runs,omitempty"`
+		WorkflowRuns []WorkflowRun `json:"workflow_runs,omitempty"`
 	}
-	err := c.api.Do(ctx, "GET", path, httpclient.WithResponseUnmarshal(&response))
+	err := c.api.Do(ctx, "GET", path,
+		httpclient.WithRequestData(opts),
+		httpclient.WithResponseUnmarshal(&response))
 	return response.WorkflowRuns, err
 }
 
+func (c *GitHubClient) ListRunsIterator(org, repo, workflow string, opts ListRunsOptions) *Iterator[WorkflowRun] {
+	path := fmt.Sprintf("%s/repos/%s/%s/actions/workflows/%s/runs", gitHubAPI, org, repo, workflow)
+	return paginateField[WorkflowRun](c, path, "workflow_runs", opts)
+}
+
 func (c *GitHubClient) CompareCommits(ctx context.Context, org, repo, base, head string) ([]RepositoryCommit, error) {
 	path := fmt.Sprintf("%s/repos/%v/%v/compare/%v...%v", gitHubAPI, org, repo, base, head)
 	var response struct {
@@ -120,6 +287,12 @@ func (c *GitHubClient) ListPullRequests(ctx context.Context, org, repo string, o
 	return prs, err
 }
 
+// ListPullRequestsIterator walks pull requests, starting from opts.Page.
+func (c *GitHubClient) ListPullRequestsIterator(org, repo string, opts PullRequestListOptions) *Iterator[PullRequest] {
+	path := fmt.Sprintf("%s/repos/%s/%s/pulls", gitHubAPI, org, repo)
+	return Paginate[PullRequest](c, path, opts)
+}
+
 func (c *GitHubClient) EditPullRequest(ctx context.Context, org, repo string, number int, body PullRequestUpdate) error {
 	path := fmt.Sprintf("%s/repos/%s/%s/pulls/%d", gitHubAPI, org, repo, number)
 	return c.api.Do(ctx, "PATCH", path, httpclient.WithRequestData(body))
@@ -144,3 +317,42 @@ func (c *GitHubClient) GetPullRequest(ctx context.Context, org, repo string, num
 		httpclient.WithResponseUnmarshal(&res))
 	return &res, err
 }
+
+// ListPullRequestFiles lists up to 3000 files changed by the pull request.
+func (c *GitHubClient) ListPullRequestFiles(ctx context.Context, org, repo string, number int) ([]CommitFile, error) {
+	path := fmt.Sprintf("%s/repos/%s/%s/pulls/%d/files", gitHubAPI, org, repo, number)
+	var files []CommitFile
+	for page := 1; ; page++ {
+		var res []CommitFile
+		err := c.api.Do(ctx, "GET", path,
+			httpclient.WithRequestData(listOptions{Page: page, PerPage: 100}),
+			httpclient.WithResponseUnmarshal(&res))
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, res...)
+		if len(res) < 100 {
+			return files, nil
+		}
+	}
+}
+
+func (c *GitHubClient) ListPullRequestFilesIterator(org, repo string, number int) *Iterator[CommitFile] {
+	path := fmt.Sprintf("%s/repos/%s/%s/pulls/%d/files", gitHubAPI, org, repo, number)
+	return Paginate[CommitFile](c, path, nil)
+}
+
+type listOptions struct {
+	Page    int `url:"page,omitempty"`
+	PerPage int `url:"per_page,omitempty"`
+}
+
+// escapeRef escapes every segment of a ref, keeping the slashes of branch
+// names like "release/v0.1" intact.
+func escapeRef(ref string) string {
+	segments := strings.Split(ref, "/")
+	for i, v := range segments {
+		segments[i] = url.PathEscape(v)
+	}
+	return strings.Join(segments, "/")
+}
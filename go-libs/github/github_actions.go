@@ -21,14 +21,6 @@ type GitHubActionsWorkflow struct {
 	Timeout   time.Duration
 }
 
-type workflowRun struct {
-	ID     int64  `json:"id"`
-	Name   string `json:"name"`
-	Status string `json:"status"` // waiting, in_progress, completed
-	ApiURL string `json:"url,omitempty"`
-	WebURL string `json:"html_url,omitempty"`
-}
-
 func (g *GitHubActionsWorkflow) Wait(ctx context.Context) error {
 	client := oauth2.NewClient(ctx, &g.Connect)
 	return retries.Wait(ctx, g.Timeout, func() *retries.Err {
@@ -56,13 +48,13 @@ func (g *GitHubActionsWorkflow) Wait(ctx context.Context) error {
 	})
 }
 
-func (g *GitHubActionsWorkflow) listRuns(client *http.Client, ref string) ([]workflowRun, error) {
+func (g *GitHubActionsWorkflow) listRuns(client *http.Client, ref string) ([]WorkflowRun, error) {
 	//ref: org/repo/workflow, e.g. databrickslabs/ucx/acceptance
 	split := strings.SplitN(ref, "/", 3)
 	path := fmt.Sprintf("/repos/%s/%s/actions/workflows/%v.yml/runs", split[0], split[1], split[2])
 	var response struct {
 		TotalCount   *int          `json:"total_count,omitempty"`
-		WorkflowRuns []workflowRun `json:"workflow_runs,omitempty"`
+		WorkflowRuns []WorkflowRun `json:"workflow_runs,omitempty"`
 	}
 	err := g.get(client, path, &response)
 	return response.WorkflowRuns, err
@@ -2,16 +2,21 @@ package github
 
 import (
 	"context"
+	"crypto/rsa"
 	"crypto/x509"
 	"encoding/base64"
 	"encoding/json"
 	"encoding/pem"
 	"errors"
 	"fmt"
+	"io"
 	"io/fs"
+	"net/http"
+	"net/url"
 	"os"
 	"os/exec"
 	"strings"
+	"sync"
 	"time"
 
 	"golang.org/x/oauth2"
@@ -23,23 +28,56 @@ type GitHubTokenSource struct {
 	PrivateKeyPath   string
 	PrivateKeyBase64 string
 	ApplicationID    int64
-	InstallationID   int
+	InstallationID   int64
 
+	// mu guards cached and permissions, which are only written by Token
+	mu     sync.Mutex
 	cached oauth2.TokenSource
+
+	// permissions of the last installation token
+	permissions map[string]string
+
+	// transport is the test seam for installation token requests
+	transport http.RoundTripper
+
+	// enterprise is set by NewClient for GitHub Enterprise Server
+	enterprise *enterpriseURLs
+}
+
+// tokenRefreshWindow renews installation tokens, that are valid for an hour,
+// a bit before they expire, so that requests in flight don't fail.
+const tokenRefreshWindow = 5 * time.Minute
+
+func (g *GitHubTokenSource) isApp() bool {
+	return g.ApplicationID != 0 && (g.PrivateKeyPath != "" || g.PrivateKeyBase64 != "")
 }
 
+// Token returns the personal access token, if it's configured, or an
+// installation token of the GitHub App, if the app is configured, which is
+// renewed automatically. Otherwise, it falls back to GITHUB_TOKEN environment
+// variable and to the token of GitHub CLI.
 func (g *GitHubTokenSource) Token() (*oauth2.Token, error) {
 	if g.Pat != "" {
 		return &oauth2.Token{
 			AccessToken: g.Pat,
 		}, nil
 	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
 	if g.cached != nil {
 		return g.cached.Token()
 	}
+	if g.isApp() {
+		ts := &ghInstallationTokenSource{g}
+		token, err := ts.Token()
+		if err != nil {
+			return nil, fmt.Errorf("installation token: %w", err)
+		}
+		g.cached = oauth2.ReuseTokenSourceWithExpiry(token, ts, tokenRefreshWindow)
+		return token, nil
+	}
 	for _, ts := range []oauth2.TokenSource{
 		&ghEnvTokenSource{"GITHUB_TOKEN"},
-		&ghInstallationTokenSource{g},
 		&ghCliTokenSource{},
 	} {
 		token, err := ts.Token()
@@ -54,6 +92,14 @@ func (g *GitHubTokenSource) Token() (*oauth2.Token, error) {
 	return nil, fmt.Errorf("no github token available")
 }
 
+// installationPermissions returns permissions of the last installation token,
+// or nil for other tokens.
+func (g *GitHubTokenSource) installationPermissions() map[string]string {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.permissions
+}
+
 type ghEnvTokenSource struct {
 	Name string
 }
@@ -117,7 +163,7 @@ func (g *ghAppTokenSource) Token() (*oauth2.Token, error) {
 	if block != nil {
 		privateKeyBytes = block.Bytes
 	}
-	parsedKey, err := x509.ParsePKCS1PrivateKey(privateKeyBytes)
+	parsedKey, err := parsePrivateKey(privateKeyBytes)
 	if err != nil {
 		return nil, err
 	}
@@ -138,6 +184,24 @@ func (g *ghAppTokenSource) Token() (*oauth2.Token, error) {
 	}, nil
 }
 
+// parsePrivateKey accepts PKCS#1 keys, which GitHub generates, and PKCS#8
+// keys, which are produced by converting them with most tools.
+func parsePrivateKey(der []byte) (*rsa.PrivateKey, error) {
+	key, err := x509.ParsePKCS1PrivateKey(der)
+	if err == nil {
+		return key, nil
+	}
+	parsed, pkcs8Err := x509.ParsePKCS8PrivateKey(der)
+	if pkcs8Err != nil {
+		return nil, fmt.Errorf("private key: %w", err)
+	}
+	key, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("private key: expected RSA, got %T", parsed)
+	}
+	return key, nil
+}
+
 // See "Generating an installation access token" section:
 // https://docs.github.com/en/apps/creating-github-apps/authenticating-with-a-github-app/authenticating-as-a-github-app-installation
 type ghInstallationTokenSource struct {
@@ -145,14 +209,31 @@ type ghInstallationTokenSource struct {
 }
 
 func (i *ghInstallationTokenSource) Token() (*oauth2.Token, error) {
+	if i.InstallationID == 0 {
+		return nil, fmt.Errorf("installation id is required for app %d", i.ApplicationID)
+	}
 	ctx := context.Background()
+	if i.transport != nil {
+		ctx = context.WithValue(ctx, oauth2.HTTPClient, &http.Client{Transport: i.transport})
+	}
 	client := oauth2.NewClient(ctx, &ghAppTokenSource{i.GitHubTokenSource})
-	tokenURL := fmt.Sprintf("https://api.github.com/app/installations/%d/access_tokens", i.InstallationID)
-	resp, err := client.Post(tokenURL, "application/vnd.github+json", nil)
+	tokenURL, err := url.Parse(fmt.Sprintf("%s/app/installations/%d/access_tokens", gitHubAPI, i.InstallationID))
+	if err != nil {
+		return nil, err
+	}
+	if i.enterprise != nil {
+		i.enterprise.rewrite(tokenURL)
+	}
+	resp, err := client.Post(tokenURL.String(), "application/vnd.github+json", nil)
 	if err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, fmt.Errorf("%d %s: %s", resp.StatusCode,
+			http.StatusText(resp.StatusCode), strings.TrimSpace(string(body)))
+	}
 	var installationToken struct {
 		Token               string            `json:"token"`
 		ExpiresAt           time.Time         `json:"expires_at"`
@@ -163,6 +244,7 @@ func (i *ghInstallationTokenSource) Token() (*oauth2.Token, error) {
 	if err != nil {
 		return nil, err
 	}
+	i.permissions = installationToken.Permissions
 	return &oauth2.Token{
 		TokenType:   "Bearer",
 		AccessToken: installationToken.Token,
@@ -0,0 +1,103 @@
+package github
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInstallationTokenIsRenewedBeforeExpiry(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	exchanges := 0
+	t.Setenv("GITHUB_TOKEN", "from-env")
+	ts := &GitHubTokenSource{
+		ApplicationID:    1,
+		InstallationID:   2,
+		PrivateKeyBase64: base64.RawStdEncoding.EncodeToString(x509.MarshalPKCS1PrivateKey(key)),
+		transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			assert.Equal(t, "/app/installations/2/access_tokens", r.URL.Path)
+			assert.True(t, strings.HasPrefix(r.Header.Get("Authorization"), "Bearer "))
+			exchanges++
+			// the first token expires within the refresh window
+			expiresAt := time.Now().Add(time.Minute)
+			if exchanges > 1 {
+				expiresAt = time.Now().Add(time.Hour)
+			}
+			return jsonResponse(201, fmt.Sprintf(`{"token": "t%d", "expires_at": "%s"}`,
+				exchanges, expiresAt.Format(time.RFC3339))), nil
+		}),
+	}
+	token, err := ts.Token()
+	require.NoError(t, err)
+	assert.Equal(t, "t1", token.AccessToken)
+
+	token, err = ts.Token()
+	require.NoError(t, err)
+	assert.Equal(t, "t2", token.AccessToken)
+
+	token, err = ts.Token()
+	require.NoError(t, err)
+	assert.Equal(t, "t2", token.AccessToken)
+	assert.Equal(t, 2, exchanges)
+}
+
+func TestInstallationTokenFailure(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	pkcs8, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+	ts := &GitHubTokenSource{
+		ApplicationID:    1,
+		InstallationID:   2,
+		PrivateKeyBase64: base64.RawStdEncoding.EncodeToString(pkcs8),
+		transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			return jsonResponse(404, `{"message": "Not Found"}`), nil
+		}),
+	}
+	_, err = ts.Token()
+	assert.ErrorContains(t, err, "installation token: 404")
+
+	ts.InstallationID = 0
+	_, err = ts.Token()
+	assert.ErrorContains(t, err, "installation id is required")
+}
+
+func TestInstallationTokenIsSharedByConcurrentCalls(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	var exchanges atomic.Int32
+	ts := &GitHubTokenSource{
+		ApplicationID:    1,
+		InstallationID:   2,
+		PrivateKeyBase64: base64.RawStdEncoding.EncodeToString(x509.MarshalPKCS1PrivateKey(key)),
+		transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			exchanges.Add(1)
+			return jsonResponse(201, fmt.Sprintf(`{"token": "t", "expires_at": "%s",
+				"permissions": {"contents": "read"}}`, time.Now().Add(time.Hour).Format(time.RFC3339))), nil
+		}),
+	}
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := ts.Token()
+			assert.NoError(t, err)
+			assert.Equal(t, "read", ts.installationPermissions()["contents"])
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int32(1), exchanges.Load())
+}
@@ -0,0 +1,206 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/databricks/databricks-sdk-go/logger"
+)
+
+var ErrNotIndexed = errors.New("module version is not available yet")
+
+// GoProxy talks to the Go module mirror, the checksum database, and
+// pkg.go.dev, which are not part of the GitHub API and never get its
+// credentials.
+// See https://go.dev/ref/mod#goproxy-protocol
+type GoProxy struct {
+	// ProxyURL defaults to https://proxy.golang.org.
+	ProxyURL string
+
+	// SumDBURL defaults to https://sum.golang.org.
+	SumDBURL string
+
+	// PkgGoDevURL defaults to https://pkg.go.dev.
+	PkgGoDevURL string
+
+	// HTTPClient defaults to http.DefaultClient.
+	HTTPClient *http.Client
+}
+
+// EscapeModulePath replaces uppercase letters with an exclamation mark and
+// the lowercase letter, like the module proxy protocol requires for paths
+// and versions on case-insensitive file systems.
+func EscapeModulePath(path string) string {
+	var sb strings.Builder
+	for _, r := range path {
+		if unicode.IsUpper(r) {
+			sb.WriteByte('!')
+			r = unicode.ToLower(r)
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
+
+func (p *GoProxy) get(ctx context.Context, url string) ([]byte, int, error) {
+	client := p.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, 0, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer resp.Body.Close()
+	raw, err := io.ReadAll(resp.Body)
+	return raw, resp.StatusCode, err
+}
+
+// GoSum returns go.sum lines of the module version, both for the module
+// and for its go.mod file, as recorded in the checksum database.
+func (p *GoProxy) GoSum(ctx context.Context, module, version string) ([]string, error) {
+	base := p.SumDBURL
+	if base == "" {
+		base = "https://sum.golang.org"
+	}
+	url := fmt.Sprintf("%s/lookup/%s@%s", base, EscapeModulePath(module), EscapeModulePath(version))
+	raw, status, err := p.get(ctx, url)
+	if err != nil {
+		return nil, fmt.Errorf("lookup: %w", err)
+	}
+	if status == http.StatusNotFound || status == http.StatusGone {
+		return nil, fmt.Errorf("lookup %s@%s: %w: %s", module, version, ErrNotIndexed, strings.TrimSpace(string(raw)))
+	}
+	if status != http.StatusOK {
+		return nil, fmt.Errorf("lookup %s@%s: %d: %s", module, version, status, strings.TrimSpace(string(raw)))
+	}
+	// the record number is followed by go.sum lines and a signed tree head
+	var lines []string
+	for _, line := range strings.Split(string(raw), "\n")[1:] {
+		if line == "" {
+			break
+		}
+		lines = append(lines, line)
+	}
+	if len(lines) == 0 {
+		return nil, fmt.Errorf("lookup %s@%s: no go.sum lines", module, version)
+	}
+	return lines, nil
+}
+
+type ModuleInfo struct {
+	Version string    `json:"Version"`
+	Time    time.Time `json:"Time"`
+}
+
+// Info fetches the version from the module mirror, which downloads it from
+// the origin on the first request.
+func (p *GoProxy) Info(ctx context.Context, module, version string) (*ModuleInfo, error) {
+	base := p.ProxyURL
+	if base == "" {
+		base = "https://proxy.golang.org"
+	}
+	url := fmt.Sprintf("%s/%s/@v/%s.info", base, EscapeModulePath(module), EscapeModulePath(version))
+	raw, status, err := p.get(ctx, url)
+	if err != nil {
+		return nil, fmt.Errorf("info: %w", err)
+	}
+	if status == http.StatusNotFound || status == http.StatusGone {
+		return nil, fmt.Errorf("info %s@%s: %w: %s", module, version, ErrNotIndexed, strings.TrimSpace(string(raw)))
+	}
+	if status != http.StatusOK {
+		return nil, fmt.Errorf("info %s@%s: %d: %s", module, version, status, strings.TrimSpace(string(raw)))
+	}
+	var info ModuleInfo
+	err = json.Unmarshal(raw, &info)
+	if err != nil {
+		return nil, fmt.Errorf("info %s@%s: %w", module, version, err)
+	}
+	return &info, nil
+}
+
+type WarmUpOptions struct {
+	// Timeout defaults to 10 minutes
+	Timeout time.Duration
+
+	// MinInterval is the first delay between polls, which doubles after every
+	// poll up to MaxInterval. Defaults to 5 seconds and 1 minute respectively.
+	MinInterval time.Duration
+	MaxInterval time.Duration
+}
+
+// WarmUp requests a freshly tagged module version from the module mirror and
+// the checksum database until both serve it, so that users can go get the
+// release right away. The mirror caches missing versions for a while, so it
+// may take a few minutes. Errors other than ErrNotIndexed are not retried.
+func (p *GoProxy) WarmUp(ctx context.Context, module, version string, opts WarmUpOptions) (*ModuleInfo, error) {
+	if opts.Timeout == 0 {
+		opts.Timeout = 10 * time.Minute
+	}
+	if opts.MinInterval == 0 {
+		opts.MinInterval = 5 * time.Second
+	}
+	if opts.MaxInterval == 0 {
+		opts.MaxInterval = time.Minute
+	}
+	ctx, cancel := context.WithTimeout(ctx, opts.Timeout)
+	defer cancel()
+	interval := opts.MinInterval
+	for {
+		info, err := p.Info(ctx, module, version)
+		if err == nil {
+			_, err = p.GoSum(ctx, module, version)
+		}
+		if err == nil {
+			logger.Infof(ctx, "%s@%s is available", module, version)
+			return info, nil
+		}
+		if !errors.Is(err, ErrNotIndexed) {
+			return nil, err
+		}
+		logger.Infof(ctx, "Waiting %s for %s", interval, err)
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, fmt.Errorf("timed out: %w", err)
+		case <-timer.C:
+		}
+		interval *= 2
+		if interval > opts.MaxInterval {
+			interval = opts.MaxInterval
+		}
+	}
+}
+
+// Documented reports, if pkg.go.dev renders documentation of the module
+// version. Documentation of modules without a redistributable license is
+// never rendered, even though the page exists.
+func (p *GoProxy) Documented(ctx context.Context, module, version string) (bool, error) {
+	base := p.PkgGoDevURL
+	if base == "" {
+		base = "https://pkg.go.dev"
+	}
+	raw, status, err := p.get(ctx, fmt.Sprintf("%s/%s@%s", base, module, version))
+	if err != nil {
+		return false, fmt.Errorf("pkg.go.dev: %w", err)
+	}
+	switch status {
+	case http.StatusOK:
+		return !strings.Contains(string(raw), "not displayed due to license restrictions"), nil
+	case http.StatusNotFound:
+		return false, nil
+	}
+	return false, fmt.Errorf("pkg.go.dev: %s@%s: %d", module, version, status)
+}
@@ -0,0 +1,67 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGoSumFromChecksumDatabase(t *testing.T) {
+	proxy := &GoProxy{HTTPClient: &http.Client{
+		Transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			assert.Equal(t, "/lookup/github.com/!a/x@v1.0.0", r.URL.Path)
+			return jsonResponse(200, "123\n"+
+				"github.com/A/x v1.0.0 h1:abc=\n"+
+				"github.com/A/x v1.0.0/go.mod h1:def=\n"+
+				"\n"+
+				"go.sum database tree\n"), nil
+		}),
+	}}
+	lines, err := proxy.GoSum(context.Background(), "github.com/A/x", "v1.0.0")
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"github.com/A/x v1.0.0 h1:abc=",
+		"github.com/A/x v1.0.0/go.mod h1:def=",
+	}, lines)
+}
+
+func TestWarmUpPollsUntilAvailable(t *testing.T) {
+	infos := 0
+	proxy := &GoProxy{HTTPClient: &http.Client{
+		Transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			switch r.URL.Path {
+			case "/github.com/a/x/@v/v1.0.0.info":
+				infos++
+				if infos == 1 {
+					return jsonResponse(404, "not found: unknown revision v1.0.0"), nil
+				}
+				return jsonResponse(200, `{"Version": "v1.0.0", "Time": "2024-01-02T03:04:05Z"}`), nil
+			case "/lookup/github.com/a/x@v1.0.0":
+				return jsonResponse(200, "1\ngithub.com/a/x v1.0.0 h1:a=\n\n"), nil
+			}
+			t.Fatalf("unexpected %s", r.URL)
+			return nil, nil
+		}),
+	}}
+	info, err := proxy.WarmUp(context.Background(), "github.com/a/x", "v1.0.0", WarmUpOptions{
+		MinInterval: time.Millisecond,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "v1.0.0", info.Version)
+	assert.Equal(t, 2, infos)
+}
+
+func TestWarmUpFailsOnOtherErrors(t *testing.T) {
+	proxy := &GoProxy{HTTPClient: &http.Client{
+		Transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			return jsonResponse(500, "boom"), nil
+		}),
+	}}
+	_, err := proxy.WarmUp(context.Background(), "github.com/a/x", "v1.0.0", WarmUpOptions{})
+	assert.ErrorContains(t, err, "500: boom")
+	assert.NotErrorIs(t, err, ErrNotIndexed)
+}
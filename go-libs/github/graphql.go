@@ -0,0 +1,157 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"strings"
+
+	"github.com/databricks/databricks-sdk-go/httpclient"
+)
+
+const gitHubGraphQL = "https://api.github.com/graphql"
+
+// GraphQLError is an error of a query, like NOT_FOUND for missing objects.
+type GraphQLError struct {
+	Type    string `json:"type,omitempty"`
+	Message string `json:"message"`
+	Path    []any  `json:"path,omitempty"`
+}
+
+type GraphQLErrors []GraphQLError
+
+func (e GraphQLErrors) Error() string {
+	var msgs []string
+	for _, v := range e {
+		msgs = append(msgs, v.Message)
+	}
+	return fmt.Sprintf("graphql: %s", strings.Join(msgs, "; "))
+}
+
+// GraphQL executes a query or mutation and unmarshals the "data" field into
+// out, with the same credentials and retries as REST requests. GitHub responds
+// with HTTP 200 even on query errors, so those are returned as GraphQLErrors.
+// See https://docs.github.com/en/graphql
+func (c *GitHubClient) GraphQL(ctx context.Context, query string, variables map[string]any, out any) error {
+	var res struct {
+		Data   json.RawMessage `json:"data"`
+		Errors GraphQLErrors   `json:"errors,omitempty"`
+	}
+	err := c.api.Do(ctx, "POST", gitHubGraphQL,
+		httpclient.WithRequestData(map[string]any{
+			"query":     query,
+			"variables": variables,
+		}),
+		httpclient.WithResponseUnmarshal(&res))
+	if err != nil {
+		return err
+	}
+	if len(res.Errors) > 0 {
+		return res.Errors
+	}
+	if out == nil || len(res.Data) == 0 {
+		return nil
+	}
+	return json.Unmarshal(res.Data, out)
+}
+
+type PageInfo struct {
+	HasNextPage bool   `json:"hasNextPage"`
+	EndCursor   string `json:"endCursor"`
+}
+
+// Connection is a page of a GraphQL connection, which has to be queried
+// with nodes and pageInfo { hasNextPage endCursor } fields.
+type Connection[T any] struct {
+	Nodes      []T      `json:"nodes"`
+	PageInfo   PageInfo `json:"pageInfo"`
+	TotalCount int      `json:"totalCount,omitempty"`
+}
+
+// PaginateGraphQL walks a connection by its cursor. The query declares the
+// $cursor: String variable and passes it as the after argument of the
+// connection, which the connection func picks from the response:
+//
+//	it := PaginateGraphQL(c, `query($owner: String!, $name: String!, $cursor: String) {
+//		repository(owner: $owner, name: $name) {
+//			discussions(first: 100, after: $cursor) {
+//				nodes { number title }
+//				pageInfo { hasNextPage endCursor }
+//			}
+//		}
+//	}`, vars, func(res *discussionsResponse) *Connection[Discussion] {
+//		return &res.Repository.Discussions
+//	})
+func PaginateGraphQL[R, T any](c *GitHubClient, query string, variables map[string]any, connection func(*R) *Connection[T]) *Iterator[T] {
+	var cursor *string
+	return &Iterator[T]{c: c, url: gitHubGraphQL, page: func(ctx context.Context) ([]T, bool, error) {
+		vars := maps.Clone(variables)
+		if vars == nil {
+			vars = map[string]any{}
+		}
+		vars["cursor"] = cursor
+		var res R
+		err := c.GraphQL(ctx, query, vars, &res)
+		if err != nil {
+			return nil, false, err
+		}
+		page := connection(&res)
+		if page == nil {
+			return nil, false, nil
+		}
+		cursor = &page.PageInfo.EndCursor
+		return page.Nodes, page.PageInfo.HasNextPage, nil
+	}}
+}
+
+func (c *GitHubClient) repoNodeID(ctx context.Context, org, repo string) (string, error) {
+	var res struct {
+		Repository struct {
+			ID string `json:"id"`
+		} `json:"repository"`
+	}
+	err := c.GraphQL(ctx, `query($owner: String!, $name: String!) {
+		repository(owner: $owner, name: $name) { id }
+	}`, map[string]any{
+		"owner": org,
+		"name":  repo,
+	}, &res)
+	return res.Repository.ID, err
+}
+
+func (c *GitHubClient) issueNodeID(ctx context.Context, org, repo string, number int) (string, error) {
+	var res struct {
+		Repository struct {
+			Issue struct {
+				ID string `json:"id"`
+			} `json:"issue"`
+		} `json:"repository"`
+	}
+	err := c.GraphQL(ctx, `query($owner: String!, $name: String!, $number: Int!) {
+		repository(owner: $owner, name: $name) { issue(number: $number) { id } }
+	}`, map[string]any{
+		"owner":  org,
+		"name":   repo,
+		"number": number,
+	}, &res)
+	return res.Repository.Issue.ID, err
+}
+
+func (c *GitHubClient) pullRequestNodeID(ctx context.Context, org, repo string, number int) (string, error) {
+	var res struct {
+		Repository struct {
+			PullRequest struct {
+				ID string `json:"id"`
+			} `json:"pullRequest"`
+		} `json:"repository"`
+	}
+	err := c.GraphQL(ctx, `query($owner: String!, $name: String!, $number: Int!) {
+		repository(owner: $owner, name: $name) { pullRequest(number: $number) { id } }
+	}`, map[string]any{
+		"owner":  org,
+		"name":   repo,
+		"number": number,
+	}, &res)
+	return res.Repository.PullRequest.ID, err
+}
@@ -0,0 +1,73 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGraphQLReturnsQueryErrors(t *testing.T) {
+	client := NewClient(&GitHubConfig{
+		GitHubTokenSource: GitHubTokenSource{Pat: "x"},
+		transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			return jsonResponse(200, `{"data": null, "errors": [
+				{"type": "NOT_FOUND", "message": "Could not resolve to a Repository"}
+			]}`), nil
+		}),
+	})
+	err := client.GraphQL(context.Background(), `query { viewer { login } }`, nil, nil)
+	var gqlErr GraphQLErrors
+	require.True(t, errors.As(err, &gqlErr))
+	assert.Equal(t, "NOT_FOUND", gqlErr[0].Type)
+}
+
+func TestPaginateGraphQL(t *testing.T) {
+	type discussion struct {
+		Number int `json:"number"`
+	}
+	type response struct {
+		Repository struct {
+			Discussions Connection[discussion] `json:"discussions"`
+		} `json:"repository"`
+	}
+	var cursors []any
+	client := NewClient(&GitHubConfig{
+		GitHubTokenSource: GitHubTokenSource{Pat: "x"},
+		transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			assert.Equal(t, "/graphql", r.URL.Path)
+			raw, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			var req struct {
+				Variables map[string]any `json:"variables"`
+			}
+			require.NoError(t, json.Unmarshal(raw, &req))
+			assert.Equal(t, "b", req.Variables["name"])
+			cursors = append(cursors, req.Variables["cursor"])
+			if req.Variables["cursor"] == nil {
+				return jsonResponse(200, `{"data": {"repository": {"discussions": {
+					"nodes": [{"number": 1}, {"number": 2}],
+					"pageInfo": {"hasNextPage": true, "endCursor": "c2"}
+				}}}}`), nil
+			}
+			return jsonResponse(200, `{"data": {"repository": {"discussions": {
+				"nodes": [{"number": 3}],
+				"pageInfo": {"hasNextPage": false, "endCursor": "c3"}
+			}}}}`), nil
+		}),
+	})
+	it := PaginateGraphQL(client, `query($name: String!, $cursor: String) { ... }`, map[string]any{
+		"name": "b",
+	}, func(res *response) *Connection[discussion] {
+		return &res.Repository.Discussions
+	})
+	all, err := ToSlice(context.Background(), it)
+	require.NoError(t, err)
+	assert.Equal(t, []discussion{{1}, {2}, {3}}, all)
+	assert.Equal(t, []any{nil, "c2"}, cursors)
+}
@@ -0,0 +1,175 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/databricks/databricks-sdk-go/httpclient"
+	"github.com/databricks/databricks-sdk-go/logger"
+)
+
+// See https://docs.github.com/en/rest/repos/webhooks#list-deliveries-for-a-repository-webhook
+
+type HookDelivery struct {
+	ID           int64     `json:"id"`
+	GUID         string    `json:"guid,omitempty"`
+	DeliveredAt  time.Time `json:"delivered_at,omitempty"`
+	Redelivery   bool      `json:"redelivery,omitempty"`
+	Duration     float64   `json:"duration,omitempty"`
+	Status       string    `json:"status,omitempty"`
+	StatusCode   int       `json:"status_code,omitempty"`
+	Event        string    `json:"event,omitempty"`
+	Action       string    `json:"action,omitempty"`
+	RepositoryID int64     `json:"repository_id,omitempty"`
+
+	// Request is only returned for individual deliveries.
+	Request *HookDeliveryRequest `json:"request,omitempty"`
+}
+
+type HookDeliveryRequest struct {
+	Headers map[string]string `json:"headers,omitempty"`
+	Payload json.RawMessage   `json:"payload,omitempty"`
+}
+
+// hookPath is a path of repository webhook or of organization webhook,
+// if repo is empty.
+func hookPath(org, repo string, hookID int64) string {
+	if repo == "" {
+		return fmt.Sprintf("%s/orgs/%s/hooks/%d", gitHubAPI, org, hookID)
+	}
+	return fmt.Sprintf("%s/repos/%s/%s/hooks/%d", gitHubAPI, org, repo, hookID)
+}
+
+// ListHookDeliveries lists the most recent deliveries of a webhook without
+// payloads. Use an empty repo for organization webhooks.
+func (c *GitHubClient) ListHookDeliveries(ctx context.Context, org, repo string, hookID int64, perPage int) ([]HookDelivery, error) {
+	var res []HookDelivery
+	err := c.api.Do(ctx, "GET", hookPath(org, repo, hookID)+"/deliveries",
+		httpclient.WithRequestData(listOptions{PerPage: perPage}),
+		httpclient.WithResponseUnmarshal(&res))
+	return res, err
+}
+
+// ListHookDeliveriesIterator walks all stored deliveries of a webhook, from
+// the newest. Use an empty repo for organization webhooks.
+func (c *GitHubClient) ListHookDeliveriesIterator(org, repo string, hookID int64) *Iterator[HookDelivery] {
+	return Paginate[HookDelivery](c, hookPath(org, repo, hookID)+"/deliveries", nil)
+}
+
+func (c *GitHubClient) GetHookDelivery(ctx context.Context, org, repo string, hookID, deliveryID int64) (*HookDelivery, error) {
+	path := fmt.Sprintf("%s/deliveries/%d", hookPath(org, repo, hookID), deliveryID)
+	var res HookDelivery
+	err := c.api.Do(ctx, "GET", path, httpclient.WithResponseUnmarshal(&res))
+	return &res, err
+}
+
+// SignPayload computes X-Hub-Signature-256 header value for the payload.
+func SignPayload(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+type ReplayOptions struct {
+	// URL of the locally running hooks server, like http://localhost:8080/hooks
+	URL string
+
+	// Secret re-signs payloads, as the production secret is unknown to
+	// developers. Payloads are sent unsigned, if it's empty.
+	Secret string
+
+	// Events to replay, like "pull_request". Default is all events.
+	Events []string
+
+	// Limit is the number of most recent deliveries. Default is 30.
+	Limit int
+
+	// HTTPClient defaults to http.DefaultClient.
+	HTTPClient *http.Client
+}
+
+type ReplayResult struct {
+	Delivery   HookDelivery
+	StatusCode int
+	Err        error
+}
+
+// ReplayHookDeliveries fetches stored deliveries of a webhook and sends them
+// to a local server in the order GitHub delivered them, so that bots can be
+// developed without exposing a public URL. Failures of individual deliveries
+// are reported in results.
+func (c *GitHubClient) ReplayHookDeliveries(ctx context.Context, org, repo string, hookID int64, opts ReplayOptions) ([]ReplayResult, error) {
+	if opts.Limit == 0 {
+		opts.Limit = 30
+	}
+	if opts.HTTPClient == nil {
+		opts.HTTPClient = http.DefaultClient
+	}
+	deliveries, err := c.ListHookDeliveries(ctx, org, repo, hookID, opts.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("deliveries: %w", err)
+	}
+	// deliveries are listed from the newest
+	slices.Reverse(deliveries)
+	var results []ReplayResult
+	for _, v := range deliveries {
+		if len(opts.Events) > 0 && !slices.Contains(opts.Events, v.Event) {
+			continue
+		}
+		delivery, err := c.GetHookDelivery(ctx, org, repo, hookID, v.ID)
+		if err != nil {
+			return results, fmt.Errorf("delivery %d: %w", v.ID, err)
+		}
+		status, err := ReplayDelivery(ctx, opts.HTTPClient, opts.URL, opts.Secret, delivery)
+		if err != nil {
+			logger.Warnf(ctx, "Replay of %s (%s) failed: %s", delivery.Event, delivery.GUID, err)
+		}
+		results = append(results, ReplayResult{
+			Delivery:   *delivery,
+			StatusCode: status,
+			Err:        err,
+		})
+	}
+	return results, nil
+}
+
+// ReplayDelivery sends a single delivery with its original headers, except
+// the signatures, which are recomputed with the secret.
+func ReplayDelivery(ctx context.Context, client *http.Client, url, secret string, delivery *HookDelivery) (int, error) {
+	if delivery.Request == nil {
+		return 0, fmt.Errorf("delivery %d has no request", delivery.ID)
+	}
+	payload := []byte(delivery.Request.Payload)
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(payload))
+	if err != nil {
+		return 0, err
+	}
+	for k, v := range delivery.Request.Headers {
+		req.Header.Set(k, v)
+	}
+	req.Header.Del("X-Hub-Signature")
+	req.Header.Del("X-Hub-Signature-256")
+	if secret != "" {
+		req.Header.Set("X-Hub-Signature-256", SignPayload(secret, payload))
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-GitHub-Event", delivery.Event)
+	req.Header.Set("X-GitHub-Delivery", delivery.GUID)
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("%s", resp.Status)
+	}
+	return resp.StatusCode, nil
+}
@@ -0,0 +1,54 @@
+package github
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReplayHookDeliveries(t *testing.T) {
+	var received []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		assert.Equal(t, SignPayload("dev", body), r.Header.Get("X-Hub-Signature-256"))
+		assert.Empty(t, r.Header.Get("X-Hub-Signature"))
+		received = append(received, r.Header.Get("X-GitHub-Event")+" "+string(body))
+		w.WriteHeader(202)
+	}))
+	defer server.Close()
+
+	client := NewClient(&GitHubConfig{
+		GitHubTokenSource: GitHubTokenSource{Pat: "x"},
+		transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			switch r.URL.Path {
+			case "/repos/a/b/hooks/1/deliveries":
+				return jsonResponse(200, `[{"id": 3, "event": "push"},
+					{"id": 2, "event": "pull_request"}, {"id": 1, "event": "issues"}]`), nil
+			case "/repos/a/b/hooks/1/deliveries/1":
+				return jsonResponse(200, `{"id": 1, "guid": "g1", "event": "issues", "request": {
+					"headers": {"X-Hub-Signature": "sha1=old"}, "payload": {"action": "opened"}}}`), nil
+			case "/repos/a/b/hooks/1/deliveries/2":
+				return jsonResponse(200, `{"id": 2, "guid": "g2", "event": "pull_request", "request": {
+					"payload": {"action": "closed"}}}`), nil
+			}
+			return jsonResponse(404, `{"message": "Not Found"}`), nil
+		}),
+	})
+	results, err := client.ReplayHookDeliveries(context.Background(), "a", "b", 1, ReplayOptions{
+		URL:    server.URL,
+		Secret: "dev",
+		Events: []string{"issues", "pull_request"},
+	})
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	assert.Equal(t, 202, results[0].StatusCode)
+	assert.Equal(t, []string{
+		`issues {"action": "opened"}`,
+		`pull_request {"action": "closed"}`,
+	}, received)
+}
@@ -0,0 +1,128 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Ensure* variants of Create* methods return an existing equivalent resource
+// instead of failing or creating a duplicate, so that automation can be
+// safely retried after partial failures. Lookups happen before creation and
+// again after validation errors, which are returned when a concurrent run
+// has created the resource in between.
+
+// EnsureRelease returns the release of the tag, including a draft one, or
+// creates it.
+func (c *GitHubClient) EnsureRelease(ctx context.Context, org, repo string, req CreateReleaseRequest) (*Release, error) {
+	existing, err := c.findRelease(ctx, org, repo, req.TagName)
+	if err != nil {
+		return nil, fmt.Errorf("find: %w", err)
+	}
+	if existing != nil {
+		return existing, nil
+	}
+	release, err := c.CreateRelease(ctx, org, repo, req)
+	if errors.Is(err, ErrValidation) {
+		existing, findErr := c.findRelease(ctx, org, repo, req.TagName)
+		if findErr == nil && existing != nil {
+			return existing, nil
+		}
+	}
+	return release, err
+}
+
+func (c *GitHubClient) findRelease(ctx context.Context, org, repo, tag string) (*Release, error) {
+	release, err := c.GetReleaseByTag(ctx, org, repo, tag)
+	if err == nil {
+		return release, nil
+	}
+	if !errors.Is(err, ErrNotFound) {
+		return nil, err
+	}
+	// drafts are not associated with tags yet
+	it := c.VersionsIterator(org, repo)
+	for it.HasNext(ctx) {
+		v, err := it.Next(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if v.Draft && v.Version == tag {
+			return &v, nil
+		}
+	}
+	return nil, nil
+}
+
+// EnsurePullRequest returns the open pull request from the head branch to
+// the base branch, or creates it.
+func (c *GitHubClient) EnsurePullRequest(ctx context.Context, org, repo string, req NewPullRequest) (*PullRequest, error) {
+	existing, err := c.findPullRequest(ctx, org, repo, req)
+	if err != nil {
+		return nil, fmt.Errorf("find: %w", err)
+	}
+	if existing != nil {
+		return existing, nil
+	}
+	pr, err := c.CreatePullRequest(ctx, org, repo, req)
+	if errors.Is(err, ErrValidation) {
+		existing, findErr := c.findPullRequest(ctx, org, repo, req)
+		if findErr == nil && existing != nil {
+			return existing, nil
+		}
+	}
+	return pr, err
+}
+
+func (c *GitHubClient) findPullRequest(ctx context.Context, org, repo string, req NewPullRequest) (*PullRequest, error) {
+	head := req.Head
+	if !strings.Contains(head, ":") {
+		head = fmt.Sprintf("%s:%s", org, head)
+	}
+	prs, err := c.ListPullRequests(ctx, org, repo, PullRequestListOptions{
+		State: "open",
+		Head:  head,
+		Base:  req.Base,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(prs) == 0 {
+		return nil, nil
+	}
+	return &prs[0], nil
+}
+
+// EnsureIssue returns the open issue with the marker, hidden in its body,
+// or creates it with the marker. Markers are like the ones of UpsertComment.
+func (c *GitHubClient) EnsureIssue(ctx context.Context, org, repo, marker string, req NewIssue) (*Issue, error) {
+	existing, err := c.FindIssue(ctx, org, repo, marker)
+	if err != nil {
+		return nil, fmt.Errorf("find: %w", err)
+	}
+	if existing != nil {
+		return existing, nil
+	}
+	req.Body = fmt.Sprintf("%s\n%s", stickyMarker(marker), req.Body)
+	return c.CreateIssue(ctx, org, repo, req)
+}
+
+// FindIssue returns the open issue with the marker of EnsureIssue, or nil,
+// if there's none. Pull requests are not considered.
+func (c *GitHubClient) FindIssue(ctx context.Context, org, repo, marker string) (*Issue, error) {
+	it := c.ListIssuesIterator(org, repo, IssueListOptions{State: "open"})
+	for it.HasNext(ctx) {
+		v, err := it.Next(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if v.PullRequest != nil {
+			continue
+		}
+		if strings.Contains(v.Body, stickyMarker(marker)) {
+			return &v, nil
+		}
+	}
+	return nil, nil
+}
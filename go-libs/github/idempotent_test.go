@@ -0,0 +1,105 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnsureReleaseFindsDraft(t *testing.T) {
+	client := NewClient(&GitHubConfig{
+		GitHubTokenSource: GitHubTokenSource{Pat: "x"},
+		transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			switch r.Method + " " + r.URL.Path {
+			case "GET /repos/a/b/releases/tags/v1.0.0":
+				return jsonResponse(404, `{"message": "Not Found"}`), nil
+			case "GET /repos/a/b/releases":
+				return jsonResponse(200, `[{"id": 2, "tag_name": "v1.1.0", "draft": true},
+					{"id": 1, "tag_name": "v1.0.0", "draft": true}]`), nil
+			}
+			t.Fatalf("unexpected %s %s", r.Method, r.URL.Path)
+			return nil, nil
+		}),
+	})
+	release, err := client.EnsureRelease(context.Background(), "a", "b", CreateReleaseRequest{
+		TagName: "v1.0.0",
+		Draft:   true,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), release.ID)
+}
+
+func TestEnsurePullRequestAfterConcurrentCreate(t *testing.T) {
+	var lookups int
+	client := NewClient(&GitHubConfig{
+		GitHubTokenSource: GitHubTokenSource{Pat: "x"},
+		transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			switch r.Method + " " + r.URL.Path {
+			case "GET /repos/a/b/pulls":
+				assert.Equal(t, "a:feature", r.URL.Query().Get("head"))
+				assert.Equal(t, "main", r.URL.Query().Get("base"))
+				lookups++
+				if lookups == 1 {
+					return jsonResponse(200, `[]`), nil
+				}
+				return jsonResponse(200, `[{"number": 7}]`), nil
+			case "POST /repos/a/b/pulls":
+				return jsonResponse(422, `{"message": "Validation Failed", "errors": [
+					{"resource": "PullRequest", "code": "custom", "message": "A pull request already exists for a:feature."}
+				]}`), nil
+			}
+			t.Fatalf("unexpected %s %s", r.Method, r.URL.Path)
+			return nil, nil
+		}),
+	})
+	pr, err := client.EnsurePullRequest(context.Background(), "a", "b", NewPullRequest{
+		Title: "Feature",
+		Head:  "feature",
+		Base:  "main",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 7, pr.Number)
+	assert.Equal(t, 2, lookups)
+}
+
+func TestEnsureIssue(t *testing.T) {
+	var created map[string]any
+	client := NewClient(&GitHubConfig{
+		GitHubTokenSource: GitHubTokenSource{Pat: "x"},
+		transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			switch r.Method + " " + r.URL.Path {
+			case "GET /repos/a/b/issues":
+				if created == nil {
+					return jsonResponse(200, `[{"number": 1, "body": "<!-- nightly -->", "pull_request": {}}]`), nil
+				}
+				return jsonResponse(200, `[{"number": 2, "body": "<!-- nightly -->\nFailed"}]`), nil
+			case "POST /repos/a/b/issues":
+				raw, err := io.ReadAll(r.Body)
+				require.NoError(t, err)
+				require.NoError(t, json.Unmarshal(raw, &created))
+				return jsonResponse(201, `{"number": 2}`), nil
+			}
+			t.Fatalf("unexpected %s %s", r.Method, r.URL.Path)
+			return nil, nil
+		}),
+	})
+	ctx := context.Background()
+	issue, err := client.EnsureIssue(ctx, "a", "b", "nightly", NewIssue{
+		Title: "Nightly build failed",
+		Body:  "Failed",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 2, issue.Number)
+	assert.Equal(t, "<!-- nightly -->\nFailed", created["body"])
+
+	issue, err = client.EnsureIssue(ctx, "a", "b", "nightly", NewIssue{
+		Title: "Nightly build failed",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 2, issue.Number)
+}
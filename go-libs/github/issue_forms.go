@@ -0,0 +1,230 @@
+package github
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// noResponse is rendered by GitHub for optional fields left empty
+const noResponse = "_No response_"
+
+// IssueFormField is the response to a single element of an issue form,
+// as rendered into the issue body under a "### Label" heading.
+type IssueFormField struct {
+	Label string
+
+	// Value is the text under the heading, which is empty for fields
+	// without a response.
+	Value string
+
+	// Checked and Unchecked are options of checkboxes elements.
+	Checked   []string
+	Unchecked []string
+}
+
+type IssueFormResponse []IssueFormField
+
+func (r IssueFormResponse) Field(label string) (*IssueFormField, bool) {
+	for i, v := range r {
+		if strings.EqualFold(v.Label, label) {
+			return &r[i], true
+		}
+	}
+	return nil, false
+}
+
+// Get returns the value of a field by its label.
+func (r IssueFormResponse) Get(label string) (string, bool) {
+	field, ok := r.Field(label)
+	if !ok {
+		return "", false
+	}
+	return field.Value, true
+}
+
+var checkboxLine = regexp.MustCompile(`^- \[([ xX])\] (.*)$`)
+
+// ParseIssueForm parses the body of an issue created from an issue form.
+// Headings inside fenced code blocks, like logs, are not fields.
+func ParseIssueForm(body string) IssueFormResponse {
+	var fields IssueFormResponse
+	var current *IssueFormField
+	var lines []string
+	inFence := false
+	flush := func() {
+		if current == nil {
+			return
+		}
+		value := strings.TrimSpace(strings.Join(lines, "\n"))
+		if value == noResponse {
+			value = ""
+		}
+		current.Value = value
+		parseCheckboxes(current)
+		fields = append(fields, *current)
+		lines = nil
+	}
+	for _, line := range strings.Split(strings.ReplaceAll(body, "\r\n", "\n"), "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			inFence = !inFence
+		}
+		if !inFence && strings.HasPrefix(line, "### ") {
+			flush()
+			current = &IssueFormField{
+				Label: strings.TrimSpace(strings.TrimPrefix(line, "### ")),
+			}
+			continue
+		}
+		lines = append(lines, line)
+	}
+	flush()
+	return fields
+}
+
+func parseCheckboxes(field *IssueFormField) {
+	if field.Value == "" {
+		return
+	}
+	var checked, unchecked []string
+	for _, line := range strings.Split(field.Value, "\n") {
+		match := checkboxLine.FindStringSubmatch(strings.TrimSpace(line))
+		if match == nil {
+			// not a checkboxes element
+			return
+		}
+		if match[1] == " " {
+			unchecked = append(unchecked, match[2])
+		} else {
+			checked = append(checked, match[2])
+		}
+	}
+	field.Checked, field.Unchecked = checked, unchecked
+}
+
+// IssueFormTemplate is an issue form from .github/ISSUE_TEMPLATE.
+// See https://docs.github.com/en/communities/using-templates-to-encourage-useful-issues-and-pull-requests/syntax-for-issue-forms
+type IssueFormTemplate struct {
+	Name        string             `yaml:"name"`
+	Description string             `yaml:"description"`
+	Title       string             `yaml:"title,omitempty"`
+	Labels      []string           `yaml:"labels,omitempty"`
+	Assignees   []string           `yaml:"assignees,omitempty"`
+	Body        []IssueFormElement `yaml:"body"`
+}
+
+type IssueFormElement struct {
+	Type        string               `yaml:"type"` // markdown, input, textarea, dropdown, checkboxes
+	ID          string               `yaml:"id,omitempty"`
+	Attributes  IssueFormAttributes  `yaml:"attributes"`
+	Validations IssueFormValidations `yaml:"validations,omitempty"`
+}
+
+type IssueFormAttributes struct {
+	Label    string            `yaml:"label,omitempty"`
+	Multiple bool              `yaml:"multiple,omitempty"`
+	Options  []IssueFormOption `yaml:"options,omitempty"`
+}
+
+type IssueFormValidations struct {
+	Required bool `yaml:"required,omitempty"`
+}
+
+// IssueFormOption is a plain string option of a dropdown or a label and
+// a required flag of a checkbox.
+type IssueFormOption struct {
+	Label    string `yaml:"label"`
+	Required bool   `yaml:"required,omitempty"`
+}
+
+func (o *IssueFormOption) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.ScalarNode {
+		return value.Decode(&o.Label)
+	}
+	type plain IssueFormOption
+	return value.Decode((*plain)(o))
+}
+
+func ParseIssueFormTemplate(raw []byte) (*IssueFormTemplate, error) {
+	var tmpl IssueFormTemplate
+	err := yaml.Unmarshal(raw, &tmpl)
+	if err != nil {
+		return nil, fmt.Errorf("issue form: %w", err)
+	}
+	return &tmpl, nil
+}
+
+// Validate checks, that required fields have responses, dropdown responses
+// are among the options, and required checkboxes are checked.
+func (t *IssueFormTemplate) Validate(response IssueFormResponse) error {
+	var errs []error
+	for _, element := range t.Body {
+		if element.Type == "markdown" {
+			continue
+		}
+		label := element.Attributes.Label
+		field, ok := response.Field(label)
+		if !ok || field.Value == "" {
+			if element.Validations.Required {
+				errs = append(errs, fmt.Errorf("%s: response is required", label))
+			}
+			continue
+		}
+		switch element.Type {
+		case "dropdown":
+			values := []string{field.Value}
+			if element.Attributes.Multiple {
+				values = strings.Split(field.Value, ", ")
+			}
+			for _, v := range values {
+				if !element.hasOption(v) {
+					errs = append(errs, fmt.Errorf("%s: unknown option: %s", label, v))
+				}
+			}
+		case "checkboxes":
+			for _, option := range element.Attributes.Options {
+				if !option.Required {
+					continue
+				}
+				if !slices.Contains(field.Checked, option.Label) {
+					errs = append(errs, fmt.Errorf("%s: must be checked: %s", label, option.Label))
+				}
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (e IssueFormElement) hasOption(value string) bool {
+	for _, option := range e.Attributes.Options {
+		if option.Label == value {
+			return true
+		}
+	}
+	return false
+}
+
+// Values maps responses to the ids of form elements, falling back to labels
+// for elements without an id, so that bots don't depend on label wording.
+func (t *IssueFormTemplate) Values(response IssueFormResponse) map[string]string {
+	values := map[string]string{}
+	for _, element := range t.Body {
+		if element.Type == "markdown" {
+			continue
+		}
+		field, ok := response.Field(element.Attributes.Label)
+		if !ok {
+			continue
+		}
+		key := element.ID
+		if key == "" {
+			key = element.Attributes.Label
+		}
+		values[key] = field.Value
+	}
+	return values
+}
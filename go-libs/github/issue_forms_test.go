@@ -0,0 +1,65 @@
+package github
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const bugReport = "### Version\n\nv0.1.0\n\n### Cloud\n\nAWS, Azure\n\n" +
+	"### Logs\n\n```shell\n### not a heading\nfailed\n```\n\n" +
+	"### Extra\n\n_No response_\n\n" +
+	"### Checks\n\n- [X] I searched for duplicates\n- [ ] I read the docs"
+
+func TestParseIssueForm(t *testing.T) {
+	response := ParseIssueForm(bugReport)
+	assert.Len(t, response, 5)
+
+	version, ok := response.Get("version")
+	assert.True(t, ok)
+	assert.Equal(t, "v0.1.0", version)
+
+	logs, _ := response.Get("Logs")
+	assert.Equal(t, "```shell\n### not a heading\nfailed\n```", logs)
+
+	extra, ok := response.Get("Extra")
+	assert.True(t, ok)
+	assert.Equal(t, "", extra)
+
+	checks, _ := response.Field("Checks")
+	assert.Equal(t, []string{"I searched for duplicates"}, checks.Checked)
+	assert.Equal(t, []string{"I read the docs"}, checks.Unchecked)
+}
+
+func TestIssueFormTemplateValidate(t *testing.T) {
+	tmpl := &IssueFormTemplate{
+		Body: []IssueFormElement{
+			{Type: "markdown"},
+			{Type: "input", ID: "version", Attributes: IssueFormAttributes{Label: "Version"},
+				Validations: IssueFormValidations{Required: true}},
+			{Type: "dropdown", ID: "cloud", Attributes: IssueFormAttributes{
+				Label: "Cloud", Multiple: true,
+				Options: []IssueFormOption{{Label: "AWS"}, {Label: "GCP"}},
+			}},
+			{Type: "textarea", Attributes: IssueFormAttributes{Label: "Extra"},
+				Validations: IssueFormValidations{Required: true}},
+			{Type: "checkboxes", ID: "checks", Attributes: IssueFormAttributes{
+				Label: "Checks",
+				Options: []IssueFormOption{
+					{Label: "I searched for duplicates", Required: true},
+					{Label: "I read the docs", Required: true},
+				},
+			}},
+		},
+	}
+	response := ParseIssueForm(bugReport)
+	err := tmpl.Validate(response)
+	assert.EqualError(t, err, "Cloud: unknown option: Azure\n"+
+		"Extra: response is required\n"+
+		"Checks: must be checked: I read the docs")
+
+	values := tmpl.Values(response)
+	assert.Equal(t, "v0.1.0", values["version"])
+	assert.Equal(t, "AWS, Azure", values["cloud"])
+	assert.Equal(t, "", values["Extra"])
+}
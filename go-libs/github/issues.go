@@ -0,0 +1,182 @@
+package github
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/databricks/databricks-sdk-go/httpclient"
+)
+
+// Lock reasons accepted by LockIssue. Empty reason locks without one.
+const (
+	LockReasonOffTopic  = "off-topic"
+	LockReasonTooHeated = "too heated"
+	LockReasonResolved  = "resolved"
+	LockReasonSpam      = "spam"
+)
+
+// LockIssue locks the conversation of an issue or a pull request, so that only
+// collaborators can comment on it.
+func (c *GitHubClient) LockIssue(ctx context.Context, org, repo string, number int, reason string) error {
+	path := fmt.Sprintf("%s/repos/%s/%s/issues/%d/lock", gitHubAPI, org, repo, number)
+	var body struct {
+		LockReason string `json:"lock_reason,omitempty"`
+	}
+	body.LockReason = reason
+	return c.api.Do(ctx, "PUT", path, httpclient.WithRequestData(body))
+}
+
+func (c *GitHubClient) UnlockIssue(ctx context.Context, org, repo string, number int) error {
+	path := fmt.Sprintf("%s/repos/%s/%s/issues/%d/lock", gitHubAPI, org, repo, number)
+	return c.api.Do(ctx, "DELETE", path)
+}
+
+// PinIssue pins the issue to the repository issues page. There's no REST
+// equivalent, so this goes through GraphQL.
+func (c *GitHubClient) PinIssue(ctx context.Context, org, repo string, number int) error {
+	issueID, err := c.issueNodeID(ctx, org, repo, number)
+	if err != nil {
+		return fmt.Errorf("issue: %w", err)
+	}
+	return c.GraphQL(ctx, `mutation($issueId: ID!) {
+		pinIssue(input: {issueId: $issueId}) { issue { id } }
+	}`, map[string]any{"issueId": issueID}, nil)
+}
+
+func (c *GitHubClient) UnpinIssue(ctx context.Context, org, repo string, number int) error {
+	issueID, err := c.issueNodeID(ctx, org, repo, number)
+	if err != nil {
+		return fmt.Errorf("issue: %w", err)
+	}
+	return c.GraphQL(ctx, `mutation($issueId: ID!) {
+		unpinIssue(input: {issueId: $issueId}) { issue { id } }
+	}`, map[string]any{"issueId": issueID}, nil)
+}
+
+type TransferredIssue struct {
+	Number  int    `json:"number"`
+	HTMLURL string `json:"url"`
+}
+
+// TransferIssue moves an issue to another repository owned by the same user or
+// organization and returns its number and address in the target repository.
+func (c *GitHubClient) TransferIssue(ctx context.Context, org, repo string, number int, targetRepo string) (*TransferredIssue, error) {
+	issueID, err := c.issueNodeID(ctx, org, repo, number)
+	if err != nil {
+		return nil, fmt.Errorf("issue: %w", err)
+	}
+	repoID, err := c.repoNodeID(ctx, org, targetRepo)
+	if err != nil {
+		return nil, fmt.Errorf("target repo: %w", err)
+	}
+	var res struct {
+		TransferIssue struct {
+			Issue TransferredIssue `json:"issue"`
+		} `json:"transferIssue"`
+	}
+	err = c.GraphQL(ctx, `mutation($issueId: ID!, $repositoryId: ID!) {
+		transferIssue(input: {issueId: $issueId, repositoryId: $repositoryId}) {
+			issue { number url }
+		}
+	}`, map[string]any{
+		"issueId":      issueID,
+		"repositoryId": repoID,
+	}, &res)
+	if err != nil {
+		return nil, err
+	}
+	return &res.TransferIssue.Issue, nil
+}
+
+type NewIssue struct {
+	Title     string   `json:"title"`
+	Body      string   `json:"body,omitempty"`
+	Labels    []string `json:"labels,omitempty"`
+	Assignees []string `json:"assignees,omitempty"`
+}
+
+func (c *GitHubClient) CreateIssue(ctx context.Context, org, repo string, req NewIssue) (*Issue, error) {
+	path := fmt.Sprintf("%s/repos/%s/%s/issues", gitHubAPI, org, repo)
+	var res Issue
+	err := c.api.Do(ctx, "POST", path,
+		httpclient.WithRequestData(req),
+		httpclient.WithResponseUnmarshal(&res))
+	return &res, err
+}
+
+func (c *GitHubClient) GetIssue(ctx context.Context, org, repo string, number int) (*Issue, error) {
+	path := fmt.Sprintf("%s/repos/%s/%s/issues/%d", gitHubAPI, org, repo, number)
+	var res Issue
+	err := c.api.Do(ctx, "GET", path, httpclient.WithResponseUnmarshal(&res))
+	return &res, err
+}
+
+// IssueUpdate changes only the fields, that are set. Empty Labels or Assignees
+// slices remove all labels or assignees, unlike nil ones.
+type IssueUpdate struct {
+	Title *string `json:"title,omitempty"`
+	Body  *string `json:"body,omitempty"`
+
+	// State is either open or closed.
+	State string `json:"state,omitempty"`
+
+	// StateReason is one of: completed, not_planned, reopened.
+	StateReason string    `json:"state_reason,omitempty"`
+	Labels      *[]string `json:"labels,omitempty"`
+	Assignees   *[]string `json:"assignees,omitempty"`
+}
+
+func (c *GitHubClient) UpdateIssue(ctx context.Context, org, repo string, number int, req IssueUpdate) (*Issue, error) {
+	path := fmt.Sprintf("%s/repos/%s/%s/issues/%d", gitHubAPI, org, repo, number)
+	var res Issue
+	err := c.api.Do(ctx, "PATCH", path,
+		httpclient.WithRequestData(req),
+		httpclient.WithResponseUnmarshal(&res))
+	return &res, err
+}
+
+// CloseIssue closes an issue as completed, or as not_planned.
+func (c *GitHubClient) CloseIssue(ctx context.Context, org, repo string, number int, reason string) (*Issue, error) {
+	if reason == "" {
+		reason = "completed"
+	}
+	return c.UpdateIssue(ctx, org, repo, number, IssueUpdate{
+		State:       "closed",
+		StateReason: reason,
+	})
+}
+
+type IssueListOptions struct {
+	// State is one of: open, closed, all. Default is open.
+	State string `url:"state,omitempty"`
+
+	// Labels is a comma-separated list of label names.
+	Labels    string `url:"labels,omitempty"`
+	Creator   string `url:"creator,omitempty"`
+	Assignee  string `url:"assignee,omitempty"`
+	Mentioned string `url:"mentioned,omitempty"`
+
+	// Milestone is a number, "*" for any milestone, or "none".
+	Milestone string `url:"milestone,omitempty"`
+	Sort      string `url:"sort,omitempty"`
+	Direction string `url:"direction,omitempty"`
+	Page      int    `url:"page,omitempty"`
+	PerPage   int    `url:"per_page,omitempty"`
+}
+
+// ListIssues lists a page of issues of the repository. Pull requests are
+// issues for GitHub, so they are returned as well, with PullRequest set.
+func (c *GitHubClient) ListIssues(ctx context.Context, org, repo string, opts IssueListOptions) ([]Issue, error) {
+	path := fmt.Sprintf("%s/repos/%s/%s/issues", gitHubAPI, org, repo)
+	var res []Issue
+	err := c.api.Do(ctx, "GET", path,
+		httpclient.WithRequestData(opts),
+		httpclient.WithResponseUnmarshal(&res))
+	return res, err
+}
+
+// ListIssuesIterator walks issues and pull requests, starting from opts.Page.
+func (c *GitHubClient) ListIssuesIterator(org, repo string, opts IssueListOptions) *Iterator[Issue] {
+	path := fmt.Sprintf("%s/repos/%s/%s/issues", gitHubAPI, org, repo)
+	return Paginate[Issue](c, path, opts)
+}
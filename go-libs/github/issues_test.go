@@ -0,0 +1,65 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateIssueSendsOnlySetFields(t *testing.T) {
+	var sent []map[string]any
+	client := NewClient(&GitHubConfig{
+		GitHubTokenSource: GitHubTokenSource{Pat: "x"},
+		transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			assert.Equal(t, "PATCH", r.Method)
+			assert.Equal(t, "/repos/a/b/issues/7", r.URL.Path)
+			raw, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			var body map[string]any
+			require.NoError(t, json.Unmarshal(raw, &body))
+			sent = append(sent, body)
+			return jsonResponse(200, `{"number": 7, "state": "closed", "state_reason": "not_planned"}`), nil
+		}),
+	})
+	ctx := context.Background()
+	title := "Release v1.2.3"
+	_, err := client.UpdateIssue(ctx, "a", "b", 7, IssueUpdate{
+		Title:  &title,
+		Labels: &[]string{},
+	})
+	require.NoError(t, err)
+	issue, err := client.CloseIssue(ctx, "a", "b", 7, "not_planned")
+	require.NoError(t, err)
+	assert.Equal(t, "closed", issue.State)
+	assert.Equal(t, []map[string]any{
+		{"title": "Release v1.2.3", "labels": []any{}},
+		{"state": "closed", "state_reason": "not_planned"},
+	}, sent)
+}
+
+func TestListIssuesFilters(t *testing.T) {
+	client := NewClient(&GitHubConfig{
+		GitHubTokenSource: GitHubTokenSource{Pat: "x"},
+		transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			q := r.URL.Query()
+			assert.Equal(t, "all", q.Get("state"))
+			assert.Equal(t, "release,tracking", q.Get("labels"))
+			assert.Equal(t, "bot", q.Get("assignee"))
+			return jsonResponse(200, `[{"number": 1}, {"number": 2, "pull_request": {}}]`), nil
+		}),
+	})
+	issues, err := client.ListIssues(context.Background(), "a", "b", IssueListOptions{
+		State:    "all",
+		Labels:   "release,tracking",
+		Assignee: "bot",
+	})
+	require.NoError(t, err)
+	require.Len(t, issues, 2)
+	assert.Nil(t, issues[0].PullRequest)
+	assert.NotNil(t, issues[1].PullRequest)
+}
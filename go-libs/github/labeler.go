@@ -0,0 +1,119 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/databricks/databricks-sdk-go/logger"
+	"github.com/databrickslabs/sandbox/go-libs/fileset"
+	"gopkg.in/yaml.v3"
+)
+
+// LabelerConfig maps labels to gitignore-style path patterns, like:
+//
+//	docs: ["docs", "*.md"]
+//	github: [".github"]
+//	core: ["internal", "!internal/testdata"]
+//
+// Patterns match files and everything in matching directories, with or
+// without a trailing slash, as only paths of files are known. The last
+// matching pattern of a label decides, so negated patterns exclude paths
+// matched by earlier patterns.
+type LabelerConfig map[string][]string
+
+func ParseLabelerConfig(raw []byte) (LabelerConfig, error) {
+	var config LabelerConfig
+	err := yaml.Unmarshal(raw, &config)
+	if err != nil {
+		return nil, fmt.Errorf("labeler: %w", err)
+	}
+	return config, nil
+}
+
+// Labeler applies labels to pull requests based on paths of changed files,
+// like actions/labeler. Labels are only added, so that labels applied by
+// people are never removed.
+type Labeler struct {
+	Client *GitHubClient
+	Config LabelerConfig
+}
+
+// Labels returns sorted labels, that match any of the files. Renamed files
+// match by both their old and new names.
+func (l *Labeler) Labels(files []CommitFile) []string {
+	var labels []string
+	for label, patterns := range l.Config {
+		ignore := &fileset.Ignore{}
+		for _, pattern := range patterns {
+			ignore.AddPatterns("", strings.TrimSuffix(pattern, "/"))
+		}
+		for _, f := range files {
+			if ignore.Ignored(f.Filename, false) || (f.PreviousFilename != "" && ignore.Ignored(f.PreviousFilename, false)) {
+				labels = append(labels, label)
+				break
+			}
+		}
+	}
+	sort.Strings(labels)
+	return labels
+}
+
+// LabelPullRequest adds missing labels to the pull request and returns them.
+func (l *Labeler) LabelPullRequest(ctx context.Context, org, repo string, pr *PullRequest) ([]string, error) {
+	files, err := l.Client.ListPullRequestFiles(ctx, org, repo, pr.Number)
+	if err != nil {
+		return nil, fmt.Errorf("files: %w", err)
+	}
+	var missing []string
+	for _, label := range l.Labels(files) {
+		if slices.ContainsFunc(pr.Labels, func(v Label) bool { return v.Name == label }) {
+			continue
+		}
+		missing = append(missing, label)
+	}
+	if len(missing) == 0 {
+		return nil, nil
+	}
+	_, err = l.Client.AddLabels(ctx, org, repo, pr.Number, missing...)
+	if err != nil {
+		return nil, fmt.Errorf("add labels: %w", err)
+	}
+	logger.Infof(ctx, "Labeled %s/%s#%d with %v", org, repo, pr.Number, missing)
+	return missing, nil
+}
+
+// HandlePullRequestEvent labels pull requests from pull_request webhook
+// events, when they are opened or get new commits.
+func (l *Labeler) HandlePullRequestEvent(ctx context.Context, org, repo string, event *PullRequestEventPayload) ([]string, error) {
+	switch event.Action {
+	case "opened", "reopened", "synchronize", "ready_for_review":
+		return l.LabelPullRequest(ctx, org, repo, &event.PullRequest)
+	default:
+		return nil, nil
+	}
+}
+
+// LabelOpenPullRequests labels all open pull requests of the repository in
+// a batch, like on a schedule or after changes to the config. Returns added
+// labels by pull request number.
+func (l *Labeler) LabelOpenPullRequests(ctx context.Context, org, repo string) (map[int][]string, error) {
+	it := l.Client.ListPullRequestsIterator(org, repo, PullRequestListOptions{State: "open"})
+	added := map[int][]string{}
+	for it.HasNext(ctx) {
+		pr, err := it.Next(ctx)
+		if err != nil {
+			return added, fmt.Errorf("pull requests: %w", err)
+		}
+		labels, err := l.LabelPullRequest(ctx, org, repo, &pr)
+		if err != nil {
+			return added, fmt.Errorf("#%d: %w", pr.Number, err)
+		}
+		if len(labels) > 0 {
+			added[pr.Number] = labels
+		}
+	}
+	return added, nil
+}
@@ -0,0 +1,71 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLabelerLabels(t *testing.T) {
+	l := &Labeler{Config: LabelerConfig{
+		"docs":   {"docs/", "*.md"},
+		"github": {".github"},
+		"core":   {"internal", "!internal/testdata"},
+	}}
+	assert.Equal(t, []string{"docs"}, l.Labels([]CommitFile{
+		{Filename: "README.md"},
+		{Filename: "internal/testdata/a.txt"},
+	}))
+	assert.Equal(t, []string{"core", "github"}, l.Labels([]CommitFile{
+		{Filename: "internal/x/y.go"},
+		{Filename: "ci/push.yml", PreviousFilename: ".github/workflows/push.yml"},
+	}))
+}
+
+func TestLabelerAddsOnlyMissingLabels(t *testing.T) {
+	var added []string
+	client := NewClient(&GitHubConfig{
+		GitHubTokenSource: GitHubTokenSource{Pat: "x"},
+		transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			switch r.Method + " " + r.URL.Path {
+			case "GET /repos/a/b/pulls/3/files":
+				return jsonResponse(200, `[{"filename": "docs/index.md"}, {"filename": "main.go"}]`), nil
+			case "POST /repos/a/b/issues/3/labels":
+				raw, err := io.ReadAll(r.Body)
+				require.NoError(t, err)
+				var body struct {
+					Labels []string `json:"labels"`
+				}
+				require.NoError(t, json.Unmarshal(raw, &body))
+				added = body.Labels
+				return jsonResponse(200, `[]`), nil
+			}
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+			return nil, nil
+		}),
+	})
+	l := &Labeler{
+		Client: client,
+		Config: LabelerConfig{"docs": {"docs"}, "go": {"*.go"}},
+	}
+	ctx := context.Background()
+	labels, err := l.HandlePullRequestEvent(ctx, "a", "b", &PullRequestEventPayload{
+		Action: "opened",
+		PullRequest: PullRequest{
+			Number: 3,
+			Labels: []Label{{Name: "docs"}},
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"go"}, labels)
+	assert.Equal(t, []string{"go"}, added)
+
+	labels, err = l.HandlePullRequestEvent(ctx, "a", "b", &PullRequestEventPayload{Action: "closed"})
+	require.NoError(t, err)
+	assert.Nil(t, labels)
+}
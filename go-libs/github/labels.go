@@ -1,11 +1,32 @@
 package github
 
-type Label struct {
-	ID          int64  `json:"id,omitempty"`
-	URL         string `json:"url,omitempty"`
-	Name        string `json:"name,omitempty"`
-	Color       string `json:"color,omitempty"`
-	Description string `json:"description,omitempty"`
-	Default     bool   `json:"default,omitempty"`
-	NodeID      string `json:"node_id,omitempty"`
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/databricks/databricks-sdk-go/httpclient"
+)
+
+// AddLabels adds labels to an issue or a pull request. Every pull request is
+// an issue under the hood, so the number of either can be used here.
+// Returns all labels present after the change.
+func (c *GitHubClient) AddLabels(ctx context.Context, org, repo string, number int, labels ...string) ([]Label, error) {
+	path := fmt.Sprintf("%s/repos/%s/%s/issues/%d/labels", gitHubAPI, org, repo, number)
+	var res []Label
+	err := c.api.Do(ctx, "POST", path,
+		httpclient.WithRequestData(map[string]any{
+			"labels": labels,
+		}),
+		httpclient.WithResponseUnmarshal(&res))
+	return res, err
+}
+
+// RemoveLabel removes a label from an issue or a pull request and returns
+// the remaining labels.
+func (c *GitHubClient) RemoveLabel(ctx context.Context, org, repo string, number int, label string) ([]Label, error) {
+	path := fmt.Sprintf("%s/repos/%s/%s/issues/%d/labels/%s", gitHubAPI, org, repo, number, url.PathEscape(label))
+	var res []Label
+	err := c.api.Do(ctx, "DELETE", path, httpclient.WithResponseUnmarshal(&res))
+	return res, err
 }
@@ -0,0 +1,164 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/databricks/databricks-sdk-go/httpclient"
+	"github.com/databrickslabs/sandbox/go-libs/localcache"
+)
+
+// noreplyEmail matches private addresses, like 123+login@users.noreply.github.com,
+// or login@users.noreply.github.com for accounts created before 2017.
+var noreplyEmail = regexp.MustCompile(`^(?:\d+\+)?([A-Za-z0-9][A-Za-z0-9-]*(?:\[bot\])?)@users\.noreply\.github\.com$`)
+
+// NoreplyLogin returns the login from a GitHub noreply email address.
+func NoreplyLogin(email string) (string, bool) {
+	match := noreplyEmail.FindStringSubmatch(strings.ToLower(email))
+	if match == nil {
+		return "", false
+	}
+	return match[1], true
+}
+
+// LoginResolver maps commit emails to GitHub logins, so that contributors
+// with many emails are counted once. Logins are learned from author fields
+// of commits, that GitHub links to accounts, from noreply addresses, and
+// from commit search as the last resort. Emails without an account are
+// remembered as well, so that they are not searched again.
+type LoginResolver struct {
+	client *GitHubClient
+	cache  localcache.LocalCache[map[string]string]
+
+	mu     sync.Mutex
+	logins map[string]string
+	dirty  bool
+}
+
+func NewLoginResolver(client *GitHubClient, cacheDir string) *LoginResolver {
+	return &LoginResolver{
+		client: client,
+		cache:  localcache.NewLocalCache[map[string]string](cacheDir, "commit-logins", 0),
+	}
+}
+
+func (r *LoginResolver) load(ctx context.Context) error {
+	if r.logins != nil {
+		return nil
+	}
+	logins, err := r.cache.Update(ctx, func(logins map[string]string) (map[string]string, error) {
+		if logins == nil {
+			logins = map[string]string{}
+		}
+		return logins, nil
+	})
+	if err != nil {
+		return fmt.Errorf("load logins: %w", err)
+	}
+	r.logins = logins
+	return nil
+}
+
+// Learn remembers emails of commits, that are linked to accounts.
+func (r *LoginResolver) Learn(ctx context.Context, commits ...RepositoryCommit) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	err := r.load(ctx)
+	if err != nil {
+		return err
+	}
+	for _, v := range commits {
+		email := strings.ToLower(v.Commit.Author.Email)
+		if email == "" || v.Author.Login == "" || r.logins[email] == v.Author.Login {
+			continue
+		}
+		r.logins[email] = v.Author.Login
+		r.dirty = true
+	}
+	return nil
+}
+
+// ResolveCommit returns the login of the commit author, or an empty string,
+// if the email is not linked to any account.
+func (r *LoginResolver) ResolveCommit(ctx context.Context, commit RepositoryCommit) (string, error) {
+	if commit.Author.Login != "" {
+		return commit.Author.Login, r.Learn(ctx, commit)
+	}
+	return r.Resolve(ctx, commit.Commit.Author.Email)
+}
+
+// Resolve returns the login for the email, or an empty string, if the email
+// is not linked to any account.
+func (r *LoginResolver) Resolve(ctx context.Context, email string) (string, error) {
+	if login, ok := NoreplyLogin(email); ok {
+		return login, nil
+	}
+	email = strings.ToLower(email)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	err := r.load(ctx)
+	if err != nil {
+		return "", err
+	}
+	if login, ok := r.logins[email]; ok {
+		return login, nil
+	}
+	login, err := r.client.searchCommitAuthor(ctx, email)
+	if err != nil {
+		return "", fmt.Errorf("search %s: %w", email, err)
+	}
+	r.logins[email] = login
+	r.dirty = true
+	return login, nil
+}
+
+// Save persists learned logins, merging them with logins saved by other
+// processes in the meantime.
+func (r *LoginResolver) Save(ctx context.Context) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !r.dirty {
+		return nil
+	}
+	logins, err := r.cache.Update(ctx, func(saved map[string]string) (map[string]string, error) {
+		if saved == nil {
+			saved = map[string]string{}
+		}
+		for email, login := range r.logins {
+			saved[email] = login
+		}
+		return saved, nil
+	})
+	if err != nil {
+		return fmt.Errorf("save logins: %w", err)
+	}
+	r.logins = logins
+	r.dirty = false
+	return nil
+}
+
+// searchCommitAuthor finds the login of any commit with the author email in
+// public repositories and in repositories, that the credentials can read.
+// See https://docs.github.com/en/rest/search/search#search-commits
+func (c *GitHubClient) searchCommitAuthor(ctx context.Context, email string) (string, error) {
+	path := fmt.Sprintf("%s/search/commits", gitHubAPI)
+	var res struct {
+		Items []RepositoryCommit `json:"items"`
+	}
+	err := c.api.Do(ctx, "GET", path,
+		httpclient.WithRequestData(struct {
+			Query   string `url:"q"`
+			PerPage int    `url:"per_page"`
+		}{fmt.Sprintf("author-email:%s", email), 1}),
+		httpclient.WithResponseUnmarshal(&res))
+	if err != nil {
+		return "", err
+	}
+	if len(res.Items) == 0 {
+		return "", nil
+	}
+	return res.Items[0].Author.Login, nil
+}
@@ -0,0 +1,65 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNoreplyLogin(t *testing.T) {
+	for email, want := range map[string]string{
+		"123+Octocat@users.noreply.github.com":              "octocat",
+		"octocat@users.noreply.github.com":                  "octocat",
+		"49699333+dependabot[bot]@users.noreply.github.com": "dependabot[bot]",
+		"octocat@github.com":                                "",
+	} {
+		login, ok := NoreplyLogin(email)
+		assert.Equal(t, want != "", ok, email)
+		assert.Equal(t, want, login, email)
+	}
+}
+
+func TestLoginResolverPersistsLogins(t *testing.T) {
+	var searches []string
+	client := NewClient(&GitHubConfig{
+		GitHubTokenSource: GitHubTokenSource{Pat: "x"},
+		transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			assert.Equal(t, "/search/commits", r.URL.Path)
+			q := r.URL.Query().Get("q")
+			searches = append(searches, q)
+			if q == "author-email:y@example.com" {
+				return jsonResponse(200, `{"items": [{"author": {"login": "why"}}]}`), nil
+			}
+			return jsonResponse(200, `{"items": []}`), nil
+		}),
+	})
+	ctx := context.Background()
+	dir := t.TempDir()
+	r := NewLoginResolver(client, dir)
+	err := r.Learn(ctx, RepositoryCommit{
+		Author: User{Login: "ex"},
+		Commit: Commit{Author: CommitAuthor{Email: "X@example.com"}},
+	})
+	require.NoError(t, err)
+	login, err := r.ResolveCommit(ctx, RepositoryCommit{Commit: Commit{Author: CommitAuthor{Email: "x@example.com"}}})
+	require.NoError(t, err)
+	assert.Equal(t, "ex", login)
+	login, err = r.Resolve(ctx, "y@example.com")
+	require.NoError(t, err)
+	assert.Equal(t, "why", login)
+	login, err = r.Resolve(ctx, "z@example.com")
+	require.NoError(t, err)
+	assert.Equal(t, "", login)
+	require.NoError(t, r.Save(ctx))
+
+	// another process reuses the cache, including unknown emails
+	r = NewLoginResolver(client, dir)
+	for _, email := range []string{"x@example.com", "y@example.com", "z@example.com"} {
+		_, err = r.Resolve(ctx, email)
+		require.NoError(t, err)
+	}
+	assert.Equal(t, []string{"author-email:y@example.com", "author-email:z@example.com"}, searches)
+}
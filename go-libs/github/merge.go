@@ -0,0 +1,100 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/databricks/databricks-sdk-go/httpclient"
+)
+
+var (
+	ErrNotMergeable = errors.New("pull request is not mergeable")
+	ErrHeadChanged  = errors.New("head of pull request has changed")
+)
+
+type MergeMethod string
+
+const (
+	MergeMethodMerge  MergeMethod = "merge"
+	MergeMethodSquash MergeMethod = "squash"
+	MergeMethodRebase MergeMethod = "rebase"
+)
+
+type MergeOptions struct {
+	// Method defaults to the merge commit. Methods, that are disabled in
+	// settings of the repository, fail with ErrNotMergeable.
+	Method MergeMethod `json:"merge_method,omitempty"`
+
+	// CommitTitle and CommitMessage default to ones GitHub generates. They
+	// are ignored by rebase merges.
+	CommitTitle   string `json:"commit_title,omitempty"`
+	CommitMessage string `json:"commit_message,omitempty"`
+
+	// SHA is the expected head of the pull request. The merge fails with
+	// ErrHeadChanged, if someone pushed after the pull request was checked.
+	SHA string `json:"sha,omitempty"`
+}
+
+// MergeResult is the outcome of a merge. SHA is the merge commit, the squashed
+// commit, or the last of rebased commits.
+type MergeResult struct {
+	SHA     string `json:"sha"`
+	Merged  bool   `json:"merged"`
+	Message string `json:"message"`
+}
+
+// MergePullRequest merges the pull request into its base branch.
+// See https://docs.github.com/en/rest/pulls/pulls#merge-a-pull-request
+func (c *GitHubClient) MergePullRequest(ctx context.Context, org, repo string, number int, opts MergeOptions) (*MergeResult, error) {
+	path := fmt.Sprintf("%s/repos/%s/%s/pulls/%d/merge", gitHubAPI, org, repo, number)
+	var res MergeResult
+	err := c.api.Do(ctx, "PUT", path,
+		httpclient.WithRequestData(opts),
+		httpclient.WithResponseUnmarshal(&res))
+	var apiErr *httpclient.HttpError
+	if errors.As(err, &apiErr) {
+		switch apiErr.StatusCode {
+		case http.StatusMethodNotAllowed:
+			return nil, fmt.Errorf("#%d: %w: %s", number, ErrNotMergeable, errorMessage(apiErr))
+		case http.StatusConflict:
+			return nil, fmt.Errorf("#%d: %w: %s", number, ErrHeadChanged, errorMessage(apiErr))
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// EnableAutoMerge merges the pull request with the method, once all
+// requirements of branch protection are met. It requires auto-merge to be
+// allowed in settings of the repository and fails with GraphQLErrors, if the
+// pull request is already mergeable, as there's nothing to wait for.
+func (c *GitHubClient) EnableAutoMerge(ctx context.Context, org, repo string, number int, method MergeMethod) error {
+	id, err := c.pullRequestNodeID(ctx, org, repo, number)
+	if err != nil {
+		return fmt.Errorf("#%d: %w", number, err)
+	}
+	if method == "" {
+		method = MergeMethodMerge
+	}
+	return c.GraphQL(ctx, `mutation($id: ID!, $method: PullRequestMergeMethod!) {
+		enablePullRequestAutoMerge(input: {pullRequestId: $id, mergeMethod: $method}) { clientMutationId }
+	}`, map[string]any{
+		"id":     id,
+		"method": strings.ToUpper(string(method)),
+	}, nil)
+}
+
+func (c *GitHubClient) DisableAutoMerge(ctx context.Context, org, repo string, number int) error {
+	id, err := c.pullRequestNodeID(ctx, org, repo, number)
+	if err != nil {
+		return fmt.Errorf("#%d: %w", number, err)
+	}
+	return c.GraphQL(ctx, `mutation($id: ID!) {
+		disablePullRequestAutoMerge(input: {pullRequestId: $id}) { clientMutationId }
+	}`, map[string]any{"id": id}, nil)
+}
@@ -0,0 +1,43 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergePullRequest(t *testing.T) {
+	client := NewClient(&GitHubConfig{
+		GitHubTokenSource: GitHubTokenSource{Pat: "x"},
+		transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			assert.Equal(t, "PUT /repos/a/b/pulls/1/merge", r.Method+" "+r.URL.Path)
+			raw, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			var opts MergeOptions
+			require.NoError(t, json.Unmarshal(raw, &opts))
+			if opts.SHA != "abc" {
+				return jsonResponse(409, `{"message": "Head branch was modified. Review and try the merge again."}`), nil
+			}
+			assert.Equal(t, MergeMethodSquash, opts.Method)
+			assert.Equal(t, "Add x (#1)", opts.CommitTitle)
+			return jsonResponse(200, `{"sha": "def", "merged": true, "message": "Pull Request successfully merged"}`), nil
+		}),
+	})
+	ctx := context.Background()
+	res, err := client.MergePullRequest(ctx, "a", "b", 1, MergeOptions{
+		Method:      MergeMethodSquash,
+		CommitTitle: "Add x (#1)",
+		SHA:         "abc",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "def", res.SHA)
+	assert.True(t, res.Merged)
+
+	_, err = client.MergePullRequest(ctx, "a", "b", 1, MergeOptions{SHA: "old"})
+	assert.ErrorIs(t, err, ErrHeadChanged)
+}
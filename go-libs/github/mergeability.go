@@ -0,0 +1,125 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/databricks/databricks-sdk-go/httpclient"
+	"github.com/databricks/databricks-sdk-go/logger"
+)
+
+var ErrMergeabilityUnknown = errors.New("mergeability is not computed yet")
+
+// MergeableState is computed by GitHub in the background after every push
+// to the pull request or its base branch.
+type MergeableState string
+
+const (
+	MergeableClean    MergeableState = "clean"
+	MergeableDirty    MergeableState = "dirty"    // merge conflicts
+	MergeableBlocked  MergeableState = "blocked"  // required reviews or checks
+	MergeableBehind   MergeableState = "behind"   // head is out of date with base
+	MergeableUnstable MergeableState = "unstable" // non-required checks fail
+	MergeableHasHooks MergeableState = "has_hooks"
+	MergeableDraft    MergeableState = "draft"
+	MergeableUnknown  MergeableState = "unknown"
+)
+
+type Mergeability struct {
+	Number    int
+	Mergeable bool
+	State     MergeableState
+
+	// Conflicts are files changed both in the pull request and on the base
+	// branch since they diverged, which is the best approximation of merge
+	// conflicts available through the API. Filled only for dirty state.
+	Conflicts []string
+}
+
+// mergeabilityTimeout and mergeabilityInterval are variables for tests
+var (
+	mergeabilityTimeout  = 2 * time.Minute
+	mergeabilityInterval = time.Second
+)
+
+// CheckMergeability polls the pull request until GitHub computes whether it
+// can be merged, which happens lazily after the first request.
+func (c *GitHubClient) CheckMergeability(ctx context.Context, org, repo string, number int) (*Mergeability, error) {
+	ctx, cancel := context.WithTimeout(ctx, mergeabilityTimeout)
+	defer cancel()
+	interval := mergeabilityInterval
+	for {
+		pr, mergeable, err := c.getMergeability(ctx, org, repo, number)
+		if err != nil {
+			return nil, err
+		}
+		state := MergeableState(pr.MergeableState)
+		if mergeable != nil && state != MergeableUnknown {
+			result := &Mergeability{
+				Number:    number,
+				Mergeable: *mergeable,
+				State:     state,
+			}
+			if state == MergeableDirty {
+				result.Conflicts, err = c.conflictCandidates(ctx, org, repo, pr)
+				if err != nil {
+					logger.Warnf(ctx, "Cannot find conflicting files of #%d: %s", number, err)
+				}
+			}
+			return result, nil
+		}
+		logger.Debugf(ctx, "Waiting %s for mergeability of #%d", interval, number)
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, fmt.Errorf("#%d: %w", number, ErrMergeabilityUnknown)
+		case <-timer.C:
+		}
+		interval *= 2
+		if interval > 10*time.Second {
+			interval = 10 * time.Second
+		}
+	}
+}
+
+// getMergeability distinguishes null mergeable flag, which means that
+// GitHub is still computing it, from false.
+func (c *GitHubClient) getMergeability(ctx context.Context, org, repo string, number int) (*PullRequest, *bool, error) {
+	path := fmt.Sprintf("%s/repos/%s/%s/pulls/%d", gitHubAPI, org, repo, number)
+	var res struct {
+		PullRequest
+		Mergeable *bool `json:"mergeable"`
+	}
+	err := c.api.Do(ctx, "GET", path,
+		httpclient.WithResponseUnmarshal(&res))
+	if err != nil {
+		return nil, nil, err
+	}
+	return &res.PullRequest, res.Mergeable, nil
+}
+
+func (c *GitHubClient) conflictCandidates(ctx context.Context, org, repo string, pr *PullRequest) ([]string, error) {
+	ours, err := c.CompareFiles(ctx, org, repo, pr.Base.Ref, pr.Head.SHA)
+	if err != nil {
+		return nil, fmt.Errorf("pull request files: %w", err)
+	}
+	theirs, err := c.CompareFiles(ctx, org, repo, pr.Head.SHA, pr.Base.Ref)
+	if err != nil {
+		return nil, fmt.Errorf("base branch files: %w", err)
+	}
+	var changed []string
+	for _, v := range theirs {
+		changed = append(changed, v.Filename)
+	}
+	var conflicts []string
+	for _, v := range ours {
+		if slices.Contains(changed, v.Filename) {
+			conflicts = append(conflicts, v.Filename)
+		}
+	}
+	return conflicts, nil
+}
@@ -0,0 +1,43 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckMergeabilityWaitsAndFindsConflicts(t *testing.T) {
+	mergeabilityInterval = 0
+	polls := 0
+	client := NewClient(&GitHubConfig{
+		GitHubTokenSource: GitHubTokenSource{Pat: "x"},
+		transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			switch r.URL.Path {
+			case "/repos/a/b/pulls/3":
+				polls++
+				if polls == 1 {
+					return jsonResponse(200, `{"number": 3, "mergeable": null, "mergeable_state": "unknown"}`), nil
+				}
+				return jsonResponse(200, `{"number": 3, "mergeable": false, "mergeable_state": "dirty",
+					"head": {"sha": "abc"}, "base": {"ref": "main"}}`), nil
+			case "/repos/a/b/compare/main...abc":
+				return jsonResponse(200, `{"files": [{"filename": "go.mod"}, {"filename": "a.go"}]}`), nil
+			case "/repos/a/b/compare/abc...main":
+				return jsonResponse(200, `{"files": [{"filename": "go.mod"}, {"filename": "b.go"}]}`), nil
+			}
+			return jsonResponse(404, `{"message": "Not Found"}`), nil
+		}),
+	})
+	result, err := client.CheckMergeability(context.Background(), "a", "b", 3)
+	require.NoError(t, err)
+	assert.Equal(t, 2, polls)
+	assert.Equal(t, &Mergeability{
+		Number:    3,
+		Mergeable: false,
+		State:     MergeableDirty,
+		Conflicts: []string{"go.mod"},
+	}, result)
+}
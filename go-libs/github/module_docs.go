@@ -0,0 +1,142 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/databricks/databricks-sdk-go/logger"
+)
+
+const semverPattern = `v\d+\.\d+\.\d+(?:-[0-9A-Za-z.]+)?`
+
+// shieldsVersionPattern is semverPattern with dashes escaped as double dashes
+const shieldsVersionPattern = `v\d+\.\d+\.\d+(?:--[0-9A-Za-z.]+)*`
+
+// shieldsEscape escapes dashes and underscores of static shields.io badges
+func shieldsEscape(text string) string {
+	return strings.NewReplacer("-", "--", "_", "__").Replace(text)
+}
+
+// BumpBadges points version references in a README to the new version:
+// pkg.go.dev links like module@v1.2.3, release links of the repository of
+// the module like github.com/org/repo/releases/tag/v1.2.3, and static
+// shields.io badges like badge/version-v1.2.3-blue, which are labeled with
+// the name of the module or link to it. It reports false, if nothing was
+// stale.
+func BumpBadges(readme []byte, module, version string) ([]byte, bool) {
+	type bump struct {
+		re      *regexp.Regexp
+		version string
+	}
+	name := module[strings.LastIndex(module, "/")+1:]
+	labels := regexp.QuoteMeta(shieldsEscape(module)) + "|" + regexp.QuoteMeta(shieldsEscape(name))
+	targets := `pkg\.go\.dev/` + regexp.QuoteMeta(module)
+	bumps := []bump{
+		{regexp.MustCompile(`(` + regexp.QuoteMeta(module) + `@)` + semverPattern + `()`), version},
+		{regexp.MustCompile(`(img\.shields\.io/badge/(?:` + labels + `)-)` + shieldsVersionPattern + `(-)`), shieldsEscape(version)},
+	}
+	if parts := strings.SplitN(module, "/", 4); len(parts) >= 3 && parts[0] == "github.com" {
+		repo := strings.Join(parts[:3], "/")
+		targets += "|" + regexp.QuoteMeta(repo)
+		bumps = append(bumps, bump{regexp.MustCompile(`(` + regexp.QuoteMeta(repo) + `/releases/tag/)` + semverPattern + `()`), version})
+	}
+	// badges with any label, which link to the module or its repository
+	bumps = append(bumps, bump{regexp.MustCompile(`(\]\(https://img\.shields\.io/badge/[^)\s]*?-)` + shieldsVersionPattern +
+		`(-[^)\s]*\)\]\(https://(?:` + targets + `)[/@#?)])`), shieldsEscape(version)})
+	out := readme
+	for _, b := range bumps {
+		out = b.re.ReplaceAll(out, []byte("${1}"+b.version+"${2}"))
+	}
+	return out, string(out) != string(readme)
+}
+
+// ModuleDocs verifies, that a released Go module is documented on pkg.go.dev
+// and that badges in the README of its repository refer to the release.
+type ModuleDocs struct {
+	Client *GitHubClient
+	Org    string
+	Repo   string
+	Module string
+
+	// GoProxy checks pkg.go.dev.
+	GoProxy *GoProxy
+}
+
+type ModuleDocsReport struct {
+	// Documented is true, once pkg.go.dev renders the documentation.
+	Documented bool
+
+	// StaleBadges is true, if the README refers to other versions.
+	StaleBadges bool
+
+	// PullRequest fixes stale badges. It's nil, if badges are fresh, or if
+	// the fix was proposed by an earlier run.
+	PullRequest *PullRequest
+}
+
+// Verify checks the documentation of the version and opens a pull request
+// against the default branch, if the README has stale badges.
+func (d *ModuleDocs) Verify(ctx context.Context, version string) (*ModuleDocsReport, error) {
+	proxy := d.GoProxy
+	if proxy == nil {
+		proxy = &GoProxy{}
+	}
+	var report ModuleDocsReport
+	var err error
+	report.Documented, err = proxy.Documented(ctx, d.Module, version)
+	if err != nil {
+		return nil, err
+	}
+	if !report.Documented {
+		logger.Warnf(ctx, "%s@%s is not documented on pkg.go.dev", d.Module, version)
+	}
+	repo, err := d.Client.GetRepo(ctx, d.Org, d.Repo)
+	if err != nil {
+		return nil, fmt.Errorf("repo: %w", err)
+	}
+	base := repo.DefaultBranch
+	file, readme, blobSHA, err := d.Client.readme(ctx, d.Org, d.Repo, base)
+	if errors.Is(err, ErrNotFound) {
+		return &report, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("readme: %w", err)
+	}
+	updated, stale := BumpBadges(readme, d.Module, version)
+	report.StaleBadges = stale
+	if !stale {
+		return &report, nil
+	}
+	sha, err := d.Client.ResolveRef(ctx, d.Org, d.Repo, base)
+	if err != nil {
+		return nil, fmt.Errorf("base: %w", err)
+	}
+	branch := fmt.Sprintf("docs/badges-%s", version)
+	err = d.Client.createBranch(ctx, d.Org, d.Repo, branch, sha)
+	if errors.Is(err, ErrValidation) {
+		logger.Infof(ctx, "Badges of %s/%s are proposed in %s already", d.Org, d.Repo, branch)
+		return &report, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("branch: %w", err)
+	}
+	title := fmt.Sprintf("Update badges to %s", version)
+	err = d.Client.updateFile(ctx, d.Org, d.Repo, file, branch, blobSHA, title, updated)
+	if err != nil {
+		return nil, fmt.Errorf("update %s: %w", file, err)
+	}
+	report.PullRequest, err = d.Client.CreatePullRequest(ctx, d.Org, d.Repo, NewPullRequest{
+		Title: title,
+		Head:  branch,
+		Base:  base,
+		Body:  fmt.Sprintf("Points badges and links in `%s` to the %s release.", file, version),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("pull request: %w", err)
+	}
+	logger.Infof(ctx, "Proposed badges of %s/%s in #%d", d.Org, d.Repo, report.PullRequest.Number)
+	return &report, nil
+}
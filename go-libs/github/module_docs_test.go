@@ -0,0 +1,105 @@
+package github
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const staleReadme = `# x
+
+[![Go Reference](https://pkg.go.dev/badge/github.com/a/x.svg)](https://pkg.go.dev/github.com/a/x@v1.1.0)
+[![Release](https://img.shields.io/badge/release-v1.1.0-blue)](https://github.com/a/x/releases/tag/v1.1.0)
+
+Built with [y](https://github.com/b/y/releases/tag/v0.3.0).
+`
+
+func TestBumpBadges(t *testing.T) {
+	out, ok := BumpBadges([]byte(staleReadme), "github.com/a/x", "v1.2.0")
+	require.True(t, ok)
+	assert.Equal(t, `# x
+
+[![Go Reference](https://pkg.go.dev/badge/github.com/a/x.svg)](https://pkg.go.dev/github.com/a/x@v1.2.0)
+[![Release](https://img.shields.io/badge/release-v1.2.0-blue)](https://github.com/a/x/releases/tag/v1.2.0)
+
+Built with [y](https://github.com/b/y/releases/tag/v0.3.0).
+`, string(out))
+
+	_, ok = BumpBadges(out, "github.com/a/x", "v1.2.0")
+	assert.False(t, ok)
+}
+
+func TestModuleDocsProposesBadges(t *testing.T) {
+	var pr map[string]any
+	client := NewClient(&GitHubConfig{
+		GitHubTokenSource: GitHubTokenSource{Pat: "x"},
+		transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			switch r.Method + " " + r.URL.Path {
+			case "GET /repos/a/x":
+				return jsonResponse(200, `{"default_branch": "main"}`), nil
+			case "GET /repos/a/x/readme":
+				assert.Equal(t, "main", r.URL.Query().Get("ref"))
+				return jsonResponse(200, `{"path": "README.md", "sha": "blob", "encoding": "base64", "content": "`+
+					base64.StdEncoding.EncodeToString([]byte(staleReadme))+`"}`), nil
+			case "GET /repos/a/x/commits/main":
+				return jsonResponse(200, "abc"), nil
+			case "POST /repos/a/x/git/refs":
+				return jsonResponse(201, `{}`), nil
+			case "PUT /repos/a/x/contents/README.md":
+				raw, err := io.ReadAll(r.Body)
+				require.NoError(t, err)
+				var body map[string]any
+				require.NoError(t, json.Unmarshal(raw, &body))
+				assert.Equal(t, "docs/badges-v1.2.0", body["branch"])
+				assert.Equal(t, "blob", body["sha"])
+				return jsonResponse(200, `{}`), nil
+			case "POST /repos/a/x/pulls":
+				raw, err := io.ReadAll(r.Body)
+				require.NoError(t, err)
+				require.NoError(t, json.Unmarshal(raw, &pr))
+				return jsonResponse(201, `{"number": 7}`), nil
+			}
+			t.Fatalf("unexpected %s %s", r.Method, r.URL.Path)
+			return nil, nil
+		}),
+	})
+	docs := &ModuleDocs{
+		Client: client,
+		Org:    "a",
+		Repo:   "x",
+		Module: "github.com/a/x",
+		GoProxy: &GoProxy{HTTPClient: &http.Client{
+			Transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+				assert.Equal(t, "/github.com/a/x@v1.2.0", r.URL.Path)
+				return jsonResponse(200, `<html>documentation</html>`), nil
+			}),
+		}},
+	}
+	report, err := docs.Verify(context.Background(), "v1.2.0")
+	require.NoError(t, err)
+	assert.True(t, report.Documented)
+	assert.True(t, report.StaleBadges)
+	require.NotNil(t, report.PullRequest)
+	assert.Equal(t, 7, report.PullRequest.Number)
+	assert.Equal(t, "docs/badges-v1.2.0", pr["head"])
+	assert.Equal(t, "main", pr["base"])
+}
+
+func TestBumpBadgesOfTheModuleOnly(t *testing.T) {
+	readme := `[![Go](https://img.shields.io/badge/go-v1.21.0-blue)](https://go.dev)
+[![x](https://img.shields.io/badge/x-v1.1.0-green)](https://example.com)
+[![Release](https://img.shields.io/badge/release-v1.2.0--rc.1-blue)](https://github.com/a/x/releases)
+`
+	out, ok := BumpBadges([]byte(readme), "github.com/a/x", "v1.3.0-beta-2")
+	require.True(t, ok)
+	assert.Equal(t, `[![Go](https://img.shields.io/badge/go-v1.21.0-blue)](https://go.dev)
+[![x](https://img.shields.io/badge/x-v1.3.0--beta--2-green)](https://example.com)
+[![Release](https://img.shields.io/badge/release-v1.3.0--beta--2-blue)](https://github.com/a/x/releases)
+`, string(out))
+}
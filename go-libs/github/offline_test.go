@@ -0,0 +1,49 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordAndServeOffline(t *testing.T) {
+	dir := t.TempDir()
+	calls := 0
+	online := NewClient(&GitHubConfig{
+		GitHubTokenSource: GitHubTokenSource{Pat: "x"},
+		RecordDir:         dir,
+		transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			calls++
+			if r.URL.Path == "/repos/a/b/pulls/2" {
+				return jsonResponse(404, `{"message": "Not Found"}`), nil
+			}
+			return jsonResponse(200, `[{"number": 1, "title": "Fix"}]`), nil
+		}),
+	})
+	ctx := context.Background()
+	prs, err := online.ListPullRequests(ctx, "a", "b", PullRequestListOptions{State: "open"})
+	require.NoError(t, err)
+	require.Len(t, prs, 1)
+	_, err = online.GetPullRequest(ctx, "a", "b", 2)
+	require.Error(t, err)
+
+	offline := NewClient(&GitHubConfig{
+		OfflineDir: dir,
+	})
+	prs, err = offline.ListPullRequests(ctx, "a", "b", PullRequestListOptions{State: "open"})
+	require.NoError(t, err)
+	assert.Equal(t, "Fix", prs[0].Title)
+	assert.Equal(t, 2, calls)
+
+	_, err = offline.GetPullRequest(ctx, "a", "b", 2)
+	assert.ErrorContains(t, err, "Not Found")
+
+	_, err = offline.ListPullRequests(ctx, "a", "b", PullRequestListOptions{State: "closed"})
+	assert.ErrorIs(t, err, ErrNotRecorded)
+
+	_, err = offline.CreateIssueComment(ctx, "a", "b", 1, "hello")
+	assert.ErrorIs(t, err, ErrOffline)
+}
@@ -0,0 +1,187 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+
+	"github.com/databricks/databricks-sdk-go/httpclient"
+)
+
+// maxPageSize is the largest per_page accepted by the API
+const maxPageSize = 100
+
+var linkNext = regexp.MustCompile(`<([^>]+)>;\s*rel="next"`)
+
+// nextPage returns the URL of the next page from the Link header, or an empty
+// string on the last page. See https://docs.github.com/en/rest/using-the-rest-api/using-pagination-in-the-rest-api
+func nextPage(h http.Header) string {
+	for _, link := range h.Values("Link") {
+		m := linkNext.FindStringSubmatch(link)
+		if m != nil {
+			return m[1]
+		}
+	}
+	return ""
+}
+
+func (c *GitHubClient) pageSize() int {
+	if c.cfg.PageSize <= 0 || c.cfg.PageSize > maxPageSize {
+		return maxPageSize
+	}
+	return c.cfg.PageSize
+}
+
+// Iterator lazily walks pages of a list endpoint, requesting the next page
+// only once all items of the previous page are consumed, so that callers can
+// stream long lists without holding them in memory:
+//
+//	it := client.ListPullRequestsIterator("org", "repo", opts)
+//	for it.HasNext(ctx) {
+//		pr, err := it.Next(ctx)
+//		...
+//	}
+type Iterator[T any] struct {
+	c     *GitHubClient
+	url   string
+	query any
+
+	// field of the response object, which holds the items, for endpoints,
+	// that don't respond with a JSON array, like {"total_count": 1, "check_runs": [...]}
+	field string
+
+	// opts are applied to requests of every page
+	opts []httpclient.DoOption
+
+	// page fetches the next page of other than REST list endpoints and
+	// reports, if there are more pages
+	page func(ctx context.Context) ([]T, bool, error)
+
+	started bool
+	buf     []T
+	seen    int
+	err     error
+}
+
+// Paginate walks a list endpoint, which responds with a JSON array, by the
+// Link headers. The url is absolute, like https://api.github.com/orgs/x/repos,
+// and the query is only sent with the first request, as links of following
+// pages already have it.
+func Paginate[T any](c *GitHubClient, url string, query any, opts ...httpclient.DoOption) *Iterator[T] {
+	return &Iterator[T]{c: c, url: url, query: query, opts: opts}
+}
+
+// paginateField is Paginate for endpoints, that wrap items in an object
+func paginateField[T any](c *GitHubClient, url, field string, query any, opts ...httpclient.DoOption) *Iterator[T] {
+	return &Iterator[T]{c: c, url: url, query: query, field: field, opts: opts}
+}
+
+// HasNext fetches the next page, if needed. It returns true on failures, so
+// that Next reports them.
+func (it *Iterator[T]) HasNext(ctx context.Context) bool {
+	if it.err != nil {
+		return true
+	}
+	max := it.c.cfg.MaxListItems
+	for len(it.buf) == 0 {
+		if it.started && it.url == "" {
+			return false
+		}
+		if max > 0 && it.seen >= max {
+			return false
+		}
+		it.err = it.fetch(ctx)
+		if it.err != nil {
+			return true
+		}
+	}
+	return max == 0 || it.seen < max
+}
+
+func (it *Iterator[T]) Next(ctx context.Context) (T, error) {
+	var v T
+	if !it.HasNext(ctx) {
+		return v, fmt.Errorf("no more items")
+	}
+	if it.err != nil {
+		return v, it.err
+	}
+	v, it.buf = it.buf[0], it.buf[1:]
+	it.seen++
+	return v, nil
+}
+
+func (it *Iterator[T]) fetch(ctx context.Context) error {
+	if it.page != nil {
+		buf, more, err := it.page(ctx)
+		if err != nil {
+			return err
+		}
+		it.started = true
+		it.buf = buf
+		if !more {
+			it.url = ""
+		}
+		return nil
+	}
+	var headers http.Header
+	var raw json.RawMessage
+	opts := append([]httpclient.DoOption{httpclient.WithResponseUnmarshal(&raw)}, it.opts...)
+	if !it.started {
+		if it.query != nil {
+			opts = append(opts, httpclient.WithRequestData(it.query))
+		}
+		opts = append(opts, httpclient.WithRequestVisitor(func(r *http.Request) error {
+			q := r.URL.Query()
+			if q.Get("per_page") == "" {
+				q.Set("per_page", strconv.Itoa(it.c.pageSize()))
+				r.URL.RawQuery = q.Encode()
+			}
+			return nil
+		}))
+	}
+	err := it.c.api.Do(withResponseHeaders(ctx, &headers), "GET", it.url, opts...)
+	if err != nil {
+		return err
+	}
+	it.started = true
+	it.url = nextPage(headers)
+	if it.field != "" {
+		var wrapper map[string]json.RawMessage
+		err = json.Unmarshal(raw, &wrapper)
+		if err != nil {
+			return fmt.Errorf("page: %w", err)
+		}
+		raw = wrapper[it.field]
+	}
+	if len(raw) == 0 {
+		return nil
+	}
+	err = json.Unmarshal(raw, &it.buf)
+	if err != nil {
+		return fmt.Errorf("page: %w", err)
+	}
+	return nil
+}
+
+// ToSlice consumes the rest of the iterator.
+func ToSlice[T any](ctx context.Context, it *Iterator[T]) ([]T, error) {
+	var all []T
+	for it.HasNext(ctx) {
+		v, err := it.Next(ctx)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, v)
+	}
+	return all, nil
+}
+
+// listAll follows the Link headers of a list endpoint, that returns a JSON
+// array, until the last page or MaxListItems.
+func listAll[T any](ctx context.Context, c *GitHubClient, url string, query any) ([]T, error) {
+	return ToSlice(ctx, Paginate[T](c, url, query))
+}
@@ -0,0 +1,139 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNextPage(t *testing.T) {
+	h := http.Header{}
+	h.Set("Link", `<https://api.github.com/user/repos?page=3&per_page=100>; rel="next", `+
+		`<https://api.github.com/user/repos?page=50&per_page=100>; rel="last"`)
+	assert.Equal(t, "https://api.github.com/user/repos?page=3&per_page=100", nextPage(h))
+
+	h.Set("Link", `<https://api.github.com/user/repos?page=1>; rel="prev"`)
+	assert.Equal(t, "", nextPage(h))
+	assert.Equal(t, "", nextPage(http.Header{}))
+}
+
+// pagedResponses serves three pages of repositories with two items each
+func pagedResponses() roundTripperFunc {
+	return func(r *http.Request) (*http.Response, error) {
+		if r.URL.Path != "/users/a/repos" {
+			return jsonResponse(404, `{"message": "Not Found"}`), nil
+		}
+		page := r.URL.Query().Get("page")
+		if page == "" {
+			page = "1"
+		}
+		resp := jsonResponse(200, fmt.Sprintf(`[{"name": "r%s-1"}, {"name": "r%s-2"}]`, page, page))
+		if page != "3" {
+			var next int
+			fmt.Sscan(page, &next)
+			resp.Header.Set("Link", fmt.Sprintf(`<https://api.github.com/users/a/repos?page=%d&per_page=%s>; rel="next"`,
+				next+1, r.URL.Query().Get("per_page")))
+		}
+		return resp, nil
+	}
+}
+
+func TestListRepositoriesFollowsLinks(t *testing.T) {
+	var perPage []string
+	pages := pagedResponses()
+	client := NewClient(&GitHubConfig{
+		GitHubTokenSource: GitHubTokenSource{Pat: "x"},
+		PageSize:          2,
+		transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			perPage = append(perPage, r.URL.Query().Get("per_page"))
+			return pages(r)
+		}),
+	})
+	repos, err := client.ListRepositories(context.Background(), "a")
+	require.NoError(t, err)
+	assert.Len(t, repos, 6)
+	assert.Equal(t, "r3-2", repos[5].Name)
+	assert.Equal(t, []string{"2", "2", "2"}, perPage)
+}
+
+func TestListRepositoriesMaxItems(t *testing.T) {
+	requests := 0
+	pages := pagedResponses()
+	client := NewClient(&GitHubConfig{
+		GitHubTokenSource: GitHubTokenSource{Pat: "x"},
+		MaxListItems:      3,
+		transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			requests++
+			return pages(r)
+		}),
+	})
+	repos, err := client.ListRepositories(context.Background(), "a")
+	require.NoError(t, err)
+	assert.Len(t, repos, 3)
+	assert.Equal(t, 2, requests)
+}
+
+func TestIteratorIsLazy(t *testing.T) {
+	requests := 0
+	pages := pagedResponses()
+	client := NewClient(&GitHubConfig{
+		GitHubTokenSource: GitHubTokenSource{Pat: "x"},
+		transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			requests++
+			return pages(r)
+		}),
+	})
+	ctx := context.Background()
+	it := client.ListRepositoriesIterator("a")
+	assert.Equal(t, 0, requests)
+	for i := 0; i < 3; i++ {
+		require.True(t, it.HasNext(ctx))
+		_, err := it.Next(ctx)
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 2, requests)
+	rest, err := ToSlice(ctx, it)
+	require.NoError(t, err)
+	assert.Len(t, rest, 3)
+	assert.Equal(t, 3, requests)
+	assert.False(t, it.HasNext(ctx))
+}
+
+func TestIteratorOfWrappedItems(t *testing.T) {
+	client := NewClient(&GitHubConfig{
+		GitHubTokenSource: GitHubTokenSource{Pat: "x"},
+		transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			if r.URL.Query().Get("page") == "2" {
+				return jsonResponse(200, `{"total_count": 3, "check_runs": [{"name": "c"}]}`), nil
+			}
+			assert.Equal(t, "latest", r.URL.Query().Get("filter"))
+			resp := jsonResponse(200, `{"total_count": 3, "check_runs": [{"name": "a"}, {"name": "b"}]}`)
+			resp.Header.Set("Link", `<https://api.github.com/repos/a/b/commits/main/check-runs?filter=latest&page=2>; rel="next"`)
+			return resp, nil
+		}),
+	})
+	runs, err := ToSlice(context.Background(), client.ListCheckRunsForRefIterator("a", "b", "main", CheckRunListOptions{
+		Filter: "latest",
+	}))
+	require.NoError(t, err)
+	require.Len(t, runs, 3)
+	assert.Equal(t, "c", runs[2].Name)
+}
+
+func TestIteratorFailure(t *testing.T) {
+	client := NewClient(&GitHubConfig{
+		GitHubTokenSource: GitHubTokenSource{Pat: "x"},
+		transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			return jsonResponse(403, `{"message": "Forbidden"}`), nil
+		}),
+	})
+	ctx := context.Background()
+	it := client.ListReviewsIterator("a", "b", 1)
+	require.True(t, it.HasNext(ctx))
+	_, err := it.Next(ctx)
+	assert.ErrorContains(t, err, "Forbidden")
+}
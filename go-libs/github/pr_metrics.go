@@ -0,0 +1,270 @@
+package github
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+)
+
+// sizeBuckets are upper bounds of changed lines for pull request sizes
+var sizeBuckets = []struct {
+	Name  string
+	Lines int
+}{
+	{"XS", 10},
+	{"S", 50},
+	{"M", 250},
+	{"L", 1000},
+}
+
+// SizeBucket classifies a pull request by the number of added and deleted lines.
+func SizeBucket(lines int) string {
+	for _, v := range sizeBuckets {
+		if lines < v.Lines {
+			return v.Name
+		}
+	}
+	return "XL"
+}
+
+type PullRequestMetricsOptions struct {
+	// Since and Until limit pull requests by creation time. Since defaults
+	// to four weeks ago and Until to now.
+	Since time.Time
+	Until time.Time
+
+	// Repos defaults to all repositories of the org, except forks and
+	// archived ones.
+	Repos []string
+}
+
+type PullRequestMetric struct {
+	Repo         string
+	Number       int
+	Title        string
+	Author       string
+	Size         string
+	Additions    int
+	Deletions    int
+	ChangedFiles int
+	CreatedAt    time.Time
+	MergedAt     time.Time
+
+	// FirstReviewAt is the first submitted review by anyone but the author.
+	FirstReviewAt time.Time
+
+	// TimeToFirstReview and TimeToMerge are zero, if that hasn't happened yet.
+	TimeToFirstReview time.Duration
+	TimeToMerge       time.Duration
+}
+
+type PullRequestMetrics []PullRequestMetric
+
+// PullRequestMetrics collects size and latency of pull requests created in
+// the org during the window, for engineering metrics dashboards.
+func (c *GitHubClient) PullRequestMetrics(ctx context.Context, org string, opts PullRequestMetricsOptions) (PullRequestMetrics, error) {
+	if opts.Until.IsZero() {
+		opts.Until = time.Now()
+	}
+	if opts.Since.IsZero() {
+		opts.Since = opts.Until.AddDate(0, 0, -28)
+	}
+	repos := opts.Repos
+	if len(repos) == 0 {
+		err := c.StreamRepositories(ctx, org, func(r Repo) error {
+			if r.IsFork || r.IsArchived {
+				return nil
+			}
+			repos = append(repos, r.Name)
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("repositories: %w", err)
+		}
+	}
+	var metrics PullRequestMetrics
+	for _, repo := range repos {
+		prs, err := c.pullRequestsCreatedIn(ctx, org, repo, opts.Since, opts.Until)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", repo, err)
+		}
+		for _, v := range prs {
+			// sizes are only returned for individual pull requests
+			pr, err := c.GetPullRequest(ctx, org, repo, v.Number)
+			if err != nil {
+				return nil, fmt.Errorf("%s#%d: %w", repo, v.Number, err)
+			}
+			reviews, err := c.ListReviews(ctx, org, repo, v.Number)
+			if err != nil {
+				return nil, fmt.Errorf("%s#%d: reviews: %w", repo, v.Number, err)
+			}
+			metrics = append(metrics, newPullRequestMetric(repo, pr, reviews))
+		}
+	}
+	return metrics, nil
+}
+
+// pullRequestsCreatedIn pages through pull requests from the newest one and
+// stops at the first one created before the window.
+func (c *GitHubClient) pullRequestsCreatedIn(ctx context.Context, org, repo string, since, until time.Time) ([]PullRequest, error) {
+	var out []PullRequest
+	for page := 1; ; page++ {
+		prs, err := c.ListPullRequests(ctx, org, repo, PullRequestListOptions{
+			State:     "all",
+			Sort:      "created",
+			Direction: "desc",
+			Page:      page,
+			PerPage:   100,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, v := range prs {
+			if v.CreatedAt.Before(since) {
+				return out, nil
+			}
+			if v.CreatedAt.After(until) {
+				continue
+			}
+			out = append(out, v)
+		}
+		if len(prs) < 100 {
+			return out, nil
+		}
+	}
+}
+
+func newPullRequestMetric(repo string, pr *PullRequest, reviews []PullRequestReview) PullRequestMetric {
+	m := PullRequestMetric{
+		Repo:         repo,
+		Number:       pr.Number,
+		Title:        pr.Title,
+		Author:       pr.User.Login,
+		Size:         SizeBucket(pr.Additions + pr.Deletions),
+		Additions:    pr.Additions,
+		Deletions:    pr.Deletions,
+		ChangedFiles: pr.ChangedFiles,
+		CreatedAt:    pr.CreatedAt,
+		MergedAt:     pr.MergedAt,
+	}
+	for _, v := range reviews {
+		if v.State == "PENDING" || v.User.Login == pr.User.Login || v.SubmittedAt.IsZero() {
+			continue
+		}
+		if m.FirstReviewAt.IsZero() || v.SubmittedAt.Before(m.FirstReviewAt) {
+			m.FirstReviewAt = v.SubmittedAt
+		}
+	}
+	if !m.FirstReviewAt.IsZero() {
+		m.TimeToFirstReview = m.FirstReviewAt.Sub(m.CreatedAt)
+	}
+	if !m.MergedAt.IsZero() {
+		m.TimeToMerge = m.MergedAt.Sub(m.CreatedAt)
+	}
+	return m
+}
+
+type PullRequestMetricsSummary struct {
+	Count  int
+	Merged int
+	Sizes  map[string]int
+
+	MedianTimeToFirstReview time.Duration
+	MedianTimeToMerge       time.Duration
+}
+
+func (m PullRequestMetrics) Summary() PullRequestMetricsSummary {
+	summary := PullRequestMetricsSummary{
+		Count: len(m),
+		Sizes: map[string]int{},
+	}
+	var review, merge []time.Duration
+	for _, v := range m {
+		summary.Sizes[v.Size]++
+		if v.TimeToFirstReview > 0 {
+			review = append(review, v.TimeToFirstReview)
+		}
+		if v.TimeToMerge > 0 {
+			summary.Merged++
+			merge = append(merge, v.TimeToMerge)
+		}
+	}
+	summary.MedianTimeToFirstReview = median(review)
+	summary.MedianTimeToMerge = median(merge)
+	return summary
+}
+
+func median(durations []time.Duration) time.Duration {
+	if len(durations) == 0 {
+		return 0
+	}
+	sort.Slice(durations, func(i, j int) bool {
+		return durations[i] < durations[j]
+	})
+	mid := len(durations) / 2
+	if len(durations)%2 == 0 {
+		return (durations[mid-1] + durations[mid]) / 2
+	}
+	return durations[mid]
+}
+
+// metricsColumns are shared by JSON and CSV outputs, with durations in seconds,
+// so that dashboards don't have to parse Go duration strings.
+var metricsColumns = []string{
+	"repo", "number", "title", "author", "size", "additions", "deletions",
+	"changed_files", "created_at", "merged_at", "first_review_at",
+	"time_to_first_review", "time_to_merge",
+}
+
+func (m PullRequestMetric) values() []any {
+	return []any{
+		m.Repo, m.Number, m.Title, m.Author, m.Size, m.Additions, m.Deletions,
+		m.ChangedFiles, timestamp(m.CreatedAt), timestamp(m.MergedAt), timestamp(m.FirstReviewAt),
+		int64(m.TimeToFirstReview.Seconds()), int64(m.TimeToMerge.Seconds()),
+	}
+}
+
+func timestamp(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+func (m PullRequestMetrics) WriteJSON(w io.Writer) error {
+	rows := []map[string]any{}
+	for _, v := range m {
+		row := map[string]any{}
+		for i, value := range v.values() {
+			row[metricsColumns[i]] = value
+		}
+		rows = append(rows, row)
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(rows)
+}
+
+func (m PullRequestMetrics) WriteCSV(w io.Writer) error {
+	out := csv.NewWriter(w)
+	err := out.Write(metricsColumns)
+	if err != nil {
+		return err
+	}
+	for _, v := range m {
+		var record []string
+		for _, value := range v.values() {
+			record = append(record, fmt.Sprint(value))
+		}
+		err = out.Write(record)
+		if err != nil {
+			return err
+		}
+	}
+	out.Flush()
+	return out.Error()
+}
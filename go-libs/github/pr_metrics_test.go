@@ -0,0 +1,74 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSizeBucket(t *testing.T) {
+	assert.Equal(t, "XS", SizeBucket(0))
+	assert.Equal(t, "S", SizeBucket(10))
+	assert.Equal(t, "M", SizeBucket(249))
+	assert.Equal(t, "L", SizeBucket(250))
+	assert.Equal(t, "XL", SizeBucket(5000))
+}
+
+func TestPullRequestMetrics(t *testing.T) {
+	client := NewClient(&GitHubConfig{
+		GitHubTokenSource: GitHubTokenSource{Pat: "x"},
+		transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			switch r.URL.Path {
+			case "/repos/a/b/pulls":
+				return jsonResponse(200, `[
+					{"number": 3, "created_at": "2024-01-20T00:00:00Z"},
+					{"number": 2, "created_at": "2024-01-10T00:00:00Z"},
+					{"number": 1, "created_at": "2023-12-01T00:00:00Z"}]`), nil
+			case "/repos/a/b/pulls/3":
+				return jsonResponse(200, `{"number": 3, "user": {"login": "x"}, "additions": 5, "deletions": 1,
+					"created_at": "2024-01-20T00:00:00Z"}`), nil
+			case "/repos/a/b/pulls/2":
+				return jsonResponse(200, `{"number": 2, "user": {"login": "x"}, "additions": 300,
+					"created_at": "2024-01-10T00:00:00Z", "merged_at": "2024-01-12T00:00:00Z"}`), nil
+			case "/repos/a/b/pulls/3/reviews":
+				return jsonResponse(200, `[]`), nil
+			case "/repos/a/b/pulls/2/reviews":
+				return jsonResponse(200, `[
+					{"user": {"login": "x"}, "state": "COMMENTED", "submitted_at": "2024-01-10T01:00:00Z"},
+					{"user": {"login": "y"}, "state": "APPROVED", "submitted_at": "2024-01-11T00:00:00Z"}]`), nil
+			}
+			return jsonResponse(404, `{"message": "Not Found"}`), nil
+		}),
+	})
+	metrics, err := client.PullRequestMetrics(context.Background(), "a", PullRequestMetricsOptions{
+		Since: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		Until: time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+		Repos: []string{"b"},
+	})
+	require.NoError(t, err)
+	require.Len(t, metrics, 2)
+	assert.Equal(t, "XS", metrics[0].Size)
+	assert.Zero(t, metrics[0].TimeToFirstReview)
+	assert.Equal(t, "L", metrics[1].Size)
+	assert.Equal(t, 24*time.Hour, metrics[1].TimeToFirstReview)
+	assert.Equal(t, 48*time.Hour, metrics[1].TimeToMerge)
+
+	summary := metrics.Summary()
+	assert.Equal(t, 2, summary.Count)
+	assert.Equal(t, 1, summary.Merged)
+	assert.Equal(t, map[string]int{"XS": 1, "L": 1}, summary.Sizes)
+	assert.Equal(t, 48*time.Hour, summary.MedianTimeToMerge)
+
+	var buf bytes.Buffer
+	require.NoError(t, metrics.WriteCSV(&buf))
+	assert.Contains(t, buf.String(), "b,2,,x,L,300,0,0,2024-01-10T00:00:00Z,2024-01-12T00:00:00Z,2024-01-11T00:00:00Z,86400,172800\n")
+
+	buf.Reset()
+	require.NoError(t, metrics.WriteJSON(&buf))
+	assert.Contains(t, buf.String(), `"time_to_merge": 172800`)
+}
@@ -0,0 +1,278 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/databricks/databricks-sdk-go/logger"
+)
+
+var ErrUnknownStatus = errors.New("unknown project status")
+
+// ProjectStatusRules name options of the single select status field of a
+// project, that items are moved to. Empty options leave items in place.
+type ProjectStatusRules struct {
+	// Opened is for pull requests, that are opened, reopened, or ready
+	// for review.
+	Opened string
+
+	// Reviewed is for pull requests with submitted reviews, except the
+	// approvals, if Approved is set.
+	Reviewed string
+	Approved string
+
+	Merged string
+
+	// Closed is for pull requests closed without merging.
+	Closed string
+}
+
+// ProjectAutomation moves Projects (v2) items of pull requests and of issues,
+// that pull requests close, between statuses, like the built-in workflows of
+// projects do for a few hardcoded transitions.
+// See https://docs.github.com/en/issues/planning-and-tracking-with-projects
+type ProjectAutomation struct {
+	Client *GitHubClient
+	Org    string
+
+	// Project is the number of the organization project, as seen in its URL.
+	Project int
+
+	// StatusField defaults to "Status".
+	StatusField string
+	Rules       ProjectStatusRules
+
+	// AddPullRequests adds pull requests, that are not in the project yet.
+	// Linked issues are never added.
+	AddPullRequests bool
+
+	mu     sync.Mutex
+	fields *projectFields
+}
+
+type projectFields struct {
+	projectID string
+	fieldID   string
+	options   map[string]string
+}
+
+func (a *ProjectAutomation) loadFields(ctx context.Context) (*projectFields, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.fields != nil {
+		return a.fields, nil
+	}
+	name := a.StatusField
+	if name == "" {
+		name = "Status"
+	}
+	var res struct {
+		Organization struct {
+			ProjectV2 struct {
+				ID    string `json:"id"`
+				Field *struct {
+					ID      string `json:"id"`
+					Options []struct {
+						ID   string `json:"id"`
+						Name string `json:"name"`
+					} `json:"options"`
+				} `json:"field"`
+			} `json:"projectV2"`
+		} `json:"organization"`
+	}
+	err := a.Client.GraphQL(ctx, `query($org: String!, $number: Int!, $field: String!) {
+		organization(login: $org) {
+			projectV2(number: $number) {
+				id
+				field(name: $field) {
+					... on ProjectV2SingleSelectField { id options { id name } }
+				}
+			}
+		}
+	}`, map[string]any{
+		"org":    a.Org,
+		"number": a.Project,
+		"field":  name,
+	}, &res)
+	if err != nil {
+		return nil, err
+	}
+	project := res.Organization.ProjectV2
+	if project.Field == nil || project.Field.ID == "" {
+		return nil, fmt.Errorf("project %d has no single select field %s", a.Project, name)
+	}
+	fields := &projectFields{
+		projectID: project.ID,
+		fieldID:   project.Field.ID,
+		options:   map[string]string{},
+	}
+	for _, v := range project.Field.Options {
+		fields.options[v.Name] = v.ID
+	}
+	a.fields = fields
+	return fields, nil
+}
+
+type projectItems struct {
+	Nodes []struct {
+		ID      string `json:"id"`
+		Project struct {
+			ID string `json:"id"`
+		} `json:"project"`
+	} `json:"nodes"`
+}
+
+func (items projectItems) in(projectID string) string {
+	for _, v := range items.Nodes {
+		if v.Project.ID == projectID {
+			return v.ID
+		}
+	}
+	return ""
+}
+
+// linkedItems returns items of the pull request and of issues it closes in
+// the project, and the node ID of the pull request.
+func (a *ProjectAutomation) linkedItems(ctx context.Context, repo string, number int, projectID string) (string, []string, error) {
+	var res struct {
+		Repository struct {
+			PullRequest struct {
+				ID           string       `json:"id"`
+				ProjectItems projectItems `json:"projectItems"`
+				Closing      struct {
+					Nodes []struct {
+						ProjectItems projectItems `json:"projectItems"`
+					} `json:"nodes"`
+				} `json:"closingIssuesReferences"`
+			} `json:"pullRequest"`
+		} `json:"repository"`
+	}
+	err := a.Client.GraphQL(ctx, `query($owner: String!, $name: String!, $number: Int!) {
+		repository(owner: $owner, name: $name) {
+			pullRequest(number: $number) {
+				id
+				projectItems(first: 50) { nodes { id project { id } } }
+				closingIssuesReferences(first: 20) {
+					nodes { projectItems(first: 50) { nodes { id project { id } } } }
+				}
+			}
+		}
+	}`, map[string]any{
+		"owner":  a.Org,
+		"name":   repo,
+		"number": number,
+	}, &res)
+	if err != nil {
+		return "", nil, err
+	}
+	pr := res.Repository.PullRequest
+	var items []string
+	if id := pr.ProjectItems.in(projectID); id != "" {
+		items = append(items, id)
+	}
+	for _, issue := range pr.Closing.Nodes {
+		if id := issue.ProjectItems.in(projectID); id != "" {
+			items = append(items, id)
+		}
+	}
+	return pr.ID, items, nil
+}
+
+func (a *ProjectAutomation) addItem(ctx context.Context, projectID, contentID string) (string, error) {
+	var res struct {
+		AddProjectV2ItemById struct {
+			Item struct {
+				ID string `json:"id"`
+			} `json:"item"`
+		} `json:"addProjectV2ItemById"`
+	}
+	err := a.Client.GraphQL(ctx, `mutation($projectId: ID!, $contentId: ID!) {
+		addProjectV2ItemById(input: {projectId: $projectId, contentId: $contentId}) { item { id } }
+	}`, map[string]any{
+		"projectId": projectID,
+		"contentId": contentID,
+	}, &res)
+	return res.AddProjectV2ItemById.Item.ID, err
+}
+
+// Move sets the status of items linked to the pull request and returns the
+// number of moved items.
+func (a *ProjectAutomation) Move(ctx context.Context, repo string, number int, status string) (int, error) {
+	fields, err := a.loadFields(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("project: %w", err)
+	}
+	optionID, ok := fields.options[status]
+	if !ok {
+		return 0, fmt.Errorf("%s: %w", status, ErrUnknownStatus)
+	}
+	prID, items, err := a.linkedItems(ctx, repo, number, fields.projectID)
+	if err != nil {
+		return 0, fmt.Errorf("items: %w", err)
+	}
+	if len(items) == 0 && a.AddPullRequests {
+		item, err := a.addItem(ctx, fields.projectID, prID)
+		if err != nil {
+			return 0, fmt.Errorf("add #%d: %w", number, err)
+		}
+		items = append(items, item)
+	}
+	for _, item := range items {
+		err = a.Client.GraphQL(ctx, `mutation($projectId: ID!, $itemId: ID!, $fieldId: ID!, $optionId: String!) {
+			updateProjectV2ItemFieldValue(input: {
+				projectId: $projectId, itemId: $itemId, fieldId: $fieldId,
+				value: {singleSelectOptionId: $optionId}
+			}) { projectV2Item { id } }
+		}`, map[string]any{
+			"projectId": fields.projectID,
+			"itemId":    item,
+			"fieldId":   fields.fieldID,
+			"optionId":  optionID,
+		}, nil)
+		if err != nil {
+			return 0, fmt.Errorf("update %s: %w", item, err)
+		}
+	}
+	if len(items) > 0 {
+		logger.Infof(ctx, "Moved %d items of %s/%s#%d to %s", len(items), a.Org, repo, number, status)
+	}
+	return len(items), nil
+}
+
+// HandlePullRequestEvent moves items on pull_request webhook events.
+func (a *ProjectAutomation) HandlePullRequestEvent(ctx context.Context, repo string, event *PullRequestEventPayload) (int, error) {
+	var status string
+	switch event.Action {
+	case "opened", "reopened", "ready_for_review":
+		status = a.Rules.Opened
+	case "closed":
+		status = a.Rules.Closed
+		if event.PullRequest.Merged {
+			status = a.Rules.Merged
+		}
+	}
+	if status == "" || event.PullRequest.Draft {
+		return 0, nil
+	}
+	return a.Move(ctx, repo, event.PullRequest.Number, status)
+}
+
+// HandlePullRequestReviewEvent moves items on pull_request_review webhook
+// events, when reviews are submitted.
+func (a *ProjectAutomation) HandlePullRequestReviewEvent(ctx context.Context, repo string, event *PullRequestReviewEventPayload) (int, error) {
+	if event.Action != "submitted" {
+		return 0, nil
+	}
+	status := a.Rules.Reviewed
+	// webhooks have lowercase states, unlike the REST API
+	if strings.EqualFold(event.Review.State, "approved") && a.Rules.Approved != "" {
+		status = a.Rules.Approved
+	}
+	if status == "" {
+		return 0, nil
+	}
+	return a.Move(ctx, repo, event.PullRequest.Number, status)
+}
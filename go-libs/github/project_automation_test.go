@@ -0,0 +1,75 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProjectAutomationMovesLinkedItems(t *testing.T) {
+	var updates []map[string]any
+	client := NewClient(&GitHubConfig{
+		GitHubTokenSource: GitHubTokenSource{Pat: "x"},
+		transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			raw, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			var req struct {
+				Query     string         `json:"query"`
+				Variables map[string]any `json:"variables"`
+			}
+			require.NoError(t, json.Unmarshal(raw, &req))
+			switch {
+			case strings.Contains(req.Query, "projectV2(number"):
+				return jsonResponse(200, `{"data": {"organization": {"projectV2": {"id": "P",
+					"field": {"id": "F", "options": [{"id": "o1", "name": "In review"}, {"id": "o2", "name": "Done"}]}}}}}`), nil
+			case strings.Contains(req.Query, "closingIssuesReferences"):
+				return jsonResponse(200, `{"data": {"repository": {"pullRequest": {"id": "PR",
+					"projectItems": {"nodes": [{"id": "other", "project": {"id": "X"}}]},
+					"closingIssuesReferences": {"nodes": [
+						{"projectItems": {"nodes": [{"id": "I1", "project": {"id": "P"}}]}},
+						{"projectItems": {"nodes": []}}]}}}}}`), nil
+			case strings.Contains(req.Query, "updateProjectV2ItemFieldValue"):
+				updates = append(updates, req.Variables)
+				return jsonResponse(200, `{"data": {}}`), nil
+			}
+			t.Fatalf("unexpected query: %s", req.Query)
+			return nil, nil
+		}),
+	})
+	a := &ProjectAutomation{
+		Client:  client,
+		Org:     "a",
+		Project: 1,
+		Rules:   ProjectStatusRules{Approved: "In review", Merged: "Done"},
+	}
+	ctx := context.Background()
+	moved, err := a.HandlePullRequestReviewEvent(ctx, "b", &PullRequestReviewEventPayload{
+		Action:      "submitted",
+		Review:      PullRequestReview{State: "approved"},
+		PullRequest: PullRequest{Number: 1},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1, moved)
+	moved, err = a.HandlePullRequestEvent(ctx, "b", &PullRequestEventPayload{
+		Action:      "opened",
+		PullRequest: PullRequest{Number: 1},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 0, moved)
+	moved, err = a.HandlePullRequestEvent(ctx, "b", &PullRequestEventPayload{
+		Action:      "closed",
+		PullRequest: PullRequest{Number: 1, Merged: true},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1, moved)
+	assert.Equal(t, []map[string]any{
+		{"projectId": "P", "itemId": "I1", "fieldId": "F", "optionId": "o1"},
+		{"projectId": "P", "itemId": "I1", "fieldId": "F", "optionId": "o2"},
+	}, updates)
+}
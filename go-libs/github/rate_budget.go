@@ -0,0 +1,176 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+var ErrRateBudgetExhausted = errors.New("rate limit budget exhausted")
+
+// defaultSubsystem gets requests made without WithSubsystem
+const defaultSubsystem = "default"
+
+type subsystemKey struct{}
+
+// WithSubsystem attributes requests made with the context to a subsystem
+// registered in the RateBudget.
+func WithSubsystem(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, subsystemKey{}, name)
+}
+
+type partition struct {
+	share    float64
+	priority int
+	spent    int
+}
+
+// RateBudget splits the hourly rate limit across subsystems, like a crawler,
+// a bot, and reports. Every subsystem may spend its share of the limit, and
+// beyond that it may borrow capacity, that is not reserved for subsystems with
+// higher priority, so that background work never starves interactive one.
+// The budget may be shared by clients using the same credentials.
+type RateBudget struct {
+	mu         sync.Mutex
+	limit      int
+	remaining  int
+	reset      time.Time
+	partitions map[string]*partition
+	now        func() time.Time
+}
+
+// NewRateBudget creates a budget for the hourly limit, which is updated from
+// rate limit headers of responses. Requests without a subsystem are accounted
+// to the "default" one with priority zero and no share.
+func NewRateBudget(limit int) *RateBudget {
+	return &RateBudget{
+		limit:     limit,
+		remaining: limit,
+		partitions: map[string]*partition{
+			defaultSubsystem: {},
+		},
+		now: time.Now,
+	}
+}
+
+// Register allocates a share of the limit, like 0.2 for 20%, to a subsystem.
+func (b *RateBudget) Register(name string, share float64, priority int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.partitions[name] = &partition{share: share, priority: priority}
+}
+
+// Spent returns the number of requests made by the subsystem in the current window.
+func (b *RateBudget) Spent(name string) int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	p, ok := b.partitions[name]
+	if !ok {
+		return 0
+	}
+	return p.spent
+}
+
+func (b *RateBudget) allowance(p *partition) int {
+	return int(p.share * float64(b.limit))
+}
+
+// acquire accounts for a request of the subsystem, or fails, if it would
+// use capacity reserved for subsystems with higher priority.
+func (b *RateBudget) acquire(name string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if !b.reset.IsZero() && b.now().After(b.reset) {
+		for _, p := range b.partitions {
+			p.spent = 0
+		}
+		b.remaining = b.limit
+		b.reset = time.Time{}
+	}
+	p, ok := b.partitions[name]
+	if !ok {
+		return fmt.Errorf("unknown subsystem: %s", name)
+	}
+	if b.remaining <= 0 {
+		return b.exhausted(name)
+	}
+	if p.spent < b.allowance(p) {
+		p.spent++
+		b.remaining--
+		return nil
+	}
+	reserved := 0
+	for _, other := range b.partitions {
+		if other.priority <= p.priority {
+			continue
+		}
+		unspent := b.allowance(other) - other.spent
+		if unspent > 0 {
+			reserved += unspent
+		}
+	}
+	if b.remaining-reserved <= 0 {
+		return b.exhausted(name)
+	}
+	p.spent++
+	b.remaining--
+	return nil
+}
+
+func (b *RateBudget) exhausted(name string) error {
+	if b.reset.IsZero() {
+		return fmt.Errorf("%s: %w", name, ErrRateBudgetExhausted)
+	}
+	return fmt.Errorf("%s: %w until %s", name, ErrRateBudgetExhausted, b.reset.Format(time.RFC3339))
+}
+
+// update synchronizes the budget with the actual rate limit, which may also
+// be spent by other processes using the same credentials. Other resources,
+// like search or graphql, have separate limits, so they are ignored.
+func (b *RateBudget) update(h http.Header) {
+	resource := h.Get("X-RateLimit-Resource")
+	if resource != "" && resource != "core" {
+		return
+	}
+	limit, err := strconv.Atoi(h.Get("X-RateLimit-Limit"))
+	if err != nil {
+		return
+	}
+	remaining, err := strconv.Atoi(h.Get("X-RateLimit-Remaining"))
+	if err != nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.limit, b.remaining = limit, remaining
+	reset, err := strconv.ParseInt(h.Get("X-RateLimit-Reset"), 10, 64)
+	if err == nil {
+		b.reset = time.Unix(reset, 0)
+	}
+}
+
+type rateBudgetTransport struct {
+	next   http.RoundTripper
+	budget *RateBudget
+}
+
+func (t *rateBudgetTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	name, ok := r.Context().Value(subsystemKey{}).(string)
+	if !ok {
+		name = defaultSubsystem
+	}
+	err := t.budget.acquire(name)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := t.next.RoundTrip(r)
+	if err != nil {
+		return nil, err
+	}
+	t.budget.update(resp.Header)
+	return resp, nil
+}
@@ -0,0 +1,72 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateBudgetReservesCapacityForHigherPriority(t *testing.T) {
+	budget := NewRateBudget(10)
+	budget.Register("crawler", 0.3, 0)
+	budget.Register("bot", 0.5, 10)
+
+	for i := 0; i < 3; i++ {
+		require.NoError(t, budget.acquire("crawler"))
+	}
+	// 7 remaining, 5 reserved for the bot, so the crawler may borrow 2
+	require.NoError(t, budget.acquire("crawler"))
+	require.NoError(t, budget.acquire("crawler"))
+	assert.ErrorIs(t, budget.acquire("crawler"), ErrRateBudgetExhausted)
+	assert.ErrorIs(t, budget.acquire(defaultSubsystem), ErrRateBudgetExhausted)
+
+	// the bot spends its share and then may borrow from the rest
+	for i := 0; i < 5; i++ {
+		require.NoError(t, budget.acquire("bot"))
+	}
+	assert.ErrorIs(t, budget.acquire("bot"), ErrRateBudgetExhausted)
+	assert.Equal(t, 5, budget.Spent("crawler"))
+}
+
+func TestRateBudgetTransport(t *testing.T) {
+	budget := NewRateBudget(5000)
+	budget.Register("reports", 0.1, 0)
+	client := NewClient(&GitHubConfig{
+		GitHubTokenSource: GitHubTokenSource{Pat: "x"},
+		RateBudget:        budget,
+		transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			resp := jsonResponse(200, `{}`)
+			resp.Header.Set("X-RateLimit-Limit", "100")
+			resp.Header.Set("X-RateLimit-Remaining", "0")
+			resp.Header.Set("X-RateLimit-Reset", "4102444800")
+			return resp, nil
+		}),
+	})
+	ctx := WithSubsystem(context.Background(), "reports")
+	_, err := client.GetRepo(ctx, "a", "b")
+	require.NoError(t, err)
+	assert.Equal(t, 1, budget.Spent("reports"))
+	// nothing remains, even though the share is not spent
+	_, err = client.GetRepo(ctx, "a", "b")
+	assert.ErrorIs(t, err, ErrRateBudgetExhausted)
+}
+
+func TestRateBudgetIgnoresOtherResources(t *testing.T) {
+	budget := NewRateBudget(5000)
+	budget.update(http.Header{
+		"X-Ratelimit-Resource":  []string{"search"},
+		"X-Ratelimit-Limit":     []string{"30"},
+		"X-Ratelimit-Remaining": []string{"0"},
+	})
+	require.NoError(t, budget.acquire(defaultSubsystem))
+
+	budget.update(http.Header{
+		"X-Ratelimit-Resource":  []string{"core"},
+		"X-Ratelimit-Limit":     []string{"5000"},
+		"X-Ratelimit-Remaining": []string{"0"},
+	})
+	assert.ErrorIs(t, budget.acquire(defaultSubsystem), ErrRateBudgetExhausted)
+}
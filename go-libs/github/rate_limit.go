@@ -0,0 +1,105 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/databricks/databricks-sdk-go/httpclient"
+)
+
+// RateLimit is the budget of requests of a resource, like core or search,
+// within the current window.
+// See https://docs.github.com/en/rest/rate-limit/rate-limit
+type RateLimit struct {
+	// Resource is empty for limits from GetRateLimit, where it's the key.
+	Resource  string `json:"resource,omitempty"`
+	Limit     int    `json:"limit"`
+	Remaining int    `json:"remaining"`
+	Used      int    `json:"used"`
+
+	// Reset is the time of the end of the window in UTC epoch seconds.
+	Reset int64 `json:"reset"`
+}
+
+// ResetTime is the end of the window, when Remaining becomes Limit again.
+func (l RateLimit) ResetTime() time.Time {
+	return time.Unix(l.Reset, 0)
+}
+
+type RateLimits struct {
+	Core       RateLimit `json:"core"`
+	Search     RateLimit `json:"search"`
+	GraphQL    RateLimit `json:"graphql"`
+	CodeSearch RateLimit `json:"code_search"`
+}
+
+// GetRateLimit returns budgets of all resources. The request doesn't count
+// towards the rate limit.
+func (c *GitHubClient) GetRateLimit(ctx context.Context) (*RateLimits, error) {
+	path := fmt.Sprintf("%s/rate_limit", gitHubAPI)
+	var res struct {
+		Resources RateLimits `json:"resources"`
+	}
+	err := c.api.Do(ctx, "GET", path, httpclient.WithResponseUnmarshal(&res))
+	if err != nil {
+		return nil, err
+	}
+	return &res.Resources, nil
+}
+
+// LastRateLimit returns the rate limit reported by the most recent response,
+// so that crawlers can pace themselves without extra requests. It reports
+// false, if no response had rate limit headers yet.
+func (c *GitHubClient) LastRateLimit() (RateLimit, bool) {
+	return c.rateLimits.last()
+}
+
+// rateLimitTracker remembers the rate limit headers of the last response.
+type rateLimitTracker struct {
+	mu    sync.Mutex
+	limit *RateLimit
+}
+
+func (t *rateLimitTracker) last() (RateLimit, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.limit == nil {
+		return RateLimit{}, false
+	}
+	return *t.limit, true
+}
+
+func (t *rateLimitTracker) update(h http.Header) {
+	limit, err := strconv.Atoi(h.Get("X-RateLimit-Limit"))
+	if err != nil {
+		return
+	}
+	l := &RateLimit{
+		Resource: h.Get("X-RateLimit-Resource"),
+		Limit:    limit,
+	}
+	l.Remaining, _ = strconv.Atoi(h.Get("X-RateLimit-Remaining"))
+	l.Used, _ = strconv.Atoi(h.Get("X-RateLimit-Used"))
+	l.Reset, _ = strconv.ParseInt(h.Get("X-RateLimit-Reset"), 10, 64)
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.limit = l
+}
+
+type rateLimitTransport struct {
+	next    http.RoundTripper
+	tracker *rateLimitTracker
+}
+
+func (t *rateLimitTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	resp, err := t.next.RoundTrip(r)
+	if err != nil {
+		return nil, err
+	}
+	t.tracker.update(resp.Header)
+	return resp, nil
+}
@@ -0,0 +1,60 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetRateLimit(t *testing.T) {
+	client := NewClient(&GitHubConfig{
+		GitHubTokenSource: GitHubTokenSource{Pat: "x"},
+		transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			assert.Equal(t, "/rate_limit", r.URL.Path)
+			return jsonResponse(200, `{"resources": {
+				"core": {"limit": 5000, "remaining": 4999, "used": 1, "reset": 1700000000},
+				"search": {"limit": 30, "remaining": 18, "used": 12, "reset": 1700000060},
+				"graphql": {"limit": 5000, "remaining": 4993, "used": 7, "reset": 1700000000}
+			}}`), nil
+		}),
+	})
+	limits, err := client.GetRateLimit(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 4999, limits.Core.Remaining)
+	assert.Equal(t, 12, limits.Search.Used)
+	assert.Equal(t, time.Unix(1700000060, 0), limits.Search.ResetTime())
+	assert.Equal(t, 5000, limits.GraphQL.Limit)
+}
+
+func TestLastRateLimit(t *testing.T) {
+	client := NewClient(&GitHubConfig{
+		GitHubTokenSource: GitHubTokenSource{Pat: "x"},
+		transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			resp := jsonResponse(200, `{}`)
+			resp.Header.Set("X-RateLimit-Limit", "5000")
+			resp.Header.Set("X-RateLimit-Remaining", "4321")
+			resp.Header.Set("X-RateLimit-Used", "679")
+			resp.Header.Set("X-RateLimit-Reset", "1700000000")
+			resp.Header.Set("X-RateLimit-Resource", "core")
+			return resp, nil
+		}),
+	})
+	_, ok := client.LastRateLimit()
+	assert.False(t, ok)
+
+	_, err := client.GetRepo(context.Background(), "a", "b")
+	require.NoError(t, err)
+	limit, ok := client.LastRateLimit()
+	require.True(t, ok)
+	assert.Equal(t, RateLimit{
+		Resource:  "core",
+		Limit:     5000,
+		Remaining: 4321,
+		Used:      679,
+		Reset:     1700000000,
+	}, limit)
+}
@@ -0,0 +1,110 @@
+package github
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/databricks/databricks-sdk-go/logger"
+)
+
+const (
+	// defaultRateLimitTimeout is the same as the default retry timeout of
+	// the API client.
+	defaultRateLimitTimeout = 5 * time.Minute
+
+	// secondaryRateLimitWait is recommended by GitHub for secondary rate
+	// limits, which don't say when requests are allowed again.
+	secondaryRateLimitWait = 1 * time.Minute
+
+	// minRateLimitWait protects from clock skew, when the reset time of the
+	// primary rate limit is already in the past.
+	minRateLimitWait = 1 * time.Second
+)
+
+// rateLimitWaiter sleeps until rate limits reset and retries the requests,
+// as long as that happens within the retry timeout. Otherwise, responses are
+// passed through and are reported as RateLimitError.
+type rateLimitWaiter struct {
+	next    http.RoundTripper
+	timeout time.Duration
+	onWait  func(ctx context.Context, wait time.Duration, secondary bool)
+	now     func() time.Time
+	sleep   func(ctx context.Context, d time.Duration) error
+}
+
+func newRateLimitWaiter(cfg *GitHubConfig, next http.RoundTripper) *rateLimitWaiter {
+	w := &rateLimitWaiter{
+		next:    next,
+		timeout: cfg.RetryTimeout,
+		onWait:  cfg.OnRateLimitWait,
+		now:     time.Now,
+		sleep:   sleepContext,
+	}
+	if w.timeout == 0 {
+		w.timeout = defaultRateLimitTimeout
+	}
+	return w
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+func (w *rateLimitWaiter) RoundTrip(r *http.Request) (*http.Response, error) {
+	ctx := r.Context()
+	deadline := w.now().Add(w.timeout)
+	for {
+		resp, err := w.next.RoundTrip(r)
+		if err != nil || !isRateLimited(resp.StatusCode, resp.Header) {
+			return resp, err
+		}
+		wait, secondary := w.waitFor(resp.Header)
+		if w.now().Add(wait).After(deadline) {
+			return resp, nil
+		}
+		if r.Body != nil && r.GetBody == nil {
+			// the body was consumed and cannot be sent again
+			return resp, nil
+		}
+		io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+		logger.Warnf(ctx, "Rate limited on %s %s, waiting %s", r.Method, r.URL.Path, wait)
+		if w.onWait != nil {
+			w.onWait(ctx, wait, secondary)
+		}
+		err = w.sleep(ctx, wait)
+		if err != nil {
+			return nil, err
+		}
+		r = r.Clone(ctx)
+		if r.GetBody != nil {
+			r.Body, err = r.GetBody()
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+}
+
+func (w *rateLimitWaiter) waitFor(h http.Header) (time.Duration, bool) {
+	now := w.now()
+	secondary := h.Get("Retry-After") != ""
+	reset := rateLimitReset(h, now)
+	if reset.IsZero() {
+		return secondaryRateLimitWait, true
+	}
+	wait := reset.Sub(now)
+	if !secondary && wait < minRateLimitWait {
+		wait = minRateLimitWait
+	}
+	return wait, secondary
+}
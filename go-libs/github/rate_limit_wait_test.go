@@ -0,0 +1,98 @@
+package github
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimitWaiterRetriesAfterSecondaryLimit(t *testing.T) {
+	var bodies []string
+	var waits []time.Duration
+	var slept time.Duration
+	w := newRateLimitWaiter(&GitHubConfig{
+		OnRateLimitWait: func(ctx context.Context, wait time.Duration, secondary bool) {
+			assert.True(t, secondary)
+			waits = append(waits, wait)
+		},
+	}, roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		raw, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		bodies = append(bodies, string(raw))
+		if len(bodies) == 1 {
+			resp := jsonResponse(403, `{"message": "secondary rate limit"}`)
+			resp.Header.Set("Retry-After", "30")
+			return resp, nil
+		}
+		return jsonResponse(201, `{}`), nil
+	}))
+	w.sleep = func(ctx context.Context, d time.Duration) error {
+		slept += d
+		return nil
+	}
+	req, err := http.NewRequest("POST", "https://api.github.com/a", strings.NewReader(`{"a": 1}`))
+	require.NoError(t, err)
+	resp, err := w.RoundTrip(req)
+	require.NoError(t, err)
+	assert.Equal(t, 201, resp.StatusCode)
+	assert.Equal(t, []string{`{"a": 1}`, `{"a": 1}`}, bodies)
+	assert.Equal(t, []time.Duration{30 * time.Second}, waits)
+	assert.Equal(t, 30*time.Second, slept)
+}
+
+func TestRateLimitWaiterGivesUpAfterRetryTimeout(t *testing.T) {
+	calls := 0
+	w := newRateLimitWaiter(&GitHubConfig{
+		RetryTimeout: time.Minute,
+	}, roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		calls++
+		resp := jsonResponse(403, `{"message": "API rate limit exceeded"}`)
+		resp.Header.Set("X-RateLimit-Remaining", "0")
+		resp.Header.Set("X-RateLimit-Reset", "1700003600")
+		return resp, nil
+	}))
+	w.now = func() time.Time { return time.Unix(1700000000, 0) }
+	w.sleep = func(ctx context.Context, d time.Duration) error {
+		t.Fatalf("unexpected sleep for %s", d)
+		return nil
+	}
+	req, err := http.NewRequest("GET", "https://api.github.com/a", nil)
+	require.NoError(t, err)
+	resp, err := w.RoundTrip(req)
+	require.NoError(t, err)
+	assert.Equal(t, 403, resp.StatusCode)
+	assert.Equal(t, 1, calls)
+}
+
+func TestRateLimitWaiterWaitsForPrimaryReset(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	calls := 0
+	w := newRateLimitWaiter(&GitHubConfig{}, roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		calls++
+		if calls > 1 {
+			return jsonResponse(200, `{}`), nil
+		}
+		resp := jsonResponse(429, `{}`)
+		resp.Header.Set("X-RateLimit-Remaining", "0")
+		resp.Header.Set("X-RateLimit-Reset", "1700000090")
+		return resp, nil
+	}))
+	w.now = func() time.Time { return now }
+	w.sleep = func(ctx context.Context, d time.Duration) error {
+		assert.Equal(t, 90*time.Second, d)
+		now = now.Add(d)
+		return nil
+	}
+	req, err := http.NewRequest("GET", "https://api.github.com/a", nil)
+	require.NoError(t, err)
+	resp, err := w.RoundTrip(req)
+	require.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+	assert.Equal(t, 2, calls)
+}
@@ -0,0 +1,186 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/databricks/databricks-sdk-go/httpclient"
+)
+
+var ErrRefNotFound = errors.New("ref not found")
+
+// ResolveRef resolves a branch name, a tag, or a short SHA to the full SHA
+// of the commit with a single request. Annotated tags are peeled to commits.
+func (c *GitHubClient) ResolveRef(ctx context.Context, org, repo, ref string) (string, error) {
+	path := fmt.Sprintf("%s/repos/%s/%s/commits/%s", gitHubAPI, org, repo, escapeRef(ref))
+	var buf bytes.Buffer
+	err := c.api.Do(ctx, "GET", path,
+		httpclient.WithRequestHeader("Accept", "application/vnd.github.sha"),
+		httpclient.WithResponseUnmarshal(&buf))
+	var apiErr *httpclient.HttpError
+	// unknown commits are reported as unprocessable entities
+	if errors.As(err, &apiErr) && (apiErr.StatusCode == http.StatusNotFound ||
+		apiErr.StatusCode == http.StatusUnprocessableEntity) {
+		return "", fmt.Errorf("%s: %w", ref, ErrRefNotFound)
+	}
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(buf.String()), nil
+}
+
+// GitRef is a branch, like refs/heads/main, or a tag, like refs/tags/v1.0.0.
+type GitRef struct {
+	Ref    string `json:"ref"`
+	Object struct {
+		// Type is commit for branches and lightweight tags, and tag for
+		// annotated tags.
+		Type string `json:"type"`
+		SHA  string `json:"sha"`
+	} `json:"object"`
+}
+
+// gitRefPath returns the ref without the refs/ prefix, as used in paths
+// of the git refs API.
+func gitRefPath(ref string) string {
+	return escapeRef(strings.TrimPrefix(ref, "refs/"))
+}
+
+// GetRef returns the ref, like heads/main or tags/v1.0.0, with or without
+// the refs/ prefix. Unlike ResolveRef, the name is matched exactly and tags
+// are not peeled. Missing refs fail with ErrRefNotFound.
+// See https://docs.github.com/en/rest/git/refs
+func (c *GitHubClient) GetRef(ctx context.Context, org, repo, ref string) (*GitRef, error) {
+	path := fmt.Sprintf("%s/repos/%s/%s/git/ref/%s", gitHubAPI, org, repo, gitRefPath(ref))
+	var res GitRef
+	err := c.api.Do(ctx, "GET", path, httpclient.WithResponseUnmarshal(&res))
+	if errors.Is(err, ErrNotFound) {
+		return nil, fmt.Errorf("%s: %w", ref, ErrRefNotFound)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// CreateRef points a new ref at the object. Existing refs fail with
+// ErrValidation.
+func (c *GitHubClient) CreateRef(ctx context.Context, org, repo, ref, sha string) (*GitRef, error) {
+	path := fmt.Sprintf("%s/repos/%s/%s/git/refs", gitHubAPI, org, repo)
+	if !strings.HasPrefix(ref, "refs/") {
+		ref = "refs/" + ref
+	}
+	var res GitRef
+	err := c.api.Do(ctx, "POST", path,
+		httpclient.WithRequestData(map[string]string{
+			"ref": ref,
+			"sha": sha,
+		}),
+		httpclient.WithResponseUnmarshal(&res))
+	if err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// UpdateRef points the ref at the commit. Unless forced, the commit must be
+// a descendant of the current one, or the update fails with ErrValidation.
+func (c *GitHubClient) UpdateRef(ctx context.Context, org, repo, ref, sha string, force bool) (*GitRef, error) {
+	path := fmt.Sprintf("%s/repos/%s/%s/git/refs/%s", gitHubAPI, org, repo, gitRefPath(ref))
+	var res GitRef
+	err := c.api.Do(ctx, "PATCH", path,
+		httpclient.WithRequestData(map[string]any{
+			"sha":   sha,
+			"force": force,
+		}),
+		httpclient.WithResponseUnmarshal(&res))
+	if err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+func (c *GitHubClient) DeleteRef(ctx context.Context, org, repo, ref string) error {
+	path := fmt.Sprintf("%s/repos/%s/%s/git/refs/%s", gitHubAPI, org, repo, gitRefPath(ref))
+	return c.api.Do(ctx, "DELETE", path)
+}
+
+// createBranch points a new branch at the commit.
+func (c *GitHubClient) createBranch(ctx context.Context, org, repo, branch, sha string) error {
+	_, err := c.CreateRef(ctx, org, repo, "refs/heads/"+branch, sha)
+	return err
+}
+
+var ErrNotFastForward = errors.New("not a fast-forward")
+
+// fastForward points the branch at the commit, which must be a descendant of
+// the current one, or fails with ErrValidation.
+func (c *GitHubClient) fastForward(ctx context.Context, org, repo, branch, sha string) error {
+	_, err := c.UpdateRef(ctx, org, repo, "heads/"+branch, sha, false)
+	return err
+}
+
+// branchHead returns the commit of the branch, matching its name exactly,
+// unlike ResolveRef, which may resolve a tag with the same name.
+func (c *GitHubClient) branchHead(ctx context.Context, org, repo, branch string) (string, error) {
+	ref, err := c.GetRef(ctx, org, repo, "heads/"+branch)
+	if err != nil {
+		return "", err
+	}
+	return ref.Object.SHA, nil
+}
+
+// EnsureBranch makes the branch contain the commit of fromRef: a missing
+// branch is created, and a branch behind fromRef is fast-forwarded. Branches,
+// that are ahead of fromRef, are left as they are, and diverged ones fail with
+// ErrNotFastForward. Returns the commit, that the branch points to.
+func (c *GitHubClient) EnsureBranch(ctx context.Context, org, repo, branch, fromRef string) (string, error) {
+	sha, err := c.ResolveRef(ctx, org, repo, fromRef)
+	if err != nil {
+		return "", err
+	}
+	head, err := c.branchHead(ctx, org, repo, branch)
+	if errors.Is(err, ErrRefNotFound) {
+		err = c.createBranch(ctx, org, repo, branch, sha)
+		if !errors.Is(err, ErrValidation) {
+			return sha, err
+		}
+		// created concurrently
+		head, err = c.branchHead(ctx, org, repo, branch)
+	}
+	if err != nil {
+		return "", err
+	}
+	if head == sha {
+		return sha, nil
+	}
+	err = c.fastForward(ctx, org, repo, branch, sha)
+	if !errors.Is(err, ErrValidation) {
+		return sha, err
+	}
+	status, err := c.compareStatus(ctx, org, repo, sha, head)
+	if err != nil {
+		return "", fmt.Errorf("compare: %w", err)
+	}
+	if status == "ahead" {
+		return head, nil
+	}
+	return "", fmt.Errorf("%s is %s %s: %w", branch, status, fromRef, ErrNotFastForward)
+}
+
+// compareStatus tells if head is ahead, behind, identical, or diverged
+// from base.
+func (c *GitHubClient) compareStatus(ctx context.Context, org, repo, base, head string) (string, error) {
+	path := fmt.Sprintf("%s/repos/%s/%s/compare/%s...%s", gitHubAPI, org, repo, base, head)
+	var res struct {
+		Status string `json:"status"`
+	}
+	err := c.api.Do(ctx, "GET", path,
+		httpclient.WithRequestData(listOptions{PerPage: 1}),
+		httpclient.WithResponseUnmarshal(&res))
+	return res.Status, err
+}
@@ -0,0 +1,129 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveRef(t *testing.T) {
+	client := NewClient(&GitHubConfig{
+		GitHubTokenSource: GitHubTokenSource{Pat: "x"},
+		transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			assert.Equal(t, "application/vnd.github.sha", r.Header.Get("Accept"))
+			switch r.URL.Path {
+			case "/repos/a/b/commits/release/v0.1":
+				return jsonResponse(200, "0123456789abcdef0123456789abcdef01234567"), nil
+			}
+			return jsonResponse(422, `{"message": "No commit found for SHA: nope"}`), nil
+		}),
+	})
+	sha, err := client.ResolveRef(context.Background(), "a", "b", "release/v0.1")
+	require.NoError(t, err)
+	assert.Equal(t, "0123456789abcdef0123456789abcdef01234567", sha)
+
+	_, err = client.ResolveRef(context.Background(), "a", "b", "nope")
+	assert.ErrorIs(t, err, ErrRefNotFound)
+}
+
+func ensureBranchClient(t *testing.T, head, status string, calls *[]string) *GitHubClient {
+	return NewClient(&GitHubConfig{
+		GitHubTokenSource: GitHubTokenSource{Pat: "x"},
+		transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			*calls = append(*calls, r.Method+" "+r.URL.Path)
+			switch r.Method + " " + r.URL.Path {
+			case "GET /repos/a/b/commits/main":
+				return jsonResponse(200, "new"), nil
+			case "GET /repos/a/b/git/ref/heads/bulk/update":
+				if head == "" {
+					return jsonResponse(404, `{"message": "Not Found"}`), nil
+				}
+				return jsonResponse(200, `{"object": {"sha": "`+head+`"}}`), nil
+			case "POST /repos/a/b/git/refs":
+				return jsonResponse(201, `{}`), nil
+			case "PATCH /repos/a/b/git/refs/heads/bulk/update":
+				if status != "behind" {
+					return jsonResponse(422, `{"message": "Update is not a fast forward"}`), nil
+				}
+				return jsonResponse(200, `{}`), nil
+			case "GET /repos/a/b/compare/new...old":
+				return jsonResponse(200, `{"status": "`+status+`"}`), nil
+			}
+			t.Fatalf("unexpected %s %s", r.Method, r.URL.Path)
+			return nil, nil
+		}),
+	})
+}
+
+func TestEnsureBranch(t *testing.T) {
+	ctx := context.Background()
+	var calls []string
+	sha, err := ensureBranchClient(t, "", "", &calls).EnsureBranch(ctx, "a", "b", "bulk/update", "main")
+	require.NoError(t, err)
+	assert.Equal(t, "new", sha)
+	assert.Contains(t, calls, "POST /repos/a/b/git/refs")
+
+	calls = nil
+	sha, err = ensureBranchClient(t, "new", "", &calls).EnsureBranch(ctx, "a", "b", "bulk/update", "main")
+	require.NoError(t, err)
+	assert.Equal(t, "new", sha)
+	assert.Len(t, calls, 2)
+
+	calls = nil
+	sha, err = ensureBranchClient(t, "old", "behind", &calls).EnsureBranch(ctx, "a", "b", "bulk/update", "main")
+	require.NoError(t, err)
+	assert.Equal(t, "new", sha)
+	assert.Contains(t, calls, "PATCH /repos/a/b/git/refs/heads/bulk/update")
+
+	calls = nil
+	sha, err = ensureBranchClient(t, "old", "ahead", &calls).EnsureBranch(ctx, "a", "b", "bulk/update", "main")
+	require.NoError(t, err)
+	assert.Equal(t, "old", sha)
+
+	calls = nil
+	_, err = ensureBranchClient(t, "old", "diverged", &calls).EnsureBranch(ctx, "a", "b", "bulk/update", "main")
+	assert.ErrorIs(t, err, ErrNotFastForward)
+}
+
+func TestRefs(t *testing.T) {
+	var calls []string
+	client := NewClient(&GitHubConfig{
+		GitHubTokenSource: GitHubTokenSource{Pat: "x"},
+		transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			calls = append(calls, r.Method+" "+r.URL.Path)
+			switch r.Method + " " + r.URL.Path {
+			case "GET /repos/a/b/git/ref/heads/main":
+				return jsonResponse(200, `{"ref": "refs/heads/main", "object": {"type": "commit", "sha": "abc"}}`), nil
+			case "GET /repos/a/b/git/ref/heads/nope":
+				return jsonResponse(404, `{"message": "Not Found"}`), nil
+			case "POST /repos/a/b/git/refs", "PATCH /repos/a/b/git/refs/heads/feature":
+				return jsonResponse(201, `{"ref": "refs/heads/feature", "object": {"sha": "def"}}`), nil
+			case "DELETE /repos/a/b/git/refs/heads/feature":
+				return jsonResponse(204, ``), nil
+			}
+			t.Fatalf("unexpected %s %s", r.Method, r.URL.Path)
+			return nil, nil
+		}),
+	})
+	ctx := context.Background()
+	ref, err := client.GetRef(ctx, "a", "b", "refs/heads/main")
+	require.NoError(t, err)
+	assert.Equal(t, "abc", ref.Object.SHA)
+
+	_, err = client.GetRef(ctx, "a", "b", "heads/nope")
+	assert.ErrorIs(t, err, ErrRefNotFound)
+
+	ref, err = client.CreateRef(ctx, "a", "b", "heads/feature", "def")
+	require.NoError(t, err)
+	assert.Equal(t, "refs/heads/feature", ref.Ref)
+
+	_, err = client.UpdateRef(ctx, "a", "b", "refs/heads/feature", "def", true)
+	require.NoError(t, err)
+
+	err = client.DeleteRef(ctx, "a", "b", "heads/feature")
+	require.NoError(t, err)
+	assert.Len(t, calls, 5)
+}
@@ -0,0 +1,381 @@
+package github
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/databricks/databricks-sdk-go/httpclient"
+	"github.com/databricks/databricks-sdk-go/logger"
+)
+
+// GetLatestRelease returns the most recent published release, that is not
+// a prerelease, unless another release is marked as the latest one.
+func (c *GitHubClient) GetLatestRelease(ctx context.Context, org, repo string) (*Release, error) {
+	path := fmt.Sprintf("%s/repos/%s/%s/releases/latest", gitHubAPI, org, repo)
+	var res Release
+	err := c.api.Do(ctx, "GET", path, httpclient.WithResponseUnmarshal(&res))
+	return &res, err
+}
+
+// DownloadAsset writes the contents of a release asset to w. Unlike browser
+// download URLs, this also works for assets of private repositories.
+func (c *GitHubClient) DownloadAsset(ctx context.Context, org, repo string, assetID int64, w io.Writer) error {
+	path := fmt.Sprintf("%s/repos/%s/%s/releases/assets/%d", gitHubAPI, org, repo, assetID)
+	return c.download(ctx, path, w,
+		httpclient.WithRequestHeader("Accept", "application/octet-stream"))
+}
+
+// download writes the body of the response to w, once the request succeeds.
+// Every attempt is spooled to a temporary file, so that a retry after a body
+// was partially read doesn't write the same bytes to w twice.
+func (c *GitHubClient) download(ctx context.Context, path string, w io.Writer, opts ...httpclient.DoOption) error {
+	spool, err := os.CreateTemp("", "download-*")
+	if err != nil {
+		return fmt.Errorf("spool: %w", err)
+	}
+	defer os.Remove(spool.Name())
+	defer spool.Close()
+	ctx = withResponseStream(ctx, func(r io.Reader) error {
+		_, err := spool.Seek(0, io.SeekStart)
+		if err != nil {
+			return err
+		}
+		err = spool.Truncate(0)
+		if err != nil {
+			return err
+		}
+		_, err = io.Copy(spool, r)
+		return err
+	})
+	err = c.api.Do(ctx, "GET", path, opts...)
+	if err != nil {
+		return err
+	}
+	_, err = spool.Seek(0, io.SeekStart)
+	if err != nil {
+		return fmt.Errorf("spool: %w", err)
+	}
+	_, err = io.Copy(w, spool)
+	return err
+}
+
+var osAliases = map[string][]string{
+	"darwin":  {"darwin", "macos", "osx", "apple"},
+	"linux":   {"linux"},
+	"windows": {"windows", "win", "win64", "win32"},
+	"freebsd": {"freebsd"},
+}
+
+var archAliases = map[string][]string{
+	"amd64": {"amd64", "x64", "64bit"},
+	"arm64": {"arm64", "aarch64"},
+	"386":   {"386", "i386", "i686", "x86", "32bit"},
+	"arm":   {"arm", "armv6", "armv7", "armhf"},
+}
+
+// auxiliarySuffixes are assets published next to binaries, that are never
+// binaries themselves.
+var auxiliarySuffixes = []string{
+	".sha256", ".sha256sum", ".sha512", ".md5", ".sig", ".asc", ".pem",
+	".sbom", ".spdx", ".json", ".txt", ".deb", ".rpm", ".apk", ".msi", ".pkg",
+}
+
+// ResolveAsset picks the asset of a release, that is built for goos and goarch,
+// recognising common spellings like "x86_64" or "macos". Optional namePattern
+// is a glob, like "databricks_cli_*", to narrow down assets of releases with
+// more than one tool. Raw binaries are preferred over .tar.gz and .zip archives.
+func ResolveAsset(release Release, goos, goarch, namePattern string) (*Asset, error) {
+	var candidates []Asset
+	for _, asset := range release.Assets {
+		name := strings.ToLower(asset.Name)
+		if namePattern != "" {
+			ok, err := path.Match(namePattern, asset.Name)
+			if err != nil {
+				return nil, fmt.Errorf("pattern: %w", err)
+			}
+			if !ok {
+				continue
+			}
+		}
+		if isAuxiliaryAsset(name) {
+			continue
+		}
+		tokens := assetTokens(name)
+		if !hasAnyToken(tokens, osAliases[goos], goos) {
+			continue
+		}
+		if !hasAnyToken(tokens, archAliases[goarch], goarch) {
+			// macOS universal binaries run on every architecture
+			if goos != "darwin" || !hasAnyToken(tokens, []string{"universal", "all"}, "") {
+				continue
+			}
+		}
+		candidates = append(candidates, asset)
+	}
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf("no asset for %s/%s in %s", goos, goarch, release.Version)
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return assetRank(candidates[i].Name) < assetRank(candidates[j].Name)
+	})
+	if len(candidates) > 1 && assetRank(candidates[0].Name) == assetRank(candidates[1].Name) {
+		var names []string
+		for _, v := range candidates {
+			names = append(names, v.Name)
+		}
+		return nil, fmt.Errorf("ambiguous assets for %s/%s in %s: %s",
+			goos, goarch, release.Version, strings.Join(names, ", "))
+	}
+	return &candidates[0], nil
+}
+
+func isAuxiliaryAsset(name string) bool {
+	if strings.Contains(name, "checksums") || strings.Contains(name, "sha256sums") {
+		return true
+	}
+	for _, v := range auxiliarySuffixes {
+		if strings.HasSuffix(name, v) {
+			return true
+		}
+	}
+	return false
+}
+
+func assetTokens(name string) map[string]bool {
+	name = strings.ReplaceAll(name, "x86_64", "amd64")
+	name = strings.ReplaceAll(name, "x86-64", "amd64")
+	tokens := map[string]bool{}
+	for _, v := range strings.FieldsFunc(name, func(r rune) bool {
+		return r == '-' || r == '_' || r == '.' || r == ' '
+	}) {
+		tokens[v] = true
+	}
+	return tokens
+}
+
+func hasAnyToken(tokens map[string]bool, aliases []string, fallback string) bool {
+	if fallback != "" && tokens[fallback] {
+		return true
+	}
+	for _, v := range aliases {
+		if tokens[v] {
+			return true
+		}
+	}
+	return false
+}
+
+func archiveKind(name string) string {
+	name = strings.ToLower(name)
+	switch {
+	case strings.HasSuffix(name, ".tar.gz"), strings.HasSuffix(name, ".tgz"):
+		return "tar.gz"
+	case strings.HasSuffix(name, ".zip"):
+		return "zip"
+	case strings.HasSuffix(name, ".tar.xz"), strings.HasSuffix(name, ".tar.bz2"):
+		return "unsupported"
+	}
+	return ""
+}
+
+func assetRank(name string) int {
+	switch archiveKind(name) {
+	case "":
+		return 0
+	case "tar.gz":
+		return 1
+	case "zip":
+		return 2
+	}
+	return 3
+}
+
+// DownloadLatestBinary installs the binary from the latest release of a
+// repository into dir and returns its path. Binaries are extracted from
+// archives, looking for an executable named after the repository, or the
+// only executable in the archive. Downloads are verified against checksums
+// of the release, if it has them.
+func (c *GitHubClient) DownloadLatestBinary(ctx context.Context, org, repo, namePattern, dir string) (string, error) {
+	release, err := c.GetLatestRelease(ctx, org, repo)
+	if err != nil {
+		return "", fmt.Errorf("latest release: %w", err)
+	}
+	asset, err := ResolveAsset(*release, runtime.GOOS, runtime.GOARCH, namePattern)
+	if err != nil {
+		return "", err
+	}
+	logger.Infof(ctx, "Downloading %s from %s/%s@%s", asset.Name, org, repo, release.Version)
+	tmp, err := os.CreateTemp(dir, ".download-*")
+	if err != nil {
+		return "", fmt.Errorf("temp: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+	err = c.DownloadAsset(ctx, org, repo, asset.ID, tmp)
+	if err != nil {
+		return "", fmt.Errorf("download %s: %w", asset.Name, err)
+	}
+	err = c.VerifyAsset(ctx, org, repo, *release, *asset, tmp.Name(), VerifyOptions{})
+	if errors.Is(err, ErrNoChecksums) {
+		logger.Warnf(ctx, "Cannot verify %s: %s", asset.Name, err)
+	} else if err != nil {
+		return "", fmt.Errorf("verify: %w", err)
+	}
+	binary := repo
+	if runtime.GOOS == "windows" {
+		binary += ".exe"
+	}
+	switch archiveKind(asset.Name) {
+	case "":
+		_, err = tmp.Seek(0, io.SeekStart)
+		if err != nil {
+			return "", err
+		}
+		target := filepath.Join(dir, binary)
+		return target, writeExecutable(tmp, target)
+	case "tar.gz":
+		return extractTarGz(tmp.Name(), binary, dir)
+	case "zip":
+		return extractZip(tmp.Name(), binary, dir)
+	}
+	return "", fmt.Errorf("unsupported archive: %s", asset.Name)
+}
+
+func extractTarGz(archive, binary, dir string) (string, error) {
+	find := func(fn func(hdr *tar.Header, r io.Reader) (bool, error)) error {
+		f, err := os.Open(archive)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		gz, err := gzip.NewReader(f)
+		if err != nil {
+			return fmt.Errorf("gzip: %w", err)
+		}
+		tr := tar.NewReader(gz)
+		for {
+			hdr, err := tr.Next()
+			if err == io.EOF {
+				return nil
+			}
+			if err != nil {
+				return fmt.Errorf("tar: %w", err)
+			}
+			if hdr.Typeflag != tar.TypeReg {
+				continue
+			}
+			done, err := fn(hdr, tr)
+			if err != nil || done {
+				return err
+			}
+		}
+	}
+	var executables []string
+	err := find(func(hdr *tar.Header, _ io.Reader) (bool, error) {
+		name := path.Base(hdr.Name)
+		if name == binary {
+			executables = []string{hdr.Name}
+			return true, nil
+		}
+		if hdr.Mode&0o111 != 0 {
+			executables = append(executables, hdr.Name)
+		}
+		return false, nil
+	})
+	if err != nil {
+		return "", err
+	}
+	entry, err := pickExecutable(executables, binary)
+	if err != nil {
+		return "", err
+	}
+	target := filepath.Join(dir, path.Base(entry))
+	err = find(func(hdr *tar.Header, r io.Reader) (bool, error) {
+		if hdr.Name != entry {
+			return false, nil
+		}
+		return true, writeExecutable(r, target)
+	})
+	return target, err
+}
+
+func extractZip(archive, binary, dir string) (string, error) {
+	zr, err := zip.OpenReader(archive)
+	if err != nil {
+		return "", fmt.Errorf("zip: %w", err)
+	}
+	defer zr.Close()
+	var executables []string
+	files := map[string]*zip.File{}
+	for _, f := range zr.File {
+		if f.FileInfo().IsDir() {
+			continue
+		}
+		files[f.Name] = f
+		name := path.Base(f.Name)
+		if name == binary {
+			executables = []string{f.Name}
+			break
+		}
+		if f.Mode()&0o111 != 0 || strings.HasSuffix(name, ".exe") {
+			executables = append(executables, f.Name)
+		}
+	}
+	entry, err := pickExecutable(executables, binary)
+	if err != nil {
+		return "", err
+	}
+	r, err := files[entry].Open()
+	if err != nil {
+		return "", err
+	}
+	defer r.Close()
+	target := filepath.Join(dir, path.Base(entry))
+	return target, writeExecutable(r, target)
+}
+
+func pickExecutable(executables []string, binary string) (string, error) {
+	switch len(executables) {
+	case 0:
+		return "", fmt.Errorf("no %s in archive", binary)
+	case 1:
+		return executables[0], nil
+	}
+	return "", fmt.Errorf("no %s in archive, but many executables: %s",
+		binary, strings.Join(executables, ", "))
+}
+
+// writeExecutable replaces target atomically, so that a running binary
+// is not corrupted by a failed download.
+func writeExecutable(r io.Reader, target string) error {
+	tmp, err := os.CreateTemp(filepath.Dir(target), ".binary-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	_, err = io.Copy(tmp, r)
+	if err != nil {
+		tmp.Close()
+		return fmt.Errorf("write: %w", err)
+	}
+	err = tmp.Close()
+	if err != nil {
+		return err
+	}
+	err = os.Chmod(tmp.Name(), 0o755)
+	if err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), target)
+}
@@ -0,0 +1,81 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveAsset(t *testing.T) {
+	release := Release{
+		Version: "v1.2.3",
+		Assets: []Asset{
+			{Name: "tool_1.2.3_checksums.txt"},
+			{Name: "tool_1.2.3_Darwin_x86_64.tar.gz"},
+			{Name: "tool_1.2.3_Darwin_arm64.tar.gz"},
+			{Name: "tool_1.2.3_Darwin_arm64.tar.gz.sig"},
+			{Name: "tool_1.2.3_linux_amd64.zip"},
+			{Name: "tool_1.2.3_linux_amd64"},
+			{Name: "tool_1.2.3_linux_arm64.deb"},
+			{Name: "tool_1.2.3_windows_amd64.zip"},
+			{Name: "other_1.2.3_windows_amd64.zip"},
+		},
+	}
+	for _, tc := range []struct {
+		goos, goarch, pattern, expected string
+	}{
+		{"darwin", "amd64", "", "tool_1.2.3_Darwin_x86_64.tar.gz"},
+		{"darwin", "arm64", "", "tool_1.2.3_Darwin_arm64.tar.gz"},
+		{"linux", "amd64", "", "tool_1.2.3_linux_amd64"},
+		{"windows", "amd64", "tool_*", "tool_1.2.3_windows_amd64.zip"},
+	} {
+		asset, err := ResolveAsset(release, tc.goos, tc.goarch, tc.pattern)
+		require.NoError(t, err, tc.goos+"/"+tc.goarch)
+		assert.Equal(t, tc.expected, asset.Name)
+	}
+
+	_, err := ResolveAsset(release, "windows", "amd64", "")
+	assert.ErrorContains(t, err, "ambiguous assets")
+
+	_, err = ResolveAsset(release, "linux", "arm64", "")
+	assert.ErrorContains(t, err, "no asset for linux/arm64 in v1.2.3")
+}
+
+type resetReader struct {
+	data string
+}
+
+func (r *resetReader) Read(p []byte) (int, error) {
+	if r.data == "" {
+		return 0, errors.New("read: connection reset by peer")
+	}
+	n := copy(p, r.data)
+	r.data = r.data[n:]
+	return n, nil
+}
+
+func TestDownloadAssetRetriesFromScratch(t *testing.T) {
+	attempts := 0
+	client := NewClient(&GitHubConfig{
+		GitHubTokenSource: GitHubTokenSource{Pat: "x"},
+		transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			attempts++
+			resp := jsonResponse(200, "binary")
+			if attempts == 1 {
+				resp.Body = io.NopCloser(&resetReader{"bin"})
+			}
+			return resp, nil
+		}),
+	})
+	var buf bytes.Buffer
+	err := client.DownloadAsset(context.Background(), "a", "b", 1, &buf)
+	require.NoError(t, err)
+	assert.Equal(t, 2, attempts)
+	assert.Equal(t, "binary", buf.String())
+}
@@ -0,0 +1,200 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/databricks/databricks-sdk-go/logger"
+)
+
+var (
+	ErrNoCandidates      = errors.New("no release candidates")
+	ErrCandidateNotGreen = errors.New("release candidate is not validated")
+)
+
+var stableVersion = regexp.MustCompile(`^v\d+\.\d+\.\d+$`)
+
+// ReleaseCandidates drives an rc workflow: prereleases vX.Y.Z-rc.N are cut
+// from a release branch, validated by check runs on their tags, and the last
+// validated one is promoted to the stable vX.Y.Z release with the same
+// commit and assets, so that exactly the tested binaries are shipped.
+type ReleaseCandidates struct {
+	Client *GitHubClient
+	Org    string
+	Repo   string
+
+	// Checks configure validation of candidates, like required checks.
+	Checks WaitForChecksOptions
+
+	// Provenance of assets is verified before promotion, unless it's nil.
+	Provenance *ProvenanceOptions
+}
+
+func candidateNumber(tag, version string) (int, bool) {
+	n, ok := strings.CutPrefix(tag, version+"-rc.")
+	if !ok {
+		return 0, false
+	}
+	number, err := strconv.Atoi(n)
+	if err != nil {
+		return 0, false
+	}
+	return number, true
+}
+
+// Candidates returns release candidates of the version, from the first one.
+func (r *ReleaseCandidates) Candidates(ctx context.Context, version string) ([]Release, error) {
+	if !stableVersion.MatchString(version) {
+		return nil, fmt.Errorf("version must look like vX.Y.Z: %s", version)
+	}
+	releases, err := r.Client.Versions(ctx, r.Org, r.Repo)
+	if err != nil {
+		return nil, fmt.Errorf("releases: %w", err)
+	}
+	var candidates []Release
+	for _, v := range releases {
+		if _, ok := candidateNumber(v.Version, version); ok {
+			candidates = append(candidates, v)
+		}
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		a, _ := candidateNumber(candidates[i].Version, version)
+		b, _ := candidateNumber(candidates[j].Version, version)
+		return a < b
+	})
+	return candidates, nil
+}
+
+// Create cuts the next release candidate of the version from the head of
+// the branch as a prerelease.
+func (r *ReleaseCandidates) Create(ctx context.Context, version, branch, notes string) (*Release, error) {
+	candidates, err := r.Candidates(ctx, version)
+	if err != nil {
+		return nil, err
+	}
+	next := 1
+	if len(candidates) > 0 {
+		last, _ := candidateNumber(candidates[len(candidates)-1].Version, version)
+		next = last + 1
+	}
+	sha, err := r.Client.ResolveRef(ctx, r.Org, r.Repo, branch)
+	if err != nil {
+		return nil, fmt.Errorf("branch: %w", err)
+	}
+	tag := fmt.Sprintf("%s-rc.%d", version, next)
+	release, err := r.Client.CreateRelease(ctx, r.Org, r.Repo, CreateReleaseRequest{
+		TagName:         tag,
+		TargetCommitish: sha,
+		Name:            tag,
+		Body:            notes,
+		Prerelease:      true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create %s: %w", tag, err)
+	}
+	logger.Infof(ctx, "Created %s from %s (%.7s)", tag, branch, sha)
+	return release, nil
+}
+
+// Validate waits for check runs and commit statuses on the tag of the
+// candidate. The summary is returned even when the error is not nil.
+func (r *ReleaseCandidates) Validate(ctx context.Context, candidate *Release) (*ChecksSummary, error) {
+	return r.Client.WaitForChecks(ctx, r.Org, r.Repo, candidate.Version, r.Checks)
+}
+
+// Promote publishes the last candidate of the version as the stable release,
+// if all its checks have passed. Assets of the candidate are copied through
+// a temporary directory, where their provenance is verified, if configured.
+// The stable release stays a draft until all assets are uploaded, so that
+// nobody downloads an incomplete release.
+func (r *ReleaseCandidates) Promote(ctx context.Context, version string) (*Release, error) {
+	candidates, err := r.Candidates(ctx, version)
+	if err != nil {
+		return nil, err
+	}
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf("%s: %w", version, ErrNoCandidates)
+	}
+	candidate := candidates[len(candidates)-1]
+	summary, err := r.Client.checksSummary(ctx, r.Org, r.Repo, candidate.Version, r.Checks)
+	if err != nil {
+		return nil, fmt.Errorf("checks: %w", err)
+	}
+	if summary.State != "success" {
+		return nil, fmt.Errorf("%s: %w", summary, ErrCandidateNotGreen)
+	}
+	sha, err := r.Client.ResolveRef(ctx, r.Org, r.Repo, candidate.Version)
+	if err != nil {
+		return nil, fmt.Errorf("candidate: %w", err)
+	}
+	release, err := r.Client.CreateRelease(ctx, r.Org, r.Repo, CreateReleaseRequest{
+		TagName:         version,
+		TargetCommitish: sha,
+		Name:            version,
+		Body:            candidate.Body,
+		Draft:           true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create %s: %w", version, err)
+	}
+	err = r.copyAssets(ctx, candidate, release.ID)
+	if err != nil {
+		return nil, fmt.Errorf("assets of %s: %w", candidate.Version, err)
+	}
+	release, err = r.Client.PublishRelease(ctx, r.Org, r.Repo, release.ID)
+	if err != nil {
+		return nil, fmt.Errorf("publish %s: %w", version, err)
+	}
+	logger.Infof(ctx, "Promoted %s to %s", candidate.Version, version)
+	return release, nil
+}
+
+func (r *ReleaseCandidates) copyAssets(ctx context.Context, candidate Release, releaseID int64) error {
+	dir, err := os.MkdirTemp("", "release-candidate")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+	for _, asset := range candidate.Assets {
+		file := filepath.Join(dir, asset.Name)
+		err = r.download(ctx, asset, file)
+		if err != nil {
+			return fmt.Errorf("download %s: %w", asset.Name, err)
+		}
+		if r.Provenance != nil {
+			_, err = r.Client.VerifyProvenance(ctx, r.Org, r.Repo, file, *r.Provenance)
+			if err != nil {
+				return err
+			}
+		}
+		_, err = r.Client.UploadAsset(ctx, r.Org, r.Repo, releaseID, file, UploadAssetOptions{
+			Name:        asset.Name,
+			Label:       asset.Label,
+			ContentType: asset.ContentType,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *ReleaseCandidates) download(ctx context.Context, asset Asset, file string) error {
+	f, err := os.Create(file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	err = r.Client.DownloadAsset(ctx, r.Org, r.Repo, asset.ID, f)
+	if err != nil {
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,99 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const candidateReleases = `[
+	{"id": 3, "tag_name": "v1.2.0-rc.10"},
+	{"id": 2, "tag_name": "v1.2.0-rc.9"},
+	{"id": 1, "tag_name": "v1.1.0-rc.1"}
+]`
+
+func TestCandidatesSortsByNumber(t *testing.T) {
+	client := NewClient(&GitHubConfig{
+		GitHubTokenSource: GitHubTokenSource{Pat: "x"},
+		transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			return jsonResponse(200, candidateReleases), nil
+		}),
+	})
+	rc := &ReleaseCandidates{Client: client, Org: "a", Repo: "b"}
+	candidates, err := rc.Candidates(context.Background(), "v1.2.0")
+	require.NoError(t, err)
+	require.Len(t, candidates, 2)
+	assert.Equal(t, "v1.2.0-rc.9", candidates[0].Version)
+	assert.Equal(t, "v1.2.0-rc.10", candidates[1].Version)
+
+	_, err = rc.Candidates(context.Background(), "1.2")
+	assert.ErrorContains(t, err, "vX.Y.Z")
+}
+
+func TestCreateCandidateFromBranch(t *testing.T) {
+	var created map[string]any
+	client := NewClient(&GitHubConfig{
+		GitHubTokenSource: GitHubTokenSource{Pat: "x"},
+		transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			switch r.Method + " " + r.URL.Path {
+			case "GET /repos/a/b/releases":
+				return jsonResponse(200, candidateReleases), nil
+			case "GET /repos/a/b/commits/release/v1.2":
+				return jsonResponse(200, "abcdef1234567890"), nil
+			case "POST /repos/a/b/releases":
+				raw, err := io.ReadAll(r.Body)
+				require.NoError(t, err)
+				require.NoError(t, json.Unmarshal(raw, &created))
+				return jsonResponse(201, `{"id": 4, "tag_name": "v1.2.0-rc.11", "prerelease": true}`), nil
+			}
+			t.Fatalf("unexpected %s %s", r.Method, r.URL.Path)
+			return nil, nil
+		}),
+	})
+	rc := &ReleaseCandidates{Client: client, Org: "a", Repo: "b"}
+	release, err := rc.Create(context.Background(), "v1.2.0", "release/v1.2", "notes")
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), release.ID)
+	assert.Equal(t, map[string]any{
+		"tag_name":         "v1.2.0-rc.11",
+		"target_commitish": "abcdef1234567890",
+		"name":             "v1.2.0-rc.11",
+		"body":             "notes",
+		"prerelease":       true,
+	}, created)
+}
+
+func TestPromoteRequiresGreenChecks(t *testing.T) {
+	client := NewClient(&GitHubConfig{
+		GitHubTokenSource: GitHubTokenSource{Pat: "x"},
+		transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			switch {
+			case r.URL.Path == "/repos/a/b/releases":
+				assert.Equal(t, "GET", r.Method)
+				return jsonResponse(200, candidateReleases), nil
+			case strings.HasSuffix(r.URL.Path, "/status"):
+				return jsonResponse(200, `{"statuses": []}`), nil
+			case strings.HasSuffix(r.URL.Path, "/check-runs"):
+				assert.Equal(t, "/repos/a/b/commits/v1.2.0-rc.10/check-runs", r.URL.Path)
+				return jsonResponse(200, `{"total_count": 1, "check_runs": [
+					{"name": "integration", "status": "completed", "conclusion": "failure"}
+				]}`), nil
+			}
+			t.Fatalf("unexpected %s %s", r.Method, r.URL.Path)
+			return nil, nil
+		}),
+	})
+	rc := &ReleaseCandidates{Client: client, Org: "a", Repo: "b"}
+	_, err := rc.Promote(context.Background(), "v1.2.0")
+	assert.ErrorIs(t, err, ErrCandidateNotGreen)
+	assert.ErrorContains(t, err, "integration (failure)")
+
+	_, err = rc.Promote(context.Background(), "v2.0.0")
+	assert.ErrorIs(t, err, ErrNoCandidates)
+}
@@ -0,0 +1,144 @@
+package github
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/databricks/databricks-sdk-go/logger"
+)
+
+type AssetRetentionPolicy struct {
+	// KeepPrereleases is the number of the newest prereleases of every
+	// repository, which assets are always kept. Default is 3.
+	KeepPrereleases int
+
+	// Delete removes obsolete assets instead of only flagging them.
+	Delete bool
+}
+
+type ReleaseAssetRecord struct {
+	Repo          string
+	Release       string
+	Prerelease    bool
+	PublishedAt   time.Time
+	AssetID       int64
+	Asset         string
+	Size          int64
+	DownloadCount int64
+
+	// Obsolete assets belong to prereleases, that are superseded by a newer
+	// stable release and are not among the newest kept prereleases.
+	Obsolete bool
+
+	// Deleted is true, if the obsolete asset was removed.
+	Deleted bool
+}
+
+type ReleaseAssetInventory []ReleaseAssetRecord
+
+// ReleaseAssetInventory lists assets of all releases across repositories of
+// the org, except forks, and flags obsolete prerelease assets according to
+// the policy. Assets of archived repositories are never deleted, as they are
+// read-only.
+func (c *GitHubClient) ReleaseAssetInventory(ctx context.Context, org string, policy AssetRetentionPolicy) (ReleaseAssetInventory, error) {
+	if policy.KeepPrereleases == 0 {
+		policy.KeepPrereleases = 3
+	}
+	var repos []Repo
+	err := c.StreamRepositories(ctx, org, func(r Repo) error {
+		if !r.IsFork {
+			repos = append(repos, r)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("repositories: %w", err)
+	}
+	var inventory ReleaseAssetInventory
+	for _, repo := range repos {
+		releases, err := ToSlice(ctx, c.VersionsIterator(org, repo.Name))
+		if err != nil {
+			return inventory, fmt.Errorf("%s: releases: %w", repo.Name, err)
+		}
+		records := assetRecords(repo.Name, releases, policy)
+		for i := range records {
+			if !records[i].Obsolete || !policy.Delete || repo.IsArchived {
+				continue
+			}
+			err = c.DeleteReleaseAsset(ctx, org, repo.Name, records[i].AssetID)
+			if err != nil {
+				return inventory, fmt.Errorf("%s: %s: %w", repo.Name, records[i].Asset, err)
+			}
+			records[i].Deleted = true
+			logger.Infof(ctx, "Deleted %s of %s in %s", records[i].Asset, records[i].Release, repo.Name)
+		}
+		inventory = append(inventory, records...)
+	}
+	return inventory, nil
+}
+
+// assetRecords expects releases from the newest, as they are listed by the API
+func assetRecords(repo string, releases []Release, policy AssetRetentionPolicy) (records []ReleaseAssetRecord) {
+	superseded := false
+	prereleases := 0
+	for _, release := range releases {
+		if release.Draft {
+			continue
+		}
+		obsolete := false
+		if release.Prerelease {
+			prereleases++
+			obsolete = superseded && prereleases > policy.KeepPrereleases
+		} else {
+			superseded = true
+		}
+		for _, asset := range release.Assets {
+			records = append(records, ReleaseAssetRecord{
+				Repo:          repo,
+				Release:       release.Version,
+				Prerelease:    release.Prerelease,
+				PublishedAt:   release.PublishedAt,
+				AssetID:       asset.ID,
+				Asset:         asset.Name,
+				Size:          asset.Size,
+				DownloadCount: asset.DownloadCount,
+				Obsolete:      obsolete,
+			})
+		}
+	}
+	return records
+}
+
+// ObsoleteSize is the total size of obsolete assets in bytes.
+func (inv ReleaseAssetInventory) ObsoleteSize() (size int64) {
+	for _, v := range inv {
+		if v.Obsolete {
+			size += v.Size
+		}
+	}
+	return size
+}
+
+func (inv ReleaseAssetInventory) WriteCSV(w io.Writer) error {
+	out := csv.NewWriter(w)
+	err := out.Write([]string{"repo", "release", "prerelease", "published_at",
+		"asset", "size", "download_count", "obsolete", "deleted"})
+	if err != nil {
+		return err
+	}
+	for _, v := range inv {
+		err = out.Write([]string{v.Repo, v.Release, strconv.FormatBool(v.Prerelease),
+			timestamp(v.PublishedAt), v.Asset, strconv.FormatInt(v.Size, 10),
+			strconv.FormatInt(v.DownloadCount, 10), strconv.FormatBool(v.Obsolete),
+			strconv.FormatBool(v.Deleted)})
+		if err != nil {
+			return err
+		}
+	}
+	out.Flush()
+	return out.Error()
+}
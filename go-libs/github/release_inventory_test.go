@@ -0,0 +1,62 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReleaseAssetInventory(t *testing.T) {
+	var deleted []string
+	client := NewClient(&GitHubConfig{
+		GitHubTokenSource: GitHubTokenSource{Pat: "x"},
+		transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			switch r.Method + " " + r.URL.Path {
+			case "GET /users/a/repos":
+				return jsonResponse(200, `[{"name": "b"}, {"name": "old", "archived": true}]`), nil
+			case "GET /repos/a/b/releases":
+				return jsonResponse(200, `[
+					{"tag_name": "v0.3.0-rc1", "prerelease": true, "assets": [{"id": 1, "name": "x", "size": 10}]},
+					{"tag_name": "v0.2.0", "assets": [{"id": 2, "name": "x", "size": 10, "download_count": 7}]},
+					{"tag_name": "v0.2.0-rc2", "prerelease": true, "assets": [{"id": 3, "name": "x", "size": 10}]},
+					{"tag_name": "v0.2.0-rc1", "prerelease": true, "assets": [{"id": 4, "name": "x", "size": 10}]},
+					{"tag_name": "v0.3.0-draft", "draft": true, "assets": [{"id": 5, "name": "x", "size": 10}]}
+				]`), nil
+			case "GET /repos/a/old/releases":
+				return jsonResponse(200, `[
+					{"tag_name": "v1.0.0"},
+					{"tag_name": "v1.0.0-rc2", "prerelease": true, "assets": [{"id": 6, "name": "y", "size": 5}]},
+					{"tag_name": "v1.0.0-rc1", "prerelease": true, "assets": [{"id": 7, "name": "y", "size": 5}]}
+				]`), nil
+			case "DELETE /repos/a/b/releases/assets/3", "DELETE /repos/a/b/releases/assets/4":
+				deleted = append(deleted, r.URL.Path)
+				return jsonResponse(204, ``), nil
+			}
+			t.Errorf("unexpected: %s %s", r.Method, r.URL.Path)
+			return jsonResponse(404, `{"message": "Not Found"}`), nil
+		}),
+	})
+	inventory, err := client.ReleaseAssetInventory(context.Background(), "a", AssetRetentionPolicy{
+		KeepPrereleases: 1,
+		Delete:          true,
+	})
+	require.NoError(t, err)
+	require.Len(t, inventory, 6)
+	var obsolete []int64
+	for _, v := range inventory {
+		if v.Obsolete {
+			obsolete = append(obsolete, v.AssetID)
+		}
+	}
+	assert.Equal(t, []int64{3, 4, 7}, obsolete)
+	assert.Equal(t, int64(25), inventory.ObsoleteSize())
+	assert.Equal(t, []string{"/repos/a/b/releases/assets/3", "/repos/a/b/releases/assets/4"}, deleted)
+
+	var buf bytes.Buffer
+	require.NoError(t, inventory.WriteCSV(&buf))
+	assert.Contains(t, buf.String(), "b,v0.2.0,false,,x,10,7,false,false")
+}
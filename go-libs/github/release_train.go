@@ -0,0 +1,267 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/databricks/databricks-sdk-go/logger"
+)
+
+var ErrDependencyCycle = errors.New("dependency cycle")
+
+// TrainCar is a Go module released by a ReleaseTrain.
+type TrainCar struct {
+	Repo string
+
+	// Dir of the module within the repository, which also prefixes its
+	// tags, like go-libs/v0.1.0. Empty for modules in the repository root.
+	Dir string
+
+	// Module path, like github.com/databrickslabs/sandbox/go-libs.
+	Module string
+
+	// Version to release, like v0.1.0.
+	Version string
+
+	// Branch to release from. Default is main.
+	Branch string
+
+	// DependsOn are repositories of the train, that have to be released first.
+	DependsOn []string
+}
+
+func (car TrainCar) file(name string) string {
+	return path.Join(car.Dir, name)
+}
+
+func (car TrainCar) tag() string {
+	if car.Dir == "" {
+		return car.Version
+	}
+	return path.Join(car.Dir, car.Version)
+}
+
+// ReleaseTrain releases interdependent modules of many repositories in
+// dependency order. Before every release, requirements on modules released
+// earlier are bumped in go.mod and go.sum, and the release waits for CI to
+// pass on the bumped commit, so that no release depends on untested code.
+type ReleaseTrain struct {
+	Client *GitHubClient
+	Org    string
+	Cars   []TrainCar
+
+	// Checks configure waiting for CI of every repository.
+	Checks WaitForChecksOptions
+
+	// GoProxy resolves go.sum lines of released modules.
+	GoProxy *GoProxy
+
+	// WarmUp configures waiting for every release to become available
+	// on the module mirror.
+	WarmUp WarmUpOptions
+}
+
+// Order returns cars in dependency order, keeping the configured order of
+// independent cars.
+func (t *ReleaseTrain) Order() ([]TrainCar, error) {
+	cars := map[string]TrainCar{}
+	for _, car := range t.Cars {
+		cars[car.Repo] = car
+	}
+	const (
+		visiting = 1
+		done     = 2
+	)
+	state := map[string]int{}
+	var order []TrainCar
+	var visit func(repo string, path []string) error
+	visit = func(repo string, path []string) error {
+		switch state[repo] {
+		case visiting:
+			return fmt.Errorf("%s: %w", strings.Join(append(path, repo), " -> "), ErrDependencyCycle)
+		case done:
+			return nil
+		}
+		car, ok := cars[repo]
+		if !ok {
+			return fmt.Errorf("%s depends on %s, which is not in the train", path[len(path)-1], repo)
+		}
+		state[repo] = visiting
+		for _, dep := range car.DependsOn {
+			err := visit(dep, append(path, repo))
+			if err != nil {
+				return err
+			}
+		}
+		state[repo] = done
+		order = append(order, car)
+		return nil
+	}
+	for _, car := range t.Cars {
+		err := visit(car.Repo, nil)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return order, nil
+}
+
+// Run releases all cars and returns their releases. A failed car stops the
+// train, so that releases of dependents are never created. So does a release,
+// which doesn't become available on the module mirror.
+func (t *ReleaseTrain) Run(ctx context.Context) ([]*Release, error) {
+	order, err := t.Order()
+	if err != nil {
+		return nil, err
+	}
+	released := map[string]string{}
+	var releases []*Release
+	for _, car := range order {
+		release, err := t.release(ctx, car, released)
+		if err != nil {
+			return releases, fmt.Errorf("%s: %w", car.Repo, err)
+		}
+		releases = append(releases, release)
+		// dependents need go.sum lines of the release
+		_, err = t.goProxy().WarmUp(ctx, car.Module, car.Version, t.WarmUp)
+		if err != nil {
+			return releases, fmt.Errorf("%s: %w", car.Repo, err)
+		}
+		released[car.Module] = car.Version
+	}
+	return releases, nil
+}
+
+func (t *ReleaseTrain) release(ctx context.Context, car TrainCar, released map[string]string) (*Release, error) {
+	if car.Branch == "" {
+		car.Branch = "main"
+	}
+	err := t.bump(ctx, car, released)
+	if err != nil {
+		return nil, fmt.Errorf("bump: %w", err)
+	}
+	sha, err := t.Client.ResolveRef(ctx, t.Org, car.Repo, car.Branch)
+	if err != nil {
+		return nil, fmt.Errorf("branch: %w", err)
+	}
+	summary, err := t.Client.WaitForChecks(ctx, t.Org, car.Repo, sha, t.Checks)
+	if err != nil {
+		return nil, fmt.Errorf("checks: %w", err)
+	}
+	logger.Infof(ctx, "%s/%s: %s", t.Org, car.Repo, summary)
+	release, err := t.Client.CreateRelease(ctx, t.Org, car.Repo, CreateReleaseRequest{
+		TagName:              car.tag(),
+		TargetCommitish:      sha,
+		Name:                 car.tag(),
+		GenerateReleaseNotes: true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("release: %w", err)
+	}
+	logger.Infof(ctx, "Released %s/%s %s", t.Org, car.Repo, car.tag())
+	return release, nil
+}
+
+// bump commits new requirements on released modules. The go.sum is updated
+// first, so that the go.mod commit is the one CI runs on with valid sums.
+func (t *ReleaseTrain) bump(ctx context.Context, car TrainCar, released map[string]string) error {
+	gomod, gomodSHA, err := t.Client.getFile(ctx, t.Org, car.Repo, car.file("go.mod"), car.Branch)
+	if err != nil {
+		return fmt.Errorf("go.mod: %w", err)
+	}
+	var bumped []string
+	var sums []string
+	modules := make([]string, 0, len(released))
+	for module := range released {
+		modules = append(modules, module)
+	}
+	sort.Strings(modules)
+	for _, module := range modules {
+		version := released[module]
+		updated, ok := BumpRequirement(gomod, module, version)
+		if !ok {
+			continue
+		}
+		gomod = updated
+		bumped = append(bumped, fmt.Sprintf("%s@%s", module, version))
+		lines, err := t.goProxy().GoSum(ctx, module, version)
+		if err != nil {
+			return err
+		}
+		sums = append(sums, lines...)
+	}
+	if len(bumped) == 0 {
+		return nil
+	}
+	message := fmt.Sprintf("Bump %s", strings.Join(bumped, ", "))
+	gosum, gosumSHA, err := t.Client.getFile(ctx, t.Org, car.Repo, car.file("go.sum"), car.Branch)
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		return fmt.Errorf("go.sum: %w", err)
+	}
+	err = t.Client.updateFile(ctx, t.Org, car.Repo, car.file("go.sum"), car.Branch, gosumSHA, message, AddGoSumLines(gosum, sums...))
+	if err != nil {
+		return fmt.Errorf("update go.sum: %w", err)
+	}
+	err = t.Client.updateFile(ctx, t.Org, car.Repo, car.file("go.mod"), car.Branch, gomodSHA, message, gomod)
+	if err != nil {
+		return fmt.Errorf("update go.mod: %w", err)
+	}
+	logger.Infof(ctx, "%s/%s: %s", t.Org, car.Repo, message)
+	return nil
+}
+
+func (t *ReleaseTrain) goProxy() *GoProxy {
+	if t.GoProxy == nil {
+		return &GoProxy{}
+	}
+	return t.GoProxy
+}
+
+// BumpRequirement sets the required version of the module in go.mod, both in
+// require blocks and in single-line require directives. It reports false, if
+// the module is not required or is already required at the version.
+func BumpRequirement(gomod []byte, module, version string) ([]byte, bool) {
+	requirement := regexp.MustCompile(`^(\s*(?:require\s+)?)` + regexp.QuoteMeta(module) + `(\s+)(\S+)(.*)$`)
+	lines := strings.Split(string(gomod), "\n")
+	inRequire, changed := false, false
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(trimmed, "require ("):
+			inRequire = true
+			continue
+		case trimmed == ")":
+			inRequire = false
+			continue
+		case !inRequire && !strings.HasPrefix(trimmed, "require "):
+			continue
+		}
+		match := requirement.FindStringSubmatch(line)
+		if match == nil || match[3] == version {
+			continue
+		}
+		lines[i] = match[1] + module + match[2] + version + match[4]
+		changed = true
+	}
+	return []byte(strings.Join(lines, "\n")), changed
+}
+
+// AddGoSumLines adds missing lines to go.sum and keeps it sorted.
+func AddGoSumLines(gosum []byte, lines ...string) []byte {
+	seen := map[string]bool{}
+	var all []string
+	for _, line := range append(strings.Split(string(gosum), "\n"), lines...) {
+		if line == "" || seen[line] {
+			continue
+		}
+		seen[line] = true
+		all = append(all, line)
+	}
+	sort.Strings(all)
+	return []byte(strings.Join(all, "\n") + "\n")
+}
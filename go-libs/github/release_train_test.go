@@ -0,0 +1,85 @@
+package github
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReleaseTrainOrder(t *testing.T) {
+	train := &ReleaseTrain{Cars: []TrainCar{
+		{Repo: "app", DependsOn: []string{"sdk", "libs"}},
+		{Repo: "sdk", DependsOn: []string{"libs"}},
+		{Repo: "libs"},
+		{Repo: "docs"},
+	}}
+	order, err := train.Order()
+	require.NoError(t, err)
+	var repos []string
+	for _, v := range order {
+		repos = append(repos, v.Repo)
+	}
+	assert.Equal(t, []string{"libs", "sdk", "app", "docs"}, repos)
+}
+
+func TestReleaseTrainDetectsCycles(t *testing.T) {
+	train := &ReleaseTrain{Cars: []TrainCar{
+		{Repo: "a", DependsOn: []string{"b"}},
+		{Repo: "b", DependsOn: []string{"a"}},
+	}}
+	_, err := train.Order()
+	assert.ErrorIs(t, err, ErrDependencyCycle)
+	assert.ErrorContains(t, err, "a -> b -> a")
+
+	train = &ReleaseTrain{Cars: []TrainCar{
+		{Repo: "a", DependsOn: []string{"c"}},
+	}}
+	_, err = train.Order()
+	assert.ErrorContains(t, err, "a depends on c, which is not in the train")
+}
+
+func TestBumpRequirement(t *testing.T) {
+	gomod := []byte(`module github.com/a/app
+
+go 1.21
+
+require github.com/a/sdk v0.1.0
+
+require (
+	github.com/a/libs v0.2.0 // indirect
+	github.com/a/libsx v0.2.0
+)
+
+replace github.com/a/libs v0.1.0 => ../libs
+`)
+	out, ok := BumpRequirement(gomod, "github.com/a/libs", "v0.3.0")
+	require.True(t, ok)
+	out, ok = BumpRequirement(out, "github.com/a/sdk", "v0.2.0")
+	require.True(t, ok)
+	assert.Equal(t, `module github.com/a/app
+
+go 1.21
+
+require github.com/a/sdk v0.2.0
+
+require (
+	github.com/a/libs v0.3.0 // indirect
+	github.com/a/libsx v0.2.0
+)
+
+replace github.com/a/libs v0.1.0 => ../libs
+`, string(out))
+
+	_, ok = BumpRequirement(out, "github.com/a/sdk", "v0.2.0")
+	assert.False(t, ok)
+	_, ok = BumpRequirement(out, "github.com/a/other", "v0.2.0")
+	assert.False(t, ok)
+}
+
+func TestAddGoSumLines(t *testing.T) {
+	out := AddGoSumLines([]byte("github.com/b/x v1.0.0 h1:b=\n"),
+		"github.com/a/x v1.0.0 h1:a=",
+		"github.com/b/x v1.0.0 h1:b=")
+	assert.Equal(t, "github.com/a/x v1.0.0 h1:a=\ngithub.com/b/x v1.0.0 h1:b=\n", string(out))
+}
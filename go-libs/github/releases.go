@@ -36,20 +36,3 @@ func (r *ReleaseCache) Load(ctx context.Context) (Versions, error) {
 		return r.client.Versions(ctx, r.Org, r.Repo)
 	})
 }
-
-type ghAsset struct {
-	Name               string `json:"name"`
-	ContentType        string `json:"content_type"`
-	Size               int    `json:"size"`
-	BrowserDownloadURL string `json:"browser_download_url"`
-}
-
-type Release struct {
-	Version     string    `json:"tag_name"`
-	CreatedAt   time.Time `json:"created_at"`
-	PublishedAt time.Time `json:"published_at"`
-	ZipballURL  string    `json:"zipball_url"`
-	Assets      []ghAsset `json:"assets"`
-}
-
-type Versions []Release
@@ -0,0 +1,43 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetReleaseByTagEscapesTag(t *testing.T) {
+	client := NewClient(&GitHubConfig{
+		GitHubTokenSource: GitHubTokenSource{Pat: "x"},
+		transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			assert.Equal(t, "/repos/a/b/releases/tags/cli/v1.0.0", r.URL.Path)
+			return jsonResponse(200, `{"id": 7, "tag_name": "cli/v1.0.0"}`), nil
+		}),
+	})
+	release, err := client.GetReleaseByTag(context.Background(), "a", "b", "cli/v1.0.0")
+	require.NoError(t, err)
+	assert.Equal(t, int64(7), release.ID)
+}
+
+func TestPublishReleaseSendsOnlyDraft(t *testing.T) {
+	client := NewClient(&GitHubConfig{
+		GitHubTokenSource: GitHubTokenSource{Pat: "x"},
+		transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			assert.Equal(t, "PATCH /repos/a/b/releases/7", r.Method+" "+r.URL.Path)
+			raw, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			var body map[string]any
+			require.NoError(t, json.Unmarshal(raw, &body))
+			assert.Equal(t, map[string]any{"draft": false}, body)
+			return jsonResponse(200, `{"id": 7, "draft": false}`), nil
+		}),
+	})
+	release, err := client.PublishRelease(context.Background(), "a", "b", 7)
+	require.NoError(t, err)
+	assert.False(t, release.Draft)
+}
@@ -0,0 +1,144 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/databricks/databricks-sdk-go/httpclient"
+	"github.com/databricks/databricks-sdk-go/logger"
+)
+
+// RepoMetadataEdit changes topics and descriptions of many repositories.
+// Archived repositories are read-only, so they are always skipped.
+type RepoMetadataEdit struct {
+	// Filter selects repositories to edit. Default is all of them.
+	Filter func(Repo) bool
+
+	AddTopics    []string
+	RemoveTopics []string
+
+	// DescriptionTemplate renders descriptions with NewTemplate from the
+	// repository, like "{{.Name}}: {{.Description}} (deprecated)". Empty
+	// template keeps descriptions as they are.
+	DescriptionTemplate string
+}
+
+// RepoMetadataChange is a planned change of a single repository.
+type RepoMetadataChange struct {
+	Repo           string
+	OldTopics      []string
+	NewTopics      []string
+	OldDescription string
+	NewDescription string
+}
+
+func (c RepoMetadataChange) topicsChanged() bool {
+	return !slices.Equal(c.OldTopics, c.NewTopics)
+}
+
+func (c RepoMetadataChange) descriptionChanged() bool {
+	return c.OldDescription != c.NewDescription
+}
+
+func (c RepoMetadataChange) String() string {
+	var parts []string
+	if c.topicsChanged() {
+		parts = append(parts, fmt.Sprintf("topics [%s] -> [%s]",
+			strings.Join(c.OldTopics, ", "), strings.Join(c.NewTopics, ", ")))
+	}
+	if c.descriptionChanged() {
+		parts = append(parts, fmt.Sprintf("description %q -> %q",
+			c.OldDescription, c.NewDescription))
+	}
+	return fmt.Sprintf("%s: %s", c.Repo, strings.Join(parts, ", "))
+}
+
+// PreviewRepoMetadata returns changes of the edit for every repository of
+// the org, that would change, without applying them.
+func (c *GitHubClient) PreviewRepoMetadata(ctx context.Context, org string, edit RepoMetadataEdit) ([]RepoMetadataChange, error) {
+	var description *Template
+	if edit.DescriptionTemplate != "" {
+		var err error
+		description, err = NewTemplate("description", edit.DescriptionTemplate)
+		if err != nil {
+			return nil, err
+		}
+	}
+	repos, err := c.ListRepositories(ctx, org)
+	if err != nil {
+		return nil, fmt.Errorf("repositories: %w", err)
+	}
+	var changes []RepoMetadataChange
+	for _, repo := range repos {
+		if repo.IsArchived || (edit.Filter != nil && !edit.Filter(repo)) {
+			continue
+		}
+		change := RepoMetadataChange{
+			Repo:           repo.Name,
+			OldTopics:      repo.Topics,
+			NewTopics:      editTopics(repo.Topics, edit.AddTopics, edit.RemoveTopics),
+			OldDescription: repo.Description,
+			NewDescription: repo.Description,
+		}
+		if description != nil {
+			change.NewDescription, err = description.Render(repo)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", repo.Name, err)
+			}
+			change.NewDescription = strings.TrimSpace(change.NewDescription)
+		}
+		if change.topicsChanged() || change.descriptionChanged() {
+			changes = append(changes, change)
+		}
+	}
+	return changes, nil
+}
+
+// editTopics keeps the order of existing topics and appends new ones. Topics
+// are lowercase for GitHub.
+func editTopics(topics, add, remove []string) []string {
+	out := []string{}
+	for _, v := range topics {
+		if !slices.Contains(remove, v) {
+			out = append(out, v)
+		}
+	}
+	for _, v := range add {
+		v = strings.ToLower(v)
+		if !slices.Contains(out, v) && !slices.Contains(remove, v) {
+			out = append(out, v)
+		}
+	}
+	if slices.Equal(out, topics) {
+		// keep nil topics of repositories without any
+		return topics
+	}
+	return out
+}
+
+// ApplyRepoMetadata applies changes returned by PreviewRepoMetadata, which
+// may be reviewed or filtered in between. Returns the number of applied
+// changes, which is less than all of them on errors.
+func (c *GitHubClient) ApplyRepoMetadata(ctx context.Context, org string, changes []RepoMetadataChange) (int, error) {
+	for i, change := range changes {
+		if change.topicsChanged() {
+			err := c.ReplaceTopics(ctx, org, change.Repo, change.NewTopics...)
+			if err != nil {
+				return i, fmt.Errorf("%s: topics: %w", change.Repo, err)
+			}
+		}
+		if change.descriptionChanged() {
+			path := fmt.Sprintf("%s/repos/%s/%s", gitHubAPI, org, change.Repo)
+			err := c.api.Do(ctx, "PATCH", path, httpclient.WithRequestData(map[string]string{
+				"description": change.NewDescription,
+			}))
+			if err != nil {
+				return i, fmt.Errorf("%s: description: %w", change.Repo, err)
+			}
+		}
+		logger.Infof(ctx, "Updated %s", change)
+	}
+	return len(changes), nil
+}
@@ -0,0 +1,58 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEditTopics(t *testing.T) {
+	assert.Equal(t, []string{"go", "sandbox"}, editTopics([]string{"go", "legacy"}, []string{"Sandbox", "go"}, []string{"legacy"}))
+	assert.Nil(t, editTopics(nil, nil, []string{"legacy"}))
+}
+
+func TestRepoMetadataPreviewAndApply(t *testing.T) {
+	var calls []string
+	var bodies []map[string]any
+	client := NewClient(&GitHubConfig{
+		GitHubTokenSource: GitHubTokenSource{Pat: "x"},
+		transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			if r.Method == "GET" {
+				return jsonResponse(200, `[
+					{"name": "x", "description": "Tool", "topics": ["go"]},
+					{"name": "y", "description": "y: Library", "topics": ["go", "sandbox"]},
+					{"name": "z", "description": "Old", "archived": true},
+					{"name": "docs", "description": "Docs"}
+				]`), nil
+			}
+			calls = append(calls, r.Method+" "+r.URL.Path)
+			var body map[string]any
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			bodies = append(bodies, body)
+			return jsonResponse(200, `{}`), nil
+		}),
+	})
+	ctx := context.Background()
+	changes, err := client.PreviewRepoMetadata(ctx, "a", RepoMetadataEdit{
+		Filter: func(r Repo) bool {
+			return r.Name != "docs"
+		},
+		AddTopics:           []string{"sandbox"},
+		DescriptionTemplate: `{{ .Name }}: {{ trimPrefix (print .Name ": ") .Description }}`,
+	})
+	require.NoError(t, err)
+	require.Len(t, changes, 1)
+	assert.Equal(t, `x: topics [go] -> [go, sandbox], description "Tool" -> "x: Tool"`, changes[0].String())
+	assert.Empty(t, calls)
+
+	applied, err := client.ApplyRepoMetadata(ctx, "a", changes)
+	require.NoError(t, err)
+	assert.Equal(t, 1, applied)
+	assert.Equal(t, []string{"PUT /repos/a/x/topics", "PATCH /repos/a/x"}, calls)
+	assert.Equal(t, []any{"go", "sandbox"}, bodies[0]["names"])
+	assert.Equal(t, "x: Tool", bodies[1]["description"])
+}
@@ -0,0 +1,187 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"sort"
+
+	"github.com/databricks/databricks-sdk-go/httpclient"
+	"github.com/databricks/databricks-sdk-go/logger"
+	"gopkg.in/yaml.v3"
+)
+
+// RepoSettings is the desired state of a repository. Nil fields are not managed.
+type RepoSettings struct {
+	Description   *string  `yaml:"description,omitempty" json:"description,omitempty"`
+	Homepage      *string  `yaml:"homepage,omitempty" json:"homepage,omitempty"`
+	Topics        []string `yaml:"topics,omitempty" json:"topics,omitempty"`
+	DefaultBranch *string  `yaml:"default_branch,omitempty" json:"default_branch,omitempty"`
+
+	HasIssues      *bool `yaml:"has_issues,omitempty" json:"has_issues,omitempty"`
+	HasProjects    *bool `yaml:"has_projects,omitempty" json:"has_projects,omitempty"`
+	HasWiki        *bool `yaml:"has_wiki,omitempty" json:"has_wiki,omitempty"`
+	HasDiscussions *bool `yaml:"has_discussions,omitempty" json:"has_discussions,omitempty"`
+
+	AllowSquashMerge    *bool `yaml:"allow_squash_merge,omitempty" json:"allow_squash_merge,omitempty"`
+	AllowMergeCommit    *bool `yaml:"allow_merge_commit,omitempty" json:"allow_merge_commit,omitempty"`
+	AllowRebaseMerge    *bool `yaml:"allow_rebase_merge,omitempty" json:"allow_rebase_merge,omitempty"`
+	AllowAutoMerge      *bool `yaml:"allow_auto_merge,omitempty" json:"allow_auto_merge,omitempty"`
+	DeleteBranchOnMerge *bool `yaml:"delete_branch_on_merge,omitempty" json:"delete_branch_on_merge,omitempty"`
+}
+
+// RepoSettingsFile is a settings-as-code document, like:
+//
+//	defaults:
+//	  allow_merge_commit: false
+//	  delete_branch_on_merge: true
+//	repos:
+//	  sandbox:
+//	    description: Experimental projects
+//	    topics: [go, github]
+type RepoSettingsFile struct {
+	Defaults RepoSettings            `yaml:"defaults,omitempty"`
+	Repos    map[string]RepoSettings `yaml:"repos"`
+}
+
+func ParseRepoSettings(raw []byte) (*RepoSettingsFile, error) {
+	var file RepoSettingsFile
+	err := yaml.Unmarshal(raw, &file)
+	if err != nil {
+		return nil, fmt.Errorf("repo settings: %w", err)
+	}
+	return &file, nil
+}
+
+// Desired returns settings of the repository overlaid on top of the defaults.
+func (f *RepoSettingsFile) Desired(repo string) map[string]any {
+	desired := settingsMap(f.Defaults)
+	for k, v := range settingsMap(f.Repos[repo]) {
+		desired[k] = v
+	}
+	return desired
+}
+
+func settingsMap(settings RepoSettings) map[string]any {
+	raw, _ := json.Marshal(settings)
+	out := map[string]any{}
+	_ = json.Unmarshal(raw, &out)
+	return out
+}
+
+type SettingDrift struct {
+	Repo    string
+	Setting string
+	Actual  any
+	Desired any
+}
+
+func (d SettingDrift) String() string {
+	return fmt.Sprintf("%s: %s is %v, but should be %v", d.Repo, d.Setting, d.Actual, d.Desired)
+}
+
+// RepoSettingsDrift compares the repository with the desired settings, which
+// have the same keys as the repository resource in the REST API.
+func (c *GitHubClient) RepoSettingsDrift(ctx context.Context, org, repo string, desired map[string]any) ([]SettingDrift, error) {
+	path := fmt.Sprintf("%s/repos/%s/%s", gitHubAPI, org, repo)
+	// merge settings are returned only to admins, so actual state is kept raw
+	// to tell missing settings apart from disabled ones
+	var actual map[string]any
+	err := c.api.Do(ctx, "GET", path, httpclient.WithResponseUnmarshal(&actual))
+	if err != nil {
+		return nil, err
+	}
+	var drift []SettingDrift
+	for setting, want := range desired {
+		have, ok := actual[setting]
+		if !ok {
+			logger.Warnf(ctx, "%s: %s is not visible, are you an admin?", repo, setting)
+			continue
+		}
+		if setting == "topics" {
+			have, want = sortedStrings(have), sortedStrings(want)
+		}
+		if reflect.DeepEqual(have, want) {
+			continue
+		}
+		drift = append(drift, SettingDrift{
+			Repo:    repo,
+			Setting: setting,
+			Actual:  have,
+			Desired: want,
+		})
+	}
+	sort.Slice(drift, func(i, j int) bool {
+		return drift[i].Setting < drift[j].Setting
+	})
+	return drift, nil
+}
+
+func sortedStrings(v any) []string {
+	var out []string
+	items, _ := v.([]any)
+	for _, item := range items {
+		out = append(out, fmt.Sprint(item))
+	}
+	slices.Sort(out)
+	return out
+}
+
+// ReconcileRepoSettings reports drift of all repositories in the file and
+// applies desired settings, if fix is true.
+func (c *GitHubClient) ReconcileRepoSettings(ctx context.Context, org string, file *RepoSettingsFile, fix bool) ([]SettingDrift, error) {
+	var repos []string
+	for name := range file.Repos {
+		repos = append(repos, name)
+	}
+	sort.Strings(repos)
+	var all []SettingDrift
+	for _, repo := range repos {
+		drift, err := c.RepoSettingsDrift(ctx, org, repo, file.Desired(repo))
+		if err != nil {
+			return all, fmt.Errorf("%s: %w", repo, err)
+		}
+		all = append(all, drift...)
+		if !fix || len(drift) == 0 {
+			continue
+		}
+		err = c.applySettings(ctx, org, repo, drift)
+		if err != nil {
+			return all, fmt.Errorf("%s: %w", repo, err)
+		}
+		logger.Infof(ctx, "Fixed %d settings of %s", len(drift), repo)
+	}
+	return all, nil
+}
+
+func (c *GitHubClient) applySettings(ctx context.Context, org, repo string, drift []SettingDrift) error {
+	update := map[string]any{}
+	for _, v := range drift {
+		if v.Setting == "topics" {
+			err := c.ReplaceTopics(ctx, org, repo, v.Desired.([]string)...)
+			if err != nil {
+				return fmt.Errorf("topics: %w", err)
+			}
+			continue
+		}
+		update[v.Setting] = v.Desired
+	}
+	if len(update) == 0 {
+		return nil
+	}
+	path := fmt.Sprintf("%s/repos/%s/%s", gitHubAPI, org, repo)
+	return c.api.Do(ctx, "PATCH", path, httpclient.WithRequestData(update))
+}
+
+// ReplaceTopics sets all topics of the repository.
+func (c *GitHubClient) ReplaceTopics(ctx context.Context, org, repo string, topics ...string) error {
+	path := fmt.Sprintf("%s/repos/%s/%s/topics", gitHubAPI, org, repo)
+	if topics == nil {
+		topics = []string{}
+	}
+	return c.api.Do(ctx, "PUT", path, httpclient.WithRequestData(map[string]any{
+		"names": topics,
+	}))
+}
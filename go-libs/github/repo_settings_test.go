@@ -0,0 +1,62 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func TestReconcileRepoSettings(t *testing.T) {
+	file := &RepoSettingsFile{
+		Defaults: RepoSettings{
+			AllowMergeCommit:    ptr(false),
+			DeleteBranchOnMerge: ptr(true),
+		},
+		Repos: map[string]RepoSettings{
+			"b": {
+				Description:      ptr("Experiments"),
+				Topics:           []string{"go", "github"},
+				AllowMergeCommit: ptr(true),
+			},
+		},
+	}
+	var calls []string
+	var update map[string]any
+	client := NewClient(&GitHubConfig{
+		GitHubTokenSource: GitHubTokenSource{Pat: "x"},
+		transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			calls = append(calls, r.Method+" "+r.URL.Path)
+			switch r.Method + " " + r.URL.Path {
+			case "GET /repos/a/b":
+				return jsonResponse(200, `{"description": "Old", "topics": ["github", "go"],
+					"allow_merge_commit": true, "delete_branch_on_merge": false}`), nil
+			case "PATCH /repos/a/b":
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&update))
+				return jsonResponse(200, `{}`), nil
+			}
+			return jsonResponse(404, `{"message": "Not Found"}`), nil
+		}),
+	})
+	drift, err := client.ReconcileRepoSettings(context.Background(), "a", file, false)
+	require.NoError(t, err)
+	assert.Equal(t, []SettingDrift{
+		{"b", "delete_branch_on_merge", false, true},
+		{"b", "description", "Old", "Experiments"},
+	}, drift)
+	assert.Equal(t, []string{"GET /repos/a/b"}, calls)
+
+	_, err = client.ReconcileRepoSettings(context.Background(), "a", file, true)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"delete_branch_on_merge": true,
+		"description":            "Experiments",
+	}, update)
+}
@@ -32,22 +32,3 @@ func (r *repositoryCache) Load(ctx context.Context) (Repositories, error) {
 		return r.client.ListRepositories(ctx, r.Org)
 	})
 }
-
-type Repositories []Repo
-
-type Repo struct {
-	Name          string   `json:"name"`
-	Description   string   `json:"description"`
-	Langauge      string   `json:"language"`
-	DefaultBranch string   `json:"default_branch"`
-	Stars         int      `json:"stargazers_count"`
-	IsFork        bool     `json:"fork"`
-	IsArchived    bool     `json:"archived"`
-	Topics        []string `json:"topics"`
-	HtmlURL       string   `json:"html_url"`
-	CloneURL      string   `json:"clone_url"`
-	SshURL        string   `json:"ssh_url"`
-	License       struct {
-		Name string `json:"name"`
-	} `json:"license"`
-}
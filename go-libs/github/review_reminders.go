@@ -0,0 +1,197 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/databricks/databricks-sdk-go/logger"
+)
+
+// ReviewReminder is sent for a pull request waiting for review beyond the SLA.
+type ReviewReminder struct {
+	Org         string
+	Repo        string
+	PullRequest PullRequest
+
+	// Reviewers are @user or @org/team mentions.
+	Reviewers []string
+	Waiting   time.Duration
+}
+
+// ReviewNotifier delivers review reminders, like chat messages or emails.
+type ReviewNotifier interface {
+	NotifyReviewers(ctx context.Context, reminder ReviewReminder) error
+}
+
+type ReviewRemindersOptions struct {
+	// SLA is how long pull requests may wait for review. Default is 24 hours.
+	SLA time.Duration
+
+	// Repos defaults to all repositories of the org, except forks and
+	// archived ones.
+	Repos []string
+
+	// Notifier defaults to commenting on the pull request.
+	Notifier ReviewNotifier
+}
+
+// commentNotifier mentions reviewers in a comment on the pull request
+type commentNotifier struct {
+	client *GitHubClient
+}
+
+func (n *commentNotifier) NotifyReviewers(ctx context.Context, reminder ReviewReminder) error {
+	body := fmt.Sprintf("%s, this pull request is waiting for your review for %s.",
+		strings.Join(reminder.Reviewers, " "), humanDuration(reminder.Waiting))
+	_, err := n.client.CreateIssueComment(ctx, reminder.Org, reminder.Repo, reminder.PullRequest.Number, body)
+	return err
+}
+
+func humanDuration(d time.Duration) string {
+	days := int(d / (24 * time.Hour))
+	hours := int(d % (24 * time.Hour) / time.Hour)
+	if days == 0 {
+		return fmt.Sprintf("%dh", hours)
+	}
+	return fmt.Sprintf("%dd %dh", days, hours)
+}
+
+// RemindReviewers finds open pull requests waiting for review longer than the
+// SLA and notifies their reviewers. Reviewers are the requested ones, falling
+// back to code owners of changed files, when nobody is requested explicitly.
+// Drafts and pull requests without reviewers are skipped.
+func (c *GitHubClient) RemindReviewers(ctx context.Context, org string, opts ReviewRemindersOptions) ([]ReviewReminder, error) {
+	if opts.SLA == 0 {
+		opts.SLA = 24 * time.Hour
+	}
+	if opts.Notifier == nil {
+		opts.Notifier = &commentNotifier{c}
+	}
+	repos := opts.Repos
+	if len(repos) == 0 {
+		err := c.StreamRepositories(ctx, org, func(r Repo) error {
+			if r.IsFork || r.IsArchived {
+				return nil
+			}
+			repos = append(repos, r.Name)
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("repositories: %w", err)
+		}
+	}
+	var reminders []ReviewReminder
+	for _, repo := range repos {
+		found, err := c.overdueReviews(ctx, org, repo, opts.SLA)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", repo, err)
+		}
+		for _, reminder := range found {
+			err = opts.Notifier.NotifyReviewers(ctx, reminder)
+			if err != nil {
+				return reminders, fmt.Errorf("%s#%d: notify: %w", repo, reminder.PullRequest.Number, err)
+			}
+			logger.Infof(ctx, "Reminded %s about %s#%d", strings.Join(reminder.Reviewers, ", "),
+				repo, reminder.PullRequest.Number)
+			reminders = append(reminders, reminder)
+		}
+	}
+	return reminders, nil
+}
+
+func (c *GitHubClient) overdueReviews(ctx context.Context, org, repo string, sla time.Duration) ([]ReviewReminder, error) {
+	var reminders []ReviewReminder
+	var owners CodeOwners
+	ownersLoaded := false
+	for page := 1; ; page++ {
+		prs, err := c.ListPullRequests(ctx, org, repo, PullRequestListOptions{
+			State:   "open",
+			Page:    page,
+			PerPage: 100,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, pr := range prs {
+			if pr.Draft {
+				continue
+			}
+			reviews, err := c.ListReviews(ctx, org, repo, pr.Number)
+			if err != nil {
+				return nil, fmt.Errorf("#%d: reviews: %w", pr.Number, err)
+			}
+			// the clock restarts with every review, as authors address feedback
+			since := pr.CreatedAt
+			for _, v := range reviews {
+				if v.User.Login != pr.User.Login && v.SubmittedAt.After(since) {
+					since = v.SubmittedAt
+				}
+			}
+			waiting := time.Since(since)
+			if waiting < sla {
+				continue
+			}
+			reviewers := requestedReviewers(org, pr)
+			if len(reviewers) == 0 && len(reviews) == 0 {
+				if !ownersLoaded {
+					owners, err = c.GetCodeOwners(ctx, org, repo, pr.Base.Ref)
+					if err != nil {
+						return nil, fmt.Errorf("code owners: %w", err)
+					}
+					ownersLoaded = true
+				}
+				reviewers, err = c.codeOwnersOf(ctx, org, repo, pr, owners)
+				if err != nil {
+					return nil, fmt.Errorf("#%d: %w", pr.Number, err)
+				}
+			}
+			if len(reviewers) == 0 {
+				continue
+			}
+			reminders = append(reminders, ReviewReminder{
+				Org:         org,
+				Repo:        repo,
+				PullRequest: pr,
+				Reviewers:   reviewers,
+				Waiting:     waiting,
+			})
+		}
+		if len(prs) < 100 {
+			return reminders, nil
+		}
+	}
+}
+
+func requestedReviewers(org string, pr PullRequest) (reviewers []string) {
+	for _, v := range pr.RequestedReviewers {
+		reviewers = append(reviewers, "@"+v.Login)
+	}
+	for _, v := range pr.RequestedTeams {
+		reviewers = append(reviewers, fmt.Sprintf("@%s/%s", org, v.Slug))
+	}
+	return reviewers
+}
+
+// codeOwnersOf returns owners of changed files, except the author
+func (c *GitHubClient) codeOwnersOf(ctx context.Context, org, repo string, pr PullRequest, owners CodeOwners) ([]string, error) {
+	if len(owners) == 0 {
+		return nil, nil
+	}
+	files, err := c.ListPullRequestFiles(ctx, org, repo, pr.Number)
+	if err != nil {
+		return nil, fmt.Errorf("files: %w", err)
+	}
+	var reviewers []string
+	for _, f := range files {
+		for _, owner := range owners.Owners(f.Filename) {
+			if owner == "@"+pr.User.Login || slices.Contains(reviewers, owner) {
+				continue
+			}
+			reviewers = append(reviewers, owner)
+		}
+	}
+	return reviewers, nil
+}
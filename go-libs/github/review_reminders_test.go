@@ -0,0 +1,82 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type notifierFunc func(ctx context.Context, reminder ReviewReminder) error
+
+func (f notifierFunc) NotifyReviewers(ctx context.Context, reminder ReviewReminder) error {
+	return f(ctx, reminder)
+}
+
+func TestRemindReviewers(t *testing.T) {
+	old := time.Now().Add(-72 * time.Hour).UTC().Format(time.RFC3339)
+	recent := time.Now().Add(-time.Hour).UTC().Format(time.RFC3339)
+	client := NewClient(&GitHubConfig{
+		GitHubTokenSource: GitHubTokenSource{Pat: "x"},
+		transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			switch r.URL.Path {
+			case "/repos/a/b/pulls":
+				return jsonResponse(200, fmt.Sprintf(`[
+					{"number": 1, "created_at": %q, "user": {"login": "x"}, "requested_reviewers": [{"login": "y"}],
+						"requested_teams": [{"slug": "core"}]},
+					{"number": 2, "created_at": %q, "user": {"login": "x"}, "base": {"ref": "main"}},
+					{"number": 3, "created_at": %q, "user": {"login": "x"}, "requested_reviewers": [{"login": "y"}]},
+					{"number": 4, "created_at": %q, "draft": true, "requested_reviewers": [{"login": "y"}]},
+					{"number": 5, "created_at": %q, "user": {"login": "x"}, "requested_reviewers": [{"login": "y"}]}]`,
+					old, old, recent, old, old)), nil
+			case "/repos/a/b/pulls/5/reviews":
+				return jsonResponse(200, fmt.Sprintf(`[{"user": {"login": "y"}, "submitted_at": %q}]`, recent)), nil
+			case "/repos/a/b/pulls/1/reviews", "/repos/a/b/pulls/2/reviews", "/repos/a/b/pulls/3/reviews":
+				return jsonResponse(200, `[]`), nil
+			case "/repos/a/b/contents/.github/CODEOWNERS":
+				assert.Equal(t, "main", r.URL.Query().Get("ref"))
+				return jsonResponse(200, "*.go @x @z\n"), nil
+			case "/repos/a/b/pulls/2/files":
+				return jsonResponse(200, `[{"filename": "main.go"}]`), nil
+			}
+			return jsonResponse(404, `{"message": "Not Found"}`), nil
+		}),
+	})
+	var notified []string
+	reminders, err := client.RemindReviewers(context.Background(), "a", ReviewRemindersOptions{
+		Repos: []string{"b"},
+		Notifier: notifierFunc(func(ctx context.Context, reminder ReviewReminder) error {
+			notified = append(notified, fmt.Sprintf("#%d %v", reminder.PullRequest.Number, reminder.Reviewers))
+			return nil
+		}),
+	})
+	require.NoError(t, err)
+	assert.Len(t, reminders, 2)
+	assert.Equal(t, []string{"#1 [@y @a/core]", "#2 [@z]"}, notified)
+}
+
+func TestCommentNotifier(t *testing.T) {
+	var path string
+	client := NewClient(&GitHubConfig{
+		GitHubTokenSource: GitHubTokenSource{Pat: "x"},
+		transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			path = r.URL.Path
+			return jsonResponse(201, `{"id": 1}`), nil
+		}),
+	})
+	notifier := &commentNotifier{client}
+	err := notifier.NotifyReviewers(context.Background(), ReviewReminder{
+		Org:         "a",
+		Repo:        "b",
+		PullRequest: PullRequest{Number: 7},
+		Reviewers:   []string{"@y"},
+		Waiting:     50 * time.Hour,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "/repos/a/b/issues/7/comments", path)
+	assert.Equal(t, "2d 2h", humanDuration(50*time.Hour))
+}
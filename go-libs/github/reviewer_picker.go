@@ -0,0 +1,227 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/databricks/databricks-sdk-go/logger"
+)
+
+var ErrNoReviewers = errors.New("no available reviewers")
+
+// ListTeamMembers lists members of the team, including members of its child
+// teams. See https://docs.github.com/en/rest/teams/members
+func (c *GitHubClient) ListTeamMembers(ctx context.Context, org, teamSlug string) ([]User, error) {
+	path := fmt.Sprintf("%s/orgs/%s/teams/%s/members", gitHubAPI, org, teamSlug)
+	return listAll[User](ctx, c, path, nil)
+}
+
+// busy reports users, who set their status to limited availability
+func (c *GitHubClient) busy(ctx context.Context, login string) (bool, error) {
+	var res struct {
+		User struct {
+			Status *struct {
+				IndicatesLimitedAvailability bool `json:"indicatesLimitedAvailability"`
+			} `json:"status"`
+		} `json:"user"`
+	}
+	err := c.GraphQL(ctx, `query($login: String!) {
+		user(login: $login) { status { indicatesLimitedAvailability } }
+	}`, map[string]any{"login": login}, &res)
+	if err != nil {
+		return false, err
+	}
+	return res.User.Status != nil && res.User.Status.IndicatesLimitedAvailability, nil
+}
+
+type loginNode struct {
+	Login string `json:"login"`
+}
+
+type pullRequestReviewers struct {
+	ReviewRequests Connection[struct {
+		RequestedReviewer *loginNode `json:"requestedReviewer"`
+	}] `json:"reviewRequests"`
+	Reviews Connection[struct {
+		Author *loginNode `json:"author"`
+	}] `json:"reviews"`
+}
+
+type reviewersSearchResponse struct {
+	Search Connection[pullRequestReviewers] `json:"search"`
+}
+
+// searchPullRequestReviewers returns requested reviewers and authors of
+// reviews of pull requests matching the search query, with GraphQL, as the
+// search API is limited to 30 requests a minute. GitHub caps the search at
+// 1000 results.
+func (c *GitHubClient) searchPullRequestReviewers(ctx context.Context, query string) ([]pullRequestReviewers, error) {
+	it := PaginateGraphQL(c, `query($q: String!, $cursor: String) {
+		search(query: $q, type: ISSUE, first: 100, after: $cursor) {
+			nodes {
+				... on PullRequest {
+					reviewRequests(first: 20) { nodes { requestedReviewer { ... on User { login } } } }
+					reviews(first: 50) { nodes { author { login } } }
+				}
+			}
+			pageInfo { hasNextPage endCursor }
+		}
+	}`, map[string]any{"q": query}, func(res *reviewersSearchResponse) *Connection[pullRequestReviewers] {
+		return &res.Search
+	})
+	return ToSlice(ctx, it)
+}
+
+// reviewCounts aggregates pending review requests and pull requests reviewed
+// since the date by login, with two searches for the whole team.
+func (c *GitHubClient) reviewCounts(ctx context.Context, org, since string) (pending, recent map[string]int, err error) {
+	open, err := c.searchPullRequestReviewers(ctx, fmt.Sprintf("org:%s is:pr is:open", org))
+	if err != nil {
+		return nil, nil, fmt.Errorf("pending reviews: %w", err)
+	}
+	pending = map[string]int{}
+	for _, pr := range open {
+		for _, v := range pr.ReviewRequests.Nodes {
+			if v.RequestedReviewer != nil {
+				pending[v.RequestedReviewer.Login]++
+			}
+		}
+	}
+	updated, err := c.searchPullRequestReviewers(ctx, fmt.Sprintf("org:%s is:pr updated:>=%s", org, since))
+	if err != nil {
+		return nil, nil, fmt.Errorf("recent reviews: %w", err)
+	}
+	recent = map[string]int{}
+	for _, pr := range updated {
+		// pull requests count once, regardless of the number of reviews
+		reviewed := map[string]bool{}
+		for _, v := range pr.Reviews.Nodes {
+			if v.Author != nil && !reviewed[v.Author.Login] {
+				reviewed[v.Author.Login] = true
+				recent[v.Author.Login]++
+			}
+		}
+	}
+	return pending, recent, nil
+}
+
+// ReviewerLoad is the number of pending review requests of a team member and
+// of pull requests, that they reviewed recently.
+type ReviewerLoad struct {
+	Login   string
+	Pending int
+	Recent  int
+}
+
+func (l ReviewerLoad) Total() int {
+	return l.Pending + l.Recent
+}
+
+// ReviewerPicker assigns reviews to the least loaded member of a team.
+type ReviewerPicker struct {
+	Client *GitHubClient
+	Org    string
+	Team   string
+
+	// OutOfOffice are logins, that are never picked.
+	OutOfOffice []string
+
+	// SkipBusy excludes members with the "busy" status on GitHub, at the
+	// cost of a GraphQL request for every member.
+	SkipBusy bool
+
+	// Window for recent reviews. Default is 14 days.
+	Window time.Duration
+
+	now func() time.Time
+}
+
+// Loads returns loads of available team members, from the least loaded.
+func (p *ReviewerPicker) Loads(ctx context.Context, exclude ...string) ([]ReviewerLoad, error) {
+	window := p.Window
+	if window == 0 {
+		window = 14 * 24 * time.Hour
+	}
+	now := time.Now
+	if p.now != nil {
+		now = p.now
+	}
+	since := now().Add(-window).Format("2006-01-02")
+	members, err := p.Client.ListTeamMembers(ctx, p.Org, p.Team)
+	if err != nil {
+		return nil, fmt.Errorf("team members: %w", err)
+	}
+	pending, recent, err := p.Client.reviewCounts(ctx, p.Org, since)
+	if err != nil {
+		return nil, err
+	}
+	var loads []ReviewerLoad
+	for _, member := range members {
+		login := member.Login
+		if slices.Contains(exclude, login) || slices.Contains(p.OutOfOffice, login) {
+			continue
+		}
+		if p.SkipBusy {
+			busy, err := p.Client.busy(ctx, login)
+			if err != nil {
+				return nil, fmt.Errorf("status of %s: %w", login, err)
+			}
+			if busy {
+				logger.Debugf(ctx, "Skipping %s, who is busy", login)
+				continue
+			}
+		}
+		loads = append(loads, ReviewerLoad{
+			Login:   login,
+			Pending: pending[login],
+			Recent:  recent[login],
+		})
+	}
+	sort.SliceStable(loads, func(i, j int) bool {
+		if loads[i].Total() != loads[j].Total() {
+			return loads[i].Total() < loads[j].Total()
+		}
+		return loads[i].Login < loads[j].Login
+	})
+	return loads, nil
+}
+
+// Pick returns the least loaded available team member, except the excluded
+// logins, like the author of the pull request.
+func (p *ReviewerPicker) Pick(ctx context.Context, exclude ...string) (string, error) {
+	loads, err := p.Loads(ctx, exclude...)
+	if err != nil {
+		return "", err
+	}
+	if len(loads) == 0 {
+		return "", fmt.Errorf("%s: %w", p.Team, ErrNoReviewers)
+	}
+	return loads[0].Login, nil
+}
+
+// HandlePullRequestEvent requests a review from the least loaded team member
+// for pull requests from pull_request webhook events, when they become ready
+// for review and nobody is requested yet. Returns the picked login.
+func (p *ReviewerPicker) HandlePullRequestEvent(ctx context.Context, org, repo string, event *PullRequestEventPayload) (string, error) {
+	pr := event.PullRequest
+	if event.Action != "opened" && event.Action != "ready_for_review" {
+		return "", nil
+	}
+	if pr.Draft || len(pr.RequestedReviewers) > 0 || len(pr.RequestedTeams) > 0 {
+		return "", nil
+	}
+	login, err := p.Pick(ctx, pr.User.Login)
+	if err != nil {
+		return "", err
+	}
+	_, err = p.Client.RequestReviewers(ctx, org, repo, pr.Number, []string{login}, nil)
+	if err != nil {
+		return "", fmt.Errorf("request %s: %w", login, err)
+	}
+	logger.Infof(ctx, "Requested review of %s/%s#%d from %s", org, repo, pr.Number, login)
+	return login, nil
+}
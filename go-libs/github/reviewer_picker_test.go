@@ -0,0 +1,74 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReviewerPickerRequestsLeastLoaded(t *testing.T) {
+	var requested, searches []string
+	client := NewClient(&GitHubConfig{
+		GitHubTokenSource: GitHubTokenSource{Pat: "x"},
+		transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			switch r.URL.Path {
+			case "/orgs/a/teams/core/members":
+				return jsonResponse(200, `[{"login": "x"}, {"login": "y"}, {"login": "z"}, {"login": "author"}]`), nil
+			case "/graphql":
+				var body struct {
+					Variables map[string]string `json:"variables"`
+				}
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+				searches = append(searches, body.Variables["q"])
+				switch body.Variables["q"] {
+				case "org:a is:pr is:open":
+					// x has 3 pending reviews and y has 1
+					return jsonResponse(200, `{"data": {"search": {"nodes": [
+						{"reviewRequests": {"nodes": [{"requestedReviewer": {"login": "x"}}, {"requestedReviewer": {"login": "y"}}]}},
+						{"reviewRequests": {"nodes": [{"requestedReviewer": {"login": "x"}}, {"requestedReviewer": {}}]}},
+						{"reviewRequests": {"nodes": [{"requestedReviewer": {"login": "x"}}]}}
+					], "pageInfo": {"hasNextPage": false}}}}`), nil
+				case "org:a is:pr updated:>=2024-01-01":
+					// y reviewed one pull request twice
+					return jsonResponse(200, `{"data": {"search": {"nodes": [
+						{"reviews": {"nodes": [{"author": {"login": "y"}}, {"author": {"login": "y"}}]}}
+					], "pageInfo": {"hasNextPage": false}}}}`), nil
+				}
+				t.Fatalf("unexpected query: %v", body.Variables)
+			case "/repos/a/b/pulls/1/requested_reviewers":
+				raw, err := io.ReadAll(r.Body)
+				require.NoError(t, err)
+				var body reviewRequest
+				require.NoError(t, json.Unmarshal(raw, &body))
+				requested = body.Reviewers
+				return jsonResponse(201, `{}`), nil
+			}
+			t.Fatalf("unexpected request: %s", r.URL.Path)
+			return nil, nil
+		}),
+	})
+	p := &ReviewerPicker{
+		Client:      client,
+		Org:         "a",
+		Team:        "core",
+		OutOfOffice: []string{"z"},
+		now: func() time.Time {
+			return time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+		},
+	}
+	login, err := p.HandlePullRequestEvent(context.Background(), "a", "b", &PullRequestEventPayload{
+		Action:      "opened",
+		PullRequest: PullRequest{Number: 1, User: User{Login: "author"}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "y", login)
+	assert.Equal(t, []string{"y"}, requested)
+	// one search for every kind of load, regardless of the team size
+	assert.Len(t, searches, 2)
+}
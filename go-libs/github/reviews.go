@@ -0,0 +1,157 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/databricks/databricks-sdk-go/httpclient"
+)
+
+type PullRequestReview struct {
+	ID                int64     `json:"id,omitempty"`
+	NodeID            string    `json:"node_id,omitempty"`
+	User              User      `json:"user,omitempty"`
+	Body              string    `json:"body,omitempty"`
+	State             string    `json:"state,omitempty"` // APPROVED, CHANGES_REQUESTED, COMMENTED, DISMISSED, PENDING
+	CommitID          string    `json:"commit_id,omitempty"`
+	HTMLURL           string    `json:"html_url,omitempty"`
+	PullRequestURL    string    `json:"pull_request_url,omitempty"`
+	AuthorAssociation string    `json:"author_association,omitempty"`
+	SubmittedAt       time.Time `json:"submitted_at,omitempty"`
+}
+
+// ReviewEvent is the outcome of a submitted review.
+type ReviewEvent string
+
+const (
+	ReviewApprove        ReviewEvent = "APPROVE"
+	ReviewRequestChanges ReviewEvent = "REQUEST_CHANGES"
+	ReviewComment        ReviewEvent = "COMMENT"
+)
+
+// ReviewLineComment is a comment on a line of the diff, that is submitted
+// together with the review.
+type ReviewLineComment struct {
+	Path string `json:"path"`
+	Line int    `json:"line"`
+
+	// Side is LEFT for deletions and RIGHT for additions or context.
+	// Default is RIGHT.
+	Side string `json:"side,omitempty"`
+	Body string `json:"body"`
+}
+
+type NewReview struct {
+	// CommitID defaults to the latest commit of the pull request. Set it to
+	// make sure the review applies to the commit, that was checked.
+	CommitID string `json:"commit_id,omitempty"`
+
+	// Body is required for ReviewRequestChanges and ReviewComment.
+	Body     string              `json:"body,omitempty"`
+	Event    ReviewEvent         `json:"event"`
+	Comments []ReviewLineComment `json:"comments,omitempty"`
+}
+
+// CreateReview submits a review. Authors can't approve or request changes on
+// their own pull requests.
+func (c *GitHubClient) CreateReview(ctx context.Context, org, repo string, number int, req NewReview) (*PullRequestReview, error) {
+	path := fmt.Sprintf("%s/repos/%s/%s/pulls/%d/reviews", gitHubAPI, org, repo, number)
+	var res PullRequestReview
+	err := c.api.Do(ctx, "POST", path,
+		httpclient.WithRequestData(req),
+		httpclient.WithResponseUnmarshal(&res))
+	return &res, err
+}
+
+func (c *GitHubClient) ListReviews(ctx context.Context, org, repo string, number int) ([]PullRequestReview, error) {
+	path := fmt.Sprintf("%s/repos/%s/%s/pulls/%d/reviews", gitHubAPI, org, repo, number)
+	var res []PullRequestReview
+	err := c.api.Do(ctx, "GET", path,
+		httpclient.WithRequestData(listOptions{PerPage: 100}),
+		httpclient.WithResponseUnmarshal(&res))
+	return res, err
+}
+
+func (c *GitHubClient) ListReviewsIterator(org, repo string, number int) *Iterator[PullRequestReview] {
+	path := fmt.Sprintf("%s/repos/%s/%s/pulls/%d/reviews", gitHubAPI, org, repo, number)
+	return Paginate[PullRequestReview](c, path, nil)
+}
+
+// DismissReview dismisses a submitted review. Only approvals and change requests
+// can be dismissed, and the message is shown to the reviewer.
+func (c *GitHubClient) DismissReview(ctx context.Context, org, repo string, number int, reviewID int64, message string) (*PullRequestReview, error) {
+	path := fmt.Sprintf("%s/repos/%s/%s/pulls/%d/reviews/%d/dismissals", gitHubAPI, org, repo, number, reviewID)
+	var res PullRequestReview
+	err := c.api.Do(ctx, "PUT", path,
+		httpclient.WithRequestData(map[string]string{
+			"message": message,
+			"event":   "DISMISS",
+		}),
+		httpclient.WithResponseUnmarshal(&res))
+	return &res, err
+}
+
+// ReRequestReview requests another review from everyone who has already reviewed
+// the pull request. When dismissMessage is not empty, their current approvals
+// are dismissed first, which is what's needed after automation force-pushes
+// and stale approvals must no longer count. Returns the re-requested logins.
+func (c *GitHubClient) ReRequestReview(ctx context.Context, org, repo string, number int, dismissMessage string) ([]string, error) {
+	reviews, err := c.ListReviews(ctx, org, repo, number)
+	if err != nil {
+		return nil, fmt.Errorf("list reviews: %w", err)
+	}
+	// reviews are sorted chronologically, so the last one per user wins
+	latest := map[string]PullRequestReview{}
+	var logins []string
+	for _, v := range reviews {
+		if v.State == "PENDING" {
+			continue
+		}
+		if _, ok := latest[v.User.Login]; !ok {
+			logins = append(logins, v.User.Login)
+		}
+		latest[v.User.Login] = v
+	}
+	if len(logins) == 0 {
+		return nil, nil
+	}
+	for _, login := range logins {
+		review := latest[login]
+		if dismissMessage == "" || review.State != "APPROVED" {
+			continue
+		}
+		_, err = c.DismissReview(ctx, org, repo, number, review.ID, dismissMessage)
+		if err != nil {
+			return nil, fmt.Errorf("dismiss review by %s: %w", login, err)
+		}
+	}
+	_, err = c.RequestReviewers(ctx, org, repo, number, logins, nil)
+	if err != nil {
+		return nil, fmt.Errorf("request reviewers: %w", err)
+	}
+	return logins, nil
+}
+
+type reviewRequest struct {
+	Reviewers     []string `json:"reviewers,omitempty"`
+	TeamReviewers []string `json:"team_reviewers,omitempty"`
+}
+
+// RequestReviewers asks users and teams, by their slugs, to review the pull
+// request. Authors of the pull request can't be requested.
+func (c *GitHubClient) RequestReviewers(ctx context.Context, org, repo string, number int, reviewers, teamReviewers []string) (*PullRequest, error) {
+	path := fmt.Sprintf("%s/repos/%s/%s/pulls/%d/requested_reviewers", gitHubAPI, org, repo, number)
+	var res PullRequest
+	err := c.api.Do(ctx, "POST", path,
+		httpclient.WithRequestData(reviewRequest{reviewers, teamReviewers}),
+		httpclient.WithResponseUnmarshal(&res))
+	return &res, err
+}
+
+// RemoveRequestedReviewers withdraws review requests from users and teams.
+func (c *GitHubClient) RemoveRequestedReviewers(ctx context.Context, org, repo string, number int, reviewers, teamReviewers []string) error {
+	path := fmt.Sprintf("%s/repos/%s/%s/pulls/%d/requested_reviewers", gitHubAPI, org, repo, number)
+	// request data of DELETE requests goes into the query
+	return c.api.Do(ctx, "DELETE", path, withJSONBody(reviewRequest{reviewers, teamReviewers}))
+}
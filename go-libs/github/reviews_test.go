@@ -0,0 +1,95 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReRequestReviewDismissesApprovals(t *testing.T) {
+	var calls []string
+	var requested map[string]any
+	client := NewClient(&GitHubConfig{
+		GitHubTokenSource: GitHubTokenSource{Pat: "x"},
+		transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			calls = append(calls, r.Method+" "+r.URL.Path)
+			switch r.Method + " " + r.URL.Path {
+			case "GET /repos/a/b/pulls/1/reviews":
+				return jsonResponse(200, `[
+					{"id": 10, "user": {"login": "x"}, "state": "CHANGES_REQUESTED"},
+					{"id": 11, "user": {"login": "y"}, "state": "COMMENTED"},
+					{"id": 12, "user": {"login": "x"}, "state": "APPROVED"}]`), nil
+			case "POST /repos/a/b/pulls/1/requested_reviewers":
+				raw, err := io.ReadAll(r.Body)
+				require.NoError(t, err)
+				require.NoError(t, json.Unmarshal(raw, &requested))
+			}
+			return jsonResponse(200, `{}`), nil
+		}),
+	})
+	logins, err := client.ReRequestReview(context.Background(), "a", "b", 1, "force-pushed")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"x", "y"}, logins)
+	assert.Equal(t, []string{
+		"GET /repos/a/b/pulls/1/reviews",
+		"PUT /repos/a/b/pulls/1/reviews/12/dismissals",
+		"POST /repos/a/b/pulls/1/requested_reviewers",
+	}, calls)
+	assert.Equal(t, map[string]any{"reviewers": []any{"x", "y"}}, requested)
+}
+
+func TestCreateReview(t *testing.T) {
+	client := NewClient(&GitHubConfig{
+		GitHubTokenSource: GitHubTokenSource{Pat: "x"},
+		transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			assert.Equal(t, "POST /repos/a/b/pulls/1/reviews", r.Method+" "+r.URL.Path)
+			raw, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			var body map[string]any
+			require.NoError(t, json.Unmarshal(raw, &body))
+			assert.Equal(t, map[string]any{
+				"event": "REQUEST_CHANGES",
+				"body":  "see comments",
+				"comments": []any{map[string]any{
+					"path": "a.go",
+					"line": float64(3),
+					"body": "typo",
+				}},
+			}, body)
+			return jsonResponse(200, `{"id": 5, "state": "CHANGES_REQUESTED"}`), nil
+		}),
+	})
+	review, err := client.CreateReview(context.Background(), "a", "b", 1, NewReview{
+		Body:     "see comments",
+		Event:    ReviewRequestChanges,
+		Comments: []ReviewLineComment{{Path: "a.go", Line: 3, Body: "typo"}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "CHANGES_REQUESTED", review.State)
+}
+
+func TestRemoveRequestedReviewers(t *testing.T) {
+	client := NewClient(&GitHubConfig{
+		GitHubTokenSource: GitHubTokenSource{Pat: "x"},
+		transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			assert.Equal(t, "DELETE /repos/a/b/pulls/1/requested_reviewers", r.Method+" "+r.URL.Path)
+			assert.Empty(t, r.URL.RawQuery)
+			raw, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			var body map[string]any
+			require.NoError(t, json.Unmarshal(raw, &body))
+			assert.Equal(t, map[string]any{
+				"reviewers":      []any{"alice"},
+				"team_reviewers": []any{"team"},
+			}, body)
+			return jsonResponse(200, `{"number": 1}`), nil
+		}),
+	})
+	err := client.RemoveRequestedReviewers(context.Background(), "a", "b", 1, []string{"alice"}, []string{"team"})
+	require.NoError(t, err)
+}
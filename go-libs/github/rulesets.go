@@ -0,0 +1,273 @@
+package github
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// See https://docs.github.com/en/rest/repos/rules
+
+type BranchRuleParameters struct {
+	// pull_request
+	RequiredApprovingReviewCount   int  `json:"required_approving_review_count,omitempty"`
+	RequireCodeOwnerReview         bool `json:"require_code_owner_review,omitempty"`
+	DismissStaleReviewsOnPush      bool `json:"dismiss_stale_reviews_on_push,omitempty"`
+	RequireLastPushApproval        bool `json:"require_last_push_approval,omitempty"`
+	RequiredReviewThreadResolution bool `json:"required_review_thread_resolution,omitempty"`
+
+	// required_status_checks
+	RequiredStatusChecks []struct {
+		Context       string `json:"context"`
+		IntegrationID int64  `json:"integration_id,omitempty"`
+	} `json:"required_status_checks,omitempty"`
+	StrictRequiredStatusChecksPolicy bool `json:"strict_required_status_checks_policy,omitempty"`
+}
+
+// BranchRule is an active rule of a ruleset, that applies to a branch.
+type BranchRule struct {
+	// Type is one of: pull_request, required_status_checks,
+	// required_signatures, non_fast_forward, deletion, required_linear_history,
+	// and a few more.
+	Type       string                `json:"type"`
+	Parameters *BranchRuleParameters `json:"parameters,omitempty"`
+
+	// RulesetSourceType is one of: Repository, Organization.
+	RulesetSourceType string `json:"ruleset_source_type"`
+	RulesetSource     string `json:"ruleset_source"`
+	RulesetID         int64  `json:"ruleset_id"`
+}
+
+// ListBranchRules lists active rules of all rulesets, that apply to the
+// branch, either from the repository or from the org. Rulesets in the
+// evaluate mode are not enforced, so they aren't listed. Rules of classic
+// branch protection aren't listed either.
+func (c *GitHubClient) ListBranchRules(ctx context.Context, org, repo, branch string) ([]BranchRule, error) {
+	path := fmt.Sprintf("%s/repos/%s/%s/rules/branches/%s", gitHubAPI, org, repo, escapeRef(branch))
+	return listAll[BranchRule](ctx, c, path, nil)
+}
+
+// RulesetPolicy is the baseline, that default branches of repositories must
+// meet, either by rulesets or by classic branch protection. Zero values are
+// not checked.
+type RulesetPolicy struct {
+	RequiredApprovals       int
+	RequireCodeOwnerReviews bool
+	RequireSignedCommits    bool
+	BlockForcePushes        bool
+
+	// RequiredStatusChecks must all be required, while repositories may
+	// require more checks.
+	RequiredStatusChecks []string
+
+	// Repos limits the report to the repositories. Default is all repositories,
+	// except forks and archived ones.
+	Repos []string
+}
+
+// ComplianceResult is the outcome of a rule of the policy for a repository.
+type ComplianceResult struct {
+	Repo   string
+	Branch string
+
+	// Rule is one of: required_approvals, code_owner_reviews, signed_commits,
+	// block_force_pushes, status_checks.
+	Rule   string
+	Pass   bool
+	Actual string
+
+	// Source is the name of the ruleset, "branch protection", or empty, if
+	// the rule isn't enforced at all.
+	Source string
+}
+
+// ComplianceReport has a result per rule of the policy per repository.
+type ComplianceReport []ComplianceResult
+
+// Failed returns results, which don't meet the policy.
+func (r ComplianceReport) Failed() (failed ComplianceReport) {
+	for _, v := range r {
+		if !v.Pass {
+			failed = append(failed, v)
+		}
+	}
+	return failed
+}
+
+func (r ComplianceReport) WriteCSV(w io.Writer) error {
+	out := csv.NewWriter(w)
+	err := out.Write([]string{"repo", "branch", "rule", "result", "actual", "source"})
+	if err != nil {
+		return err
+	}
+	for _, v := range r {
+		result := "fail"
+		if v.Pass {
+			result = "pass"
+		}
+		err = out.Write([]string{v.Repo, v.Branch, v.Rule, result, v.Actual, v.Source})
+		if err != nil {
+			return err
+		}
+	}
+	out.Flush()
+	return out.Error()
+}
+
+// effectiveProtection is the strictest combination of rulesets and classic
+// protection of a branch, with the source of every enforced rule.
+type effectiveProtection struct {
+	approvals     int
+	codeOwners    bool
+	signed        bool
+	noForcePushes bool
+	checks        []string
+	sources       map[string]string
+}
+
+func (p *effectiveProtection) enforce(rule, source string) {
+	if _, ok := p.sources[rule]; !ok {
+		p.sources[rule] = source
+	}
+}
+
+func (c *GitHubClient) effectiveProtection(ctx context.Context, org, repo, branch string) (*effectiveProtection, error) {
+	p := &effectiveProtection{sources: map[string]string{}}
+	rules, err := c.ListBranchRules(ctx, org, repo, branch)
+	if err != nil {
+		return nil, fmt.Errorf("rulesets: %w", err)
+	}
+	for _, rule := range rules {
+		source := rule.RulesetSource
+		params := rule.Parameters
+		if params == nil {
+			params = &BranchRuleParameters{}
+		}
+		switch rule.Type {
+		case "pull_request":
+			if params.RequiredApprovingReviewCount > p.approvals {
+				p.approvals = params.RequiredApprovingReviewCount
+				p.sources["required_approvals"] = source
+			}
+			if params.RequireCodeOwnerReview {
+				p.codeOwners = true
+				p.enforce("code_owner_reviews", source)
+			}
+		case "required_signatures":
+			p.signed = true
+			p.enforce("signed_commits", source)
+		case "non_fast_forward":
+			p.noForcePushes = true
+			p.enforce("block_force_pushes", source)
+		case "required_status_checks":
+			for _, check := range params.RequiredStatusChecks {
+				p.checks = append(p.checks, check.Context)
+			}
+			p.enforce("status_checks", source)
+		}
+	}
+	classic, err := c.branchProtection(ctx, org, repo, branch)
+	if err != nil {
+		return nil, fmt.Errorf("branch protection: %w", err)
+	}
+	if classic == nil {
+		return p, nil
+	}
+	const source = "branch protection"
+	if reviews := classic.RequiredPullRequestReviews; reviews != nil {
+		if reviews.RequiredApprovingReviewCount > p.approvals {
+			p.approvals = reviews.RequiredApprovingReviewCount
+			p.sources["required_approvals"] = source
+		}
+		if reviews.RequireCodeOwnerReviews {
+			p.codeOwners = true
+			p.enforce("code_owner_reviews", source)
+		}
+	}
+	if classic.RequiredSignatures.Enabled {
+		p.signed = true
+		p.enforce("signed_commits", source)
+	}
+	if !classic.AllowForcePushes.Enabled {
+		p.noForcePushes = true
+		p.enforce("block_force_pushes", source)
+	}
+	if checks := classic.RequiredStatusChecks; checks != nil && len(checks.Contexts) > 0 {
+		p.checks = append(p.checks, checks.Contexts...)
+		p.enforce("status_checks", source)
+	}
+	return p, nil
+}
+
+func (p *effectiveProtection) evaluate(repo, branch string, policy RulesetPolicy) (results ComplianceReport) {
+	result := func(rule string, pass bool, actual string) {
+		results = append(results, ComplianceResult{
+			Repo:   repo,
+			Branch: branch,
+			Rule:   rule,
+			Pass:   pass,
+			Actual: actual,
+			Source: p.sources[rule],
+		})
+	}
+	if policy.RequiredApprovals > 0 {
+		result("required_approvals", p.approvals >= policy.RequiredApprovals, strconv.Itoa(p.approvals))
+	}
+	if policy.RequireCodeOwnerReviews {
+		result("code_owner_reviews", p.codeOwners, strconv.FormatBool(p.codeOwners))
+	}
+	if policy.RequireSignedCommits {
+		result("signed_commits", p.signed, strconv.FormatBool(p.signed))
+	}
+	if policy.BlockForcePushes {
+		result("block_force_pushes", p.noForcePushes, strconv.FormatBool(p.noForcePushes))
+	}
+	if len(policy.RequiredStatusChecks) > 0 {
+		checks := slices.Clone(p.checks)
+		sort.Strings(checks)
+		checks = slices.Compact(checks)
+		pass := true
+		for _, check := range policy.RequiredStatusChecks {
+			if !slices.Contains(checks, check) {
+				pass = false
+			}
+		}
+		result("status_checks", pass, strings.Join(checks, " "))
+	}
+	return results
+}
+
+// RulesetCompliance evaluates default branches of repositories against the
+// policy, combining rulesets with classic branch protection, as GitHub
+// enforces the strictest of both. Reading classic protection requires admin
+// access to repositories.
+func (c *GitHubClient) RulesetCompliance(ctx context.Context, org string, policy RulesetPolicy) (ComplianceReport, error) {
+	var repos []Repo
+	err := c.StreamRepositories(ctx, org, func(r Repo) error {
+		if policy.Repos != nil && !slices.Contains(policy.Repos, r.Name) {
+			return nil
+		}
+		if r.IsFork || r.IsArchived {
+			return nil
+		}
+		repos = append(repos, r)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("repositories: %w", err)
+	}
+	var report ComplianceReport
+	for _, repo := range repos {
+		p, err := c.effectiveProtection(ctx, org, repo.Name, repo.DefaultBranch)
+		if err != nil {
+			return report, fmt.Errorf("%s: %w", repo.Name, err)
+		}
+		report = append(report, p.evaluate(repo.Name, repo.DefaultBranch, policy)...)
+	}
+	return report, nil
+}
@@ -0,0 +1,68 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRulesetCompliance(t *testing.T) {
+	client := NewClient(&GitHubConfig{
+		GitHubTokenSource: GitHubTokenSource{Pat: "x"},
+		transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			switch r.URL.Path {
+			case "/users/a/repos":
+				return jsonResponse(200, `[{"name": "ruled", "default_branch": "main"},
+					{"name": "classic", "default_branch": "master"},
+					{"name": "wild", "default_branch": "main"},
+					{"name": "fork", "fork": true}]`), nil
+			case "/repos/a/ruled/rules/branches/main":
+				return jsonResponse(200, `[
+					{"type": "pull_request", "ruleset_source": "baseline",
+					 "parameters": {"required_approving_review_count": 1, "require_code_owner_review": true}},
+					{"type": "required_signatures", "ruleset_source": "baseline"},
+					{"type": "non_fast_forward", "ruleset_source": "baseline"},
+					{"type": "required_status_checks", "ruleset_source": "ci",
+					 "parameters": {"required_status_checks": [{"context": "build"}]}}
+				]`), nil
+			case "/repos/a/classic/branches/master/protection":
+				return jsonResponse(200, `{"required_status_checks": {"contexts": ["lint"]},
+					"required_pull_request_reviews": {"required_approving_review_count": 2},
+					"allow_force_pushes": {"enabled": false}}`), nil
+			case "/repos/a/ruled/branches/main/protection":
+				return jsonResponse(200, `{"required_status_checks": {"contexts": ["lint"]}}`), nil
+			case "/repos/a/classic/rules/branches/master", "/repos/a/wild/rules/branches/main":
+				return jsonResponse(200, `[]`), nil
+			}
+			return jsonResponse(404, `{"message": "Branch not protected"}`), nil
+		}),
+	})
+	report, err := client.RulesetCompliance(context.Background(), "a", RulesetPolicy{
+		RequiredApprovals:    1,
+		RequireSignedCommits: true,
+		BlockForcePushes:     true,
+		RequiredStatusChecks: []string{"build", "lint"},
+	})
+	require.NoError(t, err)
+	var buf bytes.Buffer
+	require.NoError(t, report.WriteCSV(&buf))
+	assert.Equal(t, `repo,branch,rule,result,actual,source
+ruled,main,required_approvals,pass,1,baseline
+ruled,main,signed_commits,pass,true,baseline
+ruled,main,block_force_pushes,pass,true,baseline
+ruled,main,status_checks,pass,build lint,ci
+classic,master,required_approvals,pass,2,branch protection
+classic,master,signed_commits,fail,false,
+classic,master,block_force_pushes,pass,true,branch protection
+classic,master,status_checks,fail,lint,branch protection
+wild,main,required_approvals,fail,0,
+wild,main,signed_commits,fail,false,
+wild,main,block_force_pushes,fail,false,
+wild,main,status_checks,fail,,
+`, buf.String())
+	assert.Len(t, report.Failed(), 6)
+}
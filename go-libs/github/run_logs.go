@@ -0,0 +1,134 @@
+package github
+
+import (
+	"archive/zip"
+	"context"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// DownloadRunLogs streams the zip archive with logs of all jobs of the
+// workflow run to w. Logs of runs are kept for 90 days by default.
+func (c *GitHubClient) DownloadRunLogs(ctx context.Context, org, repo string, runID int64, w io.Writer) error {
+	path := fmt.Sprintf("%s/repos/%s/%s/actions/runs/%d/logs", gitHubAPI, org, repo, runID)
+	return c.download(ctx, path, w)
+}
+
+type StepLog struct {
+	Number int
+	Name   string
+	Log    []byte
+}
+
+// JobLog has the whole log of a job and, if available, logs of its steps.
+type JobLog struct {
+	Name  string
+	Log   []byte
+	Steps []StepLog
+}
+
+// Step returns the log of the step with the name, or nil, if there's none.
+func (j *JobLog) Step(name string) *StepLog {
+	for i := range j.Steps {
+		if j.Steps[i].Name == name {
+			return &j.Steps[i]
+		}
+	}
+	return nil
+}
+
+// RunLogs are logs of jobs of a workflow run, in the order of the archive.
+type RunLogs struct {
+	Jobs []JobLog
+}
+
+// Job returns logs of the job with the name, or nil, if there's none.
+func (l *RunLogs) Job(name string) *JobLog {
+	for i := range l.Jobs {
+		if l.Jobs[i].Name == name {
+			return &l.Jobs[i]
+		}
+	}
+	return nil
+}
+
+// numberedName splits names of files in log archives, like "2_Run tests.txt".
+func numberedName(file string) (int, string, bool) {
+	n, name, ok := strings.Cut(strings.TrimSuffix(file, ".txt"), "_")
+	if !ok {
+		return 0, "", false
+	}
+	number, err := strconv.Atoi(n)
+	if err != nil {
+		return 0, "", false
+	}
+	return number, name, true
+}
+
+// ParseRunLogs reads the archive of DownloadRunLogs, which has the log of
+// every job, like "0_build.txt", and logs of its steps in a directory named
+// after the job, like "build/2_Run tests.txt". Names of jobs and steps are
+// sanitized by GitHub, so characters like slashes may differ from the ones in
+// the workflow.
+func ParseRunLogs(r io.ReaderAt, size int64) (*RunLogs, error) {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, fmt.Errorf("zip: %w", err)
+	}
+	logs := &RunLogs{}
+	// indexes, as pointers are invalidated by appends
+	jobs := map[string]int{}
+	job := func(name string) *JobLog {
+		i, ok := jobs[name]
+		if !ok {
+			i = len(logs.Jobs)
+			jobs[name] = i
+			logs.Jobs = append(logs.Jobs, JobLog{Name: name})
+		}
+		return &logs.Jobs[i]
+	}
+	for _, f := range zr.File {
+		if f.FileInfo().IsDir() {
+			continue
+		}
+		dir, file := path.Split(f.Name)
+		number, name, ok := numberedName(file)
+		if !ok {
+			continue
+		}
+		log, err := readZipFile(f)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", f.Name, err)
+		}
+		if dir == "" {
+			job(name).Log = log
+			continue
+		}
+		j := job(strings.TrimSuffix(dir, "/"))
+		j.Steps = append(j.Steps, StepLog{
+			Number: number,
+			Name:   name,
+			Log:    log,
+		})
+	}
+	for i := range logs.Jobs {
+		steps := logs.Jobs[i].Steps
+		sort.Slice(steps, func(a, b int) bool {
+			return steps[a].Number < steps[b].Number
+		})
+	}
+	return logs, nil
+}
+
+func readZipFile(f *zip.File) ([]byte, error) {
+	rc, err := f.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	return io.ReadAll(rc)
+}
@@ -0,0 +1,63 @@
+package github
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// runLogsArchive zips name and content pairs
+func runLogsArchive(t *testing.T, files ...string) []byte {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for i := 0; i < len(files); i += 2 {
+		w, err := zw.Create(files[i])
+		require.NoError(t, err)
+		_, err = w.Write([]byte(files[i+1]))
+		require.NoError(t, err)
+	}
+	require.NoError(t, zw.Close())
+	return buf.Bytes()
+}
+
+func TestDownloadAndParseRunLogs(t *testing.T) {
+	archive := runLogsArchive(t,
+		"0_build.txt", "setup\nFAIL\n",
+		"build/", "",
+		"build/2_Run tests.txt", "FAIL\n",
+		"build/1_Set up job.txt", "setup\n",
+		"1_lint.txt", "ok\n")
+	client := NewClient(&GitHubConfig{
+		GitHubTokenSource: GitHubTokenSource{Pat: "x"},
+		transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			assert.Equal(t, "/repos/a/b/actions/runs/7/logs", r.URL.Path)
+			return &http.Response{
+				StatusCode: 200,
+				Header:     http.Header{"Content-Type": []string{"application/zip"}},
+				Body:       io.NopCloser(bytes.NewReader(archive)),
+				Request:    r,
+			}, nil
+		}),
+	})
+	var buf bytes.Buffer
+	err := client.DownloadRunLogs(context.Background(), "a", "b", 7, &buf)
+	require.NoError(t, err)
+
+	logs, err := ParseRunLogs(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+	require.Len(t, logs.Jobs, 2)
+	build := logs.Job("build")
+	require.NotNil(t, build)
+	assert.Equal(t, "setup\nFAIL\n", string(build.Log))
+	require.Len(t, build.Steps, 2)
+	assert.Equal(t, "Set up job", build.Steps[0].Name)
+	assert.Equal(t, "FAIL\n", string(build.Step("Run tests").Log))
+	assert.Equal(t, "ok\n", string(logs.Job("lint").Log))
+	assert.Nil(t, logs.Job("deploy"))
+}
@@ -0,0 +1,116 @@
+package github
+
+import (
+	"context"
+)
+
+// ExternalIdentity links a GitHub account to the corporate identity, that
+// the account authenticated with through SAML single sign-on to the org.
+type ExternalIdentity struct {
+	// Login is empty for identities, that aren't linked to an account yet,
+	// like the ones provisioned by SCIM before the first sign-in.
+	Login string
+
+	// NameID is the unique identifier of the user at the identity provider,
+	// which is usually the corporate email.
+	NameID     string
+	Username   string
+	GivenName  string
+	FamilyName string
+	Emails     []string
+
+	// SCIMUsername is set for identities provisioned by SCIM.
+	SCIMUsername string
+}
+
+type ExternalIdentities []ExternalIdentity
+
+// ByLogin indexes linked identities by GitHub logins, so that reports can
+// join logins with corporate identities.
+func (ids ExternalIdentities) ByLogin() map[string]ExternalIdentity {
+	out := map[string]ExternalIdentity{}
+	for _, v := range ids {
+		if v.Login == "" {
+			continue
+		}
+		out[v.Login] = v
+	}
+	return out
+}
+
+type externalIdentityNode struct {
+	SAMLIdentity *struct {
+		NameID     string `json:"nameId"`
+		Username   string `json:"username"`
+		GivenName  string `json:"givenName"`
+		FamilyName string `json:"familyName"`
+		Emails     []struct {
+			Value string `json:"value"`
+		} `json:"emails"`
+	} `json:"samlIdentity"`
+	SCIMIdentity *struct {
+		Username string `json:"username"`
+	} `json:"scimIdentity"`
+	User *struct {
+		Login string `json:"login"`
+	} `json:"user"`
+}
+
+type externalIdentitiesResponse struct {
+	Organization struct {
+		SAMLIdentityProvider *struct {
+			ExternalIdentities Connection[externalIdentityNode] `json:"externalIdentities"`
+		} `json:"samlIdentityProvider"`
+	} `json:"organization"`
+}
+
+// ListExternalIdentities lists SAML identities of the org, which requires
+// the admin:org scope. Orgs without SAML single sign-on have none, as well
+// as orgs, that enforce it on the enterprise level, where the identities
+// belong to the enterprise instead.
+func (c *GitHubClient) ListExternalIdentities(ctx context.Context, org string) (ExternalIdentities, error) {
+	it := PaginateGraphQL(c, `query($org: String!, $cursor: String) {
+		organization(login: $org) {
+			samlIdentityProvider {
+				externalIdentities(first: 100, after: $cursor) {
+					nodes {
+						samlIdentity { nameId username givenName familyName emails { value } }
+						scimIdentity { username }
+						user { login }
+					}
+					pageInfo { hasNextPage endCursor }
+				}
+			}
+		}
+	}`, map[string]any{"org": org}, func(res *externalIdentitiesResponse) *Connection[externalIdentityNode] {
+		if res.Organization.SAMLIdentityProvider == nil {
+			return nil
+		}
+		return &res.Organization.SAMLIdentityProvider.ExternalIdentities
+	})
+	nodes, err := ToSlice(ctx, it)
+	if err != nil {
+		return nil, err
+	}
+	var out ExternalIdentities
+	for _, node := range nodes {
+		var id ExternalIdentity
+		if node.User != nil {
+			id.Login = node.User.Login
+		}
+		if node.SAMLIdentity != nil {
+			id.NameID = node.SAMLIdentity.NameID
+			id.Username = node.SAMLIdentity.Username
+			id.GivenName = node.SAMLIdentity.GivenName
+			id.FamilyName = node.SAMLIdentity.FamilyName
+			for _, email := range node.SAMLIdentity.Emails {
+				id.Emails = append(id.Emails, email.Value)
+			}
+		}
+		if node.SCIMIdentity != nil {
+			id.SCIMUsername = node.SCIMIdentity.Username
+		}
+		out = append(out, id)
+	}
+	return out, nil
+}
@@ -0,0 +1,51 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListExternalIdentities(t *testing.T) {
+	client := NewClient(&GitHubConfig{
+		GitHubTokenSource: GitHubTokenSource{Pat: "x"},
+		transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			assert.Equal(t, "/graphql", r.URL.Path)
+			return jsonResponse(200, `{"data": {"organization": {"samlIdentityProvider": {"externalIdentities": {
+				"nodes": [
+					{"samlIdentity": {"nameId": "jane@example.com", "givenName": "Jane", "emails": [{"value": "jane@example.com"}]},
+					 "user": {"login": "jane"}},
+					{"samlIdentity": {"nameId": "new@example.com"}, "scimIdentity": {"username": "new"}, "user": null}
+				],
+				"pageInfo": {"hasNextPage": false}
+			}}}}}`), nil
+		}),
+	})
+	ids, err := client.ListExternalIdentities(context.Background(), "a")
+	require.NoError(t, err)
+	require.Len(t, ids, 2)
+	assert.Equal(t, "new", ids[1].SCIMUsername)
+	assert.Equal(t, map[string]ExternalIdentity{
+		"jane": {
+			Login:     "jane",
+			NameID:    "jane@example.com",
+			GivenName: "Jane",
+			Emails:    []string{"jane@example.com"},
+		},
+	}, ids.ByLogin())
+}
+
+func TestListExternalIdentitiesWithoutSAML(t *testing.T) {
+	client := NewClient(&GitHubConfig{
+		GitHubTokenSource: GitHubTokenSource{Pat: "x"},
+		transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			return jsonResponse(200, `{"data": {"organization": {"samlIdentityProvider": null}}}`), nil
+		}),
+	})
+	ids, err := client.ListExternalIdentities(context.Background(), "a")
+	require.NoError(t, err)
+	assert.Empty(t, ids)
+}
@@ -0,0 +1,25 @@
+package github
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/databricks/databricks-sdk-go/httpclient"
+)
+
+// countIssues returns the number of issues and pull requests matching the
+// search query, like "org:x is:pr is:merged", without fetching them.
+// See https://docs.github.com/en/rest/search/search#search-issues-and-pull-requests
+func (c *GitHubClient) countIssues(ctx context.Context, query string) (int, error) {
+	path := fmt.Sprintf("%s/search/issues", gitHubAPI)
+	var res struct {
+		TotalCount int `json:"total_count"`
+	}
+	err := c.api.Do(ctx, "GET", path,
+		httpclient.WithRequestData(struct {
+			Query   string `url:"q"`
+			PerPage int    `url:"per_page"`
+		}{query, 1}),
+		httpclient.WithResponseUnmarshal(&res))
+	return res.TotalCount, err
+}
@@ -0,0 +1,317 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/databricks/databricks-sdk-go/httpclient"
+)
+
+// OrgPlan is the billing plan of an organization, which is only visible to
+// its owners.
+type OrgPlan struct {
+	Name         string `json:"name"`
+	Seats        int    `json:"seats"`
+	FilledSeats  int    `json:"filled_seats"`
+	PrivateRepos int    `json:"private_repos"`
+}
+
+// GetOrgPlan returns the plan of the organization.
+func (c *GitHubClient) GetOrgPlan(ctx context.Context, org string) (*OrgPlan, error) {
+	path := fmt.Sprintf("%s/orgs/%s", gitHubAPI, org)
+	var res struct {
+		Plan *OrgPlan `json:"plan"`
+	}
+	err := c.api.Do(ctx, "GET", path, httpclient.WithResponseUnmarshal(&res))
+	if err != nil {
+		return nil, err
+	}
+	if res.Plan == nil {
+		return nil, fmt.Errorf("%s: plan is only visible to owners: %w", org, ErrUnauthorized)
+	}
+	return res.Plan, nil
+}
+
+type OrgMemberListOptions struct {
+	// Filter is one of: all, 2fa_disabled. Default is all.
+	Filter string `url:"filter,omitempty"`
+
+	// Role is one of: all, admin, member. Default is all.
+	Role string `url:"role,omitempty"`
+
+	Page    int `url:"page,omitempty"`
+	PerPage int `url:"per_page,omitempty"`
+}
+
+// ListOrgMembers lists members of the organization. See
+// https://docs.github.com/en/rest/orgs/members
+func (c *GitHubClient) ListOrgMembers(ctx context.Context, org string, opts OrgMemberListOptions) ([]User, error) {
+	return ToSlice(ctx, c.ListOrgMembersIterator(org, opts))
+}
+
+func (c *GitHubClient) ListOrgMembersIterator(org string, opts OrgMemberListOptions) *Iterator[User] {
+	path := fmt.Sprintf("%s/orgs/%s/members", gitHubAPI, org)
+	return Paginate[User](c, path, opts)
+}
+
+// ListOutsideCollaborators lists users, who have access to repositories of
+// the organization without being its members. They don't take paid seats of
+// the organization, unless they collaborate on private repositories.
+func (c *GitHubClient) ListOutsideCollaborators(ctx context.Context, org string) ([]User, error) {
+	path := fmt.Sprintf("%s/orgs/%s/outside_collaborators", gitHubAPI, org)
+	return listAll[User](ctx, c, path, nil)
+}
+
+// RemoveOrgMember removes the user from the organization along with all its
+// teams, which frees the seat.
+func (c *GitHubClient) RemoveOrgMember(ctx context.Context, org, login string) error {
+	path := fmt.Sprintf("%s/orgs/%s/memberships/%s", gitHubAPI, org, login)
+	return c.api.Do(ctx, "DELETE", path)
+}
+
+// CopilotSeat is a GitHub Copilot license assigned to a member.
+type CopilotSeat struct {
+	Assignee           User       `json:"assignee"`
+	CreatedAt          time.Time  `json:"created_at"`
+	LastActivityAt     *time.Time `json:"last_activity_at"`
+	LastActivityEditor string     `json:"last_activity_editor"`
+
+	// PendingCancellationDate is a date, like 2024-06-30, for seats, which
+	// are being removed at the end of the billing cycle.
+	PendingCancellationDate string `json:"pending_cancellation_date"`
+}
+
+// ListCopilotSeats lists Copilot licenses of the organization. Fails with
+// ErrNotFound, if Copilot isn't enabled for the organization.
+func (c *GitHubClient) ListCopilotSeats(ctx context.Context, org string) ([]CopilotSeat, error) {
+	path := fmt.Sprintf("%s/orgs/%s/copilot/billing/seats", gitHubAPI, org)
+	return ToSlice(ctx, paginateField[CopilotSeat](c, path, "seats", nil))
+}
+
+// DormantMember is a member without any activity seen since the cutoff.
+type DormantMember struct {
+	Login string
+
+	// LastActivity is zero, if no activity was seen at all.
+	LastActivity time.Time
+
+	// Copilot reports members with an active Copilot license, which can be
+	// reclaimed as well.
+	Copilot bool
+}
+
+const (
+	ActivityAuditLog  = "audit-log"
+	ActivityOrgEvents = "events"
+)
+
+type SeatUsageReport struct {
+	Org string
+
+	// Plan is nil, if the token can't see the billing plan.
+	Plan *OrgPlan
+
+	Members      int
+	Admins       int
+	CopilotSeats int
+
+	// ActivitySource is ActivityAuditLog or ActivityOrgEvents, when the
+	// audit log isn't available, like outside of GitHub Enterprise Cloud.
+	ActivitySource string
+
+	// Dormant members had no activity since the cutoff.
+	Dormant []DormantMember
+
+	// NoData are members without activity seen since the cutoff, when the
+	// activity source doesn't reach back to it, so it's unknown, whether
+	// they are dormant.
+	NoData        []DormantMember
+	DormantCutoff time.Time
+}
+
+// SeatUsageOptions configures the dormancy cutoff of SeatUsage.
+type SeatUsageOptions struct {
+	// DormantDays is the number of days without activity, after which members
+	// are reported as dormant. Default is 90.
+	DormantDays int
+
+	// now is the test seam
+	now func() time.Time
+}
+
+// SeatUsage reports paid seats of the organization and members, who haven't
+// been active for DormantDays, so that admins can reclaim their seats.
+// Activity is the latest of Copilot usage and of the audit log, which needs
+// GitHub Enterprise Cloud and the read:audit_log scope. The audit log keeps
+// web events for 180 days, so members are reported as NoData for DormantDays
+// above that, and git events, like pushes, only for 7 days, so members, who
+// only pushed before that, show up as dormant. Without the audit log,
+// public events of the organization are used, which are capped at the latest
+// 300, so members without activity in them are only reported as dormant,
+// if the events reach back to the cutoff, and as NoData otherwise. Members
+// with only private activity may still show up as dormant in that case and
+// have to be double-checked before removal.
+func (c *GitHubClient) SeatUsage(ctx context.Context, org string, opts SeatUsageOptions) (*SeatUsageReport, error) {
+	if opts.DormantDays == 0 {
+		opts.DormantDays = 90
+	}
+	if opts.now == nil {
+		opts.now = time.Now
+	}
+	now := opts.now()
+	report := &SeatUsageReport{
+		Org:           org,
+		DormantCutoff: now.AddDate(0, 0, -opts.DormantDays),
+	}
+	plan, err := c.GetOrgPlan(ctx, org)
+	if err != nil && !errors.Is(err, ErrUnauthorized) {
+		return nil, fmt.Errorf("plan: %w", err)
+	}
+	report.Plan = plan
+	members, err := c.ListOrgMembers(ctx, org, OrgMemberListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("members: %w", err)
+	}
+	report.Members = len(members)
+	admins, err := c.ListOrgMembers(ctx, org, OrgMemberListOptions{Role: "admin"})
+	if err != nil {
+		return nil, fmt.Errorf("admins: %w", err)
+	}
+	report.Admins = len(admins)
+	lastActivity := map[string]time.Time{}
+	seen := func(login string, at time.Time) {
+		if at.After(lastActivity[login]) {
+			lastActivity[login] = at
+		}
+	}
+	// the activity source reaches back to the cutoff
+	covered, err := c.auditLogActivity(ctx, org, report.DormantCutoff, now, seen)
+	report.ActivitySource = ActivityAuditLog
+	if isAuditLogUnavailable(err) {
+		report.ActivitySource = ActivityOrgEvents
+		covered, err = c.orgEventsActivity(ctx, org, report.DormantCutoff, seen)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", report.ActivitySource, err)
+	}
+	copilot := map[string]bool{}
+	seats, err := c.ListCopilotSeats(ctx, org)
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		return nil, fmt.Errorf("copilot seats: %w", err)
+	}
+	for _, seat := range seats {
+		if seat.PendingCancellationDate != "" {
+			continue
+		}
+		report.CopilotSeats++
+		copilot[seat.Assignee.Login] = true
+		if seat.LastActivityAt != nil {
+			seen(seat.Assignee.Login, *seat.LastActivityAt)
+		}
+	}
+	for _, member := range members {
+		last := lastActivity[member.Login]
+		if last.After(report.DormantCutoff) {
+			continue
+		}
+		dormant := DormantMember{
+			Login:        member.Login,
+			LastActivity: last,
+			Copilot:      copilot[member.Login],
+		}
+		if !covered {
+			report.NoData = append(report.NoData, dormant)
+			continue
+		}
+		report.Dormant = append(report.Dormant, dormant)
+	}
+	// the longest dormant first, as the first candidates to reclaim
+	sort.SliceStable(report.Dormant, func(i, j int) bool {
+		return report.Dormant[i].LastActivity.Before(report.Dormant[j].LastActivity)
+	})
+	return report, nil
+}
+
+// OrgAuditEvent is an entry of the audit log of the organization.
+type OrgAuditEvent struct {
+	Action string `json:"action"`
+	Actor  string `json:"actor"`
+
+	// Timestamp is in milliseconds since the epoch.
+	Timestamp int64 `json:"@timestamp"`
+}
+
+func (e OrgAuditEvent) Time() time.Time {
+	return time.UnixMilli(e.Timestamp)
+}
+
+type OrgAuditLogOptions struct {
+	// Phrase is the search query, like "action:repo.create created:>=2024-01-01".
+	Phrase string `url:"phrase,omitempty"`
+
+	// Include is one of: web, git, all. Default is web.
+	Include string `url:"include,omitempty"`
+
+	// Order is one of: desc, asc. Default is desc.
+	Order string `url:"order,omitempty"`
+}
+
+// ListOrgAuditLogIterator walks the audit log of the organization, which is
+// only available on GitHub Enterprise Cloud and fails with ErrNotFound
+// otherwise. Web events are kept for 180 days and Git events for 7 days.
+// See https://docs.github.com/en/enterprise-cloud@latest/rest/orgs/orgs#get-the-audit-log-for-an-organization
+func (c *GitHubClient) ListOrgAuditLogIterator(org string, opts OrgAuditLogOptions) *Iterator[OrgAuditEvent] {
+	path := fmt.Sprintf("%s/orgs/%s/audit-log", gitHubAPI, org)
+	return Paginate[OrgAuditEvent](c, path, opts)
+}
+
+// auditLogRetention is how long the audit log keeps web events
+const auditLogRetention = 180 * 24 * time.Hour
+
+// auditLogActivity calls seen for every event of the audit log since the
+// cutoff. It covers the cutoff only within the retention of web events.
+func (c *GitHubClient) auditLogActivity(ctx context.Context, org string, cutoff, now time.Time, seen func(string, time.Time)) (bool, error) {
+	it := c.ListOrgAuditLogIterator(org, OrgAuditLogOptions{
+		Phrase:  fmt.Sprintf("created:>=%s", cutoff.Format("2006-01-02")),
+		Include: "all",
+	})
+	for it.HasNext(ctx) {
+		event, err := it.Next(ctx)
+		if err != nil {
+			return false, err
+		}
+		seen(event.Actor, event.Time())
+	}
+	return !cutoff.Before(now.Add(-auditLogRetention)), nil
+}
+
+// orgEventsActivity calls seen for public events of the organization, which
+// cover the cutoff only, if the oldest of them is older than it, as GitHub
+// keeps only the latest 300 events.
+func (c *GitHubClient) orgEventsActivity(ctx context.Context, org string, cutoff time.Time, seen func(string, time.Time)) (bool, error) {
+	covered := false
+	it := c.ListOrgEventsIterator(org)
+	for it.HasNext(ctx) {
+		event, err := it.Next(ctx)
+		if err != nil {
+			return false, err
+		}
+		seen(event.Actor.Login, event.CreatedAt)
+		if !event.CreatedAt.After(cutoff) {
+			covered = true
+		}
+	}
+	return covered, nil
+}
+
+// isAuditLogUnavailable detects organizations without GitHub Enterprise
+// Cloud and tokens without the read:audit_log scope.
+func isAuditLogUnavailable(err error) bool {
+	var httpErr *httpclient.HttpError
+	return errors.Is(err, ErrNotFound) || errors.Is(err, ErrUnauthorized) ||
+		(errors.As(err, &httpErr) && httpErr.StatusCode == http.StatusForbidden)
+}
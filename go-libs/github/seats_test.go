@@ -0,0 +1,146 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSeatUsage(t *testing.T) {
+	client := NewClient(&GitHubConfig{
+		GitHubTokenSource: GitHubTokenSource{Pat: "x"},
+		transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			switch r.Method + " " + r.URL.Path {
+			case "GET /orgs/a":
+				return jsonResponse(200, `{"plan": {"name": "team", "seats": 10, "filled_seats": 4}}`), nil
+			case "GET /orgs/a/members":
+				if r.URL.Query().Get("role") == "admin" {
+					return jsonResponse(200, `[{"login": "owner"}]`), nil
+				}
+				return jsonResponse(200, `[
+					{"login": "owner"},
+					{"login": "pusher"},
+					{"login": "copilot-only"},
+					{"login": "idle"}
+				]`), nil
+			case "GET /orgs/a/audit-log":
+				return jsonResponse(404, `{"message": "Not Found"}`), nil
+			case "GET /orgs/a/events":
+				return jsonResponse(200, `[
+					{"id": "2", "actor": {"login": "pusher"}, "created_at": "2024-05-30T00:00:00Z"},
+					{"id": "1", "actor": {"login": "owner"}, "created_at": "2024-01-01T00:00:00Z"}
+				]`), nil
+			case "GET /orgs/a/copilot/billing/seats":
+				return jsonResponse(200, `{"total_seats": 3, "seats": [
+					{"assignee": {"login": "copilot-only"}, "last_activity_at": "2024-05-20T00:00:00Z"},
+					{"assignee": {"login": "idle"}, "last_activity_at": null},
+					{"assignee": {"login": "owner"}, "pending_cancellation_date": "2024-06-30"}
+				]}`), nil
+			}
+			t.Fatalf("unexpected %s %s", r.Method, r.URL.Path)
+			return nil, nil
+		}),
+	})
+	report, err := client.SeatUsage(context.Background(), "a", SeatUsageOptions{
+		DormantDays: 30,
+		now: func() time.Time {
+			return time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 4, report.Plan.FilledSeats)
+	assert.Equal(t, 4, report.Members)
+	assert.Equal(t, 1, report.Admins)
+	assert.Equal(t, 2, report.CopilotSeats)
+	assert.Equal(t, ActivityOrgEvents, report.ActivitySource)
+	assert.Empty(t, report.NoData)
+	assert.Equal(t, []DormantMember{
+		{Login: "idle", Copilot: true},
+		{Login: "owner", LastActivity: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+	}, report.Dormant)
+}
+
+func TestSeatUsageWithoutCopilotAndPlan(t *testing.T) {
+	client := NewClient(&GitHubConfig{
+		GitHubTokenSource: GitHubTokenSource{Pat: "x"},
+		transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			switch r.URL.Path {
+			case "/orgs/a":
+				return jsonResponse(200, `{"login": "a"}`), nil
+			case "/orgs/a/members":
+				return jsonResponse(200, `[{"login": "idle"}]`), nil
+			case "/orgs/a/events":
+				return jsonResponse(200, `[]`), nil
+			}
+			return jsonResponse(404, `{"message": "Not Found"}`), nil
+		}),
+	})
+	report, err := client.SeatUsage(context.Background(), "a", SeatUsageOptions{})
+	require.NoError(t, err)
+	assert.Nil(t, report.Plan)
+	assert.Empty(t, report.Dormant)
+	assert.Equal(t, []DormantMember{{Login: "idle"}}, report.NoData)
+}
+
+func TestSeatUsageFromAuditLog(t *testing.T) {
+	client := NewClient(&GitHubConfig{
+		GitHubTokenSource: GitHubTokenSource{Pat: "x"},
+		transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			switch r.URL.Path {
+			case "/orgs/a":
+				return jsonResponse(200, `{"plan": {"name": "enterprise", "seats": 3, "filled_seats": 3}}`), nil
+			case "/orgs/a/members":
+				return jsonResponse(200, `[{"login": "active"}, {"login": "idle"}]`), nil
+			case "/orgs/a/audit-log":
+				assert.Equal(t, "created:>=2024-05-02", r.URL.Query().Get("phrase"))
+				return jsonResponse(200, `[
+					{"action": "git.push", "actor": "active", "@timestamp": 1717000000000}
+				]`), nil
+			}
+			return jsonResponse(404, `{"message": "Not Found"}`), nil
+		}),
+	})
+	report, err := client.SeatUsage(context.Background(), "a", SeatUsageOptions{
+		DormantDays: 30,
+		now: func() time.Time {
+			return time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, ActivityAuditLog, report.ActivitySource)
+	assert.Equal(t, []DormantMember{{Login: "idle"}}, report.Dormant)
+	assert.Empty(t, report.NoData)
+}
+
+func TestSeatUsageBeyondAuditLogRetention(t *testing.T) {
+	client := NewClient(&GitHubConfig{
+		GitHubTokenSource: GitHubTokenSource{Pat: "x"},
+		transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			switch r.URL.Path {
+			case "/orgs/a":
+				return jsonResponse(200, `{}`), nil
+			case "/orgs/a/members":
+				return jsonResponse(200, `[{"login": "active"}, {"login": "idle"}]`), nil
+			case "/orgs/a/audit-log":
+				return jsonResponse(200, `[
+					{"action": "git.push", "actor": "active", "@timestamp": 1717000000000}
+				]`), nil
+			}
+			return jsonResponse(404, `{"message": "Not Found"}`), nil
+		}),
+	})
+	report, err := client.SeatUsage(context.Background(), "a", SeatUsageOptions{
+		DormantDays: 365,
+		now: func() time.Time {
+			return time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, ActivityAuditLog, report.ActivitySource)
+	assert.Empty(t, report.Dormant)
+	assert.Equal(t, []DormantMember{{Login: "idle"}}, report.NoData)
+}
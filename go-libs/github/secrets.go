@@ -0,0 +1,312 @@
+package github
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"time"
+
+	"github.com/databricks/databricks-sdk-go/httpclient"
+	"golang.org/x/crypto/nacl/box"
+)
+
+// See https://docs.github.com/en/rest/actions/secrets
+// and https://docs.github.com/en/rest/actions/variables
+
+type ActionsSecret struct {
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at,omitempty"`
+	UpdatedAt time.Time `json:"updated_at,omitempty"`
+
+	// Visibility is one of: all, private, selected. Only for org secrets.
+	Visibility              string `json:"visibility,omitempty"`
+	SelectedRepositoriesURL string `json:"selected_repositories_url,omitempty"`
+}
+
+type ActionsVariable struct {
+	Name      string    `json:"name"`
+	Value     string    `json:"value"`
+	CreatedAt time.Time `json:"created_at,omitempty"`
+	UpdatedAt time.Time `json:"updated_at,omitempty"`
+
+	// Visibility is one of: all, private, selected. Only for org variables.
+	Visibility              string `json:"visibility,omitempty"`
+	SelectedRepositoriesURL string `json:"selected_repositories_url,omitempty"`
+}
+
+// ListOrgSecrets lists names of organization secrets, but never their values.
+func (c *GitHubClient) ListOrgSecrets(ctx context.Context, org string) ([]ActionsSecret, error) {
+	path := fmt.Sprintf("%s/orgs/%s/actions/secrets", gitHubAPI, org)
+	return listSecrets(ctx, c, path)
+}
+
+// ListRepoSecrets lists names of repository secrets, but never their values.
+func (c *GitHubClient) ListRepoSecrets(ctx context.Context, org, repo string) ([]ActionsSecret, error) {
+	path := fmt.Sprintf("%s/repos/%s/%s/actions/secrets", gitHubAPI, org, repo)
+	return listSecrets(ctx, c, path)
+}
+
+func (c *GitHubClient) ListOrgVariables(ctx context.Context, org string) ([]ActionsVariable, error) {
+	path := fmt.Sprintf("%s/orgs/%s/actions/variables", gitHubAPI, org)
+	return listVariables(ctx, c, path)
+}
+
+func (c *GitHubClient) ListRepoVariables(ctx context.Context, org, repo string) ([]ActionsVariable, error) {
+	path := fmt.Sprintf("%s/repos/%s/%s/actions/variables", gitHubAPI, org, repo)
+	return listVariables(ctx, c, path)
+}
+
+func (c *GitHubClient) ListOrgSecretsIterator(org string) *Iterator[ActionsSecret] {
+	path := fmt.Sprintf("%s/orgs/%s/actions/secrets", gitHubAPI, org)
+	return paginateField[ActionsSecret](c, path, "secrets", nil)
+}
+
+func (c *GitHubClient) ListRepoSecretsIterator(org, repo string) *Iterator[ActionsSecret] {
+	path := fmt.Sprintf("%s/repos/%s/%s/actions/secrets", gitHubAPI, org, repo)
+	return paginateField[ActionsSecret](c, path, "secrets", nil)
+}
+
+func (c *GitHubClient) ListOrgVariablesIterator(org string) *Iterator[ActionsVariable] {
+	path := fmt.Sprintf("%s/orgs/%s/actions/variables", gitHubAPI, org)
+	return paginateField[ActionsVariable](c, path, "variables", listOptions{PerPage: variablesPerPage})
+}
+
+func (c *GitHubClient) ListRepoVariablesIterator(org, repo string) *Iterator[ActionsVariable] {
+	path := fmt.Sprintf("%s/repos/%s/%s/actions/variables", gitHubAPI, org, repo)
+	return paginateField[ActionsVariable](c, path, "variables", listOptions{PerPage: variablesPerPage})
+}
+
+func listSecrets(ctx context.Context, c *GitHubClient, path string) ([]ActionsSecret, error) {
+	var all []ActionsSecret
+	for page := 1; ; page++ {
+		var res struct {
+			TotalCount int             `json:"total_count"`
+			Secrets    []ActionsSecret `json:"secrets"`
+		}
+		err := c.api.Do(ctx, "GET", path,
+			httpclient.WithRequestData(listOptions{Page: page, PerPage: 100}),
+			httpclient.WithResponseUnmarshal(&res))
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, res.Secrets...)
+		if len(res.Secrets) == 0 || len(all) >= res.TotalCount {
+			return all, nil
+		}
+	}
+}
+
+// variablesPerPage is the maximum page size of variables API
+const variablesPerPage = 30
+
+func listVariables(ctx context.Context, c *GitHubClient, path string) ([]ActionsVariable, error) {
+	var all []ActionsVariable
+	for page := 1; ; page++ {
+		var res struct {
+			TotalCount int               `json:"total_count"`
+			Variables  []ActionsVariable `json:"variables"`
+		}
+		err := c.api.Do(ctx, "GET", path,
+			httpclient.WithRequestData(listOptions{Page: page, PerPage: variablesPerPage}),
+			httpclient.WithResponseUnmarshal(&res))
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, res.Variables...)
+		if len(res.Variables) == 0 || len(all) >= res.TotalCount {
+			return all, nil
+		}
+	}
+}
+
+// PublicKey encrypts secrets of a repository or of an organization.
+type PublicKey struct {
+	KeyID string `json:"key_id"`
+
+	// Key is a base64-encoded Curve25519 public key.
+	Key string `json:"key"`
+}
+
+// seal encrypts the value with a libsodium sealed box, which only GitHub
+// can open, and encodes it as expected by the API.
+func (k *PublicKey) seal(value string) (string, error) {
+	raw, err := base64.StdEncoding.DecodeString(k.Key)
+	if err != nil {
+		return "", fmt.Errorf("public key: %w", err)
+	}
+	if len(raw) != 32 {
+		return "", fmt.Errorf("public key: expected 32 bytes, got %d", len(raw))
+	}
+	var recipient [32]byte
+	copy(recipient[:], raw)
+	sealed, err := box.SealAnonymous(nil, []byte(value), &recipient, rand.Reader)
+	if err != nil {
+		return "", fmt.Errorf("seal: %w", err)
+	}
+	return base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+type encryptedSecret struct {
+	EncryptedValue string `json:"encrypted_value"`
+	KeyID          string `json:"key_id"`
+
+	// only for organization secrets
+	Visibility            string  `json:"visibility,omitempty"`
+	SelectedRepositoryIDs []int64 `json:"selected_repository_ids,omitempty"`
+}
+
+func (c *GitHubClient) GetRepoPublicKey(ctx context.Context, org, repo string) (*PublicKey, error) {
+	path := fmt.Sprintf("%s/repos/%s/%s/actions/secrets/public-key", gitHubAPI, org, repo)
+	var res PublicKey
+	err := c.api.Do(ctx, "GET", path, httpclient.WithResponseUnmarshal(&res))
+	return &res, err
+}
+
+// PutRepoSecret creates or updates the secret, encrypting its value with the
+// public key of the repository, so that plaintext never leaves the process.
+func (c *GitHubClient) PutRepoSecret(ctx context.Context, org, repo, name, value string) error {
+	key, err := c.GetRepoPublicKey(ctx, org, repo)
+	if err != nil {
+		return fmt.Errorf("public key: %w", err)
+	}
+	encrypted, err := key.seal(value)
+	if err != nil {
+		return err
+	}
+	path := fmt.Sprintf("%s/repos/%s/%s/actions/secrets/%s", gitHubAPI, org, repo, name)
+	return c.api.Do(ctx, "PUT", path, httpclient.WithRequestData(encryptedSecret{
+		EncryptedValue: encrypted,
+		KeyID:          key.KeyID,
+	}))
+}
+
+func (c *GitHubClient) DeleteRepoSecret(ctx context.Context, org, repo, name string) error {
+	path := fmt.Sprintf("%s/repos/%s/%s/actions/secrets/%s", gitHubAPI, org, repo, name)
+	return c.api.Do(ctx, "DELETE", path)
+}
+
+func (c *GitHubClient) GetOrgPublicKey(ctx context.Context, org string) (*PublicKey, error) {
+	path := fmt.Sprintf("%s/orgs/%s/actions/secrets/public-key", gitHubAPI, org)
+	var res PublicKey
+	err := c.api.Do(ctx, "GET", path, httpclient.WithResponseUnmarshal(&res))
+	return &res, err
+}
+
+type OrgSecretOptions struct {
+	// Visibility is one of: all, private, selected. Default is private.
+	Visibility string
+
+	// SelectedRepositoryIDs can access the secret with selected visibility.
+	SelectedRepositoryIDs []int64
+}
+
+// PutOrgSecret creates or updates the secret of the organization, like
+// PutRepoSecret does for repositories.
+func (c *GitHubClient) PutOrgSecret(ctx context.Context, org, name, value string, opts OrgSecretOptions) error {
+	key, err := c.GetOrgPublicKey(ctx, org)
+	if err != nil {
+		return fmt.Errorf("public key: %w", err)
+	}
+	encrypted, err := key.seal(value)
+	if err != nil {
+		return err
+	}
+	if opts.Visibility == "" {
+		opts.Visibility = "private"
+	}
+	path := fmt.Sprintf("%s/orgs/%s/actions/secrets/%s", gitHubAPI, org, name)
+	return c.api.Do(ctx, "PUT", path, httpclient.WithRequestData(encryptedSecret{
+		EncryptedValue:        encrypted,
+		KeyID:                 key.KeyID,
+		Visibility:            opts.Visibility,
+		SelectedRepositoryIDs: opts.SelectedRepositoryIDs,
+	}))
+}
+
+func (c *GitHubClient) DeleteOrgSecret(ctx context.Context, org, name string) error {
+	path := fmt.Sprintf("%s/orgs/%s/actions/secrets/%s", gitHubAPI, org, name)
+	return c.api.Do(ctx, "DELETE", path)
+}
+
+type variableRequest struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+
+	// only for organization variables
+	Visibility            string  `json:"visibility,omitempty"`
+	SelectedRepositoryIDs []int64 `json:"selected_repository_ids,omitempty"`
+}
+
+func (c *GitHubClient) GetRepoVariable(ctx context.Context, org, repo, name string) (*ActionsVariable, error) {
+	path := fmt.Sprintf("%s/repos/%s/%s/actions/variables/%s", gitHubAPI, org, repo, name)
+	var res ActionsVariable
+	err := c.api.Do(ctx, "GET", path, httpclient.WithResponseUnmarshal(&res))
+	return &res, err
+}
+
+// CreateRepoVariable fails with 409 Conflict, if the variable exists.
+func (c *GitHubClient) CreateRepoVariable(ctx context.Context, org, repo, name, value string) error {
+	path := fmt.Sprintf("%s/repos/%s/%s/actions/variables", gitHubAPI, org, repo)
+	return c.api.Do(ctx, "POST", path, httpclient.WithRequestData(variableRequest{
+		Name:  name,
+		Value: value,
+	}))
+}
+
+func (c *GitHubClient) UpdateRepoVariable(ctx context.Context, org, repo, name, value string) error {
+	path := fmt.Sprintf("%s/repos/%s/%s/actions/variables/%s", gitHubAPI, org, repo, name)
+	return c.api.Do(ctx, "PATCH", path, httpclient.WithRequestData(variableRequest{
+		Name:  name,
+		Value: value,
+	}))
+}
+
+func (c *GitHubClient) DeleteRepoVariable(ctx context.Context, org, repo, name string) error {
+	path := fmt.Sprintf("%s/repos/%s/%s/actions/variables/%s", gitHubAPI, org, repo, name)
+	return c.api.Do(ctx, "DELETE", path)
+}
+
+type OrgVariableOptions struct {
+	// Visibility is one of: all, private, selected. Default is private.
+	Visibility string
+
+	// SelectedRepositoryIDs can access the variable with selected visibility.
+	SelectedRepositoryIDs []int64
+}
+
+func (o OrgVariableOptions) request(name, value string) variableRequest {
+	visibility := o.Visibility
+	if visibility == "" {
+		visibility = "private"
+	}
+	return variableRequest{
+		Name:                  name,
+		Value:                 value,
+		Visibility:            visibility,
+		SelectedRepositoryIDs: o.SelectedRepositoryIDs,
+	}
+}
+
+func (c *GitHubClient) GetOrgVariable(ctx context.Context, org, name string) (*ActionsVariable, error) {
+	path := fmt.Sprintf("%s/orgs/%s/actions/variables/%s", gitHubAPI, org, name)
+	var res ActionsVariable
+	err := c.api.Do(ctx, "GET", path, httpclient.WithResponseUnmarshal(&res))
+	return &res, err
+}
+
+func (c *GitHubClient) CreateOrgVariable(ctx context.Context, org, name, value string, opts OrgVariableOptions) error {
+	path := fmt.Sprintf("%s/orgs/%s/actions/variables", gitHubAPI, org)
+	return c.api.Do(ctx, "POST", path, httpclient.WithRequestData(opts.request(name, value)))
+}
+
+// UpdateOrgVariable replaces the value and the visibility of the variable.
+func (c *GitHubClient) UpdateOrgVariable(ctx context.Context, org, name, value string, opts OrgVariableOptions) error {
+	path := fmt.Sprintf("%s/orgs/%s/actions/variables/%s", gitHubAPI, org, name)
+	return c.api.Do(ctx, "PATCH", path, httpclient.WithRequestData(opts.request(name, value)))
+}
+
+func (c *GitHubClient) DeleteOrgVariable(ctx context.Context, org, name string) error {
+	path := fmt.Sprintf("%s/orgs/%s/actions/variables/%s", gitHubAPI, org, name)
+	return c.api.Do(ctx, "DELETE", path)
+}
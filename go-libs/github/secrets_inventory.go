@@ -0,0 +1,163 @@
+package github
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"slices"
+	"sort"
+	"time"
+)
+
+// OrgScope is the scope of organization-level secrets and variables
+const OrgScope = "$org"
+
+type SecretInventoryItem struct {
+	// Kind is one of: secret, variable
+	Kind string `json:"kind"`
+
+	// Scope is a repository name or OrgScope
+	Scope      string    `json:"scope"`
+	Name       string    `json:"name"`
+	Visibility string    `json:"visibility,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+type SecretInventoryOptions struct {
+	// Repos limits the report to the repositories. Default is all repositories,
+	// except archived ones.
+	Repos []string
+}
+
+// SecretInventory lists names and timestamps of secrets and variables, but
+// never values, sorted by kind, name, and scope.
+type SecretInventory []SecretInventoryItem
+
+// SecretInventory enumerates Actions secrets and variables of the org and
+// of its repositories.
+func (c *GitHubClient) SecretInventory(ctx context.Context, org string, opts SecretInventoryOptions) (SecretInventory, error) {
+	inventory, err := c.secretInventoryOf(ctx, org, OrgScope)
+	if err != nil {
+		return nil, fmt.Errorf("org: %w", err)
+	}
+	repos := opts.Repos
+	if len(repos) == 0 {
+		err = c.StreamRepositories(ctx, org, func(r Repo) error {
+			if !r.IsArchived {
+				repos = append(repos, r.Name)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("repositories: %w", err)
+		}
+	}
+	for _, repo := range repos {
+		items, err := c.secretInventoryOf(ctx, org, repo)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", repo, err)
+		}
+		inventory = append(inventory, items...)
+	}
+	sort.SliceStable(inventory, func(i, j int) bool {
+		a, b := inventory[i], inventory[j]
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		return a.Scope < b.Scope
+	})
+	return inventory, nil
+}
+
+func (c *GitHubClient) secretInventoryOf(ctx context.Context, org, scope string) (items SecretInventory, err error) {
+	var secrets []ActionsSecret
+	var variables []ActionsVariable
+	if scope == OrgScope {
+		secrets, err = c.ListOrgSecrets(ctx, org)
+	} else {
+		secrets, err = c.ListRepoSecrets(ctx, org, scope)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("secrets: %w", err)
+	}
+	if scope == OrgScope {
+		variables, err = c.ListOrgVariables(ctx, org)
+	} else {
+		variables, err = c.ListRepoVariables(ctx, org, scope)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("variables: %w", err)
+	}
+	for _, v := range secrets {
+		items = append(items, SecretInventoryItem{
+			Kind:       "secret",
+			Scope:      scope,
+			Name:       v.Name,
+			Visibility: v.Visibility,
+			CreatedAt:  v.CreatedAt,
+			UpdatedAt:  v.UpdatedAt,
+		})
+	}
+	for _, v := range variables {
+		items = append(items, SecretInventoryItem{
+			Kind:       "variable",
+			Scope:      scope,
+			Name:       v.Name,
+			Visibility: v.Visibility,
+			CreatedAt:  v.CreatedAt,
+			UpdatedAt:  v.UpdatedAt,
+		})
+	}
+	return items, nil
+}
+
+// Stale returns items, that were not updated since the time, like credentials
+// that were never rotated.
+func (s SecretInventory) Stale(since time.Time) (stale SecretInventory) {
+	for _, v := range s {
+		if v.UpdatedAt.Before(since) {
+			stale = append(stale, v)
+		}
+	}
+	return stale
+}
+
+// Duplicated returns scopes of secrets or variables by name, that are defined
+// in more than one scope, like repository secrets shadowing org secrets.
+func (s SecretInventory) Duplicated(kind string) map[string][]string {
+	scopes := map[string][]string{}
+	for _, v := range s {
+		if v.Kind != kind || slices.Contains(scopes[v.Name], v.Scope) {
+			continue
+		}
+		scopes[v.Name] = append(scopes[v.Name], v.Scope)
+	}
+	for name, v := range scopes {
+		if len(v) < 2 {
+			delete(scopes, name)
+		}
+	}
+	return scopes
+}
+
+func (s SecretInventory) WriteCSV(w io.Writer) error {
+	out := csv.NewWriter(w)
+	err := out.Write([]string{"kind", "scope", "name", "visibility", "created_at", "updated_at"})
+	if err != nil {
+		return err
+	}
+	for _, v := range s {
+		err = out.Write([]string{v.Kind, v.Scope, v.Name, v.Visibility,
+			timestamp(v.CreatedAt), timestamp(v.UpdatedAt)})
+		if err != nil {
+			return err
+		}
+	}
+	out.Flush()
+	return out.Error()
+}
@@ -0,0 +1,60 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSecretInventory(t *testing.T) {
+	client := NewClient(&GitHubConfig{
+		GitHubTokenSource: GitHubTokenSource{Pat: "x"},
+		transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			switch r.URL.Path {
+			case "/orgs/a/actions/secrets":
+				return jsonResponse(200, `{"total_count": 2, "secrets": [
+					{"name": "PYPI_TOKEN", "visibility": "all", "updated_at": "2020-01-01T00:00:00Z"},
+					{"name": "SLACK_WEBHOOK", "visibility": "private", "updated_at": "2024-01-01T00:00:00Z"}
+				]}`), nil
+			case "/orgs/a/actions/variables":
+				assert.Equal(t, "30", r.URL.Query().Get("per_page"))
+				return jsonResponse(200, `{"total_count": 0, "variables": []}`), nil
+			case "/users/a/repos":
+				return jsonResponse(200, `[{"name": "b"}, {"name": "c", "archived": true}]`), nil
+			case "/repos/a/b/actions/secrets":
+				return jsonResponse(200, `{"total_count": 1, "secrets": [
+					{"name": "PYPI_TOKEN", "updated_at": "2024-01-01T00:00:00Z"}
+				]}`), nil
+			case "/repos/a/b/actions/variables":
+				return jsonResponse(200, `{"total_count": 1, "variables": [
+					{"name": "REGION", "value": "us-east-1", "updated_at": "2024-01-01T00:00:00Z"}
+				]}`), nil
+			}
+			return jsonResponse(404, `{"message": "Not Found"}`), nil
+		}),
+	})
+	inventory, err := client.SecretInventory(context.Background(), "a", SecretInventoryOptions{})
+	require.NoError(t, err)
+	require.Len(t, inventory, 4)
+	assert.Equal(t, "PYPI_TOKEN", inventory[0].Name)
+	assert.Equal(t, OrgScope, inventory[0].Scope)
+	assert.Equal(t, "b", inventory[1].Scope)
+	assert.Equal(t, "variable", inventory[3].Kind)
+
+	stale := inventory.Stale(time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC))
+	require.Len(t, stale, 1)
+	assert.Equal(t, "PYPI_TOKEN", stale[0].Name)
+
+	assert.Equal(t, map[string][]string{
+		"PYPI_TOKEN": {OrgScope, "b"},
+	}, inventory.Duplicated("secret"))
+
+	var buf bytes.Buffer
+	require.NoError(t, inventory.WriteCSV(&buf))
+	assert.Contains(t, buf.String(), "variable,b,REGION,,,2024-01-01T00:00:00Z")
+}
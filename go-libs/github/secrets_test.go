@@ -0,0 +1,136 @@
+package github
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/nacl/box"
+)
+
+func TestPutRepoSecretSealsValue(t *testing.T) {
+	publicKey, privateKey, err := box.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	var body map[string]any
+	client := NewClient(&GitHubConfig{
+		GitHubTokenSource: GitHubTokenSource{Pat: "x"},
+		transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			switch r.Method + " " + r.URL.Path {
+			case "GET /repos/a/b/actions/secrets/public-key":
+				return jsonResponse(200, `{"key_id": "k1", "key": "`+
+					base64.StdEncoding.EncodeToString(publicKey[:])+`"}`), nil
+			case "PUT /repos/a/b/actions/secrets/DEPLOY_TOKEN":
+				raw, err := io.ReadAll(r.Body)
+				require.NoError(t, err)
+				require.NoError(t, json.Unmarshal(raw, &body))
+				return jsonResponse(201, ``), nil
+			}
+			t.Fatalf("unexpected %s %s", r.Method, r.URL.Path)
+			return nil, nil
+		}),
+	})
+	err = client.PutRepoSecret(context.Background(), "a", "b", "DEPLOY_TOKEN", "s3cr3t")
+	require.NoError(t, err)
+
+	assert.Equal(t, "k1", body["key_id"])
+	assert.NotContains(t, body, "visibility")
+	sealed, err := base64.StdEncoding.DecodeString(body["encrypted_value"].(string))
+	require.NoError(t, err)
+	opened, ok := box.OpenAnonymous(nil, sealed, publicKey, privateKey)
+	require.True(t, ok)
+	assert.Equal(t, "s3cr3t", string(opened))
+}
+
+func TestPutOrgSecret(t *testing.T) {
+	publicKey, _, err := box.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	var body map[string]any
+	client := NewClient(&GitHubConfig{
+		GitHubTokenSource: GitHubTokenSource{Pat: "x"},
+		transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			switch r.Method + " " + r.URL.Path {
+			case "GET /orgs/a/actions/secrets/public-key":
+				return jsonResponse(200, `{"key_id": "k2", "key": "`+
+					base64.StdEncoding.EncodeToString(publicKey[:])+`"}`), nil
+			case "PUT /orgs/a/actions/secrets/DEPLOY_TOKEN":
+				raw, err := io.ReadAll(r.Body)
+				require.NoError(t, err)
+				require.NoError(t, json.Unmarshal(raw, &body))
+				return jsonResponse(204, ``), nil
+			case "DELETE /orgs/a/actions/secrets/DEPLOY_TOKEN":
+				return jsonResponse(204, ``), nil
+			}
+			t.Fatalf("unexpected %s %s", r.Method, r.URL.Path)
+			return nil, nil
+		}),
+	})
+	ctx := context.Background()
+	err = client.PutOrgSecret(ctx, "a", "DEPLOY_TOKEN", "s3cr3t", OrgSecretOptions{
+		Visibility:            "selected",
+		SelectedRepositoryIDs: []int64{1, 2},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "selected", body["visibility"])
+	assert.Equal(t, []any{1.0, 2.0}, body["selected_repository_ids"])
+	require.NoError(t, client.DeleteOrgSecret(ctx, "a", "DEPLOY_TOKEN"))
+}
+
+func TestSealRejectsInvalidKey(t *testing.T) {
+	key := &PublicKey{KeyID: "k", Key: base64.StdEncoding.EncodeToString([]byte("short"))}
+	_, err := key.seal("x")
+	assert.ErrorContains(t, err, "expected 32 bytes")
+}
+
+func TestVariablesCRUD(t *testing.T) {
+	var calls []string
+	var bodies []map[string]any
+	client := NewClient(&GitHubConfig{
+		GitHubTokenSource: GitHubTokenSource{Pat: "x"},
+		transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			calls = append(calls, r.Method+" "+r.URL.Path)
+			if r.Method == "GET" {
+				return jsonResponse(200, `{"name": "REGION", "value": "eu"}`), nil
+			}
+			if r.Body != nil {
+				raw, err := io.ReadAll(r.Body)
+				require.NoError(t, err)
+				var body map[string]any
+				require.NoError(t, json.Unmarshal(raw, &body))
+				bodies = append(bodies, body)
+			}
+			return jsonResponse(204, ``), nil
+		}),
+	})
+	ctx := context.Background()
+	v, err := client.GetRepoVariable(ctx, "a", "b", "REGION")
+	require.NoError(t, err)
+	assert.Equal(t, "eu", v.Value)
+	require.NoError(t, client.CreateRepoVariable(ctx, "a", "b", "REGION", "eu"))
+	require.NoError(t, client.UpdateRepoVariable(ctx, "a", "b", "REGION", "us"))
+	require.NoError(t, client.DeleteRepoVariable(ctx, "a", "b", "REGION"))
+	require.NoError(t, client.CreateOrgVariable(ctx, "a", "REGION", "eu", OrgVariableOptions{}))
+	require.NoError(t, client.UpdateOrgVariable(ctx, "a", "REGION", "us", OrgVariableOptions{
+		Visibility:            "selected",
+		SelectedRepositoryIDs: []int64{3},
+	}))
+	require.NoError(t, client.DeleteOrgVariable(ctx, "a", "REGION"))
+	assert.Equal(t, []string{
+		"GET /repos/a/b/actions/variables/REGION",
+		"POST /repos/a/b/actions/variables",
+		"PATCH /repos/a/b/actions/variables/REGION",
+		"DELETE /repos/a/b/actions/variables/REGION",
+		"POST /orgs/a/actions/variables",
+		"PATCH /orgs/a/actions/variables/REGION",
+		"DELETE /orgs/a/actions/variables/REGION",
+	}, calls)
+	require.Len(t, bodies, 4)
+	assert.Equal(t, map[string]any{"name": "REGION", "value": "us"}, bodies[1])
+	assert.Equal(t, "private", bodies[2]["visibility"])
+	assert.Equal(t, []any{3.0}, bodies[3]["selected_repository_ids"])
+}
@@ -0,0 +1,183 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/databricks/databricks-sdk-go/httpclient"
+)
+
+// See https://docs.github.com/en/rest/security-advisories/repository-advisories
+
+type AdvisoryPackage struct {
+	// Ecosystem is one of: go, npm, pip, maven, nuget, rubygems, composer,
+	// rust, erlang, actions, pub, swift, other.
+	Ecosystem string `json:"ecosystem"`
+	Name      string `json:"name"`
+}
+
+type AdvisoryVulnerability struct {
+	Package AdvisoryPackage `json:"package"`
+
+	// VulnerableVersionRange is like "< 1.2.3" or ">= 1.0.0, < 1.0.5".
+	VulnerableVersionRange string   `json:"vulnerable_version_range,omitempty"`
+	PatchedVersions        string   `json:"patched_versions,omitempty"`
+	VulnerableFunctions    []string `json:"vulnerable_functions,omitempty"`
+}
+
+type AdvisoryCredit struct {
+	Login string `json:"login"`
+
+	// Type is one of: analyst, finder, reporter, coordinator,
+	// remediation_developer, remediation_reviewer, remediation_verifier,
+	// tool, sponsor, other.
+	Type string `json:"type"`
+}
+
+type SecurityAdvisory struct {
+	GHSAID      string `json:"ghsa_id"`
+	CVEID       string `json:"cve_id,omitempty"`
+	URL         string `json:"url,omitempty"`
+	HTMLURL     string `json:"html_url,omitempty"`
+	Summary     string `json:"summary"`
+	Description string `json:"description,omitempty"`
+
+	// Severity is one of: critical, high, medium, low.
+	Severity string `json:"severity,omitempty"`
+
+	// State is one of: triage, draft, published, closed.
+	State           string                  `json:"state"`
+	Author          *User                   `json:"author,omitempty"`
+	Vulnerabilities []AdvisoryVulnerability `json:"vulnerabilities,omitempty"`
+	CWEIDs          []string                `json:"cwe_ids,omitempty"`
+	CreatedAt       time.Time               `json:"created_at"`
+	UpdatedAt       time.Time               `json:"updated_at"`
+	PublishedAt     *time.Time              `json:"published_at,omitempty"`
+	ClosedAt        *time.Time              `json:"closed_at,omitempty"`
+
+	// PrivateFork is the temporary fork to fix the vulnerability in private.
+	PrivateFork *Repo `json:"private_fork,omitempty"`
+}
+
+type SecurityAdvisoryListOptions struct {
+	// State is one of: triage, draft, published, closed. Default is all.
+	State string `url:"state,omitempty"`
+
+	// Sort is one of: created, updated, published. Default is created.
+	Sort      string `url:"sort,omitempty"`
+	Direction string `url:"direction,omitempty"`
+	PerPage   int    `url:"per_page,omitempty"`
+}
+
+// ListSecurityAdvisories lists advisories of the repository, including
+// unpublished ones, if the token has the repository_advisories:read
+// permission or is a collaborator on them.
+func (c *GitHubClient) ListSecurityAdvisories(ctx context.Context, org, repo string, opts SecurityAdvisoryListOptions) ([]SecurityAdvisory, error) {
+	return ToSlice(ctx, c.ListSecurityAdvisoriesIterator(org, repo, opts))
+}
+
+func (c *GitHubClient) ListSecurityAdvisoriesIterator(org, repo string, opts SecurityAdvisoryListOptions) *Iterator[SecurityAdvisory] {
+	path := fmt.Sprintf("%s/repos/%s/%s/security-advisories", gitHubAPI, org, repo)
+	return Paginate[SecurityAdvisory](c, path, opts)
+}
+
+func (c *GitHubClient) GetSecurityAdvisory(ctx context.Context, org, repo, ghsaID string) (*SecurityAdvisory, error) {
+	path := fmt.Sprintf("%s/repos/%s/%s/security-advisories/%s", gitHubAPI, org, repo, ghsaID)
+	var res SecurityAdvisory
+	err := c.api.Do(ctx, "GET", path, httpclient.WithResponseUnmarshal(&res))
+	if err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+type NewSecurityAdvisory struct {
+	Summary         string                  `json:"summary"`
+	Description     string                  `json:"description"`
+	Vulnerabilities []AdvisoryVulnerability `json:"vulnerabilities"`
+	CWEIDs          []string                `json:"cwe_ids,omitempty"`
+	Credits         []AdvisoryCredit        `json:"credits,omitempty"`
+
+	// CVEID is an existing CVE. Use RequestCVE to have GitHub assign one.
+	CVEID string `json:"cve_id,omitempty"`
+
+	// Either Severity or CVSSVectorString, like
+	// "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H", is set.
+	Severity         string `json:"severity,omitempty"`
+	CVSSVectorString string `json:"cvss_vector_string,omitempty"`
+
+	// StartPrivateFork creates a temporary private fork right away.
+	StartPrivateFork bool `json:"start_private_fork,omitempty"`
+}
+
+// CreateSecurityAdvisoryDraft creates an advisory in the draft state, which
+// is only visible to maintainers and collaborators of the advisory.
+func (c *GitHubClient) CreateSecurityAdvisoryDraft(ctx context.Context, org, repo string, req NewSecurityAdvisory) (*SecurityAdvisory, error) {
+	path := fmt.Sprintf("%s/repos/%s/%s/security-advisories", gitHubAPI, org, repo)
+	var res SecurityAdvisory
+	err := c.api.Do(ctx, "POST", path,
+		httpclient.WithRequestData(req),
+		httpclient.WithResponseUnmarshal(&res))
+	if err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// SecurityAdvisoryUpdate changes only the fields, that are set.
+type SecurityAdvisoryUpdate struct {
+	Summary          string                  `json:"summary,omitempty"`
+	Description      string                  `json:"description,omitempty"`
+	Vulnerabilities  []AdvisoryVulnerability `json:"vulnerabilities,omitempty"`
+	CWEIDs           []string                `json:"cwe_ids,omitempty"`
+	Credits          []AdvisoryCredit        `json:"credits,omitempty"`
+	CVEID            string                  `json:"cve_id,omitempty"`
+	Severity         string                  `json:"severity,omitempty"`
+	CVSSVectorString string                  `json:"cvss_vector_string,omitempty"`
+
+	// State is one of: published, closed, draft.
+	State string `json:"state,omitempty"`
+}
+
+func (c *GitHubClient) UpdateSecurityAdvisory(ctx context.Context, org, repo, ghsaID string, req SecurityAdvisoryUpdate) (*SecurityAdvisory, error) {
+	path := fmt.Sprintf("%s/repos/%s/%s/security-advisories/%s", gitHubAPI, org, repo, ghsaID)
+	var res SecurityAdvisory
+	err := c.api.Do(ctx, "PATCH", path,
+		httpclient.WithRequestData(req),
+		httpclient.WithResponseUnmarshal(&res))
+	if err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// PublishSecurityAdvisory makes the draft public, which can't be undone, and
+// sends Dependabot alerts to dependents of the vulnerable packages, once
+// GitHub reviews the advisory for the global advisory database.
+func (c *GitHubClient) PublishSecurityAdvisory(ctx context.Context, org, repo, ghsaID string) (*SecurityAdvisory, error) {
+	return c.UpdateSecurityAdvisory(ctx, org, repo, ghsaID, SecurityAdvisoryUpdate{
+		State: "published",
+	})
+}
+
+// RequestCVE asks GitHub, as the CVE numbering authority, to assign a CVE to
+// the draft. The review takes up to 72 hours, after which CVEID of the
+// advisory is set. Drafts with a CVE from another authority fail with
+// ErrValidation.
+func (c *GitHubClient) RequestCVE(ctx context.Context, org, repo, ghsaID string) error {
+	path := fmt.Sprintf("%s/repos/%s/%s/security-advisories/%s/cve", gitHubAPI, org, repo, ghsaID)
+	return c.api.Do(ctx, "POST", path)
+}
+
+// CreateAdvisoryFork creates the temporary private fork of the draft, where
+// collaborators of the advisory prepare the fix without disclosing it.
+func (c *GitHubClient) CreateAdvisoryFork(ctx context.Context, org, repo, ghsaID string) (*Repo, error) {
+	path := fmt.Sprintf("%s/repos/%s/%s/security-advisories/%s/forks", gitHubAPI, org, repo, ghsaID)
+	var res Repo
+	err := c.api.Do(ctx, "POST", path, httpclient.WithResponseUnmarshal(&res))
+	if err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
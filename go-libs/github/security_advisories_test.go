@@ -0,0 +1,70 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSecurityAdvisoryDisclosure(t *testing.T) {
+	var calls []string
+	var draft, update map[string]any
+	client := NewClient(&GitHubConfig{
+		GitHubTokenSource: GitHubTokenSource{Pat: "x"},
+		transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			calls = append(calls, r.Method+" "+r.URL.Path)
+			switch r.Method + " " + r.URL.Path {
+			case "GET /repos/a/b/security-advisories":
+				assert.Equal(t, "draft", r.URL.Query().Get("state"))
+				return jsonResponse(200, `[{"ghsa_id": "GHSA-xxxx-yyyy-zzzz", "state": "draft"}]`), nil
+			case "POST /repos/a/b/security-advisories":
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&draft))
+				return jsonResponse(201, `{"ghsa_id": "GHSA-xxxx-yyyy-zzzz", "state": "draft"}`), nil
+			case "POST /repos/a/b/security-advisories/GHSA-xxxx-yyyy-zzzz/cve":
+				return jsonResponse(202, `{}`), nil
+			case "PATCH /repos/a/b/security-advisories/GHSA-xxxx-yyyy-zzzz":
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&update))
+				return jsonResponse(200, `{"ghsa_id": "GHSA-xxxx-yyyy-zzzz", "state": "published",
+					"cve_id": "CVE-2024-0001", "published_at": "2024-06-01T00:00:00Z"}`), nil
+			}
+			t.Fatalf("unexpected %s %s", r.Method, r.URL.Path)
+			return nil, nil
+		}),
+	})
+	ctx := context.Background()
+	drafts, err := client.ListSecurityAdvisories(ctx, "a", "b", SecurityAdvisoryListOptions{State: "draft"})
+	require.NoError(t, err)
+	assert.Len(t, drafts, 1)
+
+	advisory, err := client.CreateSecurityAdvisoryDraft(ctx, "a", "b", NewSecurityAdvisory{
+		Summary:     "Path traversal in archive extraction",
+		Description: "Entries with .. escape the target directory.",
+		Vulnerabilities: []AdvisoryVulnerability{{
+			Package:                AdvisoryPackage{Ecosystem: "go", Name: "github.com/a/b"},
+			VulnerableVersionRange: "< 1.2.3",
+			PatchedVersions:        "1.2.3",
+		}},
+		CWEIDs:   []string{"CWE-22"},
+		Severity: "high",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "high", draft["severity"])
+	assert.Equal(t, []any{map[string]any{
+		"package":                  map[string]any{"ecosystem": "go", "name": "github.com/a/b"},
+		"vulnerable_version_range": "< 1.2.3",
+		"patched_versions":         "1.2.3",
+	}}, draft["vulnerabilities"])
+
+	require.NoError(t, client.RequestCVE(ctx, "a", "b", advisory.GHSAID))
+
+	published, err := client.PublishSecurityAdvisory(ctx, "a", "b", advisory.GHSAID)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"state": "published"}, update)
+	assert.Equal(t, "CVE-2024-0001", published.CVEID)
+	assert.NotNil(t, published.PublishedAt)
+	assert.Len(t, calls, 4)
+}
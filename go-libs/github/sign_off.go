@@ -0,0 +1,141 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// ListPullRequestCommits lists commits of a pull request, from the oldest.
+// GitHub returns at most 250 commits.
+func (c *GitHubClient) ListPullRequestCommits(ctx context.Context, org, repo string, number int) ([]RepositoryCommit, error) {
+	path := fmt.Sprintf("%s/repos/%s/%s/pulls/%d/commits", gitHubAPI, org, repo, number)
+	return listAll[RepositoryCommit](ctx, c, path, nil)
+}
+
+// UnsignedCommit is a commit without a matching Signed-off-by trailer.
+type UnsignedCommit struct {
+	SHA    string
+	Author CommitAuthor
+	Reason string
+}
+
+// SignOffCheck verifies the Developer Certificate of Origin: every commit
+// has to be signed off by its author, like with `git commit -s`.
+// See https://developercertificate.org
+type SignOffCheck struct {
+	Client *GitHubClient
+
+	// Name of the check run. Default is "DCO".
+	Name string
+
+	// Allowlist has logins or emails of authors, who don't have to sign off
+	// their commits, like bots, or people with a signed CLA.
+	Allowlist []string
+}
+
+func (s *SignOffCheck) allowed(commit RepositoryCommit) bool {
+	for _, v := range s.Allowlist {
+		if strings.EqualFold(v, commit.Author.Login) || strings.EqualFold(v, commit.Commit.Author.Email) {
+			return true
+		}
+	}
+	return false
+}
+
+// Unsigned returns commits, that are not signed off by their authors. Merge
+// commits are skipped, as they are made by tooling.
+func (s *SignOffCheck) Unsigned(commits []RepositoryCommit) (unsigned []UnsignedCommit) {
+	for _, commit := range commits {
+		if len(commit.Parents) > 1 || s.allowed(commit) {
+			continue
+		}
+		author := commit.Commit.Author
+		signatures := SignedOffBy(commit)
+		if slices.ContainsFunc(signatures, func(v CommitAuthor) bool {
+			return strings.EqualFold(v.Email, author.Email)
+		}) {
+			continue
+		}
+		reason := "no Signed-off-by trailer"
+		if len(signatures) > 0 {
+			reason = fmt.Sprintf("Signed-off-by does not match the author email %s", author.Email)
+		}
+		unsigned = append(unsigned, UnsignedCommit{
+			SHA:    commit.SHA,
+			Author: author,
+			Reason: reason,
+		})
+	}
+	return unsigned
+}
+
+// Run checks commits of the pull request and reports the outcome as a
+// completed check run on its head commit, with an annotation for every
+// unsigned commit.
+func (s *SignOffCheck) Run(ctx context.Context, org, repo string, pr *PullRequest) (*CheckRun, error) {
+	commits, err := s.Client.ListPullRequestCommits(ctx, org, repo, pr.Number)
+	if err != nil {
+		return nil, fmt.Errorf("commits: %w", err)
+	}
+	unsigned := s.Unsigned(commits)
+	output := &CheckRunOutput{
+		Title:   "All commits are signed off",
+		Summary: fmt.Sprintf("%d commits are signed off by their authors.", len(commits)),
+	}
+	conclusion := "success"
+	if len(unsigned) > 0 {
+		conclusion = "failure"
+		output.Title = fmt.Sprintf("%d of %d commits are not signed off", len(unsigned), len(commits))
+		output.Summary = "Every commit must have a `Signed-off-by` trailer with the email of its author. " +
+			"Amend commits with `git commit --amend -s` or sign off all commits of the branch with " +
+			"`git rebase --signoff`, then force-push."
+	}
+	for _, v := range unsigned {
+		// annotations need a path, even though they are about commits
+		output.Annotations = append(output.Annotations, CheckRunAnnotation{
+			Path:            ".",
+			StartLine:       1,
+			EndLine:         1,
+			AnnotationLevel: "failure",
+			Title:           fmt.Sprintf("Commit %.7s", v.SHA),
+			Message:         fmt.Sprintf("%s by %s <%s>: %s", shortSubject(commits, v.SHA), v.Author.Name, v.Author.Email, v.Reason),
+		})
+	}
+	name := s.Name
+	if name == "" {
+		name = "DCO"
+	}
+	now := time.Now()
+	return s.Client.CreateCheckRun(ctx, org, repo, NewCheckRun{
+		Name:        name,
+		HeadSHA:     pr.Head.SHA,
+		Status:      "completed",
+		Conclusion:  conclusion,
+		CompletedAt: &now,
+		Output:      output,
+	})
+}
+
+func shortSubject(commits []RepositoryCommit, sha string) string {
+	for _, v := range commits {
+		if v.SHA == sha {
+			subject, _, _ := strings.Cut(v.Commit.Message, "\n")
+			return fmt.Sprintf("%q", subject)
+		}
+	}
+	return sha
+}
+
+// HandlePullRequestEvent checks pull requests from pull_request webhook
+// events, whenever their commits change.
+func (s *SignOffCheck) HandlePullRequestEvent(ctx context.Context, org, repo string, event *PullRequestEventPayload) (*CheckRun, error) {
+	switch event.Action {
+	case "opened", "reopened", "synchronize":
+		return s.Run(ctx, org, repo, &event.PullRequest)
+	default:
+		return nil, nil
+	}
+}
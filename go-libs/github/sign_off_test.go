@@ -0,0 +1,58 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignOffCheck(t *testing.T) {
+	var created NewCheckRun
+	client := NewClient(&GitHubConfig{
+		GitHubTokenSource: GitHubTokenSource{Pat: "x"},
+		transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			switch r.Method + " " + r.URL.Path {
+			case "GET /repos/a/b/pulls/1/commits":
+				return jsonResponse(200, `[
+					{"sha": "aaaaaaaaaa", "commit": {"author": {"name": "X", "email": "x@example.com"},
+						"message": "Add a\n\nSigned-off-by: X <X@example.com>"}},
+					{"sha": "bbbbbbbbbb", "commit": {"author": {"name": "Y", "email": "y@example.com"},
+						"message": "Add b\n\nSigned-off-by: X <x@example.com>"}},
+					{"sha": "cccccccccc", "commit": {"author": {"name": "Y", "email": "y@example.com"},
+						"message": "Add c"}},
+					{"sha": "dddddddddd", "parents": [{}, {}], "commit": {"message": "Merge main"}},
+					{"sha": "eeeeeeeeee", "author": {"login": "dependabot[bot]"}, "commit": {"message": "Bump"}}]`), nil
+			case "POST /repos/a/b/check-runs":
+				raw, err := io.ReadAll(r.Body)
+				require.NoError(t, err)
+				require.NoError(t, json.Unmarshal(raw, &created))
+				return jsonResponse(201, `{"id": 1}`), nil
+			}
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+			return nil, nil
+		}),
+	})
+	check := &SignOffCheck{
+		Client:    client,
+		Allowlist: []string{"dependabot[bot]"},
+	}
+	_, err := check.HandlePullRequestEvent(context.Background(), "a", "b", &PullRequestEventPayload{
+		Action:      "synchronize",
+		PullRequest: PullRequest{Number: 1, Head: PullRequestBranch{SHA: "eeeeeeeeee"}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "DCO", created.Name)
+	assert.Equal(t, "eeeeeeeeee", created.HeadSHA)
+	assert.Equal(t, "failure", created.Conclusion)
+	assert.Equal(t, "2 of 5 commits are not signed off", created.Output.Title)
+	require.Len(t, created.Output.Annotations, 2)
+	assert.Equal(t, "Commit bbbbbbb", created.Output.Annotations[0].Title)
+	assert.Equal(t, `"Add b" by Y <y@example.com>: Signed-off-by does not match the author email y@example.com`,
+		created.Output.Annotations[0].Message)
+	assert.Equal(t, `"Add c" by Y <y@example.com>: no Signed-off-by trailer`, created.Output.Annotations[1].Message)
+}
@@ -0,0 +1,67 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/databricks/databricks-sdk-go/httpclient"
+)
+
+// Stargazer is a user, who starred a repository, and when it happened.
+type Stargazer struct {
+	StarredAt time.Time `json:"starred_at"`
+	User      User      `json:"user"`
+}
+
+// ListStarred lists repositories starred by the user, or by the authenticated
+// user, if user is empty.
+func (c *GitHubClient) ListStarred(ctx context.Context, user string) (Repositories, error) {
+	return ToSlice(ctx, c.ListStarredIterator(user))
+}
+
+func (c *GitHubClient) ListStarredIterator(user string) *Iterator[Repo] {
+	path := fmt.Sprintf("%s/users/%s/starred", gitHubAPI, user)
+	if user == "" {
+		path = fmt.Sprintf("%s/user/starred", gitHubAPI)
+	}
+	return Paginate[Repo](c, path, nil)
+}
+
+// ListStargazers lists users, who starred the repository, from the earliest,
+// which is useful to track adoption over time.
+func (c *GitHubClient) ListStargazers(ctx context.Context, org, repo string) ([]Stargazer, error) {
+	return ToSlice(ctx, c.ListStargazersIterator(org, repo))
+}
+
+func (c *GitHubClient) ListStargazersIterator(org, repo string) *Iterator[Stargazer] {
+	path := fmt.Sprintf("%s/repos/%s/%s/stargazers", gitHubAPI, org, repo)
+	return Paginate[Stargazer](c, path, nil,
+		httpclient.WithRequestHeader("Accept", "application/vnd.github.star+json"))
+}
+
+// IsStarred checks if the authenticated user starred the repository.
+func (c *GitHubClient) IsStarred(ctx context.Context, org, repo string) (bool, error) {
+	path := fmt.Sprintf("%s/user/starred/%s/%s", gitHubAPI, org, repo)
+	err := c.api.Do(ctx, "GET", path)
+	var apiErr *httpclient.HttpError
+	if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (c *GitHubClient) Star(ctx context.Context, org, repo string) error {
+	path := fmt.Sprintf("%s/user/starred/%s/%s", gitHubAPI, org, repo)
+	return c.api.Do(ctx, "PUT", path)
+}
+
+func (c *GitHubClient) Unstar(ctx context.Context, org, repo string) error {
+	path := fmt.Sprintf("%s/user/starred/%s/%s", gitHubAPI, org, repo)
+	return c.api.Do(ctx, "DELETE", path)
+}
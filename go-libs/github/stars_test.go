@@ -0,0 +1,47 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStars(t *testing.T) {
+	starred := false
+	client := NewClient(&GitHubConfig{
+		GitHubTokenSource: GitHubTokenSource{Pat: "x"},
+		transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			switch r.Method + " " + r.URL.Path {
+			case "GET /user/starred/a/b":
+				if starred {
+					return jsonResponse(204, ``), nil
+				}
+				return jsonResponse(404, `{"message": "Not Found"}`), nil
+			case "PUT /user/starred/a/b":
+				starred = true
+				return jsonResponse(204, ``), nil
+			case "GET /repos/a/b/stargazers":
+				assert.Equal(t, "application/vnd.github.star+json", r.Header.Get("Accept"))
+				return jsonResponse(200, `[{"starred_at": "2024-01-01T00:00:00Z", "user": {"login": "x"}}]`), nil
+			}
+			return jsonResponse(404, `{"message": "Not Found"}`), nil
+		}),
+	})
+	ctx := context.Background()
+	ok, err := client.IsStarred(ctx, "a", "b")
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	require.NoError(t, client.Star(ctx, "a", "b"))
+	ok, err = client.IsStarred(ctx, "a", "b")
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	stargazers, err := client.ListStargazers(ctx, "a", "b")
+	require.NoError(t, err)
+	assert.Equal(t, "x", stargazers[0].User.Login)
+	assert.Equal(t, 2024, stargazers[0].StarredAt.Year())
+}
@@ -0,0 +1,40 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/databricks/databricks-sdk-go/httpclient"
+)
+
+type CommitStatus struct {
+	ID          int64     `json:"id,omitempty"`
+	Context     string    `json:"context,omitempty"`
+	State       string    `json:"state,omitempty"` // error, failure, pending, success
+	Description string    `json:"description,omitempty"`
+	TargetURL   string    `json:"target_url,omitempty"`
+	Creator     User      `json:"creator,omitempty"`
+	CreatedAt   time.Time `json:"created_at,omitempty"`
+	UpdatedAt   time.Time `json:"updated_at,omitempty"`
+}
+
+type CombinedStatus struct {
+	// State is pending even if there are no statuses at all,
+	// so TotalCount has to be checked as well.
+	State      string         `json:"state,omitempty"`
+	SHA        string         `json:"sha,omitempty"`
+	TotalCount int            `json:"total_count,omitempty"`
+	Statuses   []CommitStatus `json:"statuses,omitempty"`
+}
+
+// GetCombinedStatus returns the latest status for each context on a ref,
+// which can be a SHA, a branch name, or a tag name.
+func (c *GitHubClient) GetCombinedStatus(ctx context.Context, org, repo, ref string) (*CombinedStatus, error) {
+	path := fmt.Sprintf("%s/repos/%s/%s/commits/%s/status", gitHubAPI, org, repo, escapeRef(ref))
+	var res CombinedStatus
+	err := c.api.Do(ctx, "GET", path,
+		httpclient.WithRequestData(listOptions{PerPage: 100}),
+		httpclient.WithResponseUnmarshal(&res))
+	return &res, err
+}
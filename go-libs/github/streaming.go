@@ -0,0 +1,160 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/databricks/databricks-sdk-go/httpclient"
+)
+
+var ErrResponseTooLarge = errors.New("response too large")
+
+// responseLimit fails reading responses bigger than the configured size,
+// so that a misbehaving endpoint cannot exhaust the memory of a worker.
+type responseLimit struct {
+	next  http.RoundTripper
+	limit int64
+}
+
+func (rl *responseLimit) RoundTrip(r *http.Request) (*http.Response, error) {
+	resp, err := rl.next.RoundTrip(r)
+	if err != nil {
+		return nil, err
+	}
+	if resp.ContentLength > rl.limit {
+		resp.Body.Close()
+		return nil, fmt.Errorf("%w: %s %s has %d bytes, limit is %d",
+			ErrResponseTooLarge, r.Method, r.URL.Path, resp.ContentLength, rl.limit)
+	}
+	resp.Body = &limitedBody{
+		ReadCloser: resp.Body,
+		remaining:  rl.limit,
+		limit:      rl.limit,
+	}
+	return resp, nil
+}
+
+type limitedBody struct {
+	io.ReadCloser
+	remaining int64
+	limit     int64
+}
+
+func (lb *limitedBody) Read(p []byte) (int, error) {
+	if lb.remaining <= 0 {
+		// allow responses of exactly the limit size
+		n, err := lb.ReadCloser.Read(make([]byte, 1))
+		if n > 0 {
+			return 0, fmt.Errorf("%w: more than %d bytes", ErrResponseTooLarge, lb.limit)
+		}
+		return 0, err
+	}
+	if int64(len(p)) > lb.remaining {
+		p = p[:lb.remaining]
+	}
+	n, err := lb.ReadCloser.Read(p)
+	lb.remaining -= int64(n)
+	return n, err
+}
+
+type responseStreamKey struct{}
+
+// withResponseStream makes the transport hand the body of successful
+// responses to fn, while it is read from the network. The API client buffers
+// responses as a whole, which is too much for large lists and binaries.
+func withResponseStream(ctx context.Context, fn func(io.Reader) error) context.Context {
+	return context.WithValue(ctx, responseStreamKey{}, fn)
+}
+
+// withStreamDecode is withResponseStream for JSON responses.
+func withStreamDecode(ctx context.Context, fn func(*json.Decoder) error) context.Context {
+	return withResponseStream(ctx, func(r io.Reader) error {
+		return fn(json.NewDecoder(r))
+	})
+}
+
+func streamResponse(r *http.Request, resp *http.Response) error {
+	fn, ok := r.Context().Value(responseStreamKey{}).(func(io.Reader) error)
+	if !ok || resp.StatusCode >= 300 {
+		return nil
+	}
+	defer resp.Body.Close()
+	err := fn(resp.Body)
+	if err != nil {
+		return fmt.Errorf("stream: %w", err)
+	}
+	resp.Body = io.NopCloser(strings.NewReader(""))
+	resp.ContentLength = 0
+	return nil
+}
+
+// streamList calls fn for every element of the JSON array returned by GET
+// on path, without holding the whole response in memory, and follows the Link
+// headers to the last page like Paginate. Elements decoded before a failed
+// attempt are not emitted again if the request is retried.
+func streamList[T any](ctx context.Context, c *GitHubClient, path string, query any, fn func(T) error) error {
+	for first := true; path != ""; first = false {
+		var opts []httpclient.DoOption
+		if first {
+			// links of following pages already have the query
+			opts = append(opts, httpclient.WithRequestVisitor(func(r *http.Request) error {
+				q := r.URL.Query()
+				if q.Get("per_page") == "" {
+					q.Set("per_page", strconv.Itoa(c.pageSize()))
+					r.URL.RawQuery = q.Encode()
+				}
+				return nil
+			}))
+			if query != nil {
+				opts = append(opts, httpclient.WithRequestData(query))
+			}
+		}
+		var headers http.Header
+		err := c.api.Do(withResponseHeaders(streamPage(ctx, fn), &headers), "GET", path, opts...)
+		if err != nil {
+			return err
+		}
+		path = nextPage(headers)
+	}
+	return nil
+}
+
+// streamPage decodes elements of a single page.
+func streamPage[T any](ctx context.Context, fn func(T) error) context.Context {
+	var seen int
+	return withStreamDecode(ctx, func(dec *json.Decoder) error {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		if tok == nil {
+			return nil
+		}
+		if tok != json.Delim('[') {
+			return fmt.Errorf("expected array, got %v", tok)
+		}
+		for i := 0; dec.More(); i++ {
+			var v T
+			err = dec.Decode(&v)
+			if err != nil {
+				return err
+			}
+			if i < seen {
+				continue
+			}
+			seen++
+			err = fn(v)
+			if err != nil {
+				return err
+			}
+		}
+		_, err = dec.Token()
+		return err
+	})
+}
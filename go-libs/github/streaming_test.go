@@ -0,0 +1,72 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStreamRepositories(t *testing.T) {
+	client := NewClient(&GitHubConfig{
+		GitHubTokenSource: GitHubTokenSource{Pat: "x"},
+		transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			return jsonResponse(200, `[{"name":"a"},{"name":"b"},{"name":"c"}]`), nil
+		}),
+	})
+	var names []string
+	err := client.StreamRepositories(context.Background(), "org", func(r Repo) error {
+		names = append(names, r.Name)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "b", "c"}, names)
+}
+
+func TestMaxResponseBytes(t *testing.T) {
+	body := `[` + strings.Repeat(`{"name":"a"},`, 100) + `{"name":"b"}]`
+	client := NewClient(&GitHubConfig{
+		GitHubTokenSource: GitHubTokenSource{Pat: "x"},
+		MaxResponseBytes:  128,
+		transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			resp := jsonResponse(200, body)
+			resp.ContentLength = -1
+			return resp, nil
+		}),
+	})
+	_, err := client.ListRepositories(context.Background(), "org")
+	assert.ErrorIs(t, err, ErrResponseTooLarge)
+
+	err = client.StreamRepositories(context.Background(), "org", func(r Repo) error {
+		return nil
+	})
+	assert.ErrorIs(t, err, ErrResponseTooLarge)
+}
+
+func TestStreamRepositoriesFollowsLinks(t *testing.T) {
+	var pages []string
+	client := NewClient(&GitHubConfig{
+		GitHubTokenSource: GitHubTokenSource{Pat: "x"},
+		transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			pages = append(pages, r.URL.RawQuery)
+			if r.URL.Query().Get("page") == "2" {
+				return jsonResponse(200, `[{"name":"c"}]`), nil
+			}
+			resp := jsonResponse(200, `[{"name":"a"},{"name":"b"}]`)
+			resp.Header.Set("Link", `<https://api.github.com/orgs/org/repos?page=2&per_page=100>; rel="next", `+
+				`<https://api.github.com/orgs/org/repos?page=2&per_page=100>; rel="last"`)
+			return resp, nil
+		}),
+	})
+	var names []string
+	err := client.StreamRepositories(context.Background(), "org", func(r Repo) error {
+		names = append(names, r.Name)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "b", "c"}, names)
+	assert.Equal(t, []string{"per_page=100", "page=2&per_page=100"}, pages)
+}
@@ -0,0 +1,57 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/databricks/databricks-sdk-go/httpclient"
+)
+
+type TagProtection struct {
+	ID        int64     `json:"id,omitempty"`
+	Pattern   string    `json:"pattern,omitempty"`
+	Enabled   bool      `json:"enabled,omitempty"`
+	CreatedAt time.Time `json:"created_at,omitempty"`
+	UpdatedAt time.Time `json:"updated_at,omitempty"`
+}
+
+func (c *GitHubClient) ListTagProtection(ctx context.Context, org, repo string) ([]TagProtection, error) {
+	path := fmt.Sprintf("%s/repos/%s/%s/tags/protection", gitHubAPI, org, repo)
+	var res []TagProtection
+	err := c.api.Do(ctx, "GET", path, httpclient.WithResponseUnmarshal(&res))
+	return res, err
+}
+
+// CreateTagProtection protects tags matching the pattern, like "v*",
+// so that only users with admin or maintain role can create or delete them.
+func (c *GitHubClient) CreateTagProtection(ctx context.Context, org, repo, pattern string) (*TagProtection, error) {
+	path := fmt.Sprintf("%s/repos/%s/%s/tags/protection", gitHubAPI, org, repo)
+	var res TagProtection
+	err := c.api.Do(ctx, "POST", path,
+		httpclient.WithRequestData(map[string]string{
+			"pattern": pattern,
+		}),
+		httpclient.WithResponseUnmarshal(&res))
+	return &res, err
+}
+
+func (c *GitHubClient) DeleteTagProtection(ctx context.Context, org, repo string, id int64) error {
+	path := fmt.Sprintf("%s/repos/%s/%s/tags/protection/%d", gitHubAPI, org, repo, id)
+	return c.api.Do(ctx, "DELETE", path)
+}
+
+// EnsureTagProtection creates the tag protection pattern only if it's not
+// already there, so that it's safe to apply it over and over across repos.
+func (c *GitHubClient) EnsureTagProtection(ctx context.Context, org, repo, pattern string) (*TagProtection, error) {
+	existing, err := c.ListTagProtection(ctx, org, repo)
+	if err != nil {
+		return nil, fmt.Errorf("list: %w", err)
+	}
+	for _, v := range existing {
+		if v.Pattern == pattern {
+			return &v, nil
+		}
+	}
+	return c.CreateTagProtection(ctx, org, repo, pattern)
+}
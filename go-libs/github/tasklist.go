@@ -0,0 +1,109 @@
+package github
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// IssueRef points to an issue or a pull request. Org and Repo are empty for
+// short references like "#123", which point to the same repository.
+type IssueRef struct {
+	Org    string
+	Repo   string
+	Number int
+}
+
+func (r IssueRef) String() string {
+	if r.Org == "" {
+		return "#" + strconv.Itoa(r.Number)
+	}
+	return r.Org + "/" + r.Repo + "#" + strconv.Itoa(r.Number)
+}
+
+type TaskItem struct {
+	// Line is 1-based line number of the item in the body
+	Line      int
+	Depth     int
+	Text      string
+	Completed bool
+	Refs      []IssueRef
+}
+
+type TaskList []TaskItem
+
+// Progress returns the number of completed and all items in the list
+func (tl TaskList) Progress() (completed, total int) {
+	for _, v := range tl {
+		if v.Completed {
+			completed++
+		}
+	}
+	return completed, len(tl)
+}
+
+// Refs returns all distinct issue references found in task items
+func (tl TaskList) Refs() (out []IssueRef) {
+	seen := map[IssueRef]bool{}
+	for _, v := range tl {
+		for _, ref := range v.Refs {
+			if seen[ref] {
+				continue
+			}
+			seen[ref] = true
+			out = append(out, ref)
+		}
+	}
+	return out
+}
+
+var (
+	taskItemRegex = regexp.MustCompile(`^(\s*)(?:[-*+]|\d+[.)])\s+\[([ xX])\]\s+(.*)$`)
+	issueRefRegex = regexp.MustCompile(`(?:https://github\.com/([\w.-]+)/([\w.-]+)/(?:issues|pull)/(\d+))|(?:(?:^|[^\w/])(?:([\w.-]+)/([\w.-]+))?#(\d+)\b)`)
+)
+
+// ParseTaskList extracts Markdown task list items (- [ ] and - [x]) from
+// an issue or pull request body. Items within fenced code blocks are ignored.
+func ParseTaskList(body string) (out TaskList) {
+	inFence := false
+	for i, line := range strings.Split(body, "\n") {
+		line = strings.TrimRight(line, "\r")
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			inFence = !inFence
+			continue
+		}
+		if inFence {
+			continue
+		}
+		match := taskItemRegex.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+		indent := strings.ReplaceAll(match[1], "\t", "  ")
+		text := strings.TrimSpace(match[3])
+		out = append(out, TaskItem{
+			Line:      i + 1,
+			Depth:     len(indent) / 2,
+			Text:      text,
+			Completed: match[2] != " ",
+			Refs:      ParseIssueRefs(text),
+		})
+	}
+	return out
+}
+
+// ParseIssueRefs finds references like #123, org/repo#123, and links to
+// issues or pull requests on github.com in the order they appear in text.
+func ParseIssueRefs(text string) (out []IssueRef) {
+	for _, m := range issueRefRegex.FindAllStringSubmatch(text, -1) {
+		if m[3] != "" {
+			number, _ := strconv.Atoi(m[3])
+			out = append(out, IssueRef{Org: m[1], Repo: m[2], Number: number})
+			continue
+		}
+		number, _ := strconv.Atoi(m[6])
+		out = append(out, IssueRef{Org: m[4], Repo: m[5], Number: number})
+	}
+	return out
+}
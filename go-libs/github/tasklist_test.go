@@ -0,0 +1,50 @@
+package github
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTaskList(t *testing.T) {
+	tl := ParseTaskList("Tracking:\n" +
+		"- [x] first #12\n" +
+		"- [ ] second databrickslabs/ucx#34\n" +
+		"  * [X] nested https://github.com/databrickslabs/sandbox/pull/56\n" +
+		"```\n" +
+		"- [ ] not a task\n" +
+		"```\n" +
+		"- [] malformed\n" +
+		"1. [ ] numbered")
+
+	assert.Len(t, tl, 4)
+	assert.Equal(t, TaskItem{
+		Line:      2,
+		Text:      "first #12",
+		Completed: true,
+		Refs:      []IssueRef{{Number: 12}},
+	}, tl[0])
+	assert.Equal(t, 1, tl[2].Depth)
+	assert.True(t, tl[2].Completed)
+	assert.Equal(t, "numbered", tl[3].Text)
+
+	completed, total := tl.Progress()
+	assert.Equal(t, 2, completed)
+	assert.Equal(t, 4, total)
+}
+
+func TestParseIssueRefs(t *testing.T) {
+	refs := ParseIssueRefs("fixes #1, see a/b#2 and https://github.com/c/d/issues/3, not x#4 or #5abc")
+	assert.Equal(t, []IssueRef{
+		{Number: 1},
+		{Org: "a", Repo: "b", Number: 2},
+		{Org: "c", Repo: "d", Number: 3},
+	}, refs)
+	assert.Equal(t, "a/b#2", refs[1].String())
+	assert.Equal(t, "#1", refs[0].String())
+}
+
+func TestTaskListRefsAreDistinct(t *testing.T) {
+	tl := ParseTaskList("- [ ] #1\n- [x] #1 and #2")
+	assert.Equal(t, []IssueRef{{Number: 1}, {Number: 2}}, tl.Refs())
+}
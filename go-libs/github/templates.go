@@ -0,0 +1,227 @@
+package github
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// PullRequestTemplateData is the data for bodies and commit messages of pull
+// requests, that are opened by tooling across many repositories.
+type PullRequestTemplateData struct {
+	Org     string
+	Repo    string
+	Branch  string
+	Base    string
+	Title   string
+	Author  string
+	Changes []string
+	Issues  []IssueRef
+	Extra   map[string]any
+}
+
+// ReleaseTemplateData is the data for release notes and release PRs.
+type ReleaseTemplateData struct {
+	Org             string
+	Repo            string
+	Version         string
+	PreviousVersion string
+	Date            time.Time
+	PullRequests    []PullRequest
+	Commits         []RepositoryCommit
+	Contributors    []string
+	Extra           map[string]any
+}
+
+// IssueReportTemplateData is the data for periodic reports filed as issues,
+// like dependency or compliance overviews.
+type IssueReportTemplateData struct {
+	Title     string
+	Generated time.Time
+	Summary   string
+	Headers   []string
+	Rows      [][]string
+	Extra     map[string]any
+}
+
+// Template is a text/template with functions for rendering Markdown for
+// GitHub. Missing map keys are errors rather than "<no value>".
+type Template struct {
+	tmpl *template.Template
+}
+
+func NewTemplate(name, text string) (*Template, error) {
+	tmpl, err := template.New(name).
+		Option("missingkey=error").
+		Funcs(templateFuncs).
+		Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("template %s: %w", name, err)
+	}
+	return &Template{tmpl}, nil
+}
+
+func (t *Template) Render(data any) (string, error) {
+	var buf bytes.Buffer
+	err := t.tmpl.Execute(&buf, data)
+	if err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// RenderTemplate parses and renders a template once. Use NewTemplate
+// for rendering the same template many times.
+func RenderTemplate(text string, data any) (string, error) {
+	t, err := NewTemplate("body", text)
+	if err != nil {
+		return "", err
+	}
+	return t.Render(data)
+}
+
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`, "`", "\\`", "*", `\*`, "_", `\_`, "[", `\[`, "]", `\]`,
+	"<", `\<`, ">", `\>`, "#", `\#`, "|", `\|`,
+)
+
+var templateFuncs = template.FuncMap{
+	"upper":      strings.ToUpper,
+	"lower":      strings.ToLower,
+	"trim":       strings.TrimSpace,
+	"trimPrefix": func(prefix, s string) string { return strings.TrimPrefix(s, prefix) },
+	"trimSuffix": func(suffix, s string) string { return strings.TrimSuffix(s, suffix) },
+	"replace":    func(old, new, s string) string { return strings.ReplaceAll(s, old, new) },
+	"contains":   func(substr, s string) bool { return strings.Contains(s, substr) },
+	"hasPrefix":  func(prefix, s string) bool { return strings.HasPrefix(s, prefix) },
+	"hasSuffix":  func(suffix, s string) bool { return strings.HasSuffix(s, suffix) },
+	"split":      func(sep, s string) []string { return strings.Split(s, sep) },
+	"join": func(sep string, v any) string {
+		return strings.Join(toStrings(v), sep)
+	},
+	"quote": func(s string) string { return fmt.Sprintf("%q", s) },
+	"indent": func(spaces int, s string) string {
+		pad := strings.Repeat(" ", spaces)
+		return pad + strings.ReplaceAll(s, "\n", "\n"+pad)
+	},
+	"nindent": func(spaces int, s string) string {
+		pad := strings.Repeat(" ", spaces)
+		return "\n" + pad + strings.ReplaceAll(s, "\n", "\n"+pad)
+	},
+	"default": func(fallback, v any) any {
+		if isEmpty(v) {
+			return fallback
+		}
+		return v
+	},
+	"empty": isEmpty,
+	"list":  func(v ...any) []any { return v },
+	"dict": func(kv ...any) (map[string]any, error) {
+		if len(kv)%2 != 0 {
+			return nil, fmt.Errorf("dict needs key-value pairs")
+		}
+		m := map[string]any{}
+		for i := 0; i < len(kv); i += 2 {
+			m[fmt.Sprint(kv[i])] = kv[i+1]
+		}
+		return m, nil
+	},
+	"plural": func(one, many string, n int) string {
+		if n == 1 {
+			return one
+		}
+		return many
+	},
+	"now":  time.Now,
+	"date": func(layout string, t time.Time) string { return t.Format(layout) },
+	"shortSHA": func(sha string) string {
+		if len(sha) > 7 {
+			return sha[:7]
+		}
+		return sha
+	},
+	"firstLine": func(s string) string {
+		first, _, _ := strings.Cut(s, "\n")
+		return first
+	},
+	"escape": markdownEscaper.Replace,
+	"bullets": func(v any) string {
+		var sb strings.Builder
+		for _, item := range toStrings(v) {
+			sb.WriteString("- " + item + "\n")
+		}
+		return strings.TrimSuffix(sb.String(), "\n")
+	},
+	"table": func(headers, rows any) string {
+		var sb strings.Builder
+		columns := toStrings(headers)
+		sb.WriteString("| " + strings.Join(columns, " | ") + " |\n")
+		sb.WriteString("|" + strings.Repeat(" --- |", len(columns)) + "\n")
+		for _, row := range toRows(rows) {
+			cells := make([]string, len(row))
+			for i, v := range row {
+				cells[i] = strings.ReplaceAll(v, "|", `\|`)
+			}
+			sb.WriteString("| " + strings.Join(cells, " | ") + " |\n")
+		}
+		return strings.TrimSuffix(sb.String(), "\n")
+	},
+	"mention": func(login string) string {
+		if login == "" || strings.HasPrefix(login, "@") {
+			return login
+		}
+		return "@" + login
+	},
+	"details": func(summary, body string) string {
+		return fmt.Sprintf("<details>\n<summary>%s</summary>\n\n%s\n</details>", summary, body)
+	},
+}
+
+func toStrings(v any) []string {
+	switch x := v.(type) {
+	case []string:
+		return x
+	case nil:
+		return nil
+	}
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return []string{fmt.Sprint(v)}
+	}
+	out := make([]string, rv.Len())
+	for i := 0; i < rv.Len(); i++ {
+		out[i] = fmt.Sprint(rv.Index(i).Interface())
+	}
+	return out
+}
+
+func toRows(v any) (rows [][]string) {
+	if v == nil {
+		return nil
+	}
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return [][]string{{fmt.Sprint(v)}}
+	}
+	for i := 0; i < rv.Len(); i++ {
+		rows = append(rows, toStrings(rv.Index(i).Interface()))
+	}
+	return rows
+}
+
+func isEmpty(v any) bool {
+	if v == nil {
+		return true
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Map, reflect.Array, reflect.String:
+		return rv.Len() == 0
+	case reflect.Pointer, reflect.Interface:
+		return rv.IsNil()
+	}
+	return rv.IsZero()
+}
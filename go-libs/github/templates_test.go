@@ -0,0 +1,53 @@
+package github
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderPullRequestTemplate(t *testing.T) {
+	tmpl, err := NewTemplate("pr", `Bump {{ .Repo }} to {{ .Extra.version | default "latest" }}
+
+{{ bullets .Changes }}
+{{ range .Issues }}
+Closes {{ . }}{{ end }}
+Requested by {{ mention .Author }}`)
+	require.NoError(t, err)
+
+	body, err := tmpl.Render(PullRequestTemplateData{
+		Repo:    "ucx",
+		Author:  "nfx",
+		Changes: []string{"a", "b"},
+		Issues:  []IssueRef{{Org: "databrickslabs", Repo: "ucx", Number: 1}},
+		Extra:   map[string]any{"version": ""},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, `Bump ucx to latest
+
+- a
+- b
+
+Closes databrickslabs/ucx#1
+Requested by @nfx`, body)
+}
+
+func TestRenderTemplateFuncs(t *testing.T) {
+	out, err := RenderTemplate(`{{ .Version }} ({{ date "2006-01-02" .Date }}), {{ len .Commits }} {{ plural "commit" "commits" (len .Commits) }}
+{{ range .Commits }}{{ shortSHA .SHA }} {{ firstLine .Commit.Message | escape }}
+{{ end }}{{ table (list "a" "b" | join "," | split ",") (list) }}`, ReleaseTemplateData{
+		Version: "v0.1.0",
+		Date:    time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+		Commits: []RepositoryCommit{{
+			SHA:    "0123456789",
+			Commit: Commit{Message: "Fix *bold* claims\n\nbody"},
+		}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "v0.1.0 (2024-01-02), 1 commit\n0123456 Fix \\*bold\\* claims\n| a | b |\n| --- | --- |", out)
+
+	_, err = RenderTemplate(`{{ .missing }}`, map[string]any{})
+	assert.ErrorContains(t, err, `map has no entry for key "missing"`)
+}
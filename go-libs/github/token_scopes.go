@@ -0,0 +1,171 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/databricks/databricks-sdk-go/httpclient"
+	"github.com/databricks/databricks-sdk-go/logger"
+)
+
+type TokenInfo struct {
+	// Scopes of a classic personal access token or an OAuth token,
+	// as reported by X-OAuth-Scopes header.
+	Scopes []string
+
+	// HasScopes is false for fine-grained personal access tokens
+	// and installation tokens, which don't report scopes.
+	HasScopes bool
+
+	// Permissions of the installation token, like "contents": "write".
+	Permissions map[string]string
+}
+
+// TokenInfo inspects the token the client is authenticated with. The request
+// doesn't count towards the rate limit.
+func (c *GitHubClient) TokenInfo(ctx context.Context) (*TokenInfo, error) {
+	var headers http.Header
+	path := fmt.Sprintf("%s/rate_limit", gitHubAPI)
+	err := c.api.Do(withResponseHeaders(ctx, &headers), "GET", path)
+	if err != nil {
+		return nil, err
+	}
+	info := &TokenInfo{
+		Permissions: c.cfg.installationPermissions(),
+	}
+	info.Scopes, info.HasScopes = parseScopes(headers, "X-OAuth-Scopes")
+	return info, nil
+}
+
+// AcceptedScopes returns scopes, any of which is sufficient to GET the path,
+// like "/repos/org/repo/actions/secrets", as reported by X-Accepted-OAuth-Scopes.
+func (c *GitHubClient) AcceptedScopes(ctx context.Context, path string) ([]string, error) {
+	var headers http.Header
+	err := c.api.Do(withResponseHeaders(ctx, &headers), "GET", gitHubAPI+path,
+		httpclient.WithRequestData(listOptions{PerPage: 1}))
+	if err != nil {
+		return nil, err
+	}
+	scopes, _ := parseScopes(headers, "X-Accepted-OAuth-Scopes")
+	return scopes, nil
+}
+
+// RequireScopes fails with an actionable error if the token misses any of the
+// scopes, so that long jobs fail before they start. Tokens without scopes,
+// like fine-grained personal access tokens, are only logged as unverifiable.
+func (c *GitHubClient) RequireScopes(ctx context.Context, scopes ...string) error {
+	info, err := c.TokenInfo(ctx)
+	if err != nil {
+		return fmt.Errorf("token info: %w", err)
+	}
+	if !info.HasScopes {
+		logger.Warnf(ctx, "Cannot verify scopes of the token: %s", strings.Join(scopes, ", "))
+		return nil
+	}
+	missing := missingScopes(info.Scopes, scopes)
+	if len(missing) == 0 {
+		return nil
+	}
+	return fmt.Errorf("token is missing scopes: %s. Grant them at https://github.com/settings/tokens or run: gh auth refresh -s %s",
+		strings.Join(missing, ", "), strings.Join(missing, ","))
+}
+
+// RequirePermissions fails if the installation token lacks any of permissions,
+// like "pull_requests": "write". Write access satisfies read, and admin
+// satisfies both.
+func (c *GitHubClient) RequirePermissions(ctx context.Context, permissions map[string]string) error {
+	// make sure that the token was issued
+	_, err := c.cfg.Token()
+	if err != nil {
+		return fmt.Errorf("token: %w", err)
+	}
+	granted := c.cfg.installationPermissions()
+	if granted == nil {
+		logger.Warnf(ctx, "Cannot verify permissions of a non-installation token")
+		return nil
+	}
+	var missing []string
+	for name, level := range permissions {
+		if permissionLevel(granted[name]) < permissionLevel(level) {
+			missing = append(missing, fmt.Sprintf("%s:%s", name, level))
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	sort.Strings(missing)
+	return fmt.Errorf("installation is missing permissions: %s. Update them in the settings of GitHub App %d and accept them for installation %d",
+		strings.Join(missing, ", "), c.cfg.ApplicationID, c.cfg.InstallationID)
+}
+
+func parseScopes(headers http.Header, name string) ([]string, bool) {
+	values, ok := headers[http.CanonicalHeaderKey(name)]
+	if !ok {
+		return nil, false
+	}
+	var scopes []string
+	for _, v := range strings.Split(strings.Join(values, ","), ",") {
+		v = strings.TrimSpace(v)
+		if v == "" {
+			continue
+		}
+		scopes = append(scopes, v)
+	}
+	return scopes, true
+}
+
+// impliedScopes lists scopes included in a broader scope.
+// See https://docs.github.com/en/apps/oauth-apps/building-oauth-apps/scopes-for-oauth-apps
+var impliedScopes = map[string][]string{
+	"repo":             {"repo:status", "repo_deployment", "public_repo", "repo:invite", "security_events"},
+	"admin:org":        {"write:org", "read:org", "manage_runners:org"},
+	"write:org":        {"read:org"},
+	"admin:public_key": {"write:public_key", "read:public_key"},
+	"write:public_key": {"read:public_key"},
+	"admin:repo_hook":  {"write:repo_hook", "read:repo_hook"},
+	"write:repo_hook":  {"read:repo_hook"},
+	"admin:gpg_key":    {"write:gpg_key", "read:gpg_key"},
+	"write:gpg_key":    {"read:gpg_key"},
+	"user":             {"read:user", "user:email", "user:follow"},
+	"write:packages":   {"read:packages"},
+	"project":          {"read:project"},
+	"admin:enterprise": {"manage_runners:enterprise", "manage_billing:enterprise", "read:enterprise"},
+}
+
+func missingScopes(granted, required []string) (missing []string) {
+	has := map[string]bool{}
+	var expand func(string)
+	expand = func(scope string) {
+		if has[scope] {
+			return
+		}
+		has[scope] = true
+		for _, v := range impliedScopes[scope] {
+			expand(v)
+		}
+	}
+	for _, v := range granted {
+		expand(v)
+	}
+	for _, v := range required {
+		if !has[v] {
+			missing = append(missing, v)
+		}
+	}
+	return missing
+}
+
+func permissionLevel(level string) int {
+	switch level {
+	case "read":
+		return 1
+	case "write":
+		return 2
+	case "admin":
+		return 3
+	}
+	return 0
+}
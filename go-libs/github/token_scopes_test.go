@@ -0,0 +1,57 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMissingScopes(t *testing.T) {
+	granted := []string{"repo", "admin:org"}
+	assert.Empty(t, missingScopes(granted, []string{"public_repo", "read:org", "repo"}))
+	assert.Equal(t, []string{"workflow"}, missingScopes(granted, []string{"workflow", "repo:status"}))
+}
+
+func TestRequireScopes(t *testing.T) {
+	client := NewClient(&GitHubConfig{
+		GitHubTokenSource: GitHubTokenSource{Pat: "x"},
+		transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			assert.Equal(t, "/rate_limit", r.URL.Path)
+			resp := jsonResponse(200, `{}`)
+			resp.Header.Set("X-OAuth-Scopes", "repo, read:org")
+			return resp, nil
+		}),
+	})
+	ctx := context.Background()
+	info, err := client.TokenInfo(ctx)
+	require.NoError(t, err)
+	assert.True(t, info.HasScopes)
+	assert.Equal(t, []string{"repo", "read:org"}, info.Scopes)
+
+	assert.NoError(t, client.RequireScopes(ctx, "repo:status", "read:org"))
+	err = client.RequireScopes(ctx, "workflow", "write:org")
+	assert.ErrorContains(t, err, "token is missing scopes: workflow, write:org")
+}
+
+func TestRequirePermissions(t *testing.T) {
+	client := NewClient(&GitHubConfig{
+		GitHubTokenSource: GitHubTokenSource{
+			Pat: "x",
+			permissions: map[string]string{
+				"contents": "write",
+			},
+		},
+	})
+	ctx := context.Background()
+	assert.NoError(t, client.RequirePermissions(ctx, map[string]string{
+		"contents": "read",
+	}))
+	err := client.RequirePermissions(ctx, map[string]string{
+		"contents":      "admin",
+		"pull_requests": "write",
+	})
+	assert.ErrorContains(t, err, "missing permissions: contents:admin, pull_requests:write")
+}
@@ -0,0 +1,99 @@
+package github
+
+import (
+	"net/mail"
+	"strings"
+
+	"github.com/databrickslabs/sandbox/go-libs/git"
+)
+
+// Trailer is a "Token: value" line at the end of a commit message.
+// See https://git-scm.com/docs/git-interpret-trailers
+type Trailer struct {
+	Token string
+	Value string
+}
+
+type Trailers []Trailer
+
+// Values returns all values of the token, case-insensitive.
+func (t Trailers) Values(token string) (values []string) {
+	for _, v := range t {
+		if strings.EqualFold(v.Token, token) {
+			values = append(values, v.Value)
+		}
+	}
+	return values
+}
+
+// ParseTrailers reads trailers from the last paragraph of a commit message,
+// split with git.SplitFooters. Like git, it requires continuation lines of
+// trailers to be indented with whitespace, and joins them with spaces.
+func ParseTrailers(message string) Trailers {
+	message = strings.TrimSpace(strings.ReplaceAll(message, "\r\n", "\n"))
+	_, rest, _ := strings.Cut(message, "\n")
+	_, footers := git.SplitFooters(strings.Trim(rest, "\n"))
+	var trailers Trailers
+	for _, footer := range footers {
+		lines := strings.Split(footer.Value, "\n")
+		for i, line := range lines[1:] {
+			if !strings.HasPrefix(line, " ") && !strings.HasPrefix(line, "\t") {
+				return nil
+			}
+			lines[i+1] = strings.TrimSpace(line)
+		}
+		trailers = append(trailers, Trailer{footer.Token, strings.TrimSpace(strings.Join(lines, " "))})
+	}
+	return trailers
+}
+
+// ParseIdentity parses "Name <email>" values of trailers like Co-authored-by.
+func ParseIdentity(value string) (CommitAuthor, bool) {
+	addr, err := mail.ParseAddress(value)
+	if err != nil {
+		return CommitAuthor{}, false
+	}
+	return CommitAuthor{Name: addr.Name, Email: addr.Address}, true
+}
+
+func identities(values []string) (out []CommitAuthor) {
+	for _, v := range values {
+		identity, ok := ParseIdentity(v)
+		if !ok {
+			continue
+		}
+		out = append(out, identity)
+	}
+	return out
+}
+
+// CoAuthors are identities from Co-authored-by trailers of the commit.
+func CoAuthors(commit RepositoryCommit) []CommitAuthor {
+	return identities(ParseTrailers(commit.Commit.Message).Values("Co-authored-by"))
+}
+
+// SignedOffBy are identities from Signed-off-by trailers of the commit.
+func SignedOffBy(commit RepositoryCommit) []CommitAuthor {
+	return identities(ParseTrailers(commit.Commit.Message).Values("Signed-off-by"))
+}
+
+// ReviewedBy are identities from Reviewed-by trailers of the commit.
+func ReviewedBy(commit RepositoryCommit) []CommitAuthor {
+	return identities(ParseTrailers(commit.Commit.Message).Values("Reviewed-by"))
+}
+
+// CommitCredits are the author and co-authors of the commit, deduplicated
+// by email, so that squash-merged pull requests credit everyone involved.
+func CommitCredits(commit RepositoryCommit) []CommitAuthor {
+	seen := map[string]bool{}
+	var credits []CommitAuthor
+	for _, v := range append([]CommitAuthor{commit.Commit.Author}, CoAuthors(commit)...) {
+		email := strings.ToLower(v.Email)
+		if email == "" || seen[email] {
+			continue
+		}
+		seen[email] = true
+		credits = append(credits, CommitAuthor{Name: v.Name, Email: v.Email})
+	}
+	return credits
+}
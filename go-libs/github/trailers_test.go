@@ -0,0 +1,43 @@
+package github
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTrailers(t *testing.T) {
+	trailers := ParseTrailers(`Add feature (#12)
+
+Some description: not a trailer
+
+Co-authored-by: Jane Doe <jane@example.com>
+Signed-off-by: John <john@example.com>
+Reviewed-by: Someone
+  With a long name`)
+	assert.Equal(t, Trailers{
+		{"Co-authored-by", "Jane Doe <jane@example.com>"},
+		{"Signed-off-by", "John <john@example.com>"},
+		{"Reviewed-by", "Someone With a long name"},
+	}, trailers)
+
+	assert.Nil(t, ParseTrailers("Subject: only"))
+	assert.Nil(t, ParseTrailers("Subject\n\nFixes: x\nnot a trailer"))
+}
+
+func TestCommitCredits(t *testing.T) {
+	commit := RepositoryCommit{
+		Commit: Commit{
+			Author: CommitAuthor{Name: "John", Email: "john@example.com"},
+			Message: "Fix bug\n\n" +
+				"Co-authored-by: Jane Doe <jane@example.com>\n" +
+				"Co-authored-by: John <JOHN@example.com>\n" +
+				"Co-authored-by: broken",
+		},
+	}
+	assert.Equal(t, []CommitAuthor{
+		{Name: "John", Email: "john@example.com"},
+		{Name: "Jane Doe", Email: "jane@example.com"},
+	}, CommitCredits(commit))
+	assert.Len(t, CoAuthors(commit), 2)
+}
@@ -0,0 +1,79 @@
+package github
+
+import (
+	"context"
+	"crypto/tls"
+	"net/http"
+)
+
+// newTransport wraps the configured transport, so that the client can look
+// at the raw HTTP responses, which the API client doesn't expose. Without
+// explicitly configured transport, it's the same as the API client default.
+func newTransport(cfg *GitHubConfig, rateLimits *rateLimitTracker) http.RoundTripper {
+	base := cfg.transport
+	if cfg.OfflineDir != "" {
+		base = &offline{cfg.OfflineDir}
+	} else if base == nil {
+		t := http.DefaultTransport.(*http.Transport).Clone()
+		t.TLSClientConfig = &tls.Config{
+			InsecureSkipVerify: cfg.InsecureSkipVerify,
+		}
+		base = t
+	}
+	if cfg.RecordDir != "" && cfg.OfflineDir == "" {
+		base = &recorder{base, cfg.RecordDir}
+	}
+	base = &rateLimitTransport{base, rateLimits}
+	if cfg.ETagCache != nil {
+		base = &etagTransport{base, cfg.ETagCache, cfg.MaxResponseBytes}
+	}
+	if cfg.DryRun {
+		base = &dryRun{base}
+	}
+	if cfg.AuditLog != nil {
+		base = &auditTransport{base, cfg.AuditLog, &redactor{cfg.RedactPatterns}, cfg.DryRun}
+	}
+	if cfg.RateBudget != nil {
+		base = &rateBudgetTransport{base, cfg.RateBudget}
+	}
+	if cfg.CircuitBreakerThreshold > 0 || cfg.RetryBudget > 0 {
+		base = newCircuitBreaker(cfg, base)
+	}
+	base = newRateLimitWaiter(cfg, base)
+	if cfg.MaxResponseBytes > 0 {
+		base = &responseLimit{base, cfg.MaxResponseBytes}
+	}
+	return &responseCapture{base}
+}
+
+type responseHeadersKey struct{}
+
+// withResponseHeaders makes the headers of the response to the request made
+// with the returned context available in h.
+func withResponseHeaders(ctx context.Context, h *http.Header) context.Context {
+	return context.WithValue(ctx, responseHeadersKey{}, h)
+}
+
+type responseCapture struct {
+	next http.RoundTripper
+}
+
+func (rc *responseCapture) RoundTrip(r *http.Request) (*http.Response, error) {
+	resp, err := rc.next.RoundTrip(r)
+	if err != nil {
+		return nil, err
+	}
+	h, ok := r.Context().Value(responseHeadersKey{}).(*http.Header)
+	if ok {
+		*h = resp.Header.Clone()
+	}
+	err = captureErrorResponse(r, resp)
+	if err != nil {
+		return nil, err
+	}
+	err = streamResponse(r, resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
@@ -0,0 +1,32 @@
+package github
+
+import "github.com/databrickslabs/sandbox/go-libs/github/types"
+
+// Models are defined in the types package, so that consumers can share them
+// without depending on the client. Aliases keep the existing names working.
+type (
+	User                  = types.User
+	Team                  = types.Team
+	Repo                  = types.Repo
+	Repositories          = types.Repositories
+	Permissions           = types.Permissions
+	License               = types.License
+	Label                 = types.Label
+	Milestone             = types.Milestone
+	Issue                 = types.Issue
+	IssuePullRequest      = types.IssuePullRequest
+	CommitAuthor          = types.CommitAuthor
+	SignatureVerification = types.SignatureVerification
+	Tree                  = types.Tree
+	Commit                = types.Commit
+	CommitStats           = types.CommitStats
+	CommitFile            = types.CommitFile
+	RepositoryCommit      = types.RepositoryCommit
+	PullRequest           = types.PullRequest
+	PullRequestBranch     = types.PullRequestBranch
+	PullRequestAutoMerge  = types.PullRequestAutoMerge
+	Release               = types.Release
+	Asset                 = types.Asset
+	Versions              = types.Versions
+	WorkflowRun           = types.WorkflowRun
+)
@@ -0,0 +1,22 @@
+package types
+
+import "time"
+
+type WorkflowRun struct {
+	ID           int64     `json:"id"`
+	WorkflowID   int64     `json:"workflow_id"`
+	RunNumber    int64     `json:"run_number"`
+	RunAttempt   int       `json:"run_attempt"`
+	Name         string    `json:"name"`
+	Event        string    `json:"event,omitempty"`
+	Status       string    `json:"status"` // waiting, in_progress, completed
+	Conclusion   string    `json:"conclusion,omitempty"`
+	HeadBranch   string    `json:"head_branch,omitempty"`
+	HeadSHA      string    `json:"head_sha,omitempty"`
+	Actor        *User     `json:"actor,omitempty"`
+	ApiURL       string    `json:"url,omitempty"`
+	WebURL       string    `json:"html_url,omitempty"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+	RunStartedAt time.Time `json:"run_started_at"`
+}
@@ -0,0 +1,67 @@
+package types
+
+import "time"
+
+type CommitAuthor struct {
+	Date  time.Time `json:"date,omitempty"`
+	Name  string    `json:"name,omitempty"`
+	Email string    `json:"email,omitempty"`
+}
+
+type SignatureVerification struct {
+	Verified  bool   `json:"verified,omitempty"`
+	Reason    string `json:"reason,omitempty"`
+	Signature string `json:"signature,omitempty"`
+	Payload   string `json:"payload,omitempty"`
+}
+
+type Tree struct {
+	SHA string `json:"sha,omitempty"`
+	URL string `json:"url,omitempty"`
+}
+
+type Commit struct {
+	SHA          string                `json:"sha,omitempty"`
+	Author       CommitAuthor          `json:"author,omitempty"`
+	Committer    CommitAuthor          `json:"committer,omitempty"`
+	Message      string                `json:"message,omitempty"`
+	Tree         Tree                  `json:"tree,omitempty"`
+	Parents      []Commit              `json:"parents,omitempty"`
+	Verification SignatureVerification `json:"verification,omitempty"`
+	CommentCount int                   `json:"comment_count,omitempty"`
+	URL          string                `json:"url,omitempty"`
+}
+
+type CommitStats struct {
+	Additions int `json:"additions,omitempty"`
+	Deletions int `json:"deletions,omitempty"`
+	Total     int `json:"total,omitempty"`
+}
+
+type CommitFile struct {
+	SHA              string `json:"sha,omitempty"`
+	Filename         string `json:"filename,omitempty"`
+	Status           string `json:"status,omitempty"` // added, removed, modified, renamed, copied, changed, unchanged
+	Additions        int    `json:"additions,omitempty"`
+	Deletions        int    `json:"deletions,omitempty"`
+	Changes          int    `json:"changes,omitempty"`
+	Patch            string `json:"patch,omitempty"`
+	PreviousFilename string `json:"previous_filename,omitempty"`
+	BlobURL          string `json:"blob_url,omitempty"`
+	RawURL           string `json:"raw_url,omitempty"`
+	ContentsURL      string `json:"contents_url,omitempty"`
+}
+
+type RepositoryCommit struct {
+	SHA         string       `json:"sha,omitempty"`
+	NodeID      string       `json:"node_id,omitempty"`
+	Commit      Commit       `json:"commit,omitempty"`
+	Author      User         `json:"author,omitempty"`
+	Committer   User         `json:"committer,omitempty"`
+	Parents     []Commit     `json:"parents,omitempty"`
+	Stats       *CommitStats `json:"stats,omitempty"`
+	Files       []CommitFile `json:"files,omitempty"`
+	HTMLURL     string       `json:"html_url,omitempty"`
+	URL         string       `json:"url,omitempty"`
+	CommentsURL string       `json:"comments_url,omitempty"`
+}
@@ -0,0 +1,34 @@
+package types
+
+import "time"
+
+type Issue struct {
+	ID                int64      `json:"id,omitempty"`
+	NodeID            string     `json:"node_id,omitempty"`
+	Number            int        `json:"number,omitempty"`
+	Title             string     `json:"title,omitempty"`
+	Body              string     `json:"body,omitempty"`
+	State             string     `json:"state,omitempty"`        // open, closed
+	StateReason       string     `json:"state_reason,omitempty"` // completed, not_planned, reopened
+	Locked            bool       `json:"locked,omitempty"`
+	User              User       `json:"user,omitempty"`
+	Labels            []Label    `json:"labels,omitempty"`
+	Assignees         []User     `json:"assignees,omitempty"`
+	Milestone         *Milestone `json:"milestone,omitempty"`
+	Comments          int        `json:"comments,omitempty"`
+	AuthorAssociation string     `json:"author_association,omitempty"`
+	HTMLURL           string     `json:"html_url,omitempty"`
+	URL               string     `json:"url,omitempty"`
+	CreatedAt         time.Time  `json:"created_at,omitempty"`
+	UpdatedAt         time.Time  `json:"updated_at,omitempty"`
+	ClosedAt          *time.Time `json:"closed_at,omitempty"`
+
+	// PullRequest is set for issues, that are pull requests.
+	PullRequest *IssuePullRequest `json:"pull_request,omitempty"`
+}
+
+type IssuePullRequest struct {
+	URL      string     `json:"url,omitempty"`
+	HTMLURL  string     `json:"html_url,omitempty"`
+	MergedAt *time.Time `json:"merged_at,omitempty"`
+}
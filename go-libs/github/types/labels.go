@@ -0,0 +1,30 @@
+package types
+
+import "time"
+
+type Label struct {
+	ID          int64  `json:"id,omitempty"`
+	URL         string `json:"url,omitempty"`
+	Name        string `json:"name,omitempty"`
+	Color       string `json:"color,omitempty"`
+	Description string `json:"description,omitempty"`
+	Default     bool   `json:"default,omitempty"`
+	NodeID      string `json:"node_id,omitempty"`
+}
+
+type Milestone struct {
+	ID           int64      `json:"id,omitempty"`
+	NodeID       string     `json:"node_id,omitempty"`
+	Number       int        `json:"number,omitempty"`
+	Title        string     `json:"title,omitempty"`
+	Description  string     `json:"description,omitempty"`
+	State        string     `json:"state,omitempty"` // open, closed
+	Creator      User       `json:"creator,omitempty"`
+	OpenIssues   int        `json:"open_issues,omitempty"`
+	ClosedIssues int        `json:"closed_issues,omitempty"`
+	HTMLURL      string     `json:"html_url,omitempty"`
+	CreatedAt    time.Time  `json:"created_at,omitempty"`
+	UpdatedAt    time.Time  `json:"updated_at,omitempty"`
+	ClosedAt     *time.Time `json:"closed_at,omitempty"`
+	DueOn        *time.Time `json:"due_on,omitempty"`
+}
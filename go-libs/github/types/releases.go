@@ -0,0 +1,49 @@
+package types
+
+import "time"
+
+type Asset struct {
+	ID                 int64     `json:"id"`
+	NodeID             string    `json:"node_id,omitempty"`
+	Name               string    `json:"name"`
+	Label              string    `json:"label,omitempty"`
+	State              string    `json:"state,omitempty"` // uploaded, open
+	ContentType        string    `json:"content_type"`
+	Size               int64     `json:"size"`
+	DownloadCount      int64     `json:"download_count"`
+	URL                string    `json:"url,omitempty"`
+	BrowserDownloadURL string    `json:"browser_download_url"`
+	Uploader           User      `json:"uploader,omitempty"`
+	CreatedAt          time.Time `json:"created_at"`
+	UpdatedAt          time.Time `json:"updated_at"`
+}
+
+type Release struct {
+	ID              int64     `json:"id"`
+	NodeID          string    `json:"node_id,omitempty"`
+	Version         string    `json:"tag_name"`
+	TargetCommitish string    `json:"target_commitish,omitempty"`
+	Name            string    `json:"name,omitempty"`
+	Body            string    `json:"body,omitempty"`
+	Draft           bool      `json:"draft,omitempty"`
+	Prerelease      bool      `json:"prerelease,omitempty"`
+	Author          User      `json:"author,omitempty"`
+	CreatedAt       time.Time `json:"created_at"`
+	PublishedAt     time.Time `json:"published_at"`
+	URL             string    `json:"url,omitempty"`
+	HTMLURL         string    `json:"html_url,omitempty"`
+	UploadURL       string    `json:"upload_url,omitempty"`
+	TarballURL      string    `json:"tarball_url,omitempty"`
+	ZipballURL      string    `json:"zipball_url"`
+	Assets          []Asset   `json:"assets"`
+}
+
+type Versions []Release
+
+// DownloadCount is the total number of downloads of all assets of the release.
+func (r Release) DownloadCount() (total int64) {
+	for _, v := range r.Assets {
+		total += v.DownloadCount
+	}
+	return total
+}
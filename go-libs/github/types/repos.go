@@ -0,0 +1,62 @@
+package types
+
+import "time"
+
+type Repositories []Repo
+
+// Permissions of the authenticated user on a repository.
+type Permissions struct {
+	Admin    bool `json:"admin"`
+	Maintain bool `json:"maintain"`
+	Push     bool `json:"push"`
+	Triage   bool `json:"triage"`
+	Pull     bool `json:"pull"`
+}
+
+type License struct {
+	Key    string `json:"key,omitempty"`
+	Name   string `json:"name"`
+	SpdxID string `json:"spdx_id,omitempty"`
+	URL    string `json:"url,omitempty"`
+}
+
+type Repo struct {
+	ID            int64        `json:"id"`
+	NodeID        string       `json:"node_id,omitempty"`
+	Name          string       `json:"name"`
+	FullName      string       `json:"full_name"`
+	Owner         User         `json:"owner,omitempty"`
+	Private       bool         `json:"private,omitempty"`
+	Visibility    string       `json:"visibility,omitempty"` // public, private, internal
+	Description   string       `json:"description"`
+	Homepage      string       `json:"homepage,omitempty"`
+	Langauge      string       `json:"language"`
+	DefaultBranch string       `json:"default_branch"`
+	Stars         int          `json:"stargazers_count"`
+	Watchers      int          `json:"watchers_count,omitempty"`
+	Forks         int          `json:"forks_count,omitempty"`
+	OpenIssues    int          `json:"open_issues_count,omitempty"`
+	Size          int64        `json:"size,omitempty"` // in kilobytes
+	IsFork        bool         `json:"fork"`
+	IsArchived    bool         `json:"archived"`
+	IsDisabled    bool         `json:"disabled,omitempty"`
+	IsTemplate    bool         `json:"is_template,omitempty"`
+	HasIssues     bool         `json:"has_issues,omitempty"`
+	HasProjects   bool         `json:"has_projects,omitempty"`
+	HasWiki       bool         `json:"has_wiki,omitempty"`
+	HasPages      bool         `json:"has_pages,omitempty"`
+	HasDiscussion bool         `json:"has_discussions,omitempty"`
+	Topics        []string     `json:"topics"`
+	HtmlURL       string       `json:"html_url"`
+	URL           string       `json:"url,omitempty"`
+	CloneURL      string       `json:"clone_url"`
+	SshURL        string       `json:"ssh_url"`
+	GitURL        string       `json:"git_url,omitempty"`
+	License       License      `json:"license"`
+	Permissions   *Permissions `json:"permissions,omitempty"`
+	Parent        *Repo        `json:"parent,omitempty"`
+	Source        *Repo        `json:"source,omitempty"`
+	CreatedAt     time.Time    `json:"created_at"`
+	UpdatedAt     time.Time    `json:"updated_at"`
+	PushedAt      time.Time    `json:"pushed_at"`
+}
@@ -0,0 +1,31 @@
+package types
+
+type User struct {
+	ID                int64  `json:"id,omitempty"`
+	NodeID            string `json:"node_id,omitempty"`
+	Login             string `json:"login,omitempty"`
+	Name              string `json:"name,omitempty"`
+	Company           string `json:"company,omitempty"`
+	Email             string `json:"email,omitempty"`
+	Type              string `json:"type,omitempty"` // User, Bot, Organization
+	SiteAdmin         bool   `json:"site_admin,omitempty"`
+	AvatarURL         string `json:"avatar_url,omitempty"`
+	URL               string `json:"url,omitempty"`
+	HTMLURL           string `json:"html_url,omitempty"`
+	ReposURL          string `json:"repos_url,omitempty"`
+	OrganizationsURL  string `json:"organizations_url,omitempty"`
+	EventsURL         string `json:"events_url,omitempty"`
+	ReceivedEventsURL string `json:"received_events_url,omitempty"`
+}
+
+type Team struct {
+	ID          int64  `json:"id,omitempty"`
+	NodeID      string `json:"node_id,omitempty"`
+	Name        string `json:"name,omitempty"`
+	Slug        string `json:"slug,omitempty"`
+	Description string `json:"description,omitempty"`
+	Privacy     string `json:"privacy,omitempty"`    // closed, secret
+	Permission  string `json:"permission,omitempty"` // pull, triage, push, maintain, admin
+	URL         string `json:"url,omitempty"`
+	HTMLURL     string `json:"html_url,omitempty"`
+}
@@ -0,0 +1,34 @@
+package github
+
+import (
+	"context"
+	"fmt"
+)
+
+type UserReposOptions struct {
+	// Visibility is one of: all, public, private. Default is all.
+	Visibility string `url:"visibility,omitempty"`
+
+	// Affiliation is a comma-separated list of: owner, collaborator,
+	// organization_member. Default is all of them.
+	Affiliation string `url:"affiliation,omitempty"`
+
+	// Sort is one of: created, updated, pushed, full_name. Default is full_name.
+	Sort      string `url:"sort,omitempty"`
+	Direction string `url:"direction,omitempty"`
+
+	Page    int `url:"page,omitempty"`
+	PerPage int `url:"per_page,omitempty"`
+}
+
+// ListAuthenticatedUserRepos lists all repositories the token can access,
+// including private repositories of organizations, which are missing from
+// ListRepositories.
+func (c *GitHubClient) ListAuthenticatedUserRepos(ctx context.Context, opts UserReposOptions) (Repositories, error) {
+	return ToSlice(ctx, c.ListAuthenticatedUserReposIterator(opts))
+}
+
+func (c *GitHubClient) ListAuthenticatedUserReposIterator(opts UserReposOptions) *Iterator[Repo] {
+	path := fmt.Sprintf("%s/user/repos", gitHubAPI)
+	return Paginate[Repo](c, path, opts)
+}
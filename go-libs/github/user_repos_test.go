@@ -0,0 +1,57 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListAuthenticatedUserRepos(t *testing.T) {
+	var queries []string
+	client := NewClient(&GitHubConfig{
+		GitHubTokenSource: GitHubTokenSource{Pat: "x"},
+		transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			assert.Equal(t, "/user/repos", r.URL.Path)
+			queries = append(queries, r.URL.RawQuery)
+			if r.URL.Query().Get("page") == "" {
+				names := make([]string, 100)
+				for i := range names {
+					names[i] = fmt.Sprintf(`{"name": "r%d"}`, i)
+				}
+				resp := jsonResponse(200, "["+strings.Join(names, ",")+"]")
+				resp.Header.Set("Link", `<https://api.github.com/user/repos?affiliation=organization_member&page=2&per_page=100&visibility=private>; rel="next"`)
+				return resp, nil
+			}
+			return jsonResponse(200, `[{"name": "last", "private": true}]`), nil
+		}),
+	})
+	repos, err := client.ListAuthenticatedUserRepos(context.Background(), UserReposOptions{
+		Visibility:  "private",
+		Affiliation: "organization_member",
+	})
+	require.NoError(t, err)
+	assert.Len(t, repos, 101)
+	assert.True(t, repos[100].Private)
+	require.Len(t, queries, 2)
+	assert.Contains(t, queries[0], "affiliation=organization_member")
+	assert.Contains(t, queries[0], "visibility=private")
+	assert.Contains(t, queries[1], "page=2")
+}
+
+func TestListInstallationRepositories(t *testing.T) {
+	client := NewClient(&GitHubConfig{
+		GitHubTokenSource: GitHubTokenSource{Pat: "x"},
+		transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			assert.Equal(t, "/installation/repositories", r.URL.Path)
+			return jsonResponse(200, `{"total_count": 2, "repositories": [{"name": "a"}, {"name": "b"}]}`), nil
+		}),
+	})
+	repos, err := client.ListInstallationRepositories(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, repos, 2)
+}
@@ -0,0 +1,159 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/databricks/databricks-sdk-go/httpclient"
+)
+
+// See https://docs.github.com/en/rest/repos/webhooks
+
+type HookConfig struct {
+	URL string `json:"url"`
+
+	// ContentType is one of: json, form. Default is form.
+	ContentType string `json:"content_type,omitempty"`
+
+	// Secret signs deliveries with the X-Hub-Signature-256 header. It's
+	// write-only and is returned masked.
+	Secret string `json:"secret,omitempty"`
+
+	// InsecureSSL is "1" to skip verification of the certificate of the URL.
+	InsecureSSL string `json:"insecure_ssl,omitempty"`
+}
+
+type Hook struct {
+	ID     int64      `json:"id"`
+	Name   string     `json:"name"` // web
+	Active bool       `json:"active"`
+	Events []string   `json:"events"`
+	Config HookConfig `json:"config"`
+
+	LastResponse struct {
+		Code    int    `json:"code"`
+		Status  string `json:"status"` // active, unused, ...
+		Message string `json:"message"`
+	} `json:"last_response"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type NewHook struct {
+	Config HookConfig `json:"config"`
+
+	// Events default to push. Use "*" for all events.
+	Events []string `json:"events,omitempty"`
+	Active bool     `json:"active"`
+}
+
+func (c *GitHubClient) ListRepoWebhooks(ctx context.Context, org, repo string) ([]Hook, error) {
+	path := fmt.Sprintf("%s/repos/%s/%s/hooks", gitHubAPI, org, repo)
+	return listAll[Hook](ctx, c, path, nil)
+}
+
+// CreateRepoWebhook creates a webhook, which fails with ErrValidation, if
+// there's already one with the same URL and events.
+func (c *GitHubClient) CreateRepoWebhook(ctx context.Context, org, repo string, req NewHook) (*Hook, error) {
+	path := fmt.Sprintf("%s/repos/%s/%s/hooks", gitHubAPI, org, repo)
+	body := map[string]any{
+		"name":   "web",
+		"config": req.Config,
+		"active": req.Active,
+	}
+	if len(req.Events) > 0 {
+		body["events"] = req.Events
+	}
+	var res Hook
+	err := c.api.Do(ctx, "POST", path,
+		httpclient.WithRequestData(body),
+		httpclient.WithResponseUnmarshal(&res))
+	if err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// HookUpdate changes only the fields, that are set.
+type HookUpdate struct {
+	Config       *HookConfig `json:"config,omitempty"`
+	Events       []string    `json:"events,omitempty"`
+	AddEvents    []string    `json:"add_events,omitempty"`
+	RemoveEvents []string    `json:"remove_events,omitempty"`
+	Active       *bool       `json:"active,omitempty"`
+}
+
+func (c *GitHubClient) UpdateRepoWebhook(ctx context.Context, org, repo string, hookID int64, req HookUpdate) (*Hook, error) {
+	var res Hook
+	err := c.api.Do(ctx, "PATCH", hookPath(org, repo, hookID),
+		httpclient.WithRequestData(req),
+		httpclient.WithResponseUnmarshal(&res))
+	if err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+func (c *GitHubClient) DeleteRepoWebhook(ctx context.Context, org, repo string, hookID int64) error {
+	return c.api.Do(ctx, "DELETE", hookPath(org, repo, hookID))
+}
+
+// PingWebhook sends the ping event to the webhook. Use an empty repo for
+// organization webhooks.
+func (c *GitHubClient) PingWebhook(ctx context.Context, org, repo string, hookID int64) error {
+	return c.api.Do(ctx, "POST", hookPath(org, repo, hookID)+"/pings")
+}
+
+// RedeliverHookDelivery sends the delivery again, like after a failure of
+// the receiver. Use an empty repo for organization webhooks.
+func (c *GitHubClient) RedeliverHookDelivery(ctx context.Context, org, repo string, hookID, deliveryID int64) error {
+	path := fmt.Sprintf("%s/deliveries/%d/attempts", hookPath(org, repo, hookID), deliveryID)
+	return c.api.Do(ctx, "POST", path)
+}
+
+// EnsureRepoWebhook returns the webhook with the URL of the request, updated
+// to the request, or creates it, so that a receiver can register itself on
+// every start. The secret can't be compared, so the config of an existing
+// webhook is always updated.
+func (c *GitHubClient) EnsureRepoWebhook(ctx context.Context, org, repo string, req NewHook) (*Hook, error) {
+	hook, err := c.findRepoWebhook(ctx, org, repo, req.Config.URL)
+	if err != nil {
+		return nil, fmt.Errorf("find: %w", err)
+	}
+	if hook == nil {
+		created, err := c.CreateRepoWebhook(ctx, org, repo, req)
+		if !errors.Is(err, ErrValidation) {
+			return created, err
+		}
+		// created by a concurrent run
+		existing, findErr := c.findRepoWebhook(ctx, org, repo, req.Config.URL)
+		if findErr != nil || existing == nil {
+			return nil, err
+		}
+		hook = existing
+	}
+	update := HookUpdate{
+		Config: &req.Config,
+		Events: req.Events,
+		Active: &req.Active,
+	}
+	if len(update.Events) == 0 {
+		update.Events = []string{"push"}
+	}
+	return c.UpdateRepoWebhook(ctx, org, repo, hook.ID, update)
+}
+
+func (c *GitHubClient) findRepoWebhook(ctx context.Context, org, repo, url string) (*Hook, error) {
+	hooks, err := c.ListRepoWebhooks(ctx, org, repo)
+	if err != nil {
+		return nil, fmt.Errorf("webhooks: %w", err)
+	}
+	for i := range hooks {
+		if hooks[i].Config.URL == url {
+			return &hooks[i], nil
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,81 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnsureRepoWebhook(t *testing.T) {
+	var calls []string
+	var update map[string]any
+	client := NewClient(&GitHubConfig{
+		GitHubTokenSource: GitHubTokenSource{Pat: "x"},
+		transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			calls = append(calls, r.Method+" "+r.URL.Path)
+			switch r.Method + " " + r.URL.Path {
+			case "GET /repos/a/new/hooks":
+				return jsonResponse(200, `[]`), nil
+			case "POST /repos/a/new/hooks":
+				var body map[string]any
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+				assert.Equal(t, "web", body["name"])
+				return jsonResponse(201, `{"id": 1, "active": true}`), nil
+			case "GET /repos/a/old/hooks":
+				return jsonResponse(200, `[{"id": 2, "config": {"url": "https://bot.example.com/other"}},
+					{"id": 3, "events": ["push"], "config": {"url": "https://bot.example.com/hooks"}}]`), nil
+			case "PATCH /repos/a/old/hooks/3":
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&update))
+				return jsonResponse(200, `{"id": 3, "active": true, "events": ["pull_request"]}`), nil
+			}
+			t.Fatalf("unexpected %s %s", r.Method, r.URL.Path)
+			return nil, nil
+		}),
+	})
+	req := NewHook{
+		Config: HookConfig{URL: "https://bot.example.com/hooks", ContentType: "json", Secret: "s"},
+		Events: []string{"pull_request"},
+		Active: true,
+	}
+	ctx := context.Background()
+	hook, err := client.EnsureRepoWebhook(ctx, "a", "new", req)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), hook.ID)
+
+	hook, err = client.EnsureRepoWebhook(ctx, "a", "old", req)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), hook.ID)
+	assert.Equal(t, map[string]any{
+		"config": map[string]any{"url": "https://bot.example.com/hooks", "content_type": "json", "secret": "s"},
+		"events": []any{"pull_request"},
+		"active": true,
+	}, update)
+	assert.Equal(t, []string{
+		"GET /repos/a/new/hooks",
+		"POST /repos/a/new/hooks",
+		"GET /repos/a/old/hooks",
+		"PATCH /repos/a/old/hooks/3",
+	}, calls)
+}
+
+func TestPingAndRedeliverWebhook(t *testing.T) {
+	var calls []string
+	client := NewClient(&GitHubConfig{
+		GitHubTokenSource: GitHubTokenSource{Pat: "x"},
+		transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			calls = append(calls, r.Method+" "+r.URL.Path)
+			return jsonResponse(202, `{}`), nil
+		}),
+	})
+	ctx := context.Background()
+	require.NoError(t, client.PingWebhook(ctx, "a", "b", 3))
+	require.NoError(t, client.RedeliverHookDelivery(ctx, "a", "", 4, 5))
+	assert.Equal(t, []string{
+		"POST /repos/a/b/hooks/3/pings",
+		"POST /orgs/a/hooks/4/deliveries/5/attempts",
+	}, calls)
+}
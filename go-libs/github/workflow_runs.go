@@ -0,0 +1,79 @@
+package github
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/databricks/databricks-sdk-go/httpclient"
+)
+
+type ListRunsOptions struct {
+	// Branch filters runs by the branch of the push or the head branch of
+	// the pull request.
+	Branch string `url:"branch,omitempty"`
+
+	// Event filters runs by the triggering event, like push or
+	// workflow_dispatch.
+	Event string `url:"event,omitempty"`
+
+	// Status filters runs by status, like in_progress, or by conclusion,
+	// like success or failure.
+	Status string `url:"status,omitempty"`
+
+	// Actor filters runs by the login of the user, who triggered them.
+	Actor string `url:"actor,omitempty"`
+
+	// Created filters runs by the date range of creation, like
+	// 2024-01-01..2024-01-31 or >=2024-01-01.
+	// See https://docs.github.com/en/search-github/getting-started-with-searching-on-github/understanding-the-search-syntax#query-for-dates
+	Created string `url:"created,omitempty"`
+
+	// HeadSHA filters runs by the commit.
+	HeadSHA string `url:"head_sha,omitempty"`
+
+	Page    int `url:"page,omitempty"`
+	PerPage int `url:"per_page,omitempty"`
+}
+
+// DispatchWorkflow triggers a workflow with the workflow_dispatch event on
+// the ref, which is a branch or a tag. The workflow is a file name, like
+// release.yml, or a numeric ID. GitHub doesn't return the created run, so
+// callers have to find it among runs of the workflow on the ref.
+// See https://docs.github.com/en/rest/actions/workflows#create-a-workflow-dispatch-event
+func (c *GitHubClient) DispatchWorkflow(ctx context.Context, org, repo, workflow, ref string, inputs map[string]any) error {
+	path := fmt.Sprintf("%s/repos/%s/%s/actions/workflows/%s/dispatches", gitHubAPI, org, repo, workflow)
+	return c.api.Do(ctx, "POST", path, httpclient.WithRequestData(struct {
+		Ref    string         `json:"ref"`
+		Inputs map[string]any `json:"inputs,omitempty"`
+	}{ref, inputs}))
+}
+
+func (c *GitHubClient) GetWorkflowRun(ctx context.Context, org, repo string, runID int64) (*WorkflowRun, error) {
+	path := fmt.Sprintf("%s/repos/%s/%s/actions/runs/%d", gitHubAPI, org, repo, runID)
+	var res WorkflowRun
+	err := c.api.Do(ctx, "GET", path, httpclient.WithResponseUnmarshal(&res))
+	if err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// CancelWorkflowRun requests cancellation of the run, which completes with
+// the cancelled conclusion shortly after.
+func (c *GitHubClient) CancelWorkflowRun(ctx context.Context, org, repo string, runID int64) error {
+	path := fmt.Sprintf("%s/repos/%s/%s/actions/runs/%d/cancel", gitHubAPI, org, repo, runID)
+	return c.api.Do(ctx, "POST", path)
+}
+
+// RerunWorkflowRun starts a new attempt of all jobs of a completed run.
+func (c *GitHubClient) RerunWorkflowRun(ctx context.Context, org, repo string, runID int64) error {
+	path := fmt.Sprintf("%s/repos/%s/%s/actions/runs/%d/rerun", gitHubAPI, org, repo, runID)
+	return c.api.Do(ctx, "POST", path)
+}
+
+// RerunFailedJobs starts a new attempt of failed jobs of a completed run and
+// of jobs, that depend on them.
+func (c *GitHubClient) RerunFailedJobs(ctx context.Context, org, repo string, runID int64) error {
+	path := fmt.Sprintf("%s/repos/%s/%s/actions/runs/%d/rerun-failed-jobs", gitHubAPI, org, repo, runID)
+	return c.api.Do(ctx, "POST", path)
+}
@@ -0,0 +1,88 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDispatchWorkflow(t *testing.T) {
+	client := NewClient(&GitHubConfig{
+		GitHubTokenSource: GitHubTokenSource{Pat: "x"},
+		transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			assert.Equal(t, "POST /repos/a/b/actions/workflows/release.yaml/dispatches", r.Method+" "+r.URL.Path)
+			raw, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			var body map[string]any
+			require.NoError(t, json.Unmarshal(raw, &body))
+			assert.Equal(t, map[string]any{
+				"ref":    "main",
+				"inputs": map[string]any{"version": "v1.2.3", "dry_run": true},
+			}, body)
+			return jsonResponse(204, ``), nil
+		}),
+	})
+	err := client.DispatchWorkflow(context.Background(), "a", "b", "release.yaml", "main", map[string]any{
+		"version": "v1.2.3",
+		"dry_run": true,
+	})
+	require.NoError(t, err)
+}
+
+func TestWorkflowRunManagement(t *testing.T) {
+	var calls []string
+	client := NewClient(&GitHubConfig{
+		GitHubTokenSource: GitHubTokenSource{Pat: "x"},
+		transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			calls = append(calls, r.Method+" "+r.URL.Path)
+			if r.Method == "GET" {
+				return jsonResponse(200, `{"id": 7, "status": "completed", "conclusion": "failure"}`), nil
+			}
+			return jsonResponse(201, `{}`), nil
+		}),
+	})
+	ctx := context.Background()
+	run, err := client.GetWorkflowRun(ctx, "a", "b", 7)
+	require.NoError(t, err)
+	assert.Equal(t, "failure", run.Conclusion)
+	require.NoError(t, client.CancelWorkflowRun(ctx, "a", "b", 7))
+	require.NoError(t, client.RerunWorkflowRun(ctx, "a", "b", 7))
+	require.NoError(t, client.RerunFailedJobs(ctx, "a", "b", 7))
+	assert.Equal(t, []string{
+		"GET /repos/a/b/actions/runs/7",
+		"POST /repos/a/b/actions/runs/7/cancel",
+		"POST /repos/a/b/actions/runs/7/rerun",
+		"POST /repos/a/b/actions/runs/7/rerun-failed-jobs",
+	}, calls)
+}
+
+func TestListRunsWithFilters(t *testing.T) {
+	client := NewClient(&GitHubConfig{
+		GitHubTokenSource: GitHubTokenSource{Pat: "x"},
+		transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			assert.Equal(t, "/repos/a/b/actions/workflows/release.yaml/runs", r.URL.Path)
+			assert.Equal(t, "main", r.URL.Query().Get("branch"))
+			assert.Equal(t, "workflow_dispatch", r.URL.Query().Get("event"))
+			assert.Equal(t, ">=2024-01-01", r.URL.Query().Get("created"))
+			assert.Equal(t, "10", r.URL.Query().Get("per_page"))
+			return jsonResponse(200, `{"total_count": 1, "workflow_runs": [
+				{"id": 7, "event": "workflow_dispatch", "head_branch": "main", "actor": {"login": "x"}}
+			]}`), nil
+		}),
+	})
+	runs, err := client.ListRuns(context.Background(), "a", "b", "release.yaml", ListRunsOptions{
+		Branch:  "main",
+		Event:   "workflow_dispatch",
+		Created: ">=2024-01-01",
+		PerPage: 10,
+	})
+	require.NoError(t, err)
+	require.Len(t, runs, 1)
+	assert.Equal(t, int64(7), runs[0].ID)
+	assert.Equal(t, "x", runs[0].Actor.Login)
+}
@@ -0,0 +1,154 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/databricks/databricks-sdk-go/logger"
+)
+
+// WorkflowDurationTrend compares the median duration of the most recent
+// successful runs of a workflow with the median of the runs before them.
+type WorkflowDurationTrend struct {
+	Repo     string
+	Workflow string
+	Recent   time.Duration
+	Baseline time.Duration
+
+	// Change is relative, like 0.25 for 25% slower runs
+	Change     float64
+	Regression bool
+}
+
+func (t WorkflowDurationTrend) String() string {
+	return fmt.Sprintf("%s: %s takes %s, %+.0f%% from %s",
+		t.Repo, t.Workflow, t.Recent.Round(time.Second), t.Change*100, t.Baseline.Round(time.Second))
+}
+
+// Annotation is a GitHub Actions workflow command, that shows the regression
+// as a warning in the summary of the workflow, that runs the report.
+func (t WorkflowDurationTrend) Annotation() string {
+	return fmt.Sprintf("::warning title=Slower workflow::%s", t)
+}
+
+// DurationNotifier alerts about regressions, like chat messages.
+type DurationNotifier interface {
+	NotifyRegression(ctx context.Context, trend WorkflowDurationTrend) error
+}
+
+type DurationTrendOptions struct {
+	// Repos defaults to all repositories of the org, except forks and
+	// archived ones.
+	Repos []string
+
+	// Recent is the number of the latest runs, compared with the Baseline
+	// number of runs before them. Defaults are 5 and 20.
+	Recent   int
+	Baseline int
+
+	// Threshold is the relative slowdown, that is a regression. Default is 0.2.
+	Threshold float64
+
+	// Notifier is optional.
+	Notifier DurationNotifier
+}
+
+// WorkflowDurationTrends aggregates durations of recent successful workflow
+// runs per repository and workflow and detects regressions against a rolling
+// baseline. Workflows without enough runs for both windows are skipped.
+func (c *GitHubClient) WorkflowDurationTrends(ctx context.Context, org string, opts DurationTrendOptions) ([]WorkflowDurationTrend, error) {
+	if opts.Recent == 0 {
+		opts.Recent = 5
+	}
+	if opts.Baseline == 0 {
+		opts.Baseline = 20
+	}
+	if opts.Threshold == 0 {
+		opts.Threshold = 0.2
+	}
+	repos := opts.Repos
+	if len(repos) == 0 {
+		err := c.StreamRepositories(ctx, org, func(r Repo) error {
+			if r.IsFork || r.IsArchived {
+				return nil
+			}
+			repos = append(repos, r.Name)
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("repositories: %w", err)
+		}
+	}
+	var trends []WorkflowDurationTrend
+	for _, repo := range repos {
+		durations, err := c.runDurations(ctx, org, repo, opts.Recent+opts.Baseline)
+		if err != nil {
+			return trends, fmt.Errorf("%s: %w", repo, err)
+		}
+		var workflows []string
+		for name := range durations {
+			workflows = append(workflows, name)
+		}
+		sort.Strings(workflows)
+		for _, name := range workflows {
+			trend, ok := durationTrend(repo, name, durations[name], opts)
+			if !ok {
+				continue
+			}
+			trends = append(trends, trend)
+			if !trend.Regression || opts.Notifier == nil {
+				continue
+			}
+			err = opts.Notifier.NotifyRegression(ctx, trend)
+			if err != nil {
+				logger.Warnf(ctx, "Failed to notify about %s: %s", trend, err)
+			}
+		}
+	}
+	return trends, nil
+}
+
+// runDurations returns durations of the latest successful runs by workflow
+// name, from the newest, up to limit runs for every workflow.
+func (c *GitHubClient) runDurations(ctx context.Context, org, repo string, limit int) (map[string][]time.Duration, error) {
+	path := fmt.Sprintf("%s/repos/%s/%s/actions/runs", gitHubAPI, org, repo)
+	it := paginateField[WorkflowRun](c, path, "workflow_runs", struct {
+		Status string `url:"status,omitempty"`
+	}{"success"})
+	durations := map[string][]time.Duration{}
+	// stop early, as busy repositories have thousands of runs
+	for fetched := 0; fetched < 10*limit && it.HasNext(ctx); fetched++ {
+		run, err := it.Next(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if run.RunStartedAt.IsZero() || len(durations[run.Name]) >= limit {
+			continue
+		}
+		durations[run.Name] = append(durations[run.Name], run.UpdatedAt.Sub(run.RunStartedAt))
+	}
+	return durations, nil
+}
+
+func durationTrend(repo, workflow string, durations []time.Duration, opts DurationTrendOptions) (WorkflowDurationTrend, bool) {
+	if len(durations) < opts.Recent+opts.Baseline {
+		return WorkflowDurationTrend{}, false
+	}
+	// median sorts in place
+	recent := median(append([]time.Duration{}, durations[:opts.Recent]...))
+	baseline := median(append([]time.Duration{}, durations[opts.Recent:]...))
+	if baseline == 0 {
+		return WorkflowDurationTrend{}, false
+	}
+	change := float64(recent-baseline) / float64(baseline)
+	return WorkflowDurationTrend{
+		Repo:       repo,
+		Workflow:   workflow,
+		Recent:     recent,
+		Baseline:   baseline,
+		Change:     change,
+		Regression: change > opts.Threshold,
+	}, true
+}
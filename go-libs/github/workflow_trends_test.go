@@ -0,0 +1,59 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type durationNotifierFunc func(ctx context.Context, trend WorkflowDurationTrend) error
+
+func (f durationNotifierFunc) NotifyRegression(ctx context.Context, trend WorkflowDurationTrend) error {
+	return f(ctx, trend)
+}
+
+func TestWorkflowDurationTrends(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	var runs []string
+	// newest first: 3 slow runs of build, then 6 fast ones, and a few of lint
+	for i := 0; i < 9; i++ {
+		minutes := 10
+		if i < 3 {
+			minutes = 15
+		}
+		runs = append(runs, fmt.Sprintf(`{"name": "build", "run_started_at": "%s", "updated_at": "%s"}`,
+			start.Format(time.RFC3339), start.Add(time.Duration(minutes)*time.Minute).Format(time.RFC3339)))
+	}
+	runs = append(runs, `{"name": "lint", "run_started_at": "2024-01-01T00:00:00Z", "updated_at": "2024-01-01T00:01:00Z"}`)
+	client := NewClient(&GitHubConfig{
+		GitHubTokenSource: GitHubTokenSource{Pat: "x"},
+		transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			assert.Equal(t, "/repos/a/b/actions/runs", r.URL.Path)
+			assert.Equal(t, "success", r.URL.Query().Get("status"))
+			return jsonResponse(200, `{"total_count": 10, "workflow_runs": [`+strings.Join(runs, ",")+`]}`), nil
+		}),
+	})
+	var notified []string
+	trends, err := client.WorkflowDurationTrends(context.Background(), "a", DurationTrendOptions{
+		Repos:    []string{"b"},
+		Recent:   3,
+		Baseline: 6,
+		Notifier: durationNotifierFunc(func(ctx context.Context, trend WorkflowDurationTrend) error {
+			notified = append(notified, trend.Workflow)
+			return nil
+		}),
+	})
+	require.NoError(t, err)
+	require.Len(t, trends, 1)
+	assert.Equal(t, 15*time.Minute, trends[0].Recent)
+	assert.Equal(t, 10*time.Minute, trends[0].Baseline)
+	assert.True(t, trends[0].Regression)
+	assert.Equal(t, []string{"build"}, notified)
+	assert.Equal(t, "::warning title=Slower workflow::b: build takes 15m0s, +50% from 10m0s", trends[0].Annotation())
+}
@@ -0,0 +1,122 @@
+package githubhooks
+
+import (
+	"strings"
+	"time"
+
+	"github.com/databrickslabs/sandbox/go-libs/github/types"
+)
+
+// See https://docs.github.com/en/webhooks/webhook-events-and-payloads
+
+// Installation is set for deliveries of GitHub Apps, which need its ID to
+// get an installation token.
+type Installation struct {
+	ID int64 `json:"id"`
+}
+
+// Common fields of all events, except Repository of org-level events.
+type Common struct {
+	Repository   *types.Repo   `json:"repository,omitempty"`
+	Organization *types.User   `json:"organization,omitempty"`
+	Sender       types.User    `json:"sender"`
+	Installation *Installation `json:"installation,omitempty"`
+}
+
+type PushAuthor struct {
+	Name     string `json:"name"`
+	Email    string `json:"email"`
+	Username string `json:"username,omitempty"`
+}
+
+type PushCommit struct {
+	ID        string     `json:"id"`
+	TreeID    string     `json:"tree_id"`
+	Distinct  bool       `json:"distinct"`
+	Message   string     `json:"message"`
+	Timestamp time.Time  `json:"timestamp"`
+	URL       string     `json:"url"`
+	Author    PushAuthor `json:"author"`
+	Committer PushAuthor `json:"committer"`
+	Added     []string   `json:"added"`
+	Removed   []string   `json:"removed"`
+	Modified  []string   `json:"modified"`
+}
+
+// PushEvent is delivered for pushed commits and tags. Commits are limited
+// to the 20 most recent ones.
+type PushEvent struct {
+	Common
+	Ref        string       `json:"ref"`
+	Before     string       `json:"before"`
+	After      string       `json:"after"`
+	Created    bool         `json:"created"`
+	Deleted    bool         `json:"deleted"`
+	Forced     bool         `json:"forced"`
+	Compare    string       `json:"compare"`
+	Commits    []PushCommit `json:"commits"`
+	HeadCommit *PushCommit  `json:"head_commit"`
+	Pusher     PushAuthor   `json:"pusher"`
+}
+
+// Branch returns the name of the pushed branch, or false for tags.
+func (e *PushEvent) Branch() (string, bool) {
+	return strings.CutPrefix(e.Ref, "refs/heads/")
+}
+
+// Tag returns the name of the pushed tag, or false for branches.
+func (e *PushEvent) Tag() (string, bool) {
+	return strings.CutPrefix(e.Ref, "refs/tags/")
+}
+
+type PullRequestEvent struct {
+	Common
+	// Action is one of: opened, edited, closed, reopened, synchronize,
+	// labeled, unlabeled, ready_for_review, review_requested, and more.
+	Action      string            `json:"action"`
+	Number      int               `json:"number"`
+	PullRequest types.PullRequest `json:"pull_request"`
+
+	// Label is set for labeled and unlabeled actions.
+	Label *types.Label `json:"label,omitempty"`
+
+	// Before and After are set for the synchronize action.
+	Before string `json:"before,omitempty"`
+	After  string `json:"after,omitempty"`
+}
+
+type Workflow struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+	Path string `json:"path"`
+}
+
+type WorkflowRunEvent struct {
+	Common
+	// Action is one of: requested, in_progress, completed.
+	Action      string            `json:"action"`
+	WorkflowRun types.WorkflowRun `json:"workflow_run"`
+	Workflow    Workflow          `json:"workflow"`
+}
+
+type ReleaseEvent struct {
+	Common
+	// Action is one of: published, unpublished, created, edited, deleted,
+	// prereleased, released.
+	Action  string        `json:"action"`
+	Release types.Release `json:"release"`
+}
+
+type IssuesEvent struct {
+	Common
+	// Action is one of: opened, edited, deleted, closed, reopened, assigned,
+	// unassigned, labeled, unlabeled, and more.
+	Action string      `json:"action"`
+	Issue  types.Issue `json:"issue"`
+
+	// Label is set for labeled and unlabeled actions.
+	Label *types.Label `json:"label,omitempty"`
+
+	// Assignee is set for assigned and unassigned actions.
+	Assignee *types.User `json:"assignee,omitempty"`
+}
@@ -0,0 +1,215 @@
+package githubhooks
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/databricks/databricks-sdk-go/logger"
+)
+
+// GitHub caps payloads at 25 MB and drops larger ones
+const maxPayloadSize = 25 << 20
+
+var (
+	ErrMissingSignature = errors.New("missing X-Hub-Signature-256 header")
+	ErrInvalidSignature = errors.New("invalid signature")
+	ErrInvalidPayload   = errors.New("invalid payload")
+	ErrMissingSecret    = errors.New("webhook secret is required")
+)
+
+// VerifySignature checks the X-Hub-Signature-256 header of the delivery
+// against the HMAC of the raw body with the secret of the webhook.
+func VerifySignature(secret string, body []byte, header string) error {
+	if header == "" {
+		return ErrMissingSignature
+	}
+	hexSum, ok := strings.CutPrefix(header, "sha256=")
+	if !ok {
+		return ErrInvalidSignature
+	}
+	sum, err := hex.DecodeString(hexSum)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	if !hmac.Equal(sum, mac.Sum(nil)) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// Delivery is a verified webhook request.
+type Delivery struct {
+	// ID is the GUID of the delivery, which is the same for redeliveries.
+	ID string
+
+	// Event is the X-GitHub-Event header, like pull_request.
+	Event   string
+	HookID  string
+	Payload json.RawMessage
+}
+
+// Decode unmarshals the payload into one of the *Event types.
+func (d *Delivery) Decode(v any) error {
+	err := json.Unmarshal(d.Payload, v)
+	if err != nil {
+		return fmt.Errorf("%w: %s: %s", ErrInvalidPayload, d.Event, err)
+	}
+	return nil
+}
+
+type HandlerFunc func(ctx context.Context, d *Delivery) error
+
+// Receiver is an http.Handler of webhook deliveries, which verifies their
+// signatures and dispatches them to handlers registered for their events:
+//
+//	hooks, err := githubhooks.NewReceiver(secret)
+//	if err != nil {
+//		return err
+//	}
+//	hooks.OnPullRequest(func(ctx context.Context, e *githubhooks.PullRequestEvent) error {
+//		...
+//	})
+//	http.Handle("/hooks", hooks)
+//
+// Handlers run synchronously, while GitHub waits at most 10 seconds for the
+// response, so slow work has to continue in the background. Errors of
+// handlers respond with 500, which shows up in recent deliveries of the
+// webhook, so that they can be redelivered.
+type Receiver struct {
+	secret   string
+	handlers map[string][]HandlerFunc
+}
+
+// NewReceiver verifies deliveries with the secret of the webhook. Requests
+// without a valid signature are rejected, so the secret is required and an
+// empty one fails with ErrMissingSecret, as anyone could sign with it.
+func NewReceiver(secret string) (*Receiver, error) {
+	if secret == "" {
+		return nil, ErrMissingSecret
+	}
+	return &Receiver{
+		secret:   secret,
+		handlers: map[string][]HandlerFunc{},
+	}, nil
+}
+
+// On registers the handler of raw deliveries of the event, like
+// "check_run", for events without typed handlers. Use "*" for all events.
+func (r *Receiver) On(event string, fn HandlerFunc) {
+	r.handlers[event] = append(r.handlers[event], fn)
+}
+
+func typed[T any](fn func(context.Context, *T) error) HandlerFunc {
+	return func(ctx context.Context, d *Delivery) error {
+		var event T
+		err := d.Decode(&event)
+		if err != nil {
+			return err
+		}
+		return fn(ctx, &event)
+	}
+}
+
+func (r *Receiver) OnPush(fn func(ctx context.Context, e *PushEvent) error) {
+	r.On("push", typed(fn))
+}
+
+func (r *Receiver) OnPullRequest(fn func(ctx context.Context, e *PullRequestEvent) error) {
+	r.On("pull_request", typed(fn))
+}
+
+func (r *Receiver) OnWorkflowRun(fn func(ctx context.Context, e *WorkflowRunEvent) error) {
+	r.On("workflow_run", typed(fn))
+}
+
+func (r *Receiver) OnRelease(fn func(ctx context.Context, e *ReleaseEvent) error) {
+	r.On("release", typed(fn))
+}
+
+func (r *Receiver) OnIssues(fn func(ctx context.Context, e *IssuesEvent) error) {
+	r.On("issues", typed(fn))
+}
+
+// payload returns the JSON of the delivery, which is form-encoded for
+// webhooks with the application/x-www-form-urlencoded content type.
+func payload(contentType string, body []byte) ([]byte, error) {
+	if !strings.HasPrefix(contentType, "application/x-www-form-urlencoded") {
+		return body, nil
+	}
+	form, err := url.ParseQuery(string(body))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidPayload, err)
+	}
+	return []byte(form.Get("payload")), nil
+}
+
+func (r *Receiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+	if req.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	body, err := io.ReadAll(http.MaxBytesReader(w, req.Body, maxPayloadSize))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+		return
+	}
+	err = VerifySignature(r.secret, body, req.Header.Get("X-Hub-Signature-256"))
+	if err != nil {
+		logger.Warnf(ctx, "Rejected delivery %s: %s", req.Header.Get("X-GitHub-Delivery"), err)
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	raw, err := payload(req.Header.Get("Content-Type"), body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	d := &Delivery{
+		ID:      req.Header.Get("X-GitHub-Delivery"),
+		Event:   req.Header.Get("X-GitHub-Event"),
+		HookID:  req.Header.Get("X-GitHub-Hook-ID"),
+		Payload: raw,
+	}
+	err = r.dispatch(ctx, d)
+	if errors.Is(err, ErrInvalidPayload) {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err != nil {
+		logger.Errorf(ctx, "Failed delivery %s of %s: %s", d.ID, d.Event, err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// dispatch runs handlers of the event, then handlers of all events, and
+// stops on the first error. Ping events, that are sent once the webhook is
+// created, succeed without handlers.
+func (r *Receiver) dispatch(ctx context.Context, d *Delivery) error {
+	var handlers []HandlerFunc
+	handlers = append(handlers, r.handlers[d.Event]...)
+	handlers = append(handlers, r.handlers["*"]...)
+	if len(handlers) == 0 && d.Event != "ping" {
+		logger.Debugf(ctx, "No handlers of %s for delivery %s", d.Event, d.ID)
+	}
+	for _, fn := range handlers {
+		err := fn(ctx, d)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,144 @@
+package githubhooks
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func sign(secret, body string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(body))
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func deliver(t *testing.T, h http.Handler, event, body, signature string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest("POST", "/hooks", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-GitHub-Event", event)
+	req.Header.Set("X-GitHub-Delivery", "d-1")
+	if signature != "" {
+		req.Header.Set("X-Hub-Signature-256", signature)
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestVerifySignature(t *testing.T) {
+	assert.NoError(t, VerifySignature("s", []byte("{}"), sign("s", "{}")))
+	assert.ErrorIs(t, VerifySignature("s", []byte("{}"), sign("other", "{}")), ErrInvalidSignature)
+	assert.ErrorIs(t, VerifySignature("s", []byte("{}"), "sha1=abc"), ErrInvalidSignature)
+	assert.ErrorIs(t, VerifySignature("s", []byte("{}"), "sha256=zz"), ErrInvalidSignature)
+	assert.ErrorIs(t, VerifySignature("s", []byte("{}"), ""), ErrMissingSignature)
+}
+
+func TestReceiverDispatchesTypedEvents(t *testing.T) {
+	hooks, err := NewReceiver("s")
+	require.NoError(t, err)
+	var branch, title, conclusion string
+	var all []string
+	hooks.OnPush(func(ctx context.Context, e *PushEvent) error {
+		branch, _ = e.Branch()
+		return nil
+	})
+	hooks.OnPullRequest(func(ctx context.Context, e *PullRequestEvent) error {
+		title = e.Action + " " + e.PullRequest.Title + " in " + e.Repository.Name
+		return nil
+	})
+	hooks.OnWorkflowRun(func(ctx context.Context, e *WorkflowRunEvent) error {
+		conclusion = e.WorkflowRun.Conclusion
+		return nil
+	})
+	hooks.On("*", func(ctx context.Context, d *Delivery) error {
+		all = append(all, d.Event)
+		return nil
+	})
+
+	body := `{"ref": "refs/heads/main", "after": "abc", "commits": [{"id": "abc", "added": ["a.go"]}]}`
+	rec := deliver(t, hooks, "push", body, sign("s", body))
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	assert.Equal(t, "main", branch)
+
+	body = `{"action": "opened", "number": 1, "pull_request": {"title": "Fix"}, "repository": {"name": "b"}}`
+	rec = deliver(t, hooks, "pull_request", body, sign("s", body))
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	assert.Equal(t, "opened Fix in b", title)
+
+	body = `{"action": "completed", "workflow_run": {"conclusion": "failure"}}`
+	rec = deliver(t, hooks, "workflow_run", body, sign("s", body))
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	assert.Equal(t, "failure", conclusion)
+
+	body = `{"zen": "Keep it logically awesome."}`
+	rec = deliver(t, hooks, "ping", body, sign("s", body))
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	assert.Equal(t, []string{"push", "pull_request", "workflow_run", "ping"}, all)
+}
+
+func TestReceiverRequiresSecret(t *testing.T) {
+	_, err := NewReceiver("")
+	assert.ErrorIs(t, err, ErrMissingSecret)
+}
+
+func TestReceiverRejectsDeliveries(t *testing.T) {
+	hooks, err := NewReceiver("s")
+	require.NoError(t, err)
+	called := false
+	hooks.OnIssues(func(ctx context.Context, e *IssuesEvent) error {
+		called = true
+		return nil
+	})
+	body := `{"action": "opened"}`
+	assert.Equal(t, http.StatusUnauthorized, deliver(t, hooks, "issues", body, "").Code)
+	assert.Equal(t, http.StatusUnauthorized, deliver(t, hooks, "issues", body, sign("other", body)).Code)
+	assert.False(t, called)
+
+	body = `{"action": 1}`
+	assert.Equal(t, http.StatusBadRequest, deliver(t, hooks, "issues", body, sign("s", body)).Code)
+
+	rec := httptest.NewRecorder()
+	hooks.ServeHTTP(rec, httptest.NewRequest("GET", "/hooks", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+}
+
+func TestReceiverReportsHandlerErrors(t *testing.T) {
+	hooks, err := NewReceiver("s")
+	require.NoError(t, err)
+	hooks.OnRelease(func(ctx context.Context, e *ReleaseEvent) error {
+		return errors.New("nope: " + e.Release.Version)
+	})
+	body := `{"action": "published", "release": {"tag_name": "v0.1.0"}}`
+	rec := deliver(t, hooks, "release", body, sign("s", body))
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.Contains(t, rec.Body.String(), "nope: v0.1.0")
+}
+
+func TestReceiverFormEncodedPayload(t *testing.T) {
+	hooks, err := NewReceiver("s")
+	require.NoError(t, err)
+	var ref string
+	hooks.OnPush(func(ctx context.Context, e *PushEvent) error {
+		ref = e.Ref
+		return nil
+	})
+	body := url.Values{"payload": {`{"ref": "refs/tags/v0.1.0"}`}}.Encode()
+	req := httptest.NewRequest("POST", "/hooks", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("X-GitHub-Event", "push")
+	req.Header.Set("X-Hub-Signature-256", sign("s", body))
+	rec := httptest.NewRecorder()
+	hooks.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusNoContent, rec.Code)
+	assert.Equal(t, "refs/tags/v0.1.0", ref)
+}
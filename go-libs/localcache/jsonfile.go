@@ -42,6 +42,24 @@ func (r *LocalCache[T]) Load(ctx context.Context, refresh func() (T, error)) (T,
 	return cached.Data, nil
 }
 
+// Update applies the function to the cached value, or to the zero value if
+// there's no cache yet, and saves the result regardless of validity. It fits
+// data, that is accumulated over time, rather than refreshed from scratch.
+func (r *LocalCache[T]) Update(ctx context.Context, update func(T) (T, error)) (T, error) {
+	current := r.zero
+	cached, err := r.loadCache()
+	if err == nil {
+		current = cached.Data
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return r.zero, err
+	}
+	data, err := update(current)
+	if err != nil {
+		return r.zero, fmt.Errorf("update: %w", err)
+	}
+	return r.writeCache(ctx, data)
+}
+
 type cached[T any] struct {
 	// we don't use mtime of the file because it's easier to
 	// for testdata used in the unit tests to be somewhere far
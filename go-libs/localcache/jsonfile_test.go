@@ -130,3 +130,23 @@ func TestWrongType(t *testing.T) {
 	})
 	assert.EqualError(t, err, "json marshal: json: unsupported type: chan int")
 }
+
+func TestUpdateAccumulates(t *testing.T) {
+	ctx := context.Background()
+	c := NewLocalCache[[]string](t.TempDir(), "seen", 0)
+	add := func(v string) func([]string) ([]string, error) {
+		return func(prev []string) ([]string, error) {
+			return append(prev, v), nil
+		}
+	}
+	_, err := c.Update(ctx, add("a"))
+	assert.NoError(t, err)
+	all, err := c.Update(ctx, add("b"))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a", "b"}, all)
+
+	_, err = c.Update(ctx, func([]string) ([]string, error) {
+		return nil, fmt.Errorf("nope")
+	})
+	assert.EqualError(t, err, "update: nope")
+}
@@ -25,7 +25,7 @@ func newClone() lite.Registerable[internal.Config] {
 			flags.StringVar(&req.Pat, "github-pat", "", "github pat token")
 			flags.StringVar(&req.PrivateKeyPath, "github-private-key", "", "github private key path")
 			flags.Int64Var(&req.ApplicationID, "github-application-id", 0, "github app id")
-			flags.IntVar(&req.InstallationID, "github-installation-id", 0, "github app installation id")
+			flags.Int64Var(&req.InstallationID, "github-installation-id", 0, "github app installation id")
 			flags.StringVar(&req.mailmap, "mailmap", "", "mailmap file")
 		},
 		Run: func(cmd *lite.Root[internal.Config], req *cloneRequest) error {